@@ -9,8 +9,25 @@ import (
 	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-core/pkg/operator"
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/utils/persistenceutils"
 )
 
+// Name of the directory within the chain's data directory where records of keep
+// members blamed for failed protocol executions are stored.
+const blameDirectory = "blame"
+
+// Name of the directory within the chain's data directory where TSS
+// pre-parameters generated in advance are stored.
+const preParamsDirectory = "tss-pre-params"
+
+// Name of the directory within the chain's data directory where transcripts of
+// failed protocol executions are stored.
+const transcriptsDirectory = "transcripts"
+
+// Name of the directory within the chain's data directory where states of keeps
+// the client is a member of are stored.
+const lifecycleDirectory = "keeps-lifecycle"
+
 func nodeHeader(addrStrings []string, port int) {
 	header := ` 
 
@@ -82,21 +99,88 @@ func buildPersistenceHandle(
 	chainHandle chain.OfflineHandle,
 	keyFilePassword string,
 	dataDir string,
-) (persistence.Handle, error) {
+) (persistenceutils.Handle, error) {
+	diskPersistencePath, err := chainPersistencePath(chainHandle, dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	handle, err := persistence.NewDiskHandle(diskPersistencePath)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed while creating a storage disk handler: [%v]",
+			err,
+		)
+	}
+
+	return persistenceutils.NewEncryptedDiskHandle(
+		handle,
+		diskPersistencePath,
+		keyFilePassword,
+	), nil
+}
+
+// buildDirectoryPersistenceHandle creates a handle persisting data in the given
+// directory within the chain's data directory, separately from signers. Data
+// are encrypted with the key file password if encryption is requested, which
+// should be the case for any data holding secrets.
+func buildDirectoryPersistenceHandle(
+	chainHandle chain.OfflineHandle,
+	keyFilePassword string,
+	dataDir string,
+	directory string,
+	encrypted bool,
+) (persistenceutils.Handle, error) {
+	diskPersistencePath, err := chainPersistencePath(chainHandle, dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	err = persistence.EnsureDirectoryExists(diskPersistencePath, directory)
+	if err != nil {
+		return nil, err
+	}
+
+	directoryPath := diskPersistencePath + "/" + directory
+
+	handle, err := persistence.NewDiskHandle(directoryPath)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed while creating a [%s] storage disk handler: [%v]",
+			directory,
+			err,
+		)
+	}
+
+	if encrypted {
+		return persistenceutils.NewEncryptedDiskHandle(
+			handle,
+			directoryPath,
+			keyFilePassword,
+		), nil
+	}
+
+	return persistenceutils.NewDiskHandle(handle, directoryPath), nil
+}
+
+func chainPersistencePath(
+	chainHandle chain.OfflineHandle,
+	dataDir string,
+) (string, error) {
 	// Validate chain name to avoid issues with persistence later.
 	validChainName, err := regexp.MatchString(
 		"^[a-z][a-z0-9-_]*$",
 		chainHandle.Name(),
 	)
 	if err != nil {
-		return nil, fmt.Errorf(
+		return "", fmt.Errorf(
 			"failed to verify chain name [%v]: [%v]",
 			chainHandle.Name(),
 			err,
 		)
 	}
 	if !validChainName {
-		return nil, fmt.Errorf(
+		return "", fmt.Errorf(
 			"invalid chain name: [%v]; chain name must start with a lowercase "+
 				"letter and then consist solely of lowercase letters, numbers, "+
 				" -, or _",
@@ -117,18 +201,8 @@ func buildPersistenceHandle(
 	if chainHandle.Name() != "ethereum" {
 		diskPersistencePath += "/" + strings.ToLower(chainHandle.Name())
 	}
-	handle, err := persistence.NewDiskHandle(diskPersistencePath)
-	if err != nil {
-		return nil, fmt.Errorf(
-			"failed while creating a storage disk handler: [%v]",
-			err,
-		)
-	}
 
-	return persistence.NewEncryptedPersistence(
-		handle,
-		keyFilePassword,
-	), nil
+	return diskPersistencePath, nil
 }
 
 type operatorKeys struct {
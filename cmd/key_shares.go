@@ -0,0 +1,365 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-ecdsa/config"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss"
+	"github.com/keep-network/keep-ecdsa/pkg/utils/persistenceutils"
+	"github.com/urfave/cli"
+)
+
+// Prefix of names of files under which key shares are persisted in the keep's
+// directory.
+const keyShareFileNamePrefix = "membership_"
+
+// UpgradeKeyShares upgrades key shares stored by the operator to the current
+// version of the signer serialization format. Key shares retained as snapshots
+// or in the archive are replaced with upgraded ones first. Current key shares
+// are then upgraded in place, after a snapshot of their original content is
+// made. The snapshot is deleted once the upgraded key share is saved and read
+// back successfully so that the original key share does not remain on disk.
+// Key shares already stored with the current version are left untouched.
+//
+// The command must not be executed while the client is running.
+func UpgradeKeyShares(c *cli.Context) error {
+	config, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("failed while reading config file: [%v]", err)
+	}
+
+	chainHandle, err := offlineChain(config)
+	if err != nil {
+		return err
+	}
+
+	handle, err := buildPersistenceHandle(
+		chainHandle,
+		extractKeyFilePassword(config),
+		config.Storage.DataDir,
+	)
+	if err != nil {
+		return err
+	}
+
+	retainedDescriptors, err := readKeyShareDescriptors(handle.ReadAllRetained())
+	if err != nil {
+		return err
+	}
+
+	retainedUpgradedCount := 0
+
+	for _, descriptor := range retainedDescriptors {
+		upgraded, err := upgradeRetainedKeyShare(handle, descriptor)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to upgrade key share [%s] retained in [%s]: [%v]",
+				descriptor.Name(),
+				descriptor.Directory(),
+				err,
+			)
+		}
+
+		if upgraded {
+			retainedUpgradedCount++
+		}
+	}
+
+	descriptors, err := readKeyShareDescriptors(handle.ReadAll())
+	if err != nil {
+		return err
+	}
+
+	// Upgraded key shares are read back at once, after all of them are saved,
+	// instead of reading all key shares back after saving each of them.
+	upgradedKeyShares := make(map[string]*upgradedKeyShare)
+
+	for _, descriptor := range descriptors {
+		upgraded, err := upgradeKeyShare(handle, descriptor)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to upgrade key share [%s] of keep [%s]: [%v]",
+				descriptor.Name(),
+				descriptor.Directory(),
+				err,
+			)
+		}
+
+		if upgraded != nil {
+			upgradedKeyShares[keyShareID(descriptor)] = upgraded
+		}
+	}
+
+	if err := verifyUpgradedKeyShares(handle, upgradedKeyShares); err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"upgraded [%d] out of [%d] key shares and [%d] out of [%d] retained "+
+			"key shares to format version [%d]\n",
+		len(upgradedKeyShares),
+		len(descriptors),
+		retainedUpgradedCount,
+		len(retainedDescriptors),
+		tss.CurrentSignerFormat,
+	)
+
+	return nil
+}
+
+// upgradedKeyShare holds a key share saved with the current version of
+// the signer serialization format, until it is verified.
+type upgradedKeyShare struct {
+	directory       string
+	name            string
+	originalVersion uint32
+	content         []byte
+}
+
+// keyShareID identifies the key share by the keep's directory and the name of
+// the file it is stored in.
+func keyShareID(descriptor persistence.DataDescriptor) string {
+	return descriptor.Directory() + "/" + descriptor.Name()
+}
+
+// upgradeKeyShare upgrades the key share to the current version of the signer
+// serialization format. The upgraded key share is saved after a snapshot of
+// the original one is made, and must be verified with verifyUpgradedKeyShares.
+// It returns nil if the key share is already stored with the current version.
+func upgradeKeyShare(
+	handle persistenceutils.Handle,
+	descriptor persistence.DataDescriptor,
+) (*upgradedKeyShare, error) {
+	content, err := descriptor.Content()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key share: [%v]", err)
+	}
+
+	version, upgradedContent, err := upgradeKeyShareContent(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if upgradedContent == nil {
+		return nil, nil
+	}
+
+	if err := handle.Snapshot(
+		content,
+		descriptor.Directory(),
+		"/"+descriptor.Name(),
+	); err != nil {
+		return nil, fmt.Errorf("failed to make snapshot of key share: [%v]", err)
+	}
+
+	if err := handle.Save(
+		upgradedContent,
+		descriptor.Directory(),
+		"/"+descriptor.Name(),
+	); err != nil {
+		return nil, fmt.Errorf("failed to save upgraded key share: [%v]", err)
+	}
+
+	return &upgradedKeyShare{
+		directory:       descriptor.Directory(),
+		name:            descriptor.Name(),
+		originalVersion: version,
+		content:         upgradedContent,
+	}, nil
+}
+
+// verifyUpgradedKeyShares reads back the upgraded key shares and deletes
+// snapshots of the original ones. The snapshot of the original key share is
+// kept if the upgraded one cannot be read back, so that the original one can be
+// restored.
+func verifyUpgradedKeyShares(
+	handle persistenceutils.Handle,
+	upgradedKeyShares map[string]*upgradedKeyShare,
+) error {
+	if len(upgradedKeyShares) == 0 {
+		return nil
+	}
+
+	descriptors, err := readKeyShareDescriptors(handle.ReadAll())
+	if err != nil {
+		return fmt.Errorf("failed to read upgraded key shares: [%v]", err)
+	}
+
+	savedDescriptors := make(map[string]persistence.DataDescriptor)
+	for _, descriptor := range descriptors {
+		savedDescriptors[keyShareID(descriptor)] = descriptor
+	}
+
+	for id, upgraded := range upgradedKeyShares {
+		descriptor, ok := savedDescriptors[id]
+		if !ok {
+			return fmt.Errorf(
+				"upgraded key share [%s] of keep [%s] not found",
+				upgraded.name,
+				upgraded.directory,
+			)
+		}
+
+		savedContent, err := descriptor.Content()
+		if err != nil {
+			return fmt.Errorf(
+				"failed to read upgraded key share [%s] of keep [%s]: [%v]",
+				upgraded.name,
+				upgraded.directory,
+				err,
+			)
+		}
+
+		if !bytes.Equal(savedContent, upgraded.content) {
+			return fmt.Errorf(
+				"upgraded key share [%s] of keep [%s] read from storage "+
+					"differs from the saved one",
+				upgraded.name,
+				upgraded.directory,
+			)
+		}
+
+		if err := handle.DeleteLatestSnapshot(
+			upgraded.directory,
+			"/"+upgraded.name,
+		); err != nil {
+			return fmt.Errorf(
+				"failed to delete snapshot of original key share [%s] "+
+					"of keep [%s]: [%v]",
+				upgraded.name,
+				upgraded.directory,
+				err,
+			)
+		}
+
+		fmt.Printf(
+			"upgraded key share [%s] of keep [%s] from format version [%d] to [%d]\n",
+			upgraded.name,
+			upgraded.directory,
+			upgraded.originalVersion,
+			tss.CurrentSignerFormat,
+		)
+	}
+
+	return nil
+}
+
+// upgradeRetainedKeyShare replaces the key share retained as a snapshot or in
+// the archive with one upgraded to the current version of the signer
+// serialization format. It returns false if the key share is already stored
+// with the current version.
+func upgradeRetainedKeyShare(
+	handle persistenceutils.Handle,
+	descriptor persistence.DataDescriptor,
+) (bool, error) {
+	content, err := descriptor.Content()
+	if err != nil {
+		return false, fmt.Errorf("failed to read key share: [%v]", err)
+	}
+
+	version, upgradedContent, err := upgradeKeyShareContent(content)
+	if err != nil {
+		return false, err
+	}
+
+	if upgradedContent == nil {
+		return false, nil
+	}
+
+	if err := handle.ReplaceRetained(
+		upgradedContent,
+		descriptor.Directory(),
+		descriptor.Name(),
+	); err != nil {
+		return false, fmt.Errorf("failed to replace key share: [%v]", err)
+	}
+
+	fmt.Printf(
+		"upgraded key share [%s] retained in [%s] from format version [%d] to [%d]\n",
+		descriptor.Name(),
+		descriptor.Directory(),
+		version,
+		tss.CurrentSignerFormat,
+	)
+
+	return true, nil
+}
+
+// upgradeKeyShareContent converts the serialized key share to the current
+// version of the signer serialization format. It returns the original version
+// of the key share and nil content if the key share is already serialized with
+// the current version.
+func upgradeKeyShareContent(content []byte) (uint32, []byte, error) {
+	version, err := tss.SignerFormatVersion(content)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if version == tss.CurrentSignerFormat {
+		return version, nil, nil
+	}
+
+	signer := &tss.ThresholdSigner{}
+	if err := signer.Unmarshal(content); err != nil {
+		return 0, nil, err
+	}
+
+	upgradedContent, err := signer.Marshal()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to marshal signer: [%v]", err)
+	}
+
+	// The upgraded key share is verified before the original one is replaced
+	// as key shares cannot be recovered once lost.
+	upgradedSigner := &tss.ThresholdSigner{}
+	if err := upgradedSigner.Unmarshal(upgradedContent); err != nil {
+		return 0, nil, fmt.Errorf("failed to verify upgraded signer: [%v]", err)
+	}
+
+	return version, upgradedContent, nil
+}
+
+// readKeyShareDescriptors reads descriptors of all key shares from the given
+// channels, as returned by the persistence handle.
+func readKeyShareDescriptors(
+	dataChannel <-chan persistence.DataDescriptor,
+	errorsChannel <-chan error,
+) ([]persistence.DataDescriptor, error) {
+	descriptors := []persistence.DataDescriptor{}
+	errors := []error{}
+
+	// Channels are not buffered and we do not know in what order
+	// information is written to them so they are read at the same time.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for descriptor := range dataChannel {
+			if strings.HasPrefix(descriptor.Name(), keyShareFileNamePrefix) {
+				descriptors = append(descriptors, descriptor)
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for err := range errorsChannel {
+			errors = append(errors, err)
+		}
+	}()
+
+	wg.Wait()
+
+	if len(errors) > 0 {
+		return nil, fmt.Errorf("failed to read key shares: [%v]", errors)
+	}
+
+	return descriptors, nil
+}
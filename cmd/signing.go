@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"runtime"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,6 +20,7 @@ import (
 	"github.com/keep-network/keep-ecdsa/config"
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss/params"
 	"github.com/keep-network/keep-ecdsa/pkg/registry"
 
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
@@ -29,6 +32,27 @@ import (
 // subcommand and its own subcommands.
 var SigningCommand cli.Command
 
+// signingProtocolTimeout is the timeout of the signing protocol executed by
+// the out-of-band signing subcommands. All the signers are run locally, so
+// there is no need to make it configurable.
+const signingProtocolTimeout = 10 * time.Minute
+
+// keyShareRefreshProtocolTimeout is the timeout of the key share refresh
+// protocol executed by the out-of-band refresh subcommand.
+const keyShareRefreshProtocolTimeout = 8 * time.Minute
+
+const refreshKeySharesDescription = `Refreshes key shares of all members of
+a keep, provided as decrypted key share files in the given directory, and
+stores the refreshed key shares in the output directory under the same file
+names. The public key of the keep does not change.
+
+Key shares of all keep members have to be provided, as all members take part
+in the refresh executed locally. The command cannot refresh key shares held by
+running clients and there is no command triggering a refresh in a running
+client. Running clients refresh key shares of their keeps periodically only if
+'KeyShareRefreshInterval' is set in the client configuration; it is not set by
+default.`
+
 func init() {
 	SigningCommand = cli.Command{
 		Name:  "signing",
@@ -51,12 +75,50 @@ func init() {
 					},
 				},
 			},
+			{
+				Name: "upgrade-key-shares",
+				Usage: "Upgrades key shares stored by the operator to the current " +
+					"serialization format; must not be run while the client is running",
+				Action: UpgradeKeyShares,
+			},
 			{
 				Name:      "sign-digest",
 				Usage:     "Sign a given digest using provided key shares",
 				Action:    SignDigest,
 				ArgsUsage: "[unprefixed-hex-digest] [key-shares-dir]",
 			},
+			{
+				Name:      "sign-digests",
+				Usage:     "Sign all digests from a given file in a single batch using provided key shares",
+				Action:    SignDigests,
+				ArgsUsage: "[digests-file] [key-shares-dir]",
+				Flags: []cli.Flag{
+					cli.IntFlag{
+						Name:  "concurrency",
+						Usage: "Maximum number of signatures calculated at the same time",
+					},
+				},
+			},
+			{
+				Name:        "refresh-key-shares",
+				Usage:       "Refresh provided key shares without changing the public key",
+				Description: refreshKeySharesDescription,
+				Action:      RefreshKeyShares,
+				ArgsUsage:   "[key-shares-dir]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output-dir,o",
+						Usage: "Output directory for the refreshed key shares",
+					},
+				},
+			},
+			{
+				Name:        "replay-transcripts",
+				Usage:       "Replays transcripts of failed protocol executions recorded for the given keep",
+				Description: replayTranscriptsDescription,
+				Action:      ReplayTranscripts,
+				ArgsUsage:   "[keep-address] [transcript-name]",
+			},
 			ChainSigningCommand,
 		},
 	}
@@ -129,52 +191,9 @@ func SignDigest(c *cli.Context) error {
 		return fmt.Errorf("invalid key shares directory name")
 	}
 
-	keySharesFiles, err := ioutil.ReadDir(keySharesDir)
+	signers, networkProviders, _, err := readKeySharesSigners(keySharesDir)
 	if err != nil {
-		return fmt.Errorf(
-			"could not read key shares directory: [%v]",
-			err,
-		)
-	}
-
-	signers := make([]tss.ThresholdSigner, len(keySharesFiles))
-	networkProviders := make([]net.Provider, len(keySharesFiles))
-
-	for i, keyShareFile := range keySharesFiles {
-		keyShareBytes, err := ioutil.ReadFile(
-			fmt.Sprintf("%s/%s", keySharesDir, keyShareFile.Name()),
-		)
-		if err != nil {
-			return fmt.Errorf(
-				"could not read key share file [%v]: [%v]",
-				keyShareFile.Name(),
-				err,
-			)
-		}
-
-		var signer tss.ThresholdSigner
-		err = signer.Unmarshal(keyShareBytes)
-		if err != nil {
-			return fmt.Errorf(
-				"could not unmarshal signer from file [%v]: [%v]",
-				keyShareFile.Name(),
-				err,
-			)
-		}
-
-		operatorPublicKey, err := signer.MemberID().PublicKey()
-		if err != nil {
-			return fmt.Errorf(
-				"could not get operator public key: [%v]",
-				err,
-			)
-		}
-
-		networkKey := key.NetworkPublic(*operatorPublicKey)
-		networkProvider := local.ConnectWithKey(&networkKey)
-
-		signers[i] = signer
-		networkProviders[i] = networkProvider
+		return err
 	}
 
 	digestBytes, err := hex.DecodeString(digest)
@@ -212,6 +231,7 @@ func SignDigest(c *cli.Context) error {
 				digestBytes,
 				networkProviders[signerIndex],
 				pubKeyToAddressFn,
+				signingProtocolTimeout,
 			)
 
 			signingOutcomesChannel <- &signingOutcome{
@@ -269,6 +289,360 @@ func SignDigest(c *cli.Context) error {
 	return nil
 }
 
+// SignDigests signs all digests from the given file using key shares from
+// the provided directory. Digests are signed in a single batch so that
+// the protocol setup is executed once for all of them. The file is expected
+// to contain one unprefixed hex digest per line.
+func SignDigests(c *cli.Context) error {
+	digestsFile := c.Args().First()
+	if len(digestsFile) == 0 {
+		return fmt.Errorf("invalid digests file name")
+	}
+
+	keySharesDir := c.Args().Get(1)
+	if len(keySharesDir) == 0 {
+		return fmt.Errorf("invalid key shares directory name")
+	}
+
+	digestsBytes, err := ioutil.ReadFile(digestsFile)
+	if err != nil {
+		return fmt.Errorf("could not read digests file: [%v]", err)
+	}
+
+	digests := [][]byte{}
+	for _, line := range strings.Split(string(digestsBytes), "\n") {
+		digest := strings.TrimSpace(line)
+		if len(digest) == 0 {
+			continue
+		}
+
+		digestBytes, err := hex.DecodeString(digest)
+		if err != nil {
+			return fmt.Errorf(
+				"could not decode digest string [%s]: [%v]",
+				digest,
+				err,
+			)
+		}
+
+		digests = append(digests, digestBytes)
+	}
+
+	if len(digests) == 0 {
+		return fmt.Errorf("no digests found in the file")
+	}
+
+	signers, networkProviders, _, err := readKeySharesSigners(keySharesDir)
+	if err != nil {
+		return err
+	}
+
+	// If the flag is not set, the default concurrency is used.
+	tssConfig := &tss.Config{SigningBatchConcurrency: c.Int("concurrency")}
+	concurrency := tssConfig.GetSigningBatchConcurrency()
+
+	// The batch signing applies the protocol timeout to each signature so
+	// the whole batch is not limited by a single timeout here.
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(len(signers))
+
+	signerSignatures := make([][]*ecdsa.Signature, len(signers))
+	signingErrors := make([]error, len(signers))
+
+	pubKeyToAddressFn := func(publicKey cecdsa.PublicKey) []byte {
+		return elliptic.Marshal(publicKey.Curve, publicKey.X, publicKey.Y)
+	}
+
+	for i := range signers {
+		go func(signerIndex int) {
+			defer waitGroup.Done()
+
+			signerSignatures[signerIndex], signingErrors[signerIndex] =
+				signers[signerIndex].CalculateSignatures(
+					ctx,
+					digests,
+					networkProviders[signerIndex],
+					pubKeyToAddressFn,
+					signingProtocolTimeout,
+					concurrency,
+				)
+		}(i)
+	}
+
+	waitGroup.Wait()
+
+	for signerIndex, err := range signingErrors {
+		if err != nil {
+			return fmt.Errorf(
+				"signer with index [%v] returned an error: [%v]",
+				signerIndex,
+				err,
+			)
+		}
+	}
+
+	publicKey, err := chain.SerializePublicKey(signers[0].PublicKey())
+	if err != nil {
+		return err
+	}
+
+	for digestIndex, digest := range digests {
+		signatures := make(map[string]int)
+
+		for _, batchSignatures := range signerSignatures {
+			signature := batchSignatures[digestIndex]
+			signatures[fmt.Sprintf(
+				"%064s%064s",
+				signature.R.Text(16),
+				signature.S.Text(16),
+			)]++
+		}
+
+		if len(signatures) != 1 {
+			return fmt.Errorf(
+				"signing digest [%x] failed; a single signature should be produced",
+				digest,
+			)
+		}
+
+		for signature := range signatures {
+			fmt.Println(
+				hex.EncodeToString(publicKey[:]),
+				"\t",
+				hex.EncodeToString(digest),
+				"\t",
+				signature,
+			)
+		}
+	}
+
+	return nil
+}
+
+// readKeySharesSigners reads signers from all key shares in the provided
+// directory and connects each of them to the local network. Names of files
+// the signers were read from are returned in the same order as the signers.
+func readKeySharesSigners(
+	keySharesDir string,
+) ([]tss.ThresholdSigner, []net.Provider, []string, error) {
+	keySharesFiles, err := ioutil.ReadDir(keySharesDir)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"could not read key shares directory: [%v]",
+			err,
+		)
+	}
+
+	signers := make([]tss.ThresholdSigner, len(keySharesFiles))
+	networkProviders := make([]net.Provider, len(keySharesFiles))
+	fileNames := make([]string, len(keySharesFiles))
+
+	for i, keyShareFile := range keySharesFiles {
+		keyShareBytes, err := ioutil.ReadFile(
+			fmt.Sprintf("%s/%s", keySharesDir, keyShareFile.Name()),
+		)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf(
+				"could not read key share file [%v]: [%v]",
+				keyShareFile.Name(),
+				err,
+			)
+		}
+
+		var signer tss.ThresholdSigner
+		err = signer.Unmarshal(keyShareBytes)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf(
+				"could not unmarshal signer from file [%v]: [%v]",
+				keyShareFile.Name(),
+				err,
+			)
+		}
+
+		operatorPublicKey, err := signer.MemberID().PublicKey()
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf(
+				"could not get operator public key: [%v]",
+				err,
+			)
+		}
+
+		networkKey := key.NetworkPublic(*operatorPublicKey)
+		networkProvider := local.ConnectWithKey(&networkKey)
+
+		signers[i] = signer
+		networkProviders[i] = networkProvider
+		fileNames[i] = keyShareFile.Name()
+	}
+
+	return signers, networkProviders, fileNames, nil
+}
+
+// RefreshKeyShares refreshes key shares from the provided directory and stores
+// the refreshed key shares in the output directory under the same file names.
+// All key shares of the keep have to be provided.
+func RefreshKeyShares(c *cli.Context) error {
+	keySharesDir := c.Args().First()
+	if len(keySharesDir) == 0 {
+		return fmt.Errorf("invalid key shares directory name")
+	}
+
+	outputDir := c.String("output-dir")
+	if len(outputDir) == 0 {
+		return fmt.Errorf("invalid output directory name")
+	}
+
+	signers, networkProviders, fileNames, err := readKeySharesSigners(
+		keySharesDir,
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(signers) == 0 {
+		return fmt.Errorf("no key shares found in the directory")
+	}
+
+	paramsBoxes := make([]*params.Box, len(signers))
+	for i := range signers {
+		preParams, err := tss.GenerateTSSPreParams(
+			5*time.Minute,
+			runtime.NumCPU(),
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"could not generate pre-parameters for key share [%v]: [%v]",
+				fileNames[i],
+				err,
+			)
+		}
+
+		paramsBoxes[i] = params.NewBox(preParams)
+	}
+
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		keyShareRefreshProtocolTimeout,
+	)
+	defer cancelCtx()
+
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(len(signers))
+
+	refreshedSigners := make([]*tss.ThresholdSigner, len(signers))
+	refreshErrors := make([]error, len(signers))
+
+	pubKeyToAddressFn := func(publicKey cecdsa.PublicKey) []byte {
+		return elliptic.Marshal(publicKey.Curve, publicKey.X, publicKey.Y)
+	}
+
+	for i := range signers {
+		go func(signerIndex int) {
+			defer waitGroup.Done()
+
+			refreshedSigners[signerIndex], refreshErrors[signerIndex] =
+				signers[signerIndex].RefreshKeyShare(
+					ctx,
+					networkProviders[signerIndex],
+					pubKeyToAddressFn,
+					paramsBoxes[signerIndex],
+					keyShareRefreshProtocolTimeout,
+				)
+		}(i)
+	}
+
+	waitGroup.Wait()
+
+	for signerIndex, err := range refreshErrors {
+		if err != nil {
+			return fmt.Errorf(
+				"signer with index [%v] returned an error: [%v]",
+				signerIndex,
+				err,
+			)
+		}
+	}
+
+	// Refreshed signers hold also the key shares from before the refresh
+	// until all members confirm they hold refreshed key shares. All key shares
+	// are refreshed here, so they are reconciled right away.
+	waitGroup.Add(len(refreshedSigners))
+
+	reconciledSigners := make([]*tss.ThresholdSigner, len(refreshedSigners))
+	reconcileErrors := make([]error, len(refreshedSigners))
+
+	for i := range refreshedSigners {
+		go func(signerIndex int) {
+			defer waitGroup.Done()
+
+			reconciledSigners[signerIndex], reconcileErrors[signerIndex] =
+				refreshedSigners[signerIndex].ReconcileKeyShare(
+					ctx,
+					networkProviders[signerIndex],
+					pubKeyToAddressFn,
+					time.Now(),
+				)
+		}(i)
+	}
+
+	waitGroup.Wait()
+
+	for signerIndex, err := range reconcileErrors {
+		if err != nil {
+			return fmt.Errorf(
+				"signer with index [%v] returned an error: [%v]",
+				signerIndex,
+				err,
+			)
+		}
+
+		if !reconciledSigners[signerIndex].IsReconciled() {
+			return fmt.Errorf(
+				"signer with index [%v] could not reconcile key shares",
+				signerIndex,
+			)
+		}
+	}
+
+	refreshedSigners = reconciledSigners
+
+	if err := os.MkdirAll(outputDir, 0700); err != nil {
+		return fmt.Errorf("could not create output directory: [%v]", err)
+	}
+
+	for i, refreshedSigner := range refreshedSigners {
+		refreshedSignerBytes, err := refreshedSigner.Marshal()
+		if err != nil {
+			return fmt.Errorf(
+				"failed to marshal refreshed signer for key share [%v]: [%v]",
+				fileNames[i],
+				err,
+			)
+		}
+
+		outputFilePath := fmt.Sprintf("%s/%s", outputDir, fileNames[i])
+		err = ioutil.WriteFile(outputFilePath, refreshedSignerBytes, 0444)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to write refreshed key share to a file [%s]: [%v]",
+				outputFilePath,
+				err,
+			)
+		}
+	}
+
+	publicKey, err := chain.SerializePublicKey(signers[0].PublicKey())
+	if err != nil {
+		return err
+	}
+	fmt.Println(hex.EncodeToString(publicKey[:]), "\t", outputDir)
+
+	return nil
+}
+
 // If `output-file` flag is provided stores the output in a file.
 // `fileMode` determines the access permission for the output file. Sample values:
 // 	0444 - read-only for all
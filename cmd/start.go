@@ -2,7 +2,11 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/keep-network/keep-ecdsa/pkg/metrics"
@@ -19,10 +23,12 @@ import (
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
 	"github.com/keep-network/keep-core/pkg/net/retransmission"
 	"github.com/keep-network/keep-ecdsa/config"
+	"github.com/keep-network/keep-ecdsa/pkg/admin"
 	"github.com/keep-network/keep-ecdsa/pkg/chain/bitcoin"
 	"github.com/keep-network/keep-ecdsa/pkg/client"
 	"github.com/keep-network/keep-ecdsa/pkg/extensions/tbtc/recovery"
 	"github.com/keep-network/keep-ecdsa/pkg/firewall"
+	"github.com/keep-network/keep-ecdsa/pkg/utils/persistenceutils"
 
 	"github.com/urfave/cli"
 )
@@ -82,7 +88,12 @@ func Start(c *cli.Context) error {
 		return fmt.Errorf("failed while reading config file: [%v]", err)
 	}
 
-	ctx := context.Background()
+	if err := config.TSS.Validate(); err != nil {
+		return fmt.Errorf("misconfigured tss protocol: [%v]", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	chainHandle, operatorKeys, err := connectChain(ctx, config)
 	if err != nil {
@@ -121,13 +132,65 @@ func Start(c *cli.Context) error {
 		return err
 	}
 
+	// Blame records and keep states do not contain any secrets so they are
+	// stored unencrypted. Pre-parameters are secret and transcripts hold
+	// protocol messages, so they are stored encrypted.
+	blamePersistence, err := buildDirectoryPersistenceHandle(
+		chainHandle,
+		extractKeyFilePassword(config),
+		config.Storage.DataDir,
+		blameDirectory,
+		false,
+	)
+	if err != nil {
+		return err
+	}
+
+	preParamsPersistence, err := buildDirectoryPersistenceHandle(
+		chainHandle,
+		extractKeyFilePassword(config),
+		config.Storage.DataDir,
+		preParamsDirectory,
+		true,
+	)
+	if err != nil {
+		return err
+	}
+
+	transcriptsPersistence, err := buildDirectoryPersistenceHandle(
+		chainHandle,
+		extractKeyFilePassword(config),
+		config.Storage.DataDir,
+		transcriptsDirectory,
+		true,
+	)
+	if err != nil {
+		return err
+	}
+
+	lifecyclePersistence, err := buildDirectoryPersistenceHandle(
+		chainHandle,
+		extractKeyFilePassword(config),
+		config.Storage.DataDir,
+		lifecycleDirectory,
+		false,
+	)
+	if err != nil {
+		return err
+	}
+
+	// The network provider outlives the client context so that protocols
+	// in progress can complete when the client shuts down.
+	networkCtx, cancelNetwork := context.WithCancel(context.Background())
+	defer cancelNetwork()
+
 	networkProvider, err := libp2p.Connect(
-		ctx,
+		networkCtx,
 		config.LibP2P,
 		networkPrivateKey,
 		libp2p.ProtocolECDSA,
 		firewall.NewStakeOrActiveKeepPolicy(chainHandle, stakeMonitor),
-		retransmission.NewTimeTicker(ctx, 1*time.Second),
+		retransmission.NewTimeTicker(networkCtx, 1*time.Second),
 		libp2p.WithRoutingTableRefreshPeriod(routingTableRefreshPeriod),
 	)
 	if err != nil {
@@ -157,6 +220,10 @@ func Start(c *cli.Context) error {
 		chainHandle,
 		networkProvider,
 		persistence,
+		blamePersistence,
+		preParamsPersistence,
+		transcriptsPersistence,
+		lifecyclePersistence,
 		derivationIndexPersistence,
 		&config.Client,
 		&config.Extensions.TBTC,
@@ -172,18 +239,89 @@ func Start(c *cli.Context) error {
 		chainHandle.OperatorID().String(),
 		clientHandle,
 	)
-	initializeDiagnostics(config, networkProvider)
+	initializeDiagnostics(config, networkProvider, clientHandle)
+	initializeAdmin(ctx, config, clientHandle)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
 
 	logger.Info("client started")
 
-	select {
-	case <-ctx.Done():
-		if err != nil {
-			return err
-		}
+	sig := <-signals
+
+	logger.Infof("received signal [%v]; shutting down the client", sig)
+
+	shutdown(
+		cancel,
+		clientHandle,
+		cancelNetwork,
+		networkProvider,
+		[]persistenceutils.Handle{
+			persistence,
+			blamePersistence,
+			preParamsPersistence,
+			transcriptsPersistence,
+			lifecyclePersistence,
+		},
+		config.Client.GetShutdownDrainTimeout(),
+	)
+
+	return nil
+}
 
-		return fmt.Errorf("unexpected context cancellation")
+// shutdown gracefully stops the client. It cancels the client context, which
+// stops processing of new events and unsubscribes from chain events. Then, it
+// waits up to the drain timeout for protocols in progress to complete, so that
+// their results are persisted, disconnects from all network peers and stops
+// the network provider. Finally, it closes persistence handles so that all
+// the persisted data is flushed to the disk before the client exits.
+func shutdown(
+	cancelClient context.CancelFunc,
+	clientHandle *client.Handle,
+	cancelNetwork context.CancelFunc,
+	networkProvider net.Provider,
+	persistenceHandles []persistenceutils.Handle,
+	drainTimeout time.Duration,
+) {
+	cancelClient()
+
+	logger.Infof(
+		"waiting up to [%v] for protocols in progress to complete",
+		drainTimeout,
+	)
+
+	drainCtx, cancelDrain := context.WithTimeout(
+		context.Background(),
+		drainTimeout,
+	)
+	defer cancelDrain()
+
+	if err := clientHandle.Shutdown(drainCtx); err != nil {
+		logger.Errorf(
+			"failed to complete protocols in progress before shutdown: [%v]",
+			err,
+		)
+	} else {
+		logger.Info("all protocols in progress completed")
+	}
+
+	connectionManager := networkProvider.ConnectionManager()
+	for _, peer := range connectionManager.ConnectedPeers() {
+		connectionManager.DisconnectPeer(peer)
 	}
+
+	// The libp2p provider has no close function; it stops all its routines
+	// once the context it has been connected with is done.
+	cancelNetwork()
+
+	for _, handle := range persistenceHandles {
+		if err := handle.Close(); err != nil {
+			logger.Errorf("failed to close persistence handle: [%v]", err)
+		}
+	}
+
+	logger.Info("client stopped")
 }
 
 func initializeMetrics(
@@ -237,11 +375,26 @@ func initializeMetrics(
 		clientHandle,
 		time.Duration(config.Metrics.ClientMetricsTick)*time.Second,
 	)
+
+	metrics.ObserveTSSPreParamsPoolFill(
+		ctx,
+		registry,
+		clientHandle,
+		time.Duration(config.Metrics.ClientMetricsTick)*time.Second,
+	)
+
+	metrics.ObserveTSSPreParamsGenerationsInProgress(
+		ctx,
+		registry,
+		clientHandle,
+		time.Duration(config.Metrics.ClientMetricsTick)*time.Second,
+	)
 }
 
 func initializeDiagnostics(
 	config *config.Config,
 	netProvider net.Provider,
+	clientHandle *client.Handle,
 ) {
 	registry, isConfigured := diagnostics.Initialize(
 		config.Diagnostics.Port,
@@ -258,4 +411,34 @@ func initializeDiagnostics(
 
 	diagnostics.RegisterConnectedPeersSource(registry, netProvider)
 	diagnostics.RegisterClientInfoSource(registry, netProvider)
+
+	registry.RegisterSource("keeps_blame", func() string {
+		bytes, err := json.Marshal(clientHandle.BlameRecords())
+		if err != nil {
+			logger.Errorf("error on serializing blame records to JSON: [%v]", err)
+			return ""
+		}
+
+		return string(bytes)
+	})
+}
+
+func initializeAdmin(
+	ctx context.Context,
+	config *config.Config,
+	clientHandle *client.Handle,
+) {
+	if config.Admin.Port == 0 {
+		logger.Infof("admin API is not configured")
+		return
+	}
+
+	address := config.Admin.GetAddress()
+
+	if err := admin.Serve(ctx, address, clientHandle); err != nil {
+		logger.Errorf("could not start admin API: [%v]", err)
+		return
+	}
+
+	logger.Infof("enabled admin API on [%s]", address)
 }
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-ecdsa/config"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss"
+	"github.com/urfave/cli"
+)
+
+// Prefix of names of files under which transcripts of failed protocol
+// executions are persisted in the keep's directory. It has to match the prefix
+// used by the client persisting them.
+const transcriptFileNamePrefix = "transcript-"
+
+const replayTranscriptsDescription = `Replays transcripts of failed protocol
+executions recorded by the client for the given keep. Transcripts are read from
+the encrypted transcripts directory of the client, so the command has to be
+run with the client configuration. If the transcript name is provided, only
+this transcript is replayed.
+
+Protocol messages recorded in each transcript are fed to local parties of the
+operator and verified the same way parties verify messages received from the
+network. Inbound messages are also checked against outbound messages of
+the operator recorded in the same protocol session. The first message which
+failed verification is reported for each transcript.
+
+Parties are not started, as random values drawn by the party of the operator
+during the execution are not recorded, so the content of messages cannot be
+verified against the state of the party, e.g. zero-knowledge proofs.
+Transcripts of EdDSA keeps cannot be replayed.`
+
+// ReplayTranscripts replays transcripts recorded by the client for the given
+// keep and reports the first message of each of them which failed
+// verification. An error is returned if any transcript failed the replay.
+func ReplayTranscripts(c *cli.Context) error {
+	config, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("failed while reading config file: [%v]", err)
+	}
+
+	chainHandle, err := offlineChain(config)
+	if err != nil {
+		return err
+	}
+
+	keepID, err := chainHandle.UnmarshalID(c.Args().First())
+	if err != nil {
+		return fmt.Errorf("could not interpret keep ID: [%v]", err)
+	}
+
+	transcriptName := c.Args().Get(1)
+
+	handle, err := buildDirectoryPersistenceHandle(
+		chainHandle,
+		extractKeyFilePassword(config),
+		config.Storage.DataDir,
+		transcriptsDirectory,
+		true,
+	)
+	if err != nil {
+		return err
+	}
+
+	descriptors, err := readTranscriptDescriptors(handle.ReadAll())
+	if err != nil {
+		return err
+	}
+
+	var keepDescriptors []persistence.DataDescriptor
+	for _, descriptor := range descriptors {
+		if descriptor.Directory() != keepID.String() {
+			continue
+		}
+		if transcriptName != "" && descriptor.Name() != transcriptName {
+			continue
+		}
+
+		keepDescriptors = append(keepDescriptors, descriptor)
+	}
+
+	if len(keepDescriptors) == 0 {
+		return fmt.Errorf("no transcripts found for keep [%s]", keepID)
+	}
+
+	// Transcript file names end with the time they were persisted at, so
+	// transcripts are replayed in the order they were recorded.
+	sort.Slice(keepDescriptors, func(i, j int) bool {
+		return keepDescriptors[i].Name() < keepDescriptors[j].Name()
+	})
+
+	failedCount := 0
+
+	for _, descriptor := range keepDescriptors {
+		content, err := descriptor.Content()
+		if err != nil {
+			return fmt.Errorf(
+				"failed to read transcript [%s]: [%v]",
+				descriptor.Name(),
+				err,
+			)
+		}
+
+		transcript := &tss.Transcript{}
+		if err := transcript.Unmarshal(content); err != nil {
+			return fmt.Errorf(
+				"failed to unmarshal transcript [%s]: [%v]",
+				descriptor.Name(),
+				err,
+			)
+		}
+
+		failure, err := transcript.Replay()
+		if err != nil {
+			return fmt.Errorf(
+				"failed to replay transcript [%s]: [%v]",
+				descriptor.Name(),
+				err,
+			)
+		}
+
+		if failure != nil {
+			failedCount++
+			fmt.Printf(
+				"transcript [%s] of [%s] failed replay: %s\n",
+				descriptor.Name(),
+				transcript.Description(),
+				failure,
+			)
+			continue
+		}
+
+		fmt.Printf(
+			"transcript [%s] of [%s] passed replay\n",
+			descriptor.Name(),
+			transcript.Description(),
+		)
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf(
+			"[%d] out of [%d] transcripts failed replay",
+			failedCount,
+			len(keepDescriptors),
+		)
+	}
+
+	return nil
+}
+
+func readTranscriptDescriptors(
+	dataChannel <-chan persistence.DataDescriptor,
+	errorsChannel <-chan error,
+) ([]persistence.DataDescriptor, error) {
+	descriptors := []persistence.DataDescriptor{}
+	errors := []error{}
+
+	// Channels are not buffered and we do not know in what order
+	// information is written to them so they are read at the same time.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for descriptor := range dataChannel {
+			if strings.HasPrefix(descriptor.Name(), transcriptFileNamePrefix) {
+				descriptors = append(descriptors, descriptor)
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for err := range errorsChannel {
+			errors = append(errors, err)
+		}
+	}()
+
+	wg.Wait()
+
+	if len(errors) > 0 {
+		return nil, fmt.Errorf("failed to read transcripts: [%v]", errors)
+	}
+
+	return descriptors, nil
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"strconv"
 
 	"github.com/BurntSushi/toml"
 	"github.com/ethereum/go-ethereum/common"
@@ -28,6 +30,7 @@ type Config struct {
 	TSS                    tss.Config
 	Metrics                Metrics
 	Diagnostics            Diagnostics
+	Admin                  Admin
 	Extensions             Extensions
 }
 
@@ -73,6 +76,30 @@ type Diagnostics struct {
 	Port int
 }
 
+// Admin stores configuration of the admin API.
+type Admin struct {
+	// Port on which the admin API is served. The admin API is not served if
+	// the port is not set.
+	Port int
+	// Host on which the admin API is served. The admin API exposes details of
+	// the client's keeps, so it is served only on the loopback interface if
+	// the host is not set.
+	Host string
+}
+
+const defaultAdminHost = "localhost"
+
+// GetAddress returns the address on which the admin API is served. If the host
+// is not set it returns an address with a default host.
+func (a *Admin) GetAddress() string {
+	host := a.Host
+	if host == "" {
+		host = defaultAdminHost
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(a.Port))
+}
+
 // Extensions stores app-specific extensions configuration.
 type Extensions struct {
 	TBTC tbtc.Config
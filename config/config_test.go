@@ -79,6 +79,22 @@ func TestReadConfig(t *testing.T) {
 			readValueFunc: func(c *Config) interface{} { return c.Client.GetSigningTimeout() },
 			expectedValue: time.Duration(12600000000000),
 		},
+		"Client.KeyShareRefreshInterval": {
+			readValueFunc: func(c *Config) interface{} { return c.Client.GetKeyShareRefreshInterval() },
+			expectedValue: uint64(43200),
+		},
+		"Client.RetryInitialDelay": {
+			readValueFunc: func(c *Config) interface{} { return c.Client.RetryInitialDelay.ToDuration() },
+			expectedValue: 2 * time.Second,
+		},
+		"Client.RetryMaxDelay": {
+			readValueFunc: func(c *Config) interface{} { return c.Client.RetryMaxDelay.ToDuration() },
+			expectedValue: 5 * time.Minute,
+		},
+		"Client.ShutdownDrainTimeout": {
+			readValueFunc: func(c *Config) interface{} { return c.Client.GetShutdownDrainTimeout() },
+			expectedValue: 10 * time.Minute,
+		},
 		"TSS.PreParamsGenerationTimeout": {
 			readValueFunc: func(c *Config) interface{} { return c.TSS.GetPreParamsGenerationTimeout() },
 			expectedValue: time.Duration(397000000000),
@@ -87,6 +103,54 @@ func TestReadConfig(t *testing.T) {
 			readValueFunc: func(c *Config) interface{} { return c.TSS.GetPreParamsTargetPoolSize() },
 			expectedValue: 36,
 		},
+		"TSS.PreParamsGenerationWorkers": {
+			readValueFunc: func(c *Config) interface{} { return c.TSS.GetPreParamsGenerationWorkers() },
+			expectedValue: 4,
+		},
+		"TSS.PreParamsGenerationCPUs": {
+			readValueFunc: func(c *Config) interface{} { return c.TSS.GetPreParamsGenerationCPUs() },
+			expectedValue: 6,
+		},
+		"TSS.PreParamsGenerationConcurrency": {
+			readValueFunc: func(c *Config) interface{} { return c.TSS.GetPreParamsGenerationConcurrency() },
+			expectedValue: 1,
+		},
+		"TSS.RecordTranscripts": {
+			readValueFunc: func(c *Config) interface{} { return c.TSS.RecordTranscripts },
+			expectedValue: true,
+		},
+		"TSS.KeyGenerationProtocolTimeout": {
+			readValueFunc: func(c *Config) interface{} { return c.TSS.GetKeyGenerationProtocolTimeout() },
+			expectedValue: 9 * time.Minute,
+		},
+		"TSS.SigningProtocolTimeout": {
+			readValueFunc: func(c *Config) interface{} { return c.TSS.GetSigningProtocolTimeout() },
+			expectedValue: 12*time.Minute + 30*time.Second,
+		},
+		"TSS.SigningBatchConcurrency": {
+			readValueFunc: func(c *Config) interface{} { return c.TSS.GetSigningBatchConcurrency() },
+			expectedValue: 3,
+		},
+		"TSS.SchnorrSigningProtocolTimeout": {
+			readValueFunc: func(c *Config) interface{} { return c.TSS.GetSchnorrSigningProtocolTimeout() },
+			expectedValue: 4*time.Minute + 30*time.Second,
+		},
+		"TSS.KeyShareRefreshProtocolTimeout": {
+			readValueFunc: func(c *Config) interface{} { return c.TSS.GetKeyShareRefreshProtocolTimeout() },
+			expectedValue: 11 * time.Minute,
+		},
+		"TSS.ProtocolAnnounceTimeout": {
+			readValueFunc: func(c *Config) interface{} { return c.TSS.GetProtocolAnnounceTimeout() },
+			expectedValue: 3 * time.Minute,
+		},
+		"TSS.RecoveryReadyTimeout": {
+			readValueFunc: func(c *Config) interface{} { return c.TSS.GetRecoveryReadyTimeout() },
+			expectedValue: 90 * time.Second,
+		},
+		"Admin.GetAddress()": {
+			readValueFunc: func(c *Config) interface{} { return c.Admin.GetAddress() },
+			expectedValue: "0.0.0.0:9701",
+		},
 		"Extensions.TBTC.TBTCSystem": {
 			readValueFunc: func(c *Config) interface{} { return c.Extensions.TBTC.TBTCSystem },
 			expectedValue: "0xa4888eDD97A5a3A739B4E0807C71817c8a418273",
@@ -95,6 +159,10 @@ func TestReadConfig(t *testing.T) {
 			readValueFunc: func(c *Config) interface{} { return c.Extensions.TBTC.GetLiquidationRecoveryTimeout() },
 			expectedValue: time.Duration(49 * 60 * 60 * 1000000000), // 49 hours in nanoseconds
 		},
+		"Extensions.TBTC.GetBlockConfirmations()": {
+			readValueFunc: func(c *Config) interface{} { return c.Extensions.TBTC.GetBlockConfirmations() },
+			expectedValue: uint64(20),
+		},
 		"Extensions.TBTC.Bitcoin.ElectrsURL": {
 			readValueFunc: func(c *Config) interface{} { return *c.Extensions.TBTC.Bitcoin.ElectrsURL },
 			expectedValue: "example.com",
@@ -227,6 +295,19 @@ func TestDefaultVbyteFee(t *testing.T) {
 	}
 }
 
+func TestAdminAddressWithDefault(t *testing.T) {
+	admin := &Admin{Port: 9701}
+
+	expectedAddress := "localhost:9701"
+	if address := admin.GetAddress(); address != expectedAddress {
+		t.Errorf(
+			"unexpected address\nexpected: [%v]\nactual:   [%v]",
+			expectedAddress,
+			address,
+		)
+	}
+}
+
 func TestElectrsURLWithDefault(t *testing.T) {
 	var electrsURLTests = map[string]struct {
 		url         []string
@@ -14,6 +14,9 @@ import (
 const (
 	testFixtureDirFormat  = "%s/tss"
 	testFixtureFileFormat = "keygen_data_%d.json"
+
+	signerGoldenFileFormat      = "signer_v%d.golden"
+	eddsaSignerGoldenFileFormat = "eddsa_signer_v%d.golden"
 )
 
 // LoadKeygenTestFixtures loads key generation test data.
@@ -52,3 +55,39 @@ func makeTestFixtureFilePath(partyIndex int) string {
 	fixtureDirName := fmt.Sprintf(testFixtureDirFormat, srcDirName)
 	return fmt.Sprintf("%s/"+testFixtureFileFormat, fixtureDirName, partyIndex)
 }
+
+// LoadSignerGoldenFile loads a signer serialized with the given version of
+// the signer serialization format. All golden files contain the same signer
+// of the third member of a five-member group with key share from
+// `keygen_data_2.json`. Golden files of historical versions must never be
+// regenerated as they reflect signers persisted by previous releases.
+func LoadSignerGoldenFile(version uint32) ([]byte, error) {
+	goldenFilePath := fmt.Sprintf(
+		"%s/"+signerGoldenFileFormat,
+		filepath.Dir(makeTestFixtureFilePath(0)),
+		version,
+	)
+
+	// #nosec G304 (file path provided as taint input)
+	// This line is used to read a test fixture file.
+	// There is no user input.
+	return ioutil.ReadFile(goldenFilePath)
+}
+
+// LoadEdDSASignerGoldenFile loads an EdDSA signer serialized with the given
+// version of the EdDSA signer serialization format. The golden file contains
+// a signer of the first member of a three-member group with group ID
+// `test-group-id-1`. Golden files of historical versions must never be
+// regenerated as they reflect signers persisted by previous releases.
+func LoadEdDSASignerGoldenFile(version uint32) ([]byte, error) {
+	goldenFilePath := fmt.Sprintf(
+		"%s/"+eddsaSignerGoldenFileFormat,
+		filepath.Dir(makeTestFixtureFilePath(0)),
+		version,
+	)
+
+	// #nosec G304 (file path provided as taint input)
+	// This line is used to read a test fixture file.
+	// There is no user input.
+	return ioutil.ReadFile(goldenFilePath)
+}
@@ -0,0 +1,301 @@
+package netsim
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/net/retransmission"
+)
+
+type broadcastChannel struct {
+	name     string
+	provider *provider
+
+	counter uint64
+
+	unmarshalers *unmarshalers
+
+	handlersMutex sync.Mutex
+	handlers      []*messageHandler
+
+	filterMutex sync.RWMutex
+	filter      net.BroadcastChannelFilter
+}
+
+type messageHandler struct {
+	ctx     context.Context
+	channel chan net.Message
+}
+
+func newBroadcastChannel(name string, provider *provider) *broadcastChannel {
+	return &broadcastChannel{
+		name:     name,
+		provider: provider,
+		unmarshalers: &unmarshalers{
+			byType: make(map[string]func() net.TaggedUnmarshaler),
+		},
+	}
+}
+
+func (bc *broadcastChannel) Name() string {
+	return bc.name
+}
+
+func (bc *broadcastChannel) Send(
+	ctx context.Context,
+	m net.TaggedMarshaler,
+) error {
+	payload, err := m.Marshal()
+	if err != nil {
+		return fmt.Errorf("could not marshal message: [%v]", err)
+	}
+
+	seqno := atomic.AddUint64(&bc.counter, 1)
+
+	transmit := func() {
+		for _, receiver := range bc.provider.network.getBroadcastChannels(bc.name) {
+			receiver := receiver
+			bc.provider.network.transmit(
+				bc.provider.id.String(),
+				receiver.provider.id.String(),
+				payload,
+				func(payload []byte) {
+					receiver.receive(bc.provider, m.Type(), payload, seqno)
+				},
+			)
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(retransmissionInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				transmit()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	transmit()
+
+	return nil
+}
+
+func (bc *broadcastChannel) receive(
+	sender *provider,
+	messageType string,
+	payload []byte,
+	seqno uint64,
+) {
+	bc.filterMutex.RLock()
+	filter := bc.filter
+	bc.filterMutex.RUnlock()
+
+	if filter != nil && !filter((*ecdsa.PublicKey)(sender.staticKey)) {
+		return
+	}
+
+	unmarshaled, err := bc.unmarshalers.unmarshal(messageType, payload)
+	if err != nil {
+		logger.Warningf(
+			"dropping broadcast message from [%s]: [%v]",
+			sender.id,
+			err,
+		)
+		return
+	}
+
+	msg := &message{
+		transportSenderID: sender.id,
+		senderPublicKey:   key.Marshal(sender.staticKey),
+		payload:           unmarshaled,
+		messageType:       messageType,
+		seqno:             seqno,
+	}
+
+	bc.handlersMutex.Lock()
+	defer bc.handlersMutex.Unlock()
+
+	for _, handler := range bc.handlers {
+		if handler.ctx.Err() != nil {
+			continue
+		}
+
+		select {
+		case handler.channel <- msg:
+		default:
+			logger.Warningf("handler too slow, dropping message")
+		}
+	}
+}
+
+func (bc *broadcastChannel) Recv(
+	ctx context.Context,
+	handler func(m net.Message),
+) {
+	messageHandler := &messageHandler{
+		ctx:     ctx,
+		channel: make(chan net.Message, messageHandlerThrottle),
+	}
+
+	bc.handlersMutex.Lock()
+	bc.handlers = append(bc.handlers, messageHandler)
+	bc.handlersMutex.Unlock()
+
+	handleWithRetransmissions := retransmission.WithRetransmissionSupport(handler)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				bc.removeHandler(messageHandler)
+				return
+			case msg := <-messageHandler.channel:
+				// The handler must not be called once the context is done.
+				if ctx.Err() != nil {
+					continue
+				}
+
+				handleWithRetransmissions(msg)
+			}
+		}
+	}()
+}
+
+func (bc *broadcastChannel) removeHandler(handler *messageHandler) {
+	bc.handlersMutex.Lock()
+	defer bc.handlersMutex.Unlock()
+
+	for i, h := range bc.handlers {
+		if h == handler {
+			bc.handlers = append(bc.handlers[:i], bc.handlers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (bc *broadcastChannel) SetUnmarshaler(
+	unmarshaler func() net.TaggedUnmarshaler,
+) {
+	bc.unmarshalers.set(unmarshaler)
+}
+
+func (bc *broadcastChannel) SetFilter(filter net.BroadcastChannelFilter) error {
+	bc.filterMutex.Lock()
+	defer bc.filterMutex.Unlock()
+
+	bc.filter = filter
+
+	return nil
+}
+
+type unicastChannel struct {
+	provider *provider
+	peer     *provider
+
+	counter uint64
+
+	unmarshalers *unmarshalers
+
+	receiversMutex sync.Mutex
+	receivers      []*unicastChannelReceiver
+}
+
+type unicastChannelReceiver struct {
+	ctx      context.Context
+	handleFn func(m net.Message)
+}
+
+func newUnicastChannel(provider *provider, peer *provider) *unicastChannel {
+	return &unicastChannel{
+		provider: provider,
+		peer:     peer,
+		unmarshalers: &unmarshalers{
+			byType: make(map[string]func() net.TaggedUnmarshaler),
+		},
+	}
+}
+
+func (uc *unicastChannel) Send(m net.TaggedMarshaler) error {
+	payload, err := m.Marshal()
+	if err != nil {
+		return fmt.Errorf("could not marshal message: [%v]", err)
+	}
+
+	// The peer's channel with the current member is opened when the channel
+	// is created, so it always exists.
+	receiver := uc.peer.unicastChannelWith(uc.provider)
+
+	uc.provider.network.transmit(
+		uc.provider.id.String(),
+		uc.peer.id.String(),
+		payload,
+		func(payload []byte) {
+			receiver.receive(m.Type(), payload)
+		},
+	)
+
+	return nil
+}
+
+func (uc *unicastChannel) receive(messageType string, payload []byte) {
+	unmarshaled, err := uc.unmarshalers.unmarshal(messageType, payload)
+	if err != nil {
+		logger.Warningf(
+			"dropping unicast message from [%s]: [%v]",
+			uc.peer.id,
+			err,
+		)
+		return
+	}
+
+	msg := &message{
+		transportSenderID: uc.peer.id,
+		senderPublicKey:   key.Marshal(uc.peer.staticKey),
+		payload:           unmarshaled,
+		messageType:       messageType,
+		seqno:             atomic.AddUint64(&uc.counter, 1),
+	}
+
+	uc.receiversMutex.Lock()
+	defer uc.receiversMutex.Unlock()
+
+	active := uc.receivers[:0]
+	for _, receiver := range uc.receivers {
+		if receiver.ctx.Err() != nil {
+			continue
+		}
+
+		active = append(active, receiver)
+
+		// Handlers are fired asynchronously to not block the delivery.
+		go receiver.handleFn(msg)
+	}
+	uc.receivers = active
+}
+
+func (uc *unicastChannel) Recv(ctx context.Context, handler func(m net.Message)) {
+	uc.receiversMutex.Lock()
+	defer uc.receiversMutex.Unlock()
+
+	uc.receivers = append(
+		uc.receivers,
+		&unicastChannelReceiver{ctx: ctx, handleFn: handler},
+	)
+}
+
+func (uc *unicastChannel) SetUnmarshaler(
+	unmarshaler func() net.TaggedUnmarshaler,
+) {
+	uc.unmarshalers.set(unmarshaler)
+}
@@ -0,0 +1,456 @@
+// Package netsim provides a simulated implementation of the network provider
+// for tests executing multi-party protocols. Unlike the local provider from
+// keep-core, the simulated network can drop, delay, duplicate, reorder and
+// corrupt messages sent by selected members.
+//
+// Faults are injected based on a seeded source of randomness kept separately
+// for each pair of members. Decisions about the n-th message sent between two
+// members depend only on the seed, so a failure observed with the given seed
+// can be reproduced as long as members send messages in the same order.
+// Broadcast messages are retransmitted periodically, the same way as in
+// the real network, so the number of retransmissions depends on timing.
+package netsim
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+)
+
+var logger = log.Logger("keep-netsim")
+
+const (
+	// Interval of broadcast messages retransmissions.
+	retransmissionInterval = 50 * time.Millisecond
+
+	// Delay of reordered messages on top of the maximum delay of the sender
+	// so that messages sent after the reordered one are delivered before it.
+	reorderDelay = 100 * time.Millisecond
+
+	// Capacity of the buffer of messages waiting to be handled by a broadcast
+	// channel handler. Messages exceeding the capacity are dropped.
+	messageHandlerThrottle = 256
+)
+
+// Faults describes faults injected into messages sent by a member. Rates are
+// probabilities from 0 to 1 applied independently to each transmission of
+// a message to each receiver. Messages delivered by a member to itself are
+// never affected.
+type Faults struct {
+	// DropRate is the probability that the message is not delivered.
+	DropRate float64
+	// DuplicateRate is the probability that the message is delivered twice.
+	DuplicateRate float64
+	// CorruptRate is the probability that one byte of the message payload is
+	// flipped. Messages which cannot be unmarshaled after the corruption are
+	// not delivered.
+	CorruptRate float64
+	// ReorderRate is the probability that the message is held back and
+	// delivered after messages sent after it.
+	ReorderRate float64
+	// MaxDelay is the maximum delay of the message delivery. Each message is
+	// delayed by a random period from zero to MaxDelay.
+	MaxDelay time.Duration
+}
+
+// Network is a simulated network connecting providers created with Connect.
+type Network struct {
+	seed int64
+
+	mutex             sync.Mutex
+	providers         map[string]*provider
+	faults            map[string]Faults
+	links             map[string]*rand.Rand
+	broadcastChannels map[string][]*broadcastChannel
+}
+
+// NewNetwork creates a simulated network injecting faults based on the given
+// seed.
+func NewNetwork(seed int64) *Network {
+	return &Network{
+		seed:              seed,
+		providers:         make(map[string]*provider),
+		faults:            make(map[string]Faults),
+		links:             make(map[string]*rand.Rand),
+		broadcastChannels: make(map[string][]*broadcastChannel),
+	}
+}
+
+// Connect returns a network provider of a member identified by the given
+// network key. Each member should be connected once.
+func (n *Network) Connect(staticKey *key.NetworkPublic) net.Provider {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	id := createIdentifier(staticKey)
+
+	p := &provider{
+		id:                     id,
+		staticKey:              staticKey,
+		network:                n,
+		unicastChannels:        make(map[string]*unicastChannel),
+		broadcastChannels:      make(map[string]*broadcastChannel),
+		onUnicastChannelOpened: make([]func(net.UnicastChannel), 0),
+		onUnicastChannelMutex:  &sync.Mutex{},
+		unicastChannelsMutex:   &sync.Mutex{},
+		broadcastChannelsMutex: &sync.Mutex{},
+	}
+
+	n.providers[id.String()] = p
+
+	return p
+}
+
+// SetFaults sets faults injected into messages sent by the member identified
+// by the given network key. Faults can be changed at any time, e.g. between
+// protocol executions.
+func (n *Network) SetFaults(staticKey *key.NetworkPublic, faults Faults) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.faults[createIdentifier(staticKey).String()] = faults
+}
+
+// transmit transmits the payload from the sender to the receiver injecting
+// faults configured for the sender. The deliver function is called for each
+// delivered copy of the payload.
+func (n *Network) transmit(
+	senderID string,
+	receiverID string,
+	payload []byte,
+	deliver func(payload []byte),
+) {
+	if senderID == receiverID {
+		deliver(payload)
+		return
+	}
+
+	n.mutex.Lock()
+	faults := n.faults[senderID]
+	link := n.link(senderID, receiverID)
+
+	// All the values are drawn for each message, regardless of the faults
+	// configured, so that decisions about a message depend only on its
+	// position on the link.
+	drop := link.Float64() < faults.DropRate
+	duplicate := link.Float64() < faults.DuplicateRate
+	corrupt := link.Float64() < faults.CorruptRate
+	reorder := link.Float64() < faults.ReorderRate
+	delayFraction := link.Float64()
+	corruptedIndex := link.Int()
+	n.mutex.Unlock()
+
+	if drop {
+		return
+	}
+
+	if corrupt && len(payload) > 0 {
+		corrupted := make([]byte, len(payload))
+		copy(corrupted, payload)
+		corrupted[corruptedIndex%len(corrupted)] ^= 0xff
+		payload = corrupted
+	}
+
+	delay := time.Duration(delayFraction * float64(faults.MaxDelay))
+	if reorder {
+		delay += faults.MaxDelay + reorderDelay
+	}
+
+	copies := 1
+	if duplicate {
+		copies = 2
+	}
+
+	for i := 0; i < copies; i++ {
+		if delay == 0 {
+			deliver(payload)
+		} else {
+			time.AfterFunc(delay, func() { deliver(payload) })
+		}
+	}
+}
+
+// link returns the source of randomness for messages sent from the sender to
+// the receiver. It must be called with the network mutex locked.
+func (n *Network) link(senderID string, receiverID string) *rand.Rand {
+	linkID := senderID + "->" + receiverID
+
+	link, ok := n.links[linkID]
+	if !ok {
+		linkHash := sha256.Sum256([]byte(linkID))
+		linkSeed := n.seed ^ int64(binary.BigEndian.Uint64(linkHash[:8]))
+
+		// #nosec G404 (insecure random number source (rand))
+		// Simulated faults do not require secure randomness.
+		link = rand.New(rand.NewSource(linkSeed))
+		n.links[linkID] = link
+	}
+
+	return link
+}
+
+func (n *Network) getProvider(id string) (*provider, bool) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	p, ok := n.providers[id]
+	return p, ok
+}
+
+func (n *Network) peers(id string) []*provider {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	peers := make([]*provider, 0, len(n.providers))
+	for peerID, peer := range n.providers {
+		if peerID != id {
+			peers = append(peers, peer)
+		}
+	}
+
+	return peers
+}
+
+func (n *Network) registerBroadcastChannel(channel *broadcastChannel) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.broadcastChannels[channel.name] = append(
+		n.broadcastChannels[channel.name],
+		channel,
+	)
+}
+
+func (n *Network) getBroadcastChannels(name string) []*broadcastChannel {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return append([]*broadcastChannel{}, n.broadcastChannels[name]...)
+}
+
+type identifier string
+
+func (i identifier) String() string {
+	return string(i)
+}
+
+func createIdentifier(staticKey *key.NetworkPublic) identifier {
+	return identifier(hex.EncodeToString(key.Marshal(staticKey)))
+}
+
+type provider struct {
+	id        identifier
+	staticKey *key.NetworkPublic
+	network   *Network
+
+	unicastChannelsMutex *sync.Mutex
+	unicastChannels      map[string]*unicastChannel
+
+	onUnicastChannelMutex  *sync.Mutex
+	onUnicastChannelOpened []func(net.UnicastChannel)
+
+	broadcastChannelsMutex *sync.Mutex
+	broadcastChannels      map[string]*broadcastChannel
+}
+
+func (p *provider) ID() net.TransportIdentifier {
+	return p.id
+}
+
+func (p *provider) Type() string {
+	return "netsim"
+}
+
+func (p *provider) UnicastChannelWith(
+	peerID net.TransportIdentifier,
+) (net.UnicastChannel, error) {
+	peer, ok := p.network.getProvider(peerID.String())
+	if !ok {
+		return nil, fmt.Errorf("remote peer not known [%v]", peerID)
+	}
+
+	channel := p.unicastChannelWith(peer)
+	peer.unicastChannelWith(p)
+
+	return channel, nil
+}
+
+// unicastChannelWith returns the unicast channel with the given peer. If the
+// channel is opened by the call, handlers registered with
+// OnUnicastChannelOpened are notified.
+func (p *provider) unicastChannelWith(peer *provider) *unicastChannel {
+	p.unicastChannelsMutex.Lock()
+	channel, ok := p.unicastChannels[peer.id.String()]
+	if !ok {
+		channel = newUnicastChannel(p, peer)
+		p.unicastChannels[peer.id.String()] = channel
+	}
+	p.unicastChannelsMutex.Unlock()
+
+	if !ok {
+		p.onUnicastChannelMutex.Lock()
+		handlers := append([]func(net.UnicastChannel){}, p.onUnicastChannelOpened...)
+		p.onUnicastChannelMutex.Unlock()
+
+		for _, handler := range handlers {
+			go handler(channel)
+		}
+	}
+
+	return channel
+}
+
+func (p *provider) OnUnicastChannelOpened(
+	handler func(channel net.UnicastChannel),
+) {
+	p.onUnicastChannelMutex.Lock()
+	defer p.onUnicastChannelMutex.Unlock()
+
+	p.onUnicastChannelOpened = append(p.onUnicastChannelOpened, handler)
+}
+
+func (p *provider) BroadcastChannelFor(name string) (net.BroadcastChannel, error) {
+	p.broadcastChannelsMutex.Lock()
+	defer p.broadcastChannelsMutex.Unlock()
+
+	channel, ok := p.broadcastChannels[name]
+	if !ok {
+		channel = newBroadcastChannel(name, p)
+		p.broadcastChannels[name] = channel
+		p.network.registerBroadcastChannel(channel)
+	}
+
+	return channel, nil
+}
+
+func (p *provider) ConnectionManager() net.ConnectionManager {
+	return &connectionManager{p}
+}
+
+func (p *provider) CreateTransportIdentifier(
+	publicKey ecdsa.PublicKey,
+) (net.TransportIdentifier, error) {
+	networkPublicKey := key.NetworkPublic(publicKey)
+	return createIdentifier(&networkPublicKey), nil
+}
+
+func (p *provider) BroadcastChannelForwarderFor(name string) {
+	// no-op
+}
+
+type connectionManager struct {
+	provider *provider
+}
+
+func (cm *connectionManager) ConnectedPeers() []string {
+	peers := cm.provider.network.peers(cm.provider.id.String())
+
+	connectedPeers := make([]string, len(peers))
+	for i, peer := range peers {
+		connectedPeers[i] = peer.id.String()
+	}
+
+	return connectedPeers
+}
+
+func (cm *connectionManager) GetPeerPublicKey(
+	connectedPeer string,
+) (*key.NetworkPublic, error) {
+	peer, ok := cm.provider.network.getProvider(connectedPeer)
+	if !ok {
+		return nil, fmt.Errorf("peer not known [%v]", connectedPeer)
+	}
+
+	return peer.staticKey, nil
+}
+
+func (cm *connectionManager) DisconnectPeer(connectedPeer string) {
+	// no-op
+}
+
+func (cm *connectionManager) AddrStrings() []string {
+	return make([]string, 0)
+}
+
+func (cm *connectionManager) IsConnected(address string) bool {
+	_, ok := cm.provider.network.getProvider(address)
+	return ok
+}
+
+// message is a message delivered by the simulated network.
+type message struct {
+	transportSenderID net.TransportIdentifier
+	senderPublicKey   []byte
+	payload           interface{}
+	messageType       string
+	seqno             uint64
+}
+
+func (m *message) TransportSenderID() net.TransportIdentifier {
+	return m.transportSenderID
+}
+
+func (m *message) SenderPublicKey() []byte {
+	return m.senderPublicKey
+}
+
+func (m *message) Payload() interface{} {
+	return m.payload
+}
+
+func (m *message) Type() string {
+	return m.messageType
+}
+
+func (m *message) Seqno() uint64 {
+	return m.seqno
+}
+
+// unmarshalers holds unmarshalers registered for a channel.
+type unmarshalers struct {
+	mutex  sync.RWMutex
+	byType map[string]func() net.TaggedUnmarshaler
+}
+
+func (u *unmarshalers) set(unmarshaler func() net.TaggedUnmarshaler) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.byType[unmarshaler().Type()] = unmarshaler
+}
+
+func (u *unmarshalers) unmarshal(
+	messageType string,
+	payload []byte,
+) (net.TaggedUnmarshaler, error) {
+	u.mutex.RLock()
+	unmarshaler, ok := u.byType[messageType]
+	u.mutex.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf(
+			"couldn't find unmarshaler for type [%s]",
+			messageType,
+		)
+	}
+
+	unmarshaled := unmarshaler()
+	if err := unmarshaled.Unmarshal(payload); err != nil {
+		return nil, fmt.Errorf(
+			"could not unmarshal message of type [%s]: [%v]",
+			messageType,
+			err,
+		)
+	}
+
+	return unmarshaled, nil
+}
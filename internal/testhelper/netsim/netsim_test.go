@@ -0,0 +1,355 @@
+package netsim
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+)
+
+func TestBroadcastChannel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	network := NewNetwork(1)
+	members := connectMembers(t, network, generateKeys(t, 3))
+
+	receivers := make([]*testReceiver, len(members))
+	for i, member := range members {
+		channel, err := member.provider.BroadcastChannelFor("test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		channel.SetUnmarshaler(newTestMessage)
+
+		receivers[i] = newTestReceiver()
+		channel.Recv(ctx, receivers[i].handle)
+	}
+
+	channel, err := members[0].provider.BroadcastChannelFor("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := channel.Send(ctx, &testMessage{[]byte("hello")}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Wait for retransmissions which should be filtered out.
+	time.Sleep(3 * retransmissionInterval)
+
+	for i, receiver := range receivers {
+		expectedMessages := []string{"hello"}
+		if !reflect.DeepEqual(expectedMessages, receiver.messages()) {
+			t.Errorf(
+				"unexpected messages of member [%d]\nexpected: [%v]\nactual:   [%v]",
+				i,
+				expectedMessages,
+				receiver.messages(),
+			)
+		}
+	}
+}
+
+func TestBroadcastChannelRetransmitsDroppedMessages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	network := NewNetwork(1)
+	members := connectMembers(t, network, generateKeys(t, 2))
+
+	network.SetFaults(members[0].staticKey, Faults{DropRate: 0.8})
+
+	receiver := newTestReceiver()
+
+	receiverChannel, err := members[1].provider.BroadcastChannelFor("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiverChannel.SetUnmarshaler(newTestMessage)
+	receiverChannel.Recv(ctx, receiver.handle)
+
+	senderChannel, err := members[0].provider.BroadcastChannelFor("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	senderChannel.SetUnmarshaler(newTestMessage)
+
+	if err := senderChannel.Send(ctx, &testMessage{[]byte("hello")}); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(40 * retransmissionInterval)
+
+	expectedMessages := []string{"hello"}
+	if !reflect.DeepEqual(expectedMessages, receiver.messages()) {
+		t.Errorf(
+			"unexpected messages\nexpected: [%v]\nactual:   [%v]",
+			expectedMessages,
+			receiver.messages(),
+		)
+	}
+}
+
+func TestUnicastChannelFaults(t *testing.T) {
+	var tests = map[string]struct {
+		faults           Faults
+		expectedMessages []string
+	}{
+		"no faults": {
+			faults:           Faults{},
+			expectedMessages: []string{"hello"},
+		},
+		"drop": {
+			faults:           Faults{DropRate: 1},
+			expectedMessages: []string{},
+		},
+		"duplicate": {
+			faults:           Faults{DuplicateRate: 1},
+			expectedMessages: []string{"hello", "hello"},
+		},
+		"delay": {
+			faults:           Faults{MaxDelay: 10 * time.Millisecond},
+			expectedMessages: []string{"hello"},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			network := NewNetwork(1)
+			members := connectMembers(t, network, generateKeys(t, 2))
+
+			network.SetFaults(members[0].staticKey, test.faults)
+
+			link := newTestLink(t, members[0], members[1])
+			link.send(t, "hello")
+			waitForDelivery()
+
+			if !reflect.DeepEqual(test.expectedMessages, link.receiver.messages()) {
+				t.Errorf(
+					"unexpected messages\nexpected: [%v]\nactual:   [%v]",
+					test.expectedMessages,
+					link.receiver.messages(),
+				)
+			}
+		})
+	}
+}
+
+func TestUnicastChannelCorruption(t *testing.T) {
+	network := NewNetwork(1)
+	members := connectMembers(t, network, generateKeys(t, 2))
+
+	network.SetFaults(members[0].staticKey, Faults{CorruptRate: 1})
+
+	link := newTestLink(t, members[0], members[1])
+	link.send(t, "hello")
+	waitForDelivery()
+
+	messages := link.receiver.messages()
+	if len(messages) != 1 {
+		t.Fatalf(
+			"unexpected number of messages\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(messages),
+		)
+	}
+
+	differentBytes := 0
+	for i := range messages[0] {
+		if messages[0][i] != "hello"[i] {
+			differentBytes++
+		}
+	}
+
+	if differentBytes != 1 {
+		t.Errorf(
+			"unexpected number of corrupted bytes\nexpected: [%v]\nactual:   [%v]",
+			1,
+			differentBytes,
+		)
+	}
+}
+
+func TestUnicastChannelReorder(t *testing.T) {
+	network := NewNetwork(1)
+	members := connectMembers(t, network, generateKeys(t, 2))
+
+	link := newTestLink(t, members[0], members[1])
+
+	network.SetFaults(members[0].staticKey, Faults{ReorderRate: 1})
+	link.send(t, "first")
+
+	network.SetFaults(members[0].staticKey, Faults{})
+	link.send(t, "second")
+
+	waitForDelivery()
+
+	expectedMessages := []string{"second", "first"}
+	if !reflect.DeepEqual(expectedMessages, link.receiver.messages()) {
+		t.Errorf(
+			"unexpected messages\nexpected: [%v]\nactual:   [%v]",
+			expectedMessages,
+			link.receiver.messages(),
+		)
+	}
+}
+
+func TestFaultsDeterminism(t *testing.T) {
+	keys := generateKeys(t, 2)
+
+	deliveredMessages := func(seed int64) []string {
+		network := NewNetwork(seed)
+		members := connectMembers(t, network, keys)
+
+		network.SetFaults(members[0].staticKey, Faults{DropRate: 0.5})
+
+		link := newTestLink(t, members[0], members[1])
+		for i := 0; i < 50; i++ {
+			link.send(t, fmt.Sprintf("message-%d", i))
+		}
+		waitForDelivery()
+
+		return link.receiver.messages()
+	}
+
+	if !reflect.DeepEqual(deliveredMessages(1), deliveredMessages(1)) {
+		t.Errorf("different messages delivered with the same seed")
+	}
+
+	if reflect.DeepEqual(deliveredMessages(1), deliveredMessages(2)) {
+		t.Errorf("the same messages delivered with different seeds")
+	}
+}
+
+type testMember struct {
+	staticKey *key.NetworkPublic
+	provider  net.Provider
+}
+
+func generateKeys(t *testing.T, count int) []*key.NetworkPublic {
+	keys := make([]*key.NetworkPublic, count)
+	for i := range keys {
+		_, staticKey, err := key.GenerateStaticNetworkKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		keys[i] = staticKey
+	}
+
+	return keys
+}
+
+func connectMembers(
+	t *testing.T,
+	network *Network,
+	keys []*key.NetworkPublic,
+) []*testMember {
+	members := make([]*testMember, len(keys))
+	for i, staticKey := range keys {
+		members[i] = &testMember{
+			staticKey: staticKey,
+			provider:  network.Connect(staticKey),
+		}
+	}
+
+	return members
+}
+
+// testLink is a unicast channel from the sender to the receiver together
+// with messages received by the receiver.
+type testLink struct {
+	channel  net.UnicastChannel
+	receiver *testReceiver
+}
+
+func newTestLink(t *testing.T, sender, receiver *testMember) *testLink {
+	senderChannel, err := sender.provider.UnicastChannelWith(
+		receiver.provider.ID(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	receiverChannel, err := receiver.provider.UnicastChannelWith(
+		sender.provider.ID(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testReceiver := newTestReceiver()
+	receiverChannel.SetUnmarshaler(newTestMessage)
+	receiverChannel.Recv(context.Background(), testReceiver.handle)
+
+	return &testLink{
+		channel:  senderChannel,
+		receiver: testReceiver,
+	}
+}
+
+func (tl *testLink) send(t *testing.T, message string) {
+	if err := tl.channel.Send(&testMessage{[]byte(message)}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Handlers are fired asynchronously so wait a bit to preserve the order
+	// of messages.
+	time.Sleep(time.Millisecond)
+}
+
+// waitForDelivery waits until all the messages, including the reordered ones,
+// are delivered.
+func waitForDelivery() {
+	time.Sleep(reorderDelay + 50*time.Millisecond)
+}
+
+type testReceiver struct {
+	mutex    sync.Mutex
+	received []string
+}
+
+func newTestReceiver() *testReceiver {
+	return &testReceiver{received: []string{}}
+}
+
+func (tr *testReceiver) handle(m net.Message) {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	tr.received = append(tr.received, string(m.Payload().(*testMessage).payload))
+}
+
+func (tr *testReceiver) messages() []string {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	return append([]string{}, tr.received...)
+}
+
+type testMessage struct {
+	payload []byte
+}
+
+func newTestMessage() net.TaggedUnmarshaler {
+	return &testMessage{}
+}
+
+func (tm *testMessage) Type() string {
+	return "netsim/test_message"
+}
+
+func (tm *testMessage) Marshal() ([]byte, error) {
+	return tm.payload, nil
+}
+
+func (tm *testMessage) Unmarshal(payload []byte) error {
+	tm.payload = append([]byte{}, payload...)
+	return nil
+}
@@ -12,6 +12,8 @@ type PersistenceHandleMock struct {
 	PersistedGroups  []*TestFileInfo
 	Snapshots        []*TestFileInfo
 	ArchivedGroups   []string
+	ArchivedFiles    []*TestFileInfo
+	DeletedGroups    []string
 	outputDataChan   chan persistence.DataDescriptor
 	outputErrorsChan chan error
 }
@@ -67,6 +69,22 @@ func (phm *PersistenceHandleMock) MockSigner(membershipIndex int, keepID string,
 	return nil
 }
 
+// MockEdDSASigner registers a mock of an EdDSA signer for membership and keep.
+func (phm *PersistenceHandleMock) MockEdDSASigner(membershipIndex int, keepID string, signer *tss.EdDSAThresholdSigner) error {
+	signerBytes, err := signer.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal signer: %w", err)
+	}
+
+	phm.outputDataChan <- &testDataDescriptor{
+		fmt.Sprintf("/eddsa_membership_%d", membershipIndex),
+		keepID,
+		signerBytes,
+	}
+
+	return nil
+}
+
 // ReadAll reads all data stored in persistence handle.
 func (phm *PersistenceHandleMock) ReadAll() (<-chan persistence.DataDescriptor, <-chan error) {
 	close(phm.outputDataChan)
@@ -83,6 +101,60 @@ func (phm *PersistenceHandleMock) Archive(directory string) error {
 	return nil
 }
 
+// ArchiveData archives data under a single file in persistence handle.
+func (phm *PersistenceHandleMock) ArchiveData(data []byte, directory string, name string) error {
+	phm.ArchivedFiles = append(
+		phm.ArchivedFiles,
+		&TestFileInfo{Data: data, Directory: directory, Name: name},
+	)
+
+	return nil
+}
+
+// Delete deletes a directory in persistence handle.
+func (phm *PersistenceHandleMock) Delete(directory string) error {
+	phm.DeletedGroups = append(phm.DeletedGroups, directory)
+
+	return nil
+}
+
+// DeleteLatestSnapshot deletes the latest snapshot of a file in persistence
+// handle.
+func (phm *PersistenceHandleMock) DeleteLatestSnapshot(directory string, name string) error {
+	for i := len(phm.Snapshots) - 1; i >= 0; i-- {
+		snapshot := phm.Snapshots[i]
+		if snapshot.Directory == directory && snapshot.Name == name {
+			phm.Snapshots = append(phm.Snapshots[:i], phm.Snapshots[i+1:]...)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no snapshot of file [%s]", name)
+}
+
+// ReadAllRetained reads all snapshots and archived data stored in persistence
+// handle. No data are retained by the mock.
+func (phm *PersistenceHandleMock) ReadAllRetained() (<-chan persistence.DataDescriptor, <-chan error) {
+	dataChan := make(chan persistence.DataDescriptor)
+	errorsChan := make(chan error)
+
+	close(dataChan)
+	close(errorsChan)
+
+	return dataChan, errorsChan
+}
+
+// ReplaceRetained replaces a snapshot or archived file in persistence handle.
+// No data are retained by the mock.
+func (phm *PersistenceHandleMock) ReplaceRetained(data []byte, directory string, name string) error {
+	return fmt.Errorf("no retained file [%s]", name)
+}
+
+// Close closes persistence handle.
+func (phm *PersistenceHandleMock) Close() error {
+	return nil
+}
+
 type testDataDescriptor struct {
 	name      string
 	directory string
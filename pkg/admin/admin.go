@@ -0,0 +1,153 @@
+// Package admin provides an HTTP API exposing the runtime state of the client
+// to the operator: keeps the client is a member of, protocols in progress and
+// TSS pre-parameters pool. All endpoints are read-only and respond with JSON.
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-ecdsa/pkg/client"
+	"github.com/keep-network/keep-ecdsa/pkg/node"
+)
+
+var logger = log.Logger("keep-admin")
+
+// Paths under which the admin API endpoints are registered.
+const (
+	KeepsPath     = "/admin/keeps"
+	ProtocolsPath = "/admin/protocols"
+	PreParamsPath = "/admin/pre-params"
+)
+
+// Time within which the admin API server reads request headers. It protects
+// the server against clients holding connections open without sending
+// a request.
+const readHeaderTimeout = 10 * time.Second
+
+// Client is the source of the client state exposed by the admin API.
+type Client interface {
+	Keeps() []*client.KeepStatus
+	ProtocolExecutions() []*node.ProtocolExecution
+	ProtocolsInProgress() int
+	TSSPreParamsPoolSize() int
+	TSSPreParamsPoolTargetSize() int
+	TSSPreParamsGenerationsInProgress() int
+}
+
+// ProtocolsStatus describes protocols in progress. Executions list key
+// generation and signing executions with their current attempts. InProgress
+// is the number of all protocols the client waits for on shutdown, including
+// key share refreshes.
+type ProtocolsStatus struct {
+	InProgress int                       `json:"inProgress"`
+	Executions []*node.ProtocolExecution `json:"executions"`
+}
+
+// PreParamsStatus describes the TSS pre-parameters pool.
+type PreParamsStatus struct {
+	PoolSize              int `json:"poolSize"`
+	PoolTargetSize        int `json:"poolTargetSize"`
+	GenerationsInProgress int `json:"generationsInProgress"`
+}
+
+// Serve starts a server exposing the admin API on the given address. The server
+// uses its own multiplexer, so the admin API is not exposed by other servers of
+// the client serving the default one. The server is closed when the context is
+// done.
+func Serve(ctx context.Context, address string, c Client) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("could not listen on [%s]: [%v]", address, err)
+	}
+
+	serve(ctx, listener, c)
+
+	return nil
+}
+
+func serve(ctx context.Context, listener net.Listener, c Client) {
+	mux := http.NewServeMux()
+	RegisterHandlers(mux, c)
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	go func() {
+		err := server.Serve(listener)
+		if err != nil && err != http.ErrServerClosed {
+			logger.Errorf("admin API server failed: [%v]", err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+
+		if err := server.Close(); err != nil {
+			logger.Errorf("could not close admin API server: [%v]", err)
+		}
+	}()
+}
+
+// RegisterHandlers registers handlers of the admin API endpoints in the given
+// multiplexer.
+func RegisterHandlers(mux *http.ServeMux, c Client) {
+	mux.HandleFunc(KeepsPath, handler(func() interface{} {
+		return c.Keeps()
+	}))
+
+	mux.HandleFunc(ProtocolsPath, handler(func() interface{} {
+		return &ProtocolsStatus{
+			InProgress: c.ProtocolsInProgress(),
+			Executions: c.ProtocolExecutions(),
+		}
+	}))
+
+	mux.HandleFunc(PreParamsPath, handler(func() interface{} {
+		return &PreParamsStatus{
+			PoolSize:              c.TSSPreParamsPoolSize(),
+			PoolTargetSize:        c.TSSPreParamsPoolTargetSize(),
+			GenerationsInProgress: c.TSSPreParamsGenerationsInProgress(),
+		}
+	}))
+}
+
+// handler returns an HTTP handler responding to GET requests with the JSON
+// representation of the value returned by the given function.
+func handler(value func() interface{}) http.HandlerFunc {
+	return func(response http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodGet {
+			response.Header().Set("Allow", http.MethodGet)
+			http.Error(
+				response,
+				http.StatusText(http.StatusMethodNotAllowed),
+				http.StatusMethodNotAllowed,
+			)
+			return
+		}
+
+		bytes, err := json.Marshal(value())
+		if err != nil {
+			logger.Errorf("could not serialize response: [%v]", err)
+			http.Error(
+				response,
+				http.StatusText(http.StatusInternalServerError),
+				http.StatusInternalServerError,
+			)
+			return
+		}
+
+		response.Header().Set("Content-Type", "application/json")
+		if _, err := response.Write(bytes); err != nil {
+			logger.Errorf("could not write response: [%v]", err)
+		}
+	}
+}
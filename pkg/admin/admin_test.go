@@ -0,0 +1,215 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-ecdsa/pkg/client"
+	"github.com/keep-network/keep-ecdsa/pkg/client/lifecycle"
+	"github.com/keep-network/keep-ecdsa/pkg/node"
+)
+
+type testClient struct {
+	keeps      []*client.KeepStatus
+	executions []*node.ProtocolExecution
+}
+
+func (tc *testClient) Keeps() []*client.KeepStatus {
+	return tc.keeps
+}
+
+func (tc *testClient) ProtocolExecutions() []*node.ProtocolExecution {
+	return tc.executions
+}
+
+func (tc *testClient) ProtocolsInProgress() int {
+	return 3
+}
+
+func (tc *testClient) TSSPreParamsPoolSize() int {
+	return 5
+}
+
+func (tc *testClient) TSSPreParamsPoolTargetSize() int {
+	return 20
+}
+
+func (tc *testClient) TSSPreParamsGenerationsInProgress() int {
+	return 2
+}
+
+func TestEndpoints(t *testing.T) {
+	isActive := true
+	stateUpdatedAt := time.Unix(1600000000, 0).UTC()
+
+	tc := &testClient{
+		keeps: []*client.KeepStatus{
+			{
+				KeepID:            "0x770a9E2F2Aa1eC2d3Ca916Fc3e6A55058A898632",
+				SignatureScheme:   "ECDSA",
+				Active:            &isActive,
+				State:             lifecycle.Active,
+				StateUpdatedAt:    &stateUpdatedAt,
+				PendingSignatures: []string{"0102"},
+			},
+		},
+		executions: []*node.ProtocolExecution{
+			{
+				KeepID:    "0x770a9E2F2Aa1eC2d3Ca916Fc3e6A55058A898632",
+				Stage:     node.SigningStage,
+				Digest:    "0102",
+				StartedAt: time.Unix(1600000000, 0).UTC(),
+				Attempt:   2,
+			},
+		},
+	}
+
+	mux := http.NewServeMux()
+	RegisterHandlers(mux, tc)
+
+	var tests = map[string]struct {
+		path          string
+		expectedValue interface{}
+		actualValue   interface{}
+	}{
+		"keeps": {
+			path:          KeepsPath,
+			expectedValue: &tc.keeps,
+			actualValue:   &[]*client.KeepStatus{},
+		},
+		"protocols": {
+			path: ProtocolsPath,
+			expectedValue: &ProtocolsStatus{
+				InProgress: 3,
+				Executions: tc.executions,
+			},
+			actualValue: &ProtocolsStatus{},
+		},
+		"pre-params": {
+			path: PreParamsPath,
+			expectedValue: &PreParamsStatus{
+				PoolSize:              5,
+				PoolTargetSize:        20,
+				GenerationsInProgress: 2,
+			},
+			actualValue: &PreParamsStatus{},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(
+				recorder,
+				httptest.NewRequest(http.MethodGet, test.path, nil),
+			)
+
+			if recorder.Code != http.StatusOK {
+				t.Fatalf(
+					"unexpected status code\nexpected: [%v]\nactual:   [%v]",
+					http.StatusOK,
+					recorder.Code,
+				)
+			}
+
+			err := json.Unmarshal(recorder.Body.Bytes(), test.actualValue)
+			if err != nil {
+				t.Fatalf("could not unmarshal response: [%v]", err)
+			}
+
+			if !reflect.DeepEqual(test.expectedValue, test.actualValue) {
+				t.Errorf(
+					"unexpected response\nexpected: [%+v]\nactual:   [%+v]",
+					test.expectedValue,
+					test.actualValue,
+				)
+			}
+		})
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	mux := http.NewServeMux()
+	RegisterHandlers(mux, &testClient{})
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(
+		recorder,
+		httptest.NewRequest(http.MethodPost, KeepsPath, nil),
+	)
+
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf(
+			"unexpected status code\nexpected: [%v]\nactual:   [%v]",
+			http.StatusMethodNotAllowed,
+			recorder.Code,
+		)
+	}
+}
+
+func TestServe(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	serve(ctx, listener, &testClient{})
+
+	url := fmt.Sprintf("http://%s%s", listener.Addr(), PreParamsPath)
+
+	response, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf(
+			"unexpected status code\nexpected: [%v]\nactual:   [%v]",
+			http.StatusOK,
+			response.StatusCode,
+		)
+	}
+
+	// Handlers of the admin API are not registered in the default
+	// multiplexer served by other servers of the client.
+	recorder := httptest.NewRecorder()
+	http.DefaultServeMux.ServeHTTP(
+		recorder,
+		httptest.NewRequest(http.MethodGet, PreParamsPath, nil),
+	)
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf(
+			"unexpected status code of the default multiplexer\n"+
+				"expected: [%v]\nactual:   [%v]",
+			http.StatusNotFound,
+			recorder.Code,
+		)
+	}
+
+	cancel()
+
+	// The server is closed asynchronously once the context is done.
+	for i := 0; ; i++ {
+		response, err := http.Get(url)
+		if err != nil {
+			break
+		}
+		response.Body.Close()
+
+		if i == 100 {
+			t.Fatalf("server should be closed once the context is done")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -16,13 +16,13 @@ import (
 var logger = log.Logger("keep-bitcoin")
 
 const (
-	// defaultTimeout defines a period within which the member tries to call Electrs
-	// API. If the time is reached an error will be returned.
+	// ElectrsTimeout defines a period within which the member tries to call
+	// Electrs API. If the time is reached an error will be returned.
 	//
-	// It is important that this value is less than the timeout used for a
-	// liquidation recovery protocol (recoveryProtocolReadyTimeout), so the nodes
+	// It is important that this value is less than the ready timeout used for
+	// a liquidation recovery protocol (TSS.RecoveryReadyTimeout), so the nodes
 	// can correctly synchronize liquidation protocol execution.
-	defaultTimeout = 1 * time.Minute
+	ElectrsTimeout = 1 * time.Minute
 )
 
 type httpClient interface {
@@ -42,7 +42,7 @@ func Connect(apiURL string) Handle {
 	return &electrsConnection{
 		apiURL:  apiURL,
 		client:  http.DefaultClient,
-		timeout: defaultTimeout,
+		timeout: ElectrsTimeout,
 	}
 }
 
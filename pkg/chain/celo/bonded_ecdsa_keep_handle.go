@@ -3,6 +3,7 @@
 package celo
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"sort"
@@ -14,6 +15,7 @@ import (
 	"github.com/keep-network/keep-common/pkg/chain/ethlike"
 	"github.com/keep-network/keep-common/pkg/subscription"
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/chain/gen/celo/abi"
 	"github.com/keep-network/keep-ecdsa/pkg/chain/gen/celo/contract"
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
 	"github.com/keep-network/keep-ecdsa/pkg/utils/byteutils"
@@ -84,19 +86,33 @@ func (bekh *bondedEcdsaKeepHandle) ID() chain.ID {
 func (bekh *bondedEcdsaKeepHandle) OnSignatureRequested(
 	handler func(event *chain.SignatureRequestedEvent),
 ) (subscription.EventSubscription, error) {
-	onEvent := func(
-		Digest [32]uint8,
-		blockNumber uint64,
-	) {
-		handler(&chain.SignatureRequestedEvent{
-			Digest:      Digest,
-			BlockNumber: blockNumber,
-		})
-	}
-	return bekh.contract.SignatureRequested(
-		nil,
-		nil,
-	).OnEvent(onEvent), nil
+	sink := make(chan *abi.BondedECDSAKeepSignatureRequested)
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-sink:
+				if isRemoved(event.Raw.Removed, "SignatureRequested") {
+					continue
+				}
+
+				handler(&chain.SignatureRequestedEvent{
+					Digest:      event.Digest,
+					BlockNumber: event.Raw.BlockNumber,
+					BlockHash:   event.Raw.BlockHash,
+				})
+			}
+		}
+	}()
+
+	sub := bekh.contract.SignatureRequested(nil, nil).Pipe(sink)
+	return subscription.NewEventSubscription(func() {
+		sub.Unsubscribe()
+		cancelCtx()
+	}), nil
 }
 
 // OnConflictingPublicKeySubmitted installs a callback that is invoked when an
@@ -209,13 +225,35 @@ func (bekh *bondedEcdsaKeepHandle) SubmitSignature(
 func (bekh *bondedEcdsaKeepHandle) OnKeepClosed(
 	handler func(event *chain.KeepClosedEvent),
 ) (subscription.EventSubscription, error) {
-	onEvent := func(blockNumber uint64) {
-		handler(&chain.KeepClosedEvent{BlockNumber: blockNumber})
-	}
-	return bekh.contract.KeepClosed(&ethlike.SubscribeOpts{
+	sink := make(chan *abi.BondedECDSAKeepKeepClosed)
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-sink:
+				if isRemoved(event.Raw.Removed, "KeepClosed") {
+					continue
+				}
+
+				handler(&chain.KeepClosedEvent{
+					BlockNumber: event.Raw.BlockNumber,
+					BlockHash:   event.Raw.BlockHash,
+				})
+			}
+		}
+	}()
+
+	sub := bekh.contract.KeepClosed(&ethlike.SubscribeOpts{
 		Tick:       4 * time.Hour,
 		PastBlocks: 2000,
-	}).OnEvent(onEvent), nil
+	}).Pipe(sink)
+	return subscription.NewEventSubscription(func() {
+		sub.Unsubscribe()
+		cancelCtx()
+	}), nil
 }
 
 // OnKeepTerminated installs a callback that is invoked on-chain when keep
@@ -223,13 +261,35 @@ func (bekh *bondedEcdsaKeepHandle) OnKeepClosed(
 func (bekh *bondedEcdsaKeepHandle) OnKeepTerminated(
 	handler func(event *chain.KeepTerminatedEvent),
 ) (subscription.EventSubscription, error) {
-	onEvent := func(blockNumber uint64) {
-		handler(&chain.KeepTerminatedEvent{BlockNumber: blockNumber})
-	}
-	return bekh.contract.KeepTerminated(&ethlike.SubscribeOpts{
+	sink := make(chan *abi.BondedECDSAKeepKeepTerminated)
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-sink:
+				if isRemoved(event.Raw.Removed, "KeepTerminated") {
+					continue
+				}
+
+				handler(&chain.KeepTerminatedEvent{
+					BlockNumber: event.Raw.BlockNumber,
+					BlockHash:   event.Raw.BlockHash,
+				})
+			}
+		}
+	}()
+
+	sub := bekh.contract.KeepTerminated(&ethlike.SubscribeOpts{
 		Tick:       4 * time.Hour,
 		PastBlocks: 2000,
-	}).OnEvent(onEvent), nil
+	}).Pipe(sink)
+	return subscription.NewEventSubscription(func() {
+		sub.Unsubscribe()
+		cancelCtx()
+	}), nil
 }
 
 // IsAwaitingSignature checks if the keep is waiting for a signature to be
@@ -367,6 +427,57 @@ func (bekh *bondedEcdsaKeepHandle) PastSignatureSubmittedEvents(
 	return result, nil
 }
 
+// PastSignatureRequestedEvents returns all signature requested events
+// for the given keep which occurred after the provided start block.
+// Returned events are sorted by the block number in the ascending order.
+func (bekh *bondedEcdsaKeepHandle) PastSignatureRequestedEvents(
+	startBlock uint64,
+) ([]*chain.SignatureRequestedEvent, error) {
+	events, err := bekh.contract.PastSignatureRequestedEvents(
+		startBlock,
+		nil, // latest block
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*chain.SignatureRequestedEvent, 0)
+
+	for _, event := range events {
+		if isRemoved(event.Raw.Removed, "SignatureRequested") {
+			continue
+		}
+
+		result = append(result, &chain.SignatureRequestedEvent{
+			Digest:      event.Digest,
+			BlockNumber: event.Raw.BlockNumber,
+			BlockHash:   event.Raw.BlockHash,
+		})
+	}
+
+	// Make sure events are sorted by block number in ascending order.
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].BlockNumber < result[j].BlockNumber
+	})
+
+	return result, nil
+}
+
+// isRemoved returns true if the event log has been removed by a chain
+// reorganization. Removed logs are delivered again by the subscription with
+// the removed flag set and their events must not be handled.
+func isRemoved(removed bool, eventName string) bool {
+	if removed {
+		logger.Warningf(
+			"dropping [%s] event removed by a chain reorganization",
+			eventName,
+		)
+	}
+
+	return removed
+}
+
 // TODO Move to keep-common and parametrize by number of retries and delay?
 func withRetry(fn func() error) error {
 	const numberOfRetries = 10
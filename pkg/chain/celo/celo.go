@@ -162,6 +162,19 @@ func (cc *celoChain) BlockTimestamp(blockNumber *big.Int) (uint64, error) {
 	return header.Time, nil
 }
 
+// BlockHash returns given block's hash.
+func (cc *celoChain) BlockHash(blockNumber *big.Int) ([32]byte, error) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancelCtx()
+
+	header, err := cc.client.HeaderByNumber(ctx, blockNumber)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return header.Hash(), nil
+}
+
 // weiBalanceOf returns the wei balance of the given address from the latest
 // known block.
 func (cc *celoChain) weiBalanceOf(address common.Address) (*celo.Wei, error) {
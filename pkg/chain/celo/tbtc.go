@@ -3,6 +3,7 @@
 package celo
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"sort"
@@ -12,6 +13,7 @@ import (
 	"github.com/keep-network/keep-common/pkg/subscription"
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
 	"github.com/keep-network/keep-ecdsa/pkg/chain/gen/celo/contract"
+	tbtcabi "github.com/keep-network/tbtc/pkg/chain/celo/gen/abi/system"
 	tbtcchain "github.com/keep-network/tbtc/pkg/chain/celo/gen/contract"
 )
 
@@ -141,110 +143,171 @@ func (ta *tbtcApplication) UpdateStatusForApplication() error {
 // OnDepositCreated installs a callback that is invoked when an
 // on-chain notification of a new deposit creation is seen.
 func (ta *tbtcApplication) OnDepositCreated(
-	handler func(depositAddress string),
+	handler func(event *chain.DepositEvent),
 ) subscription.EventSubscription {
-	onEvent := func(
-		DepositContractAddress common.Address,
-		KeepAddress common.Address,
-		Timestamp *big.Int,
-		blockNumber uint64,
-	) {
-		handler(DepositContractAddress.Hex())
-	}
-
-	return ta.tbtcSystemContract.Created(
-		nil,
-		nil,
-		nil,
-	).OnEvent(onEvent)
+	sink := make(chan *tbtcabi.TBTCSystemCreated)
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-sink:
+				if isRemoved(event.Raw.Removed, "Created") {
+					continue
+				}
+
+				handler(&chain.DepositEvent{
+					DepositAddress: event.DepositContractAddress.Hex(),
+					BlockNumber:    event.Raw.BlockNumber,
+					BlockHash:      event.Raw.BlockHash,
+				})
+			}
+		}
+	}()
+
+	sub := ta.tbtcSystemContract.Created(nil, nil, nil).Pipe(sink)
+	return subscription.NewEventSubscription(func() {
+		sub.Unsubscribe()
+		cancelCtx()
+	})
 }
 
 // OnDepositRegisteredPubkey installs a callback that is invoked when an
 // on-chain notification of a deposit's pubkey registration is seen.
 func (ta *tbtcApplication) OnDepositRegisteredPubkey(
-	handler func(depositAddress string),
+	handler func(event *chain.DepositEvent),
 ) subscription.EventSubscription {
-	onEvent := func(
-		DepositContractAddress common.Address,
-		SigningGroupPubkeyX [32]uint8,
-		SigningGroupPubkeyY [32]uint8,
-		Timestamp *big.Int,
-		blockNumber uint64,
-	) {
-		handler(DepositContractAddress.Hex())
-	}
-
-	return ta.tbtcSystemContract.RegisteredPubkey(nil, nil).OnEvent(onEvent)
+	sink := make(chan *tbtcabi.TBTCSystemRegisteredPubkey)
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-sink:
+				if isRemoved(event.Raw.Removed, "RegisteredPubkey") {
+					continue
+				}
+
+				handler(&chain.DepositEvent{
+					DepositAddress: event.DepositContractAddress.Hex(),
+					BlockNumber:    event.Raw.BlockNumber,
+					BlockHash:      event.Raw.BlockHash,
+				})
+			}
+		}
+	}()
+
+	sub := ta.tbtcSystemContract.RegisteredPubkey(nil, nil).Pipe(sink)
+	return subscription.NewEventSubscription(func() {
+		sub.Unsubscribe()
+		cancelCtx()
+	})
 }
 
 // OnDepositRedemptionRequested installs a callback that is invoked when an
 // on-chain notification of a deposit redemption request is seen.
 func (ta *tbtcApplication) OnDepositRedemptionRequested(
-	handler func(depositAddress string),
+	handler func(event *chain.DepositEvent),
 ) subscription.EventSubscription {
-	onEvent := func(
-		DepositContractAddress common.Address,
-		Requester common.Address,
-		Digest [32]uint8,
-		UtxoValue *big.Int,
-		RedeemerOutputScript []uint8,
-		RequestedFee *big.Int,
-		Outpoint []uint8,
-		blockNumber uint64,
-	) {
-		handler(DepositContractAddress.Hex())
-	}
-
-	return ta.tbtcSystemContract.RedemptionRequested(
-		nil,
-		nil,
-		nil,
-		nil,
-	).OnEvent(onEvent)
+	sink := make(chan *tbtcabi.TBTCSystemRedemptionRequested)
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-sink:
+				if isRemoved(event.Raw.Removed, "RedemptionRequested") {
+					continue
+				}
+
+				handler(&chain.DepositEvent{
+					DepositAddress: event.DepositContractAddress.Hex(),
+					BlockNumber:    event.Raw.BlockNumber,
+					BlockHash:      event.Raw.BlockHash,
+				})
+			}
+		}
+	}()
+
+	sub := ta.tbtcSystemContract.RedemptionRequested(nil, nil, nil, nil).Pipe(sink)
+	return subscription.NewEventSubscription(func() {
+		sub.Unsubscribe()
+		cancelCtx()
+	})
 }
 
 // OnDepositGotRedemptionSignature installs a callback that is invoked when an
 // on-chain notification of a deposit receiving a redemption signature is seen.
 func (ta *tbtcApplication) OnDepositGotRedemptionSignature(
-	handler func(depositAddress string),
+	handler func(event *chain.DepositEvent),
 ) subscription.EventSubscription {
-	onEvent := func(
-		DepositContractAddress common.Address,
-		Digest [32]uint8,
-		R [32]uint8,
-		S [32]uint8,
-		Timestamp *big.Int,
-		blockNumber uint64,
-	) {
-		handler(DepositContractAddress.Hex())
-	}
-
-	return ta.tbtcSystemContract.GotRedemptionSignature(
-		nil,
-		nil,
-		nil,
-	).OnEvent(onEvent)
+	sink := make(chan *tbtcabi.TBTCSystemGotRedemptionSignature)
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-sink:
+				if isRemoved(event.Raw.Removed, "GotRedemptionSignature") {
+					continue
+				}
+
+				handler(&chain.DepositEvent{
+					DepositAddress: event.DepositContractAddress.Hex(),
+					BlockNumber:    event.Raw.BlockNumber,
+					BlockHash:      event.Raw.BlockHash,
+				})
+			}
+		}
+	}()
+
+	sub := ta.tbtcSystemContract.GotRedemptionSignature(nil, nil, nil).Pipe(sink)
+	return subscription.NewEventSubscription(func() {
+		sub.Unsubscribe()
+		cancelCtx()
+	})
 }
 
 // OnDepositRedeemed installs a callback that is invoked when an
 // on-chain notification of a deposit redemption is seen.
 func (ta *tbtcApplication) OnDepositRedeemed(
-	handler func(depositAddress string),
+	handler func(event *chain.DepositEvent),
 ) subscription.EventSubscription {
-	onEvent := func(
-		DepositContractAddress common.Address,
-		Txid [32]uint8,
-		Timestamp *big.Int,
-		blockNumber uint64,
-	) {
-		handler(DepositContractAddress.Hex())
-	}
-
-	return ta.tbtcSystemContract.Redeemed(
-		nil,
-		nil,
-		nil,
-	).OnEvent(onEvent)
+	sink := make(chan *tbtcabi.TBTCSystemRedeemed)
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-sink:
+				if isRemoved(event.Raw.Removed, "Redeemed") {
+					continue
+				}
+
+				handler(&chain.DepositEvent{
+					DepositAddress: event.DepositContractAddress.Hex(),
+					BlockNumber:    event.Raw.BlockNumber,
+					BlockHash:      event.Raw.BlockHash,
+				})
+			}
+		}
+	}()
+
+	sub := ta.tbtcSystemContract.Redeemed(nil, nil, nil).Pipe(sink)
+	return subscription.NewEventSubscription(func() {
+		sub.Unsubscribe()
+		cancelCtx()
+	})
 }
 
 // PastDepositRedemptionRequestedEvents returns all redemption requested
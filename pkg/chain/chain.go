@@ -82,6 +82,10 @@ type Handle interface {
 	// BlockTimestamp returns given block's timestamp.
 	// In case the block is not yet mined, an error should be returned.
 	BlockTimestamp(blockNumber *big.Int) (uint64, error)
+	// BlockHash returns given block's hash. It is used to detect blocks
+	// removed by chain reorganizations. In case the block is not yet mined,
+	// an error should be returned.
+	BlockHash(blockNumber *big.Int) ([32]byte, error)
 
 	BondedECDSAKeepFactory
 }
@@ -211,6 +215,62 @@ type BondedECDSAKeepHandle interface {
 	PastSignatureSubmittedEvents(
 		startBlock uint64,
 	) ([]*SignatureSubmittedEvent, error)
+
+	// PastSignatureRequestedEvents returns all signature requested events
+	// for the given keep which occurred after the provided start block.
+	// All implementations should returns those events sorted by the
+	// block number in the ascending order.
+	PastSignatureRequestedEvents(
+		startBlock uint64,
+	) ([]*SignatureRequestedEvent, error)
+}
+
+// EdDSAKeepHandle is a handle of a keep which may use a signature scheme other
+// than ECDSA. Bonded ECDSA keep contracts support only the ECDSA signature
+// scheme so their handles do not implement this interface. Keeps with handles
+// not implementing it are always treated as ECDSA keeps.
+//
+// There is no keep contract supporting the EdDSA signature scheme, so the
+// interface is implemented only by the local chain and EdDSA keeps cannot be
+// operated on Ethereum or Celo. EdDSA signers can be generated and used only
+// by clients built with the `eddsa` build tag.
+type EdDSAKeepHandle interface {
+	BondedECDSAKeepHandle
+
+	// GetSignatureScheme returns the signature scheme the keep uses. Keep
+	// members generate a key and calculate signatures of this scheme.
+	GetSignatureScheme() (SignatureScheme, error)
+
+	// SubmitEdDSAPublicKey submits a 32-byte serialized Ed25519 public key to
+	// a keep contract deployed under a given address. It is expected to be
+	// called only for keeps using the EdDSA signature scheme.
+	SubmitEdDSAPublicKey(publicKey [32]byte) error
+
+	// SubmitEdDSASignature submits a 64-byte Ed25519 signature to a keep
+	// contract deployed under a given address. It is expected to be called
+	// only for keeps using the EdDSA signature scheme.
+	SubmitEdDSASignature(signature [64]byte) error
+}
+
+// SignatureScheme is a signature scheme used by a keep.
+type SignatureScheme int
+
+const (
+	// ECDSA is the ECDSA signature scheme over secp256k1 curve.
+	ECDSA SignatureScheme = iota
+	// EdDSA is the EdDSA signature scheme over Ed25519 curve.
+	EdDSA
+)
+
+func (ss SignatureScheme) String() string {
+	switch ss {
+	case ECDSA:
+		return "ECDSA"
+	case EdDSA:
+		return "EdDSA"
+	default:
+		return fmt.Sprintf("SignatureScheme(%d)", int(ss))
+	}
 }
 
 // BondedECDSAKeepApplicationHandle is a handle to a specific application that
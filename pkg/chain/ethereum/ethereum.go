@@ -164,6 +164,19 @@ func (ec *ethereumChain) BlockTimestamp(blockNumber *big.Int) (uint64, error) {
 	return header.Time, nil
 }
 
+// BlockHash returns given block's hash.
+func (ec *ethereumChain) BlockHash(blockNumber *big.Int) ([32]byte, error) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancelCtx()
+
+	header, err := ec.client.HeaderByNumber(ctx, blockNumber)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return header.Hash(), nil
+}
+
 // WeiBalanceOf returns the wei balance of the given address from the latest
 // known block.
 func (ec *ethereumChain) WeiBalanceOf(
@@ -30,16 +30,19 @@ type PublicKeyPublishedEvent struct {
 type SignatureRequestedEvent struct {
 	Digest      [32]byte
 	BlockNumber uint64
+	BlockHash   [32]byte
 }
 
 // KeepClosedEvent is an event emitted when a keep has been closed.
 type KeepClosedEvent struct {
 	BlockNumber uint64
+	BlockHash   [32]byte
 }
 
 // KeepTerminatedEvent is an event emitted when a keep has been terminated.
 type KeepTerminatedEvent struct {
 	BlockNumber uint64
+	BlockHash   [32]byte
 }
 
 // SignatureSubmittedEvent is an event emitted when a keep submits a signature.
@@ -0,0 +1,354 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-common/pkg/subscription"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/utils/backoff"
+)
+
+var logger = log.Logger("keep-chain")
+
+// DefaultConfirmationDepth is the default number of blocks which should be
+// mined on top of the block an event has been emitted in, before the event is
+// considered final.
+const DefaultConfirmationDepth = uint64(12)
+
+// Maximum number of attempts to check the chain state before an event is
+// dropped as not possible to validate.
+const maxValidationAttempts = 5
+
+// EventPipeline buffers on-chain events until they are confirmed by the number
+// of blocks required by their subscriptions and only then delivers them to
+// subscribers.
+//
+// Events carry the hash of the block they have been emitted in, as reported
+// with the event log. The pipeline compares it with the hash of the block with
+// the same number once the event is confirmed. An event from a block removed
+// by a chain reorganization is retracted and never delivered. If such an event
+// has been included in another block, it is emitted by the chain again and
+// passes through the pipeline once more. Confirmed events are additionally
+// validated against the current chain state, so that events whose effects
+// have been reverted or superseded in the meantime are dropped.
+type EventPipeline struct {
+	blockCounter chain.BlockCounter
+	blockHash    func(blockNumber *big.Int) ([32]byte, error)
+
+	validationBackoff backoff.Policy
+
+	mutex   sync.Mutex
+	pending int
+}
+
+// NewEventPipeline creates an event pipeline observing blocks with the given
+// block counter and tracking hashes of blocks with the given function.
+func NewEventPipeline(
+	blockCounter chain.BlockCounter,
+	blockHash func(blockNumber *big.Int) ([32]byte, error),
+) *EventPipeline {
+	return &EventPipeline{
+		blockCounter: blockCounter,
+		blockHash:    blockHash,
+		validationBackoff: &backoff.Exponential{
+			InitialDelay: 5 * time.Second,
+			MaxDelay:     1 * time.Minute,
+			Jitter:       1 * time.Second,
+		},
+	}
+}
+
+// Pending returns the number of events buffered by the pipeline until they
+// are confirmed.
+func (ep *EventPipeline) Pending() int {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+
+	return ep.pending
+}
+
+// Subscribe creates a subscription delivering events of the given name once
+// they are confirmed by the given number of blocks.
+func (ep *EventPipeline) Subscribe(
+	eventName string,
+	confirmationDepth uint64,
+) *ConfirmedEventSubscription {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	return &ConfirmedEventSubscription{
+		pipeline:          ep,
+		eventName:         eventName,
+		confirmationDepth: confirmationDepth,
+		ctx:               ctx,
+		cancelCtx:         cancelCtx,
+	}
+}
+
+// ConfirmEvent blocks until the event emitted in the block with the given
+// number and hash is confirmed by the given number of blocks and validates it
+// with the provided function, if not nil. It returns false if the event has
+// been retracted by a chain reorganization or is not reflected in the chain
+// state. The wait is abandoned and validation is not retried once the context
+// is done.
+func (ep *EventPipeline) ConfirmEvent(
+	ctx context.Context,
+	blockNumber uint64,
+	blockHash [32]byte,
+	confirmationDepth uint64,
+	validate func() (bool, error),
+) (bool, error) {
+	ep.buffer()
+	defer ep.release()
+
+	return ep.confirm(ctx, blockNumber, blockHash, confirmationDepth, validate)
+}
+
+// confirm waits until the event emitted in the block with the given hash is
+// confirmed and validates it.
+func (ep *EventPipeline) confirm(
+	ctx context.Context,
+	blockNumber uint64,
+	emittedBlockHash [32]byte,
+	confirmationDepth uint64,
+	validate func() (bool, error),
+) (bool, error) {
+	confirmationBlock := blockNumber + confirmationDepth
+	logger.Debugf(
+		"waiting for block [%d] to confirm event from block [%d]",
+		confirmationBlock,
+		blockNumber,
+	)
+
+	if err := ep.waitForBlockHeight(ctx, confirmationBlock); err != nil {
+		return false, fmt.Errorf("failed to wait for block height: [%v]", err)
+	}
+
+	confirmedBlockHash, err := ep.blockHash(new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return false, fmt.Errorf(
+			"could not get hash of block [%d]: [%v]",
+			blockNumber,
+			err,
+		)
+	}
+
+	if confirmedBlockHash != emittedBlockHash {
+		logger.Warningf(
+			"block [%d] has been removed by a chain reorganization; "+
+				"retracting event emitted in that block",
+			blockNumber,
+		)
+		return false, nil
+	}
+
+	if validate == nil {
+		return true, nil
+	}
+
+	return ep.validate(ctx, validate)
+}
+
+// ConfirmState blocks until the given number of blocks is mined on top of
+// the current block and checks the chain state with the provided function.
+// It is used to confirm effects of transactions which are not observed with
+// events. The wait is abandoned and the check is not retried once the context
+// is done.
+func (ep *EventPipeline) ConfirmState(
+	ctx context.Context,
+	confirmationDepth uint64,
+	check func() (bool, error),
+) (bool, error) {
+	currentBlock, err := ep.blockCounter.CurrentBlock()
+	if err != nil {
+		return false, fmt.Errorf("could not get current block: [%v]", err)
+	}
+
+	confirmationBlock := currentBlock + confirmationDepth
+	logger.Debugf("waiting for block [%d] to confirm chain state", confirmationBlock)
+
+	if err := ep.waitForBlockHeight(ctx, confirmationBlock); err != nil {
+		return false, fmt.Errorf("failed to wait for block height: [%v]", err)
+	}
+
+	return ep.validate(ctx, check)
+}
+
+// waitForBlockHeight blocks until the given block height is reached or
+// the context is done.
+func (ep *EventPipeline) waitForBlockHeight(
+	ctx context.Context,
+	blockHeight uint64,
+) error {
+	waiter, err := ep.blockCounter.BlockHeightWaiter(blockHeight)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-waiter:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// validate checks the chain state with the provided function retrying
+// the check if it fails with an error until the context is done.
+func (ep *EventPipeline) validate(
+	ctx context.Context,
+	check func() (bool, error),
+) (bool, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := check()
+		if err == nil {
+			return result, nil
+		}
+
+		if attempt == maxValidationAttempts {
+			return false, fmt.Errorf(
+				"failed to check chain state: [%v]; "+
+					"the maximum number of attempts reached",
+				err,
+			)
+		}
+
+		delay := ep.validationBackoff.Delay(attempt)
+
+		logger.Warningf(
+			"failed to check chain state: [%v]; retrying after: [%v]",
+			err,
+			delay,
+		)
+
+		if waitErr := backoff.Wait(ctx, delay); waitErr != nil {
+			return false, fmt.Errorf(
+				"failed to check chain state: [%v]; "+
+					"retries have been cancelled: [%v]",
+				err,
+				waitErr,
+			)
+		}
+	}
+}
+
+func (ep *EventPipeline) buffer() {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+
+	ep.pending++
+}
+
+func (ep *EventPipeline) release() {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+
+	ep.pending--
+}
+
+// ConfirmedEventSubscription delivers events of a single subscription once
+// they are confirmed by the subscription's confirmation depth.
+type ConfirmedEventSubscription struct {
+	pipeline          *EventPipeline
+	eventName         string
+	confirmationDepth uint64
+
+	// Context of the subscription, done once the subscription is cancelled.
+	// Validation of buffered events is not retried after that.
+	ctx       context.Context
+	cancelCtx context.CancelFunc
+
+	mutex        sync.RWMutex
+	unsubscribed bool
+}
+
+// Deliver passes the event emitted in the block with the given number and
+// hash through the pipeline. Once the event is confirmed and validated with
+// the provided function, if not nil, the handler is called in a separate
+// goroutine. Events retracted by a chain reorganization, not reflected in
+// the chain state or delivered after the subscription has been cancelled are
+// dropped.
+func (ces *ConfirmedEventSubscription) Deliver(
+	blockNumber uint64,
+	blockHash [32]byte,
+	validate func() (bool, error),
+	handler func(),
+) {
+	ces.pipeline.buffer()
+
+	go func() {
+		isConfirmed, err := ces.pipeline.confirm(
+			ces.ctx,
+			blockNumber,
+			blockHash,
+			ces.confirmationDepth,
+			validate,
+		)
+		ces.pipeline.release()
+
+		if err != nil {
+			logger.Errorf(
+				"could not confirm [%s] event emitted in block [%d]: [%v]",
+				ces.eventName,
+				blockNumber,
+				err,
+			)
+			return
+		}
+
+		if !isConfirmed {
+			logger.Warningf(
+				"[%s] event emitted in block [%d] is not confirmed; "+
+					"dropping the event",
+				ces.eventName,
+				blockNumber,
+			)
+			return
+		}
+
+		if ces.isUnsubscribed() {
+			logger.Debugf(
+				"dropping confirmed [%s] event emitted in block [%d] "+
+					"for cancelled subscription",
+				ces.eventName,
+				blockNumber,
+			)
+			return
+		}
+
+		handler()
+	}()
+}
+
+// Unsubscribe cancels the subscription. Events buffered in the pipeline are no
+// longer delivered to the subscriber.
+func (ces *ConfirmedEventSubscription) Unsubscribe() {
+	ces.mutex.Lock()
+	defer ces.mutex.Unlock()
+
+	ces.unsubscribed = true
+	ces.cancelCtx()
+}
+
+func (ces *ConfirmedEventSubscription) isUnsubscribed() bool {
+	ces.mutex.RLock()
+	defer ces.mutex.RUnlock()
+
+	return ces.unsubscribed
+}
+
+// With returns a subscription cancelling both the given source subscription
+// delivering events to the pipeline and this subscription.
+func (ces *ConfirmedEventSubscription) With(
+	source subscription.EventSubscription,
+) subscription.EventSubscription {
+	return subscription.NewEventSubscription(func() {
+		source.Unsubscribe()
+		ces.Unsubscribe()
+	})
+}
@@ -0,0 +1,287 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-ecdsa/pkg/utils/backoff"
+)
+
+// testBlockHashes simulates hashes of blocks which can be changed by a chain
+// reorganization.
+type testBlockHashes struct {
+	mutex  sync.Mutex
+	hashes map[uint64][32]byte
+}
+
+func (tbh *testBlockHashes) blockHash(blockNumber *big.Int) ([32]byte, error) {
+	tbh.mutex.Lock()
+	defer tbh.mutex.Unlock()
+
+	return tbh.hashes[blockNumber.Uint64()], nil
+}
+
+func (tbh *testBlockHashes) reorganize(blockNumber uint64) {
+	tbh.mutex.Lock()
+	defer tbh.mutex.Unlock()
+
+	tbh.hashes[blockNumber] = [32]byte{1}
+}
+
+func newTestEventPipeline(t *testing.T) (*EventPipeline, *testBlockHashes) {
+	blockCounter, err := local.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blockHashes := &testBlockHashes{hashes: make(map[uint64][32]byte)}
+
+	pipeline := NewEventPipeline(blockCounter, blockHashes.blockHash)
+	pipeline.validationBackoff = backoff.Constant(time.Millisecond)
+
+	return pipeline, blockHashes
+}
+
+func TestConfirmEvent(t *testing.T) {
+	var tests = map[string]struct {
+		reorganized    bool
+		validate       func() (bool, error)
+		expectedResult bool
+		expectedError  bool
+	}{
+		"confirmed event": {
+			expectedResult: true,
+		},
+		"confirmed and valid event": {
+			validate: func() (bool, error) {
+				return true, nil
+			},
+			expectedResult: true,
+		},
+		"event not reflected in the chain state": {
+			validate: func() (bool, error) {
+				return false, nil
+			},
+			expectedResult: false,
+		},
+		"event retracted by chain reorganization": {
+			reorganized: true,
+			validate: func() (bool, error) {
+				return true, nil
+			},
+			expectedResult: false,
+		},
+		"chain state check failure": {
+			validate: func() (bool, error) {
+				return false, fmt.Errorf("chain not available")
+			},
+			expectedResult: false,
+			expectedError:  true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			pipeline, blockHashes := newTestEventPipeline(t)
+
+			eventBlock, err := pipeline.blockCounter.CurrentBlock()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.reorganized {
+				go func() {
+					// Reorganize the block while the event is buffered.
+					time.Sleep(100 * time.Millisecond)
+					blockHashes.reorganize(eventBlock)
+				}()
+			}
+
+			// The hash of the block is taken from the event log before
+			// the block could be reorganized.
+			eventBlockHash, err := blockHashes.blockHash(
+				new(big.Int).SetUint64(eventBlock),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := pipeline.ConfirmEvent(
+				context.Background(),
+				eventBlock,
+				eventBlockHash,
+				1,
+				test.validate,
+			)
+
+			if test.expectedError != (err != nil) {
+				t.Errorf(
+					"unexpected error\nexpected error: [%v]\nactual error:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+
+			if test.expectedResult != result {
+				t.Errorf(
+					"unexpected result\nexpected: [%v]\nactual:   [%v]",
+					test.expectedResult,
+					result,
+				)
+			}
+
+			currentBlock, err := pipeline.blockCounter.CurrentBlock()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if currentBlock < eventBlock+1 {
+				t.Errorf(
+					"event confirmed before the confirmation block\n"+
+						"expected: [>= %v]\nactual:   [%v]",
+					eventBlock+1,
+					currentBlock,
+				)
+			}
+		})
+	}
+}
+
+func TestConfirmedEventSubscription(t *testing.T) {
+	pipeline, blockHashes := newTestEventPipeline(t)
+
+	subscription := pipeline.Subscribe("test", 2)
+
+	delivered := make(chan uint64, 3)
+
+	eventBlock, err := pipeline.blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, blockNumber := range []uint64{eventBlock, eventBlock + 1} {
+		blockNumber := blockNumber
+		subscription.Deliver(blockNumber, [32]byte{}, nil, func() {
+			delivered <- blockNumber
+		})
+	}
+
+	// The event emitted in the second block is removed by a reorganization.
+	blockHashes.reorganize(eventBlock + 1)
+
+	if pending := pipeline.Pending(); pending != 2 {
+		t.Errorf(
+			"unexpected number of pending events\nexpected: [%v]\nactual:   [%v]",
+			2,
+			pending,
+		)
+	}
+
+	select {
+	case blockNumber := <-delivered:
+		if blockNumber != eventBlock {
+			t.Errorf(
+				"unexpected delivered event\nexpected: [%v]\nactual:   [%v]",
+				eventBlock,
+				blockNumber,
+			)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("event has not been delivered")
+	}
+
+	subscription.Deliver(eventBlock, [32]byte{}, nil, func() {
+		delivered <- eventBlock
+	})
+	subscription.Unsubscribe()
+
+	// Wait for all buffered events to be confirmed.
+	time.Sleep(3 * time.Second)
+
+	select {
+	case blockNumber := <-delivered:
+		t.Errorf("unexpected event delivered from block [%v]", blockNumber)
+	default:
+	}
+
+	if pending := pipeline.Pending(); pending != 0 {
+		t.Errorf(
+			"unexpected number of pending events\nexpected: [%v]\nactual:   [%v]",
+			0,
+			pending,
+		)
+	}
+}
+
+func TestConfirmStateCancelled(t *testing.T) {
+	pipeline, _ := newTestEventPipeline(t)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	checks := 0
+	result, err := pipeline.ConfirmState(ctx, 0, func() (bool, error) {
+		checks++
+		cancelCtx()
+		return false, fmt.Errorf("chain not available")
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if result {
+		t.Errorf("unexpected result\nexpected: [%v]\nactual:   [%v]", false, result)
+	}
+
+	// The check is not retried once the context is done.
+	if checks != 1 {
+		t.Errorf(
+			"unexpected number of checks\nexpected: [%v]\nactual:   [%v]",
+			1,
+			checks,
+		)
+	}
+}
+
+func TestConfirmStateCancelledWhileWaiting(t *testing.T) {
+	pipeline, _ := newTestEventPipeline(t)
+
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		100*time.Millisecond,
+	)
+	defer cancelCtx()
+
+	checks := 0
+	start := time.Now()
+	result, err := pipeline.ConfirmState(ctx, 1000, func() (bool, error) {
+		checks++
+		return true, nil
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if result {
+		t.Errorf("unexpected result\nexpected: [%v]\nactual:   [%v]", false, result)
+	}
+
+	// The wait for the confirmation block is abandoned once the context is
+	// done and the state is not checked.
+	if checks != 0 {
+		t.Errorf(
+			"unexpected number of checks\nexpected: [%v]\nactual:   [%v]",
+			0,
+			checks,
+		)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("wait has not been abandoned; took [%v]", elapsed)
+	}
+}
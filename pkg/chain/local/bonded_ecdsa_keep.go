@@ -25,17 +25,23 @@ type localKeep struct {
 	keepID common.Address
 	owner  common.Address
 
-	publicKey    [64]byte
-	members      []common.Address
-	status       keepStatus
-	latestDigest [32]byte
+	signatureScheme chain.SignatureScheme
+	publicKey       [64]byte
+	eddsaPublicKey  [32]byte
+	members         []common.Address
+	honestThreshold uint64
+	status          keepStatus
+	latestDigest    [32]byte
+	openedTimestamp time.Time
 
 	signatureRequestedHandlers map[int]func(event *chain.SignatureRequestedEvent)
 
 	keepClosedHandlers     map[int]func(event *chain.KeepClosedEvent)
 	keepTerminatedHandlers map[int]func(event *chain.KeepTerminatedEvent)
 
+	signatureRequestedEvents []*chain.SignatureRequestedEvent
 	signatureSubmittedEvents []*chain.SignatureSubmittedEvent
+	eddsaSignatures          [][64]byte
 }
 
 func (lc *localChain) GetKeepWithID(
@@ -142,19 +148,85 @@ func (lk *localKeep) SubmitSignature(signature *ecdsa.Signature) error {
 		return err
 	}
 
+	blockNumber, err := lk.chain.blockCounter.CurrentBlock()
+	if err != nil {
+		return err
+	}
+
 	lk.signatureSubmittedEvents = append(
 		lk.signatureSubmittedEvents,
 		&chain.SignatureSubmittedEvent{
-			Digest:     lk.latestDigest,
-			R:          rBytes,
-			S:          sBytes,
-			RecoveryID: uint8(signature.RecoveryID),
+			Digest:      lk.latestDigest,
+			R:           rBytes,
+			S:           sBytes,
+			RecoveryID:  uint8(signature.RecoveryID),
+			BlockNumber: blockNumber,
 		},
 	)
 
 	return nil
 }
 
+// GetSignatureScheme returns the signature scheme the keep has been opened
+// with.
+func (lk *localKeep) GetSignatureScheme() (chain.SignatureScheme, error) {
+	lk.chain.localChainMutex.Lock()
+	defer lk.chain.localChainMutex.Unlock()
+
+	return lk.signatureScheme, nil
+}
+
+// SubmitEdDSAPublicKey checks if public key has been already submitted for
+// given EdDSA keep, if not it stores the key.
+func (lk *localKeep) SubmitEdDSAPublicKey(publicKey [32]byte) error {
+	lk.chain.localChainMutex.Lock()
+	defer lk.chain.localChainMutex.Unlock()
+
+	if lk.signatureScheme != chain.EdDSA {
+		return fmt.Errorf(
+			"keep [%s] does not support EdDSA public keys",
+			lk.ID().String(),
+		)
+	}
+
+	if lk.eddsaPublicKey != [32]byte{} {
+		return fmt.Errorf(
+			"public key already submitted for keep [%s]",
+			lk.ID().String(),
+		)
+	}
+
+	lk.eddsaPublicKey = publicKey
+
+	return nil
+}
+
+// SubmitEdDSASignature submits an EdDSA signature to a keep contract deployed
+// under a given address.
+func (lk *localKeep) SubmitEdDSASignature(signature [64]byte) error {
+	lk.chain.localChainMutex.Lock()
+	defer lk.chain.localChainMutex.Unlock()
+
+	if lk.signatureScheme != chain.EdDSA {
+		return fmt.Errorf(
+			"keep [%s] does not support EdDSA signatures",
+			lk.ID().String(),
+		)
+	}
+
+	// force the right workflow sequence
+	if lk.latestDigest == [32]byte{} {
+		return fmt.Errorf(
+			"keep [%s] is not awaiting for a signature",
+			lk.ID().String(),
+		)
+	}
+
+	lk.eddsaSignatures = append(lk.eddsaSignatures, signature)
+
+	return nil
+}
+
 func (lk *localKeep) OnKeepClosed(
 	handler func(event *chain.KeepClosedEvent),
 ) (subscription.EventSubscription, error) {
@@ -209,17 +281,34 @@ func (lk *localKeep) IsActive() (bool, error) {
 }
 
 func (lk *localKeep) LatestDigest() ([32]byte, error) {
-	panic("implement")
+	lk.chain.localChainMutex.Lock()
+	defer lk.chain.localChainMutex.Unlock()
+
+	return lk.latestDigest, nil
 }
 
 func (lk *localKeep) SignatureRequestedBlock(digest [32]byte) (uint64, error) {
-	panic("implement")
+	lk.chain.localChainMutex.Lock()
+	defer lk.chain.localChainMutex.Unlock()
+
+	for i := len(lk.signatureRequestedEvents) - 1; i >= 0; i-- {
+		event := lk.signatureRequestedEvents[i]
+		if event.Digest == digest {
+			return event.BlockNumber, nil
+		}
+	}
+
+	return 0, fmt.Errorf("signature for digest [%x] was not requested", digest)
 }
 
 func (lk *localKeep) GetPublicKey() ([]uint8, error) {
 	lk.chain.localChainMutex.Lock()
 	defer lk.chain.localChainMutex.Unlock()
 
+	if lk.signatureScheme == chain.EdDSA {
+		return lk.eddsaPublicKey[:], nil
+	}
+
 	return lk.publicKey[:], nil
 }
 
@@ -276,11 +365,17 @@ func (lk *localKeep) GetOwner() (chain.ID, error) {
 }
 
 func (lk *localKeep) GetHonestThreshold() (uint64, error) {
-	panic("implement")
+	lk.chain.localChainMutex.Lock()
+	defer lk.chain.localChainMutex.Unlock()
+
+	return lk.honestThreshold, nil
 }
 
 func (lk *localKeep) GetOpenedTimestamp() (time.Time, error) {
-	panic("implement")
+	lk.chain.localChainMutex.Lock()
+	defer lk.chain.localChainMutex.Unlock()
+
+	return lk.openedTimestamp, nil
 }
 
 func (lk *localKeep) PastSignatureSubmittedEvents(
@@ -289,7 +384,30 @@ func (lk *localKeep) PastSignatureSubmittedEvents(
 	lk.chain.localChainMutex.Lock()
 	defer lk.chain.localChainMutex.Unlock()
 
-	return lk.signatureSubmittedEvents, nil
+	events := make([]*chain.SignatureSubmittedEvent, 0)
+	for _, event := range lk.signatureSubmittedEvents {
+		if event.BlockNumber >= startBlock {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+func (lk *localKeep) PastSignatureRequestedEvents(
+	startBlock uint64,
+) ([]*chain.SignatureRequestedEvent, error) {
+	lk.chain.localChainMutex.Lock()
+	defer lk.chain.localChainMutex.Unlock()
+
+	events := make([]*chain.SignatureRequestedEvent, 0)
+	for _, event := range lk.signatureRequestedEvents {
+		if event.BlockNumber >= startBlock {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
 }
 
 func (lc *localChain) RequestSignature(keepAddress common.Address, digest [32]byte) error {
@@ -305,19 +423,31 @@ func (lc *localChain) RequestSignature(keepAddress common.Address, digest [32]by
 	}
 
 	// force the right workflow sequence
-	if keep.publicKey == [64]byte{} {
+	if keep.publicKey == [64]byte{} && keep.eddsaPublicKey == [32]byte{} {
 		return fmt.Errorf(
 			"public key for keep [%s] is not set",
 			keepAddress.String(),
 		)
 	}
 
+	blockNumber, err := lc.blockCounter.CurrentBlock()
+	if err != nil {
+		return err
+	}
+
 	keep.latestDigest = digest
 
 	signatureRequestedEvent := &chain.SignatureRequestedEvent{
-		Digest: digest,
+		Digest:      digest,
+		BlockNumber: blockNumber,
+		BlockHash:   blockHash(blockNumber),
 	}
 
+	keep.signatureRequestedEvents = append(
+		keep.signatureRequestedEvents,
+		signatureRequestedEvent,
+	)
+
 	for _, handler := range keep.signatureRequestedHandlers {
 		go func(handler func(event *chain.SignatureRequestedEvent), signatureRequestedEvent *chain.SignatureRequestedEvent) {
 			handler(signatureRequestedEvent)
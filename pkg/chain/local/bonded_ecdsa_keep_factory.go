@@ -2,6 +2,7 @@ package local
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
@@ -10,13 +11,19 @@ import (
 func (c *localChain) createKeep(
 	keepAddress common.Address,
 ) error {
-	return c.createKeepWithMembers(keepAddress, keepAddress, []common.Address{})
+	return c.createKeepWithMembers(
+		keepAddress,
+		keepAddress,
+		[]common.Address{},
+		chain.ECDSA,
+	)
 }
 
 func (c *localChain) createKeepWithMembers(
 	keepAddress common.Address,
 	ownerAddress common.Address,
 	members []common.Address,
+	signatureScheme chain.SignatureScheme,
 ) error {
 	c.localChainMutex.Lock()
 	defer c.localChainMutex.Unlock()
@@ -32,11 +39,15 @@ func (c *localChain) createKeepWithMembers(
 		chain:                      c,
 		keepID:                     keepAddress,
 		owner:                      ownerAddress,
+		signatureScheme:            signatureScheme,
 		publicKey:                  [64]byte{},
 		members:                    members,
+		honestThreshold:            uint64(len(members)),
+		openedTimestamp:            time.Now(),
 		signatureRequestedHandlers: make(map[int]func(event *chain.SignatureRequestedEvent)),
 		keepClosedHandlers:         make(map[int]func(event *chain.KeepClosedEvent)),
 		keepTerminatedHandlers:     make(map[int]func(event *chain.KeepTerminatedEvent)),
+		signatureRequestedEvents:   make([]*chain.SignatureRequestedEvent, 0),
 		signatureSubmittedEvents:   make([]*chain.SignatureSubmittedEvent, 0),
 	}
 
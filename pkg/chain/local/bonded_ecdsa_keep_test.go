@@ -101,3 +101,53 @@ func TestRequestSignature(t *testing.T) {
 		t.Fatal(ctx.Err())
 	}
 }
+
+func TestPastSignatureRequestedEvents(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+	keepAddress := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+	digests := [][32]byte{{1}, {2}}
+
+	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
+
+	var keepPubkey [64]byte
+	rand.Read(keepPubkey[:])
+
+	err := keep.SubmitKeepPublicKey(keepPubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, digest := range digests {
+		err = localChain.RequestSignature(keepAddress, digest)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	events, err := keep.PastSignatureRequestedEvents(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != len(digests) {
+		t.Fatalf(
+			"unexpected number of events\nexpected: [%v]\nactual:   [%v]",
+			len(digests),
+			len(events),
+		)
+	}
+
+	for i, event := range events {
+		if !bytes.Equal(event.Digest[:], digests[i][:]) {
+			t.Errorf(
+				"unexpected digest of event [%v]\nexpected: [%x]\nactual:   [%x]",
+				i,
+				digests[i],
+				event.Digest,
+			)
+		}
+	}
+}
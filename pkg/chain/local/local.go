@@ -5,6 +5,7 @@ import (
 	cecdsa "crypto/ecdsa"
 	"crypto/elliptic"
 	crand "crypto/rand"
+	"crypto/sha256"
 	"fmt"
 	"math/big"
 	"math/rand"
@@ -31,6 +32,11 @@ type Chain interface {
 		ownerAddress common.Address,
 		members []common.Address,
 	) chain.BondedECDSAKeepHandle
+	OpenEdDSAKeep(
+		keepAddress common.Address,
+		ownerAddress common.Address,
+		members []common.Address,
+	) chain.EdDSAKeepHandle
 	CloseKeep(keepAddress common.Address) error
 	TerminateKeep(keepAddress common.Address) error
 	RequestSignature(keepAddress common.Address, digest [32]byte) error
@@ -124,7 +130,29 @@ func (lc *localChain) OpenKeep(
 	ownerAddress common.Address,
 	members []common.Address,
 ) chain.BondedECDSAKeepHandle {
-	err := lc.createKeepWithMembers(keepAddress, ownerAddress, members)
+	return lc.openKeep(keepAddress, ownerAddress, members, chain.ECDSA)
+}
+
+func (lc *localChain) OpenEdDSAKeep(
+	keepAddress common.Address,
+	ownerAddress common.Address,
+	members []common.Address,
+) chain.EdDSAKeepHandle {
+	return lc.openKeep(keepAddress, ownerAddress, members, chain.EdDSA).(chain.EdDSAKeepHandle)
+}
+
+func (lc *localChain) openKeep(
+	keepAddress common.Address,
+	ownerAddress common.Address,
+	members []common.Address,
+	signatureScheme chain.SignatureScheme,
+) chain.BondedECDSAKeepHandle {
+	err := lc.createKeepWithMembers(
+		keepAddress,
+		ownerAddress,
+		members,
+		signatureScheme,
+	)
 	if err != nil {
 		panic(err)
 	}
@@ -205,6 +233,18 @@ func (lc *localChain) BlockTimestamp(blockNumber *big.Int) (uint64, error) {
 	return blockTimestamp.(uint64), nil
 }
 
+// BlockHash returns a hash derived from the block number as the local chain
+// is never reorganized.
+func (lc *localChain) BlockHash(blockNumber *big.Int) ([32]byte, error) {
+	return blockHash(blockNumber.Uint64()), nil
+}
+
+// blockHash returns the hash of the block with the given number. Local chain
+// events are emitted with the block hash the same way as logs of a real chain.
+func blockHash(blockNumber uint64) [32]byte {
+	return sha256.Sum256(new(big.Int).SetUint64(blockNumber).Bytes())
+}
+
 func generateHandlerID() int {
 	// #nosec G404 (insecure random number source (rand))
 	// Local chain implementation doesn't require secure randomness.
@@ -250,7 +290,7 @@ func (lc *localChain) closeKeep(keepAddress common.Address) error {
 
 	keep.status = closed
 
-	keepClosedEvent := &chain.KeepClosedEvent{}
+	keepClosedEvent := &chain.KeepClosedEvent{BlockHash: blockHash(0)}
 
 	for _, handler := range keep.keepClosedHandlers {
 		go func(
@@ -282,7 +322,7 @@ func (lc *localChain) terminateKeep(keepAddress common.Address) error {
 
 	keep.status = terminated
 
-	keepTerminatedEvent := &chain.KeepTerminatedEvent{}
+	keepTerminatedEvent := &chain.KeepTerminatedEvent{BlockHash: blockHash(0)}
 
 	for _, handler := range keep.keepTerminatedHandlers {
 		go func(
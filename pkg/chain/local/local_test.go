@@ -85,12 +85,14 @@ func TestOnSignatureRequested(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	expectedEvent := &chain.SignatureRequestedEvent{
-		Digest: digest,
-	}
-
 	select {
 	case event := <-eventFired:
+		expectedEvent := &chain.SignatureRequestedEvent{
+			Digest:      digest,
+			BlockNumber: event.BlockNumber,
+			BlockHash:   blockHash(event.BlockNumber),
+		}
+
 		if !reflect.DeepEqual(event, expectedEvent) {
 			t.Fatalf(
 				"unexpected signature requested event\nexpected: [%v]\nactual:   [%v]",
@@ -190,14 +192,14 @@ func TestSubmitSignature(t *testing.T) {
 	expectedRBytes, _ := byteutils.BytesTo32Byte(signature.R.Bytes())
 	expectedSBytes, _ := byteutils.BytesTo32Byte(signature.S.Bytes())
 	expectedEvent := &chain.SignatureSubmittedEvent{
-		Digest:      digest,
-		R:           expectedRBytes,
-		S:           expectedSBytes,
-		RecoveryID:  1,
-		BlockNumber: 0,
+		Digest:     digest,
+		R:          expectedRBytes,
+		S:          expectedSBytes,
+		RecoveryID: 1,
 	}
 
 	lastEvent := events[len(events)-1]
+	expectedEvent.BlockNumber = lastEvent.BlockNumber
 
 	if !reflect.DeepEqual(expectedEvent, lastEvent) {
 		t.Fatalf(
@@ -208,6 +210,99 @@ func TestSubmitSignature(t *testing.T) {
 	}
 }
 
+func TestSubmitEdDSASignature(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+
+	keepAddress := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
+	keepPublicKey := [32]byte{11, 12, 13, 14, 15, 16}
+
+	keep := localChain.OpenEdDSAKeep(keepAddress, emptyAddress, []common.Address{})
+
+	signatureScheme, err := keep.GetSignatureScheme()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signatureScheme != chain.EdDSA {
+		t.Errorf(
+			"unexpected signature scheme\nexpected: [%v]\nactual:   [%v]",
+			chain.EdDSA,
+			signatureScheme,
+		)
+	}
+
+	err = keep.SubmitEdDSAPublicKey(keepPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	onChainPubKey, err := keep.GetPublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(keepPublicKey[:]) != hex.EncodeToString(onChainPubKey) {
+		t.Errorf(
+			"unexpected result\nexpected: [%+v]\nactual:   [%+v]",
+			hex.EncodeToString(keepPublicKey[:]),
+			hex.EncodeToString(onChainPubKey),
+		)
+	}
+
+	digest := [32]byte{17, 18}
+
+	err = localChain.RequestSignature(keepAddress, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature := [64]byte{19, 20, 21}
+
+	err = keep.SubmitEdDSASignature(signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localKeep := keep.(*localKeep)
+	expectedSignatures := [][64]byte{signature}
+	if !reflect.DeepEqual(expectedSignatures, localKeep.eddsaSignatures) {
+		t.Errorf(
+			"unexpected submitted signatures\nexpected: [%x]\nactual:   [%x]",
+			expectedSignatures,
+			localKeep.eddsaSignatures,
+		)
+	}
+}
+
+func TestSubmitEdDSASignatureToECDSAKeep(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+
+	keepAddress := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
+	expectedError := fmt.Errorf(
+		"keep [%s] does not support EdDSA signatures",
+		keepAddress.String(),
+	)
+
+	keep := localChain.OpenKeep(
+		keepAddress,
+		emptyAddress,
+		[]common.Address{},
+	).(chain.EdDSAKeepHandle)
+
+	err := keep.SubmitEdDSASignature([64]byte{19, 20, 21})
+	if !reflect.DeepEqual(expectedError, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%+v]\nactual:   [%+v]",
+			expectedError,
+			err,
+		)
+	}
+}
+
 func TestIsAwaitingSignature(t *testing.T) {
 	ctx, cancelCtx := context.WithCancel(context.Background())
 	defer cancelCtx()
@@ -107,11 +107,11 @@ type TBTCLocalChain struct {
 	alwaysFailingTransactions map[string]bool
 
 	deposits                              map[string]*localDeposit
-	depositCreatedHandlers                map[int]func(depositAddress string)
-	depositRegisteredPubkeyHandlers       map[int]func(depositAddress string)
-	depositRedemptionRequestedHandlers    map[int]func(depositAddress string)
-	depositGotRedemptionSignatureHandlers map[int]func(depositAddress string)
-	depositRedeemedHandlers               map[int]func(depositAddress string)
+	depositCreatedHandlers                map[int]func(event *chain.DepositEvent)
+	depositRegisteredPubkeyHandlers       map[int]func(event *chain.DepositEvent)
+	depositRedemptionRequestedHandlers    map[int]func(event *chain.DepositEvent)
+	depositGotRedemptionSignatureHandlers map[int]func(event *chain.DepositEvent)
+	depositRedeemedHandlers               map[int]func(event *chain.DepositEvent)
 }
 
 func (lc *localChain) TBTCApplicationHandle() (chain.TBTCHandle, error) {
@@ -126,11 +126,11 @@ func NewTBTCLocalChain(ctx context.Context) *TBTCLocalChain {
 
 		alwaysFailingTransactions:             make(map[string]bool),
 		deposits:                              make(map[string]*localDeposit),
-		depositCreatedHandlers:                make(map[int]func(depositAddress string)),
-		depositRegisteredPubkeyHandlers:       make(map[int]func(depositAddress string)),
-		depositRedemptionRequestedHandlers:    make(map[int]func(depositAddress string)),
-		depositGotRedemptionSignatureHandlers: make(map[int]func(depositAddress string)),
-		depositRedeemedHandlers:               make(map[int]func(depositAddress string)),
+		depositCreatedHandlers:                make(map[int]func(event *chain.DepositEvent)),
+		depositRegisteredPubkeyHandlers:       make(map[int]func(event *chain.DepositEvent)),
+		depositRedemptionRequestedHandlers:    make(map[int]func(event *chain.DepositEvent)),
+		depositGotRedemptionSignatureHandlers: make(map[int]func(event *chain.DepositEvent)),
+		depositRedeemedHandlers:               make(map[int]func(event *chain.DepositEvent)),
 	}
 }
 
@@ -189,17 +189,20 @@ func (tlc *TBTCLocalChain) CreateDeposit(
 		redemptionRequestedEvents: make([]*chain.DepositRedemptionRequestedEvent, 0),
 	}
 
+	depositEvent, err := tlc.newDepositEvent(depositAddress)
+	if err != nil {
+		panic(err)
+	}
+
 	for _, handler := range tlc.depositCreatedHandlers {
-		go func(handler func(depositAddress string), depositAddress string) {
-			handler(depositAddress)
-		}(handler, depositAddress)
+		go handler(depositEvent)
 	}
 }
 
 // OnDepositCreated installs a callback that is invoked when a
 // local-chain notification of a new deposit creation is seen.
 func (tlc *TBTCLocalChain) OnDepositCreated(
-	handler func(depositAddress string),
+	handler func(event *chain.DepositEvent),
 ) subscription.EventSubscription {
 	tlc.tbtcLocalChainMutex.Lock()
 	defer tlc.tbtcLocalChainMutex.Unlock()
@@ -219,7 +222,7 @@ func (tlc *TBTCLocalChain) OnDepositCreated(
 // OnDepositRegisteredPubkey installs a callback that is invoked when a
 // local-chain notification of a deposit registration is seen.
 func (tlc *TBTCLocalChain) OnDepositRegisteredPubkey(
-	handler func(depositAddress string),
+	handler func(event *chain.DepositEvent),
 ) subscription.EventSubscription {
 	tlc.tbtcLocalChainMutex.Lock()
 	defer tlc.tbtcLocalChainMutex.Unlock()
@@ -300,10 +303,13 @@ func (tlc *TBTCLocalChain) RedeemDeposit(depositAddress string) error {
 		return err
 	}
 
+	depositEvent, err := tlc.newDepositEvent(depositAddress)
+	if err != nil {
+		return err
+	}
+
 	for _, handler := range tlc.depositRedemptionRequestedHandlers {
-		go func(handler func(depositAddress string), depositAddress string) {
-			handler(depositAddress)
-		}(handler, depositAddress)
+		go handler(depositEvent)
 	}
 
 	currentBlock, err := tlc.BlockCounter().CurrentBlock()
@@ -330,7 +336,7 @@ func (tlc *TBTCLocalChain) RedeemDeposit(depositAddress string) error {
 // OnDepositRedemptionRequested installs a callback that is invoked when a
 // redemption is requested.
 func (tlc *TBTCLocalChain) OnDepositRedemptionRequested(
-	handler func(depositAddress string),
+	handler func(event *chain.DepositEvent),
 ) subscription.EventSubscription {
 	tlc.tbtcLocalChainMutex.Lock()
 	defer tlc.tbtcLocalChainMutex.Unlock()
@@ -350,7 +356,7 @@ func (tlc *TBTCLocalChain) OnDepositRedemptionRequested(
 // OnDepositGotRedemptionSignature installs a callback that is invoked when the
 // signers sign off on a redemption
 func (tlc *TBTCLocalChain) OnDepositGotRedemptionSignature(
-	handler func(depositAddress string),
+	handler func(event *chain.DepositEvent),
 ) subscription.EventSubscription {
 	tlc.tbtcLocalChainMutex.Lock()
 	defer tlc.tbtcLocalChainMutex.Unlock()
@@ -370,7 +376,7 @@ func (tlc *TBTCLocalChain) OnDepositGotRedemptionSignature(
 // OnDepositRedeemed installs a callback that is invoked when the redemption
 // process is successful
 func (tlc *TBTCLocalChain) OnDepositRedeemed(
-	handler func(depositAddress string),
+	handler func(event *chain.DepositEvent),
 ) subscription.EventSubscription {
 	tlc.tbtcLocalChainMutex.Lock()
 	defer tlc.tbtcLocalChainMutex.Unlock()
@@ -387,6 +393,23 @@ func (tlc *TBTCLocalChain) OnDepositRedeemed(
 	})
 }
 
+// newDepositEvent creates an event of the deposit with the given address
+// emitted in the current block.
+func (tlc *TBTCLocalChain) newDepositEvent(
+	depositAddress string,
+) (*chain.DepositEvent, error) {
+	currentBlock, err := tlc.BlockCounter().CurrentBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return &chain.DepositEvent{
+		DepositAddress: depositAddress,
+		BlockNumber:    currentBlock,
+		BlockHash:      blockHash(currentBlock),
+	}, nil
+}
+
 // PastDepositRedemptionRequestedEvents the redemption requested events relevant to a particular deposit
 func (tlc *TBTCLocalChain) PastDepositRedemptionRequestedEvents(
 	startBlock uint64,
@@ -463,10 +486,13 @@ func (tlc *TBTCLocalChain) RetrieveSignerPubkey(depositAddress string) error {
 	deposit.pubkey = keep.publicKey[:]
 	deposit.state = chain.AwaitingBtcFundingProof
 
+	depositEvent, err := tlc.newDepositEvent(depositAddress)
+	if err != nil {
+		return err
+	}
+
 	for _, handler := range tlc.depositRegisteredPubkeyHandlers {
-		go func(handler func(depositAddress string), depositAddress string) {
-			handler(depositAddress)
-		}(handler, depositAddress)
+		go handler(depositEvent)
 	}
 
 	return nil
@@ -512,10 +538,13 @@ func (tlc *TBTCLocalChain) ProvideRedemptionSignature(
 		S: s,
 	}
 
+	depositEvent, err := tlc.newDepositEvent(depositAddress)
+	if err != nil {
+		return err
+	}
+
 	for _, handler := range tlc.depositGotRedemptionSignatureHandlers {
-		go func(handler func(depositAddress string), depositAddress string) {
-			handler(depositAddress)
-		}(handler, depositAddress)
+		go handler(depositEvent)
 	}
 
 	return nil
@@ -588,10 +617,13 @@ func (tlc *TBTCLocalChain) IncreaseRedemptionFee(
 		return err
 	}
 
+	depositEvent, err := tlc.newDepositEvent(depositAddress)
+	if err != nil {
+		return err
+	}
+
 	for _, handler := range tlc.depositRedemptionRequestedHandlers {
-		go func(handler func(depositAddress string), depositAddress string) {
-			handler(depositAddress)
-		}(handler, depositAddress)
+		go handler(depositEvent)
 	}
 
 	currentBlock, err := tlc.BlockCounter().CurrentBlock()
@@ -644,10 +676,13 @@ func (tlc *TBTCLocalChain) ProvideRedemptionProof(
 	deposit.state = chain.Redeemed
 	deposit.redemptionProof = &TxProof{}
 
+	depositEvent, err := tlc.newDepositEvent(depositAddress)
+	if err != nil {
+		return err
+	}
+
 	for _, handler := range tlc.depositRedeemedHandlers {
-		go func(handler func(depositAddress string), depositAddress string) {
-			handler(depositAddress)
-		}(handler, depositAddress)
+		go handler(depositEvent)
 	}
 
 	return nil
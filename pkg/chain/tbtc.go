@@ -72,32 +72,32 @@ type TBTCSystem interface {
 	// OnDepositCreated installs a callback that is invoked when an
 	// on-chain notification of a new deposit creation is seen.
 	OnDepositCreated(
-		handler func(depositAddress string),
+		handler func(event *DepositEvent),
 	) subscription.EventSubscription
 
 	// OnDepositRegisteredPubkey installs a callback that is invoked when an
 	// on-chain notification of a deposit's pubkey registration is seen.
 	OnDepositRegisteredPubkey(
-		handler func(depositAddress string),
+		handler func(event *DepositEvent),
 	) subscription.EventSubscription
 
 	// OnDepositRedemptionRequested installs a callback that is invoked when an
 	// on-chain notification of a deposit redemption request is seen.
 	OnDepositRedemptionRequested(
-		handler func(depositAddress string),
+		handler func(event *DepositEvent),
 	) subscription.EventSubscription
 
 	// OnDepositGotRedemptionSignature installs a callback that is invoked
 	// when an on-chain notification of a deposit receiving a redemption
 	// signature is seen.
 	OnDepositGotRedemptionSignature(
-		handler func(depositAddress string),
+		handler func(event *DepositEvent),
 	) subscription.EventSubscription
 
 	// OnDepositRedeemed installs a callback that is invoked when an
 	// on-chain notification of a deposit redemption is seen.
 	OnDepositRedeemed(
-		handler func(depositAddress string),
+		handler func(event *DepositEvent),
 	) subscription.EventSubscription
 
 	// PastDepositRedemptionRequestedEvents returns all redemption requested
@@ -125,6 +125,15 @@ type FundingInfo struct {
 	OutputIndex     uint32
 }
 
+// DepositEvent is an event emitted when the state of a deposit changes. It is
+// emitted for deposit creation, public key registration, redemption request,
+// redemption signature and redemption proof.
+type DepositEvent struct {
+	DepositAddress string
+	BlockNumber    uint64
+	BlockHash      [32]byte
+}
+
 // DepositRedemptionRequestedEvent is an event emitted when a deposit
 // redemption has been requested or the redemption fee has been increased.
 type DepositRedemptionRequestedEvent struct {
@@ -4,36 +4,32 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"time"
 
-	"github.com/keep-network/keep-common/pkg/chain/ethlike"
-
 	"github.com/ipfs/go-log"
 
+	"github.com/keep-network/keep-common/pkg/cache"
 	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-common/pkg/subscription"
 	"github.com/keep-network/keep-common/pkg/wrappers"
-	corechain "github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/operator"
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
 	"github.com/keep-network/keep-ecdsa/pkg/chain/bitcoin"
 	"github.com/keep-network/keep-ecdsa/pkg/client/event"
+	"github.com/keep-network/keep-ecdsa/pkg/client/lifecycle"
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss"
 	"github.com/keep-network/keep-ecdsa/pkg/extensions/tbtc"
 	"github.com/keep-network/keep-ecdsa/pkg/extensions/tbtc/recovery"
 	"github.com/keep-network/keep-ecdsa/pkg/node"
 	"github.com/keep-network/keep-ecdsa/pkg/registry"
+	"github.com/keep-network/keep-ecdsa/pkg/utils/persistenceutils"
 )
 
 var logger = log.Logger("keep-ecdsa")
 
-// The number of block confirmations the client waits until it starts the
-// requested signing process. This value prevents from reporting unauthorized
-// signings by adversaries in case of a chain fork.
-const blockConfirmations = 12
-
 // The timeout for executing repeated on-chain check for a keep awaiting
 // a signature. Once the client receives a signature requested event, it needs
 // to deduplicate it and execute on-chain check. This action is repeated with
@@ -43,7 +39,14 @@ const awaitingSignatureEventCheckTimeout = 60 * time.Second
 
 // Handle represents a handle to the ECDSA client.
 type Handle struct {
-	tssNode *node.Node
+	tssNode           *node.Node
+	hostChain         chain.Handle
+	keepsRegistry     *registry.Keeps
+	eventDeduplicator *event.Deduplicator
+	keepsLifecycle    *lifecycle.Tracker
+
+	activeKeepsCache   *cache.TimeCache
+	inactiveKeepsCache *cache.TimeCache
 }
 
 // TSSPreParamsPoolSize returns the current size of the TSS params pool.
@@ -51,6 +54,32 @@ func (h *Handle) TSSPreParamsPoolSize() int {
 	return h.tssNode.TSSPreParamsPoolSize()
 }
 
+// TSSPreParamsPoolTargetSize returns the target size of the TSS params pool.
+func (h *Handle) TSSPreParamsPoolTargetSize() int {
+	return h.tssNode.TSSPreParamsPoolTargetSize()
+}
+
+// TSSPreParamsGenerationsInProgress returns the number of TSS params currently
+// being generated.
+func (h *Handle) TSSPreParamsGenerationsInProgress() int {
+	return h.tssNode.TSSPreParamsGenerationsInProgress()
+}
+
+// BlameRecords returns records of keep members blamed for failed protocol
+// executions indexed by keep ID.
+func (h *Handle) BlameRecords() map[string][]*node.BlameRecord {
+	return h.tssNode.BlameRecords()
+}
+
+// Shutdown waits for key generation and signing protocols in progress to
+// complete, so that their results are persisted before the client exits.
+// Protocols are not interrupted; the function returns an error if they do not
+// complete before the context is done. The context passed to Initialize should
+// be cancelled before calling this function so that no new protocols start.
+func (h *Handle) Shutdown(ctx context.Context) error {
+	return h.tssNode.Drain(ctx)
+}
+
 // Initialize initializes the ECDSA client with rules related to events handling.
 // Expects a slice of sanctioned applications selected by the operator for which
 // operator will be registered as a member candidate.
@@ -59,7 +88,11 @@ func Initialize(
 	operatorPublicKey *operator.PublicKey,
 	hostChain chain.Handle,
 	networkProvider net.Provider,
-	persistence persistence.Handle,
+	persistence persistenceutils.Handle,
+	blamePersistence persistence.Handle,
+	preParamsPersistence persistenceutils.Handle,
+	transcriptsPersistence persistence.Handle,
+	lifecyclePersistence persistence.Handle,
 	derivationIndexStorage *recovery.DerivationIndexStorage,
 	clientConfig *Config,
 	tbtcConfig *tbtc.Config,
@@ -70,9 +103,29 @@ func Initialize(
 		hostChain.UnmarshalID,
 	)
 
-	tssNode := node.NewNode(hostChain, networkProvider, tssConfig)
+	// All on-chain events handled by the client pass through a single
+	// pipeline which delivers them only once they are confirmed and not
+	// retracted by a chain reorganization.
+	eventPipeline := chain.NewEventPipeline(
+		hostChain.BlockCounter(),
+		hostChain.BlockHash,
+	)
+
+	tssNode := node.NewNode(
+		hostChain,
+		eventPipeline,
+		networkProvider,
+		tssConfig,
+		clientConfig.GetRetryBackoff(),
+	)
+
+	tssNode.InitializeTSSPreParamsPool(preParamsPersistence)
 
-	tssNode.InitializeTSSPreParamsPool()
+	tssNode.InitializeBlameRegistry(blamePersistence)
+
+	if tssConfig.RecordTranscripts {
+		tssNode.InitializeTranscriptRecording(transcriptsPersistence)
+	}
 
 	eventDeduplicator := event.NewDeduplicator(
 		keepsRegistry,
@@ -82,17 +135,15 @@ func Initialize(
 	// Load current keeps' signers from storage and register for signing events.
 	keepsRegistry.LoadExistingKeeps()
 
-	confirmIsInactive := func(keep chain.BondedECDSAKeepHandle) bool {
-		currentBlock, err := hostChain.BlockCounter().CurrentBlock()
-		if err != nil {
-			logger.Errorf("failed to get current block height [%v]", err)
-			return false
-		}
+	keepsLifecycle := lifecycle.NewTracker(
+		lifecyclePersistence,
+		hostChain.UnmarshalID,
+	)
 
-		isKeepActive, err := ethlike.WaitForBlockConfirmations(
-			hostChain.BlockCounter(),
-			currentBlock,
-			blockConfirmations,
+	confirmIsInactive := func(keep chain.BondedECDSAKeepHandle) bool {
+		isKeepActive, err := eventPipeline.ConfirmState(
+			ctx,
+			chain.DefaultConfirmationDepth,
 			keep.IsActive,
 		)
 		if err != nil {
@@ -118,22 +169,67 @@ func Initialize(
 			hostChain.Name(),
 		)
 	} else {
-		go checkStatusAndRegisterForApplication(ctx, blockCounter, tbtcApplicationHandle)
+		go checkStatusAndRegisterForApplication(
+			ctx,
+			blockCounter,
+			eventPipeline,
+			tbtcApplicationHandle,
+		)
 	}
 
-	for _, keepID := range keepsRegistry.GetKeepsIDs() {
+	// Processing of keeps is resumed according to their recorded states.
+	for _, keepID := range keepsToResume(keepsRegistry, keepsLifecycle) {
 		go func(keepID chain.ID) {
 			keep, err := hostChain.GetKeepWithID(keepID)
 			if err != nil {
 				logger.Errorf(
 					"failed to look up keep [%s] for active check: [%v]; "+
 						"subscriptions for keep signing and closing events are skipped",
-					keep.ID(),
+					keepID,
 					err,
 				)
 				return
 			}
 
+			state, ok := keepsLifecycle.State(keepID)
+			if !ok {
+				state, ok = adoptKeep(keep, hostChain.BlockCounter(), keepsLifecycle)
+				if !ok {
+					return
+				}
+			}
+
+			switch state.State {
+			case lifecycle.AwaitingKeyGeneration:
+				// The signer has been registered, so the public key has been
+				// submitted before the client stopped.
+				recordTransition(keepsLifecycle, keepID, lifecycle.KeySubmitted)
+			case lifecycle.Closing:
+				logger.Infof(
+					"resuming unregistration of closed keep [%s]",
+					keepID,
+				)
+				keepsRegistry.UnregisterKeep(keepID)
+				recordTransition(keepsLifecycle, keepID, lifecycle.Archived)
+				return
+			case lifecycle.Terminated, lifecycle.Recovering:
+				resumeLiquidationRecovery(
+					ctx,
+					hostChain,
+					tbtcApplicationHandle,
+					networkProvider,
+					tbtcConfig,
+					tssNode,
+					operatorPublicKey,
+					keep,
+					keepsRegistry,
+					keepsLifecycle,
+					derivationIndexStorage,
+					eventDeduplicator,
+				)
+				return
+			}
+
 			isActive, err := keep.IsActive()
 			if err != nil {
 				logger.Errorf(
@@ -156,29 +252,29 @@ func Initialize(
 						keep.ID(),
 					)
 					keepsRegistry.UnregisterKeep(keepID)
+					recordTransition(keepsLifecycle, keepID, lifecycle.Archived)
 					return
 				}
 				logger.Warningf("keep [%s] is still active", keep.ID())
 			}
 
-			signer, err := keepsRegistry.GetSigner(keepID)
-			if err != nil {
+			if !hasSigner(keepsRegistry, keepID) {
 				// If there are no signer for loaded keep then something is clearly
 				// wrong. We don't want to continue processing for this keep.
-				logger.Errorf(
-					"no signer for keep [%s]: [%v]",
-					keep.ID(),
-					err,
-				)
+				logger.Errorf("no signer for keep [%s]", keep.ID())
 				return
 			}
 
+			resumeKeepProcessing(ctx, keep, keepsLifecycle)
+
 			subscriptionOnSignatureRequested, err := monitorSigningRequests(
 				hostChain,
+				eventPipeline,
 				clientConfig,
 				tssNode,
 				keep,
-				signer,
+				keepsRegistry,
+				keepsLifecycle,
 				eventDeduplicator,
 			)
 			if err != nil {
@@ -193,15 +289,27 @@ func Initialize(
 				return
 			}
 			go monitorKeepClosedEvents(
+				ctx,
 				hostChain,
+				eventPipeline,
 				keep,
 				keepsRegistry,
+				keepsLifecycle,
 				subscriptionOnSignatureRequested,
 				eventDeduplicator,
 			)
+			go monitorKeyShareRefresh(
+				ctx,
+				hostChain,
+				clientConfig,
+				tssNode,
+				keep,
+				keepsRegistry,
+			)
 			go monitorKeepTerminatedEvent(
 				ctx,
 				hostChain,
+				eventPipeline,
 				tbtcApplicationHandle,
 				networkProvider,
 				clientConfig,
@@ -210,6 +318,7 @@ func Initialize(
 				operatorPublicKey,
 				keep,
 				keepsRegistry,
+				keepsLifecycle,
 				derivationIndexStorage,
 				eventDeduplicator,
 				subscriptionOnSignatureRequested,
@@ -218,9 +327,26 @@ func Initialize(
 		}(keepID)
 	}
 
+	go resumeKeyGeneration(
+		ctx,
+		hostChain,
+		eventPipeline,
+		tbtcApplicationHandle,
+		networkProvider,
+		clientConfig,
+		tbtcConfig,
+		tssNode,
+		operatorPublicKey,
+		keepsRegistry,
+		keepsLifecycle,
+		derivationIndexStorage,
+		eventDeduplicator,
+	)
+
 	go checkAwaitingKeyGeneration(
 		ctx,
 		hostChain,
+		eventPipeline,
 		tbtcApplicationHandle,
 		networkProvider,
 		clientConfig,
@@ -228,12 +354,13 @@ func Initialize(
 		tssNode,
 		operatorPublicKey,
 		keepsRegistry,
+		keepsLifecycle,
 		derivationIndexStorage,
 		eventDeduplicator,
 	)
 
 	// Watch for new keeps creation.
-	_ = hostChain.OnBondedECDSAKeepCreated(func(event *chain.BondedECDSAKeepCreatedEvent) {
+	subscriptionOnKeepCreated := hostChain.OnBondedECDSAKeepCreated(func(event *chain.BondedECDSAKeepCreatedEvent) {
 		logger.Infof(
 			"new keep [%s] created with members: [%s] at block [%d]",
 			event.Keep.ID(),
@@ -267,6 +394,7 @@ func Initialize(
 				generateKeyForKeep(
 					ctx,
 					hostChain,
+					eventPipeline,
 					tbtcApplicationHandle,
 					networkProvider,
 					clientConfig,
@@ -274,6 +402,7 @@ func Initialize(
 					tssNode,
 					operatorPublicKey,
 					keepsRegistry,
+					keepsLifecycle,
 					derivationIndexStorage,
 					eventDeduplicator,
 					keep,
@@ -289,30 +418,68 @@ func Initialize(
 		}
 	})
 
+	go func() {
+		<-ctx.Done()
+		logger.Info("unsubscribing from keep created events")
+		subscriptionOnKeepCreated.Unsubscribe()
+	}()
+
 	initializeExtensions(
 		ctx,
+		hostChain,
+		eventPipeline,
 		tbtcApplicationHandle,
-		blockCounter,
-		hostChain.BlockTimestamp,
+		keepsRegistry,
+		tbtcConfig,
 	)
 
 	return &Handle{
-		tssNode: tssNode,
+		tssNode:           tssNode,
+		hostChain:         hostChain,
+		keepsRegistry:     keepsRegistry,
+		eventDeduplicator: eventDeduplicator,
+		keepsLifecycle:    keepsLifecycle,
+
+		activeKeepsCache:   cache.NewTimeCache(keepStatusCachePeriod),
+		inactiveKeepsCache: cache.NewTimeCache(keepStatusCachePeriod),
 	}
 }
 
 func initializeExtensions(
 	ctx context.Context,
+	hostChain chain.Handle,
+	eventPipeline *chain.EventPipeline,
 	tbtcHandle chain.TBTCHandle,
-	blockCounter corechain.BlockCounter,
-	blockTimestamp func(blockNumber *big.Int) (uint64, error),
+	keepsRegistry *registry.Keeps,
+	tbtcConfig *tbtc.Config,
 ) {
 	if tbtcHandle != nil {
+		// Keeps this client is a member of, used to resume monitoring of
+		// deposits they back.
+		keeps := make([]chain.BondedECDSAKeepHandle, 0)
+		for _, keepID := range keepsRegistry.GetKeepsIDs() {
+			keep, err := hostChain.GetKeepWithID(keepID)
+			if err != nil {
+				logger.Errorf(
+					"failed to look up keep [%s]; monitoring of its deposit "+
+						"will not be resumed: [%v]",
+					keepID,
+					err,
+				)
+				continue
+			}
+
+			keeps = append(keeps, keep)
+		}
+
 		tbtc.Initialize(
 			ctx,
 			tbtcHandle,
-			blockCounter,
-			blockTimestamp,
+			hostChain.BlockCounter(),
+			eventPipeline,
+			hostChain.BlockTimestamp,
+			keeps,
+			tbtcConfig,
 		)
 	} else {
 		logger.Errorf(
@@ -324,6 +491,7 @@ func initializeExtensions(
 func checkAwaitingKeyGeneration(
 	ctx context.Context,
 	hostChain chain.Handle,
+	eventPipeline *chain.EventPipeline,
 	tbtcHandle chain.TBTCHandle,
 	networkProvider net.Provider,
 	clientConfig *Config,
@@ -331,6 +499,7 @@ func checkAwaitingKeyGeneration(
 	tssNode *node.Node,
 	operatorPublicKey *operator.PublicKey,
 	keepsRegistry *registry.Keeps,
+	keepsLifecycle *lifecycle.Tracker,
 	derivationIndexStorage *recovery.DerivationIndexStorage,
 	eventDeduplicator *event.Deduplicator,
 ) {
@@ -385,9 +554,19 @@ func checkAwaitingKeyGeneration(
 			break
 		}
 
+		// Keeps with a recorded state are resumed according to that state.
+		if _, ok := keepsLifecycle.State(keep.ID()); ok {
+			logger.Debugf(
+				"skipping awaiting key generation check for tracked keep [%s]",
+				keep.ID(),
+			)
+			continue
+		}
+
 		err = checkAwaitingKeyGenerationForKeep(
 			ctx,
 			hostChain,
+			eventPipeline,
 			tbtcHandle,
 			networkProvider,
 			clientConfig,
@@ -395,6 +574,7 @@ func checkAwaitingKeyGeneration(
 			tssNode,
 			operatorPublicKey,
 			keepsRegistry,
+			keepsLifecycle,
 			derivationIndexStorage,
 			eventDeduplicator,
 			keep,
@@ -412,6 +592,7 @@ func checkAwaitingKeyGeneration(
 func checkAwaitingKeyGenerationForKeep(
 	ctx context.Context,
 	hostChain chain.Handle,
+	eventPipeline *chain.EventPipeline,
 	tbtcHandle chain.TBTCHandle,
 	networkProvider net.Provider,
 	clientConfig *Config,
@@ -419,6 +600,7 @@ func checkAwaitingKeyGenerationForKeep(
 	tssNode *node.Node,
 	operatorPublicKey *operator.PublicKey,
 	keepsRegistry *registry.Keeps,
+	keepsLifecycle *lifecycle.Tracker,
 	derivationIndexStorage *recovery.DerivationIndexStorage,
 	eventDeduplicator *event.Deduplicator,
 	keep chain.BondedECDSAKeepHandle,
@@ -438,7 +620,8 @@ func checkAwaitingKeyGenerationForKeep(
 	// - public key submission transactions are still mining,
 	// - conflicting public key has been submitted.
 	// In both cases, the client should not attempt to generate the key again.
-	if keepsRegistry.HasSigner(keep.ID()) {
+	if keepsRegistry.HasSigner(keep.ID()) ||
+		keepsRegistry.HasEdDSASigner(keep.ID()) {
 		logger.Warningf(
 			"keep public key is not registered on-chain but key material "+
 				"is stored on disk; skipping key generation; PLEASE INSPECT "+
@@ -467,6 +650,7 @@ func checkAwaitingKeyGenerationForKeep(
 		go generateKeyForKeep(
 			ctx,
 			hostChain,
+			eventPipeline,
 			tbtcHandle,
 			networkProvider,
 			clientConfig,
@@ -474,6 +658,7 @@ func checkAwaitingKeyGenerationForKeep(
 			tssNode,
 			operatorPublicKey,
 			keepsRegistry,
+			keepsLifecycle,
 			derivationIndexStorage,
 			eventDeduplicator,
 			keep,
@@ -488,6 +673,7 @@ func checkAwaitingKeyGenerationForKeep(
 func generateKeyForKeep(
 	ctx context.Context,
 	hostChain chain.Handle,
+	eventPipeline *chain.EventPipeline,
 	tbtcHandle chain.TBTCHandle,
 	networkProvider net.Provider,
 	clientConfig *Config,
@@ -495,33 +681,14 @@ func generateKeyForKeep(
 	tssNode *node.Node,
 	operatorPublicKey *operator.PublicKey,
 	keepsRegistry *registry.Keeps,
+	keepsLifecycle *lifecycle.Tracker,
 	derivationIndexStorage *recovery.DerivationIndexStorage,
 	eventDeduplicator *event.Deduplicator,
 	keep chain.BondedECDSAKeepHandle,
 	members []chain.ID,
 	honestThreshold uint64,
 ) {
-	if len(members) < 2 {
-		// TODO: #408 Implement single signer support.
-		logger.Errorf(
-			"keep [%s] has [%d] members; only keeps with at least 2 members are supported",
-			keep.ID(),
-			len(members),
-		)
-		return
-	}
-
-	if honestThreshold != uint64(len(members)) {
-		// TODO: #325 Implement threshold support.
-		logger.Errorf(
-			"keep [%s] has honest threshold [%s] and [%d] members; "+
-				"only keeps with honest threshold same as group size are supported",
-			keep.ID(),
-			honestThreshold,
-			len(members),
-		)
-		return
-	}
+	recordTransition(keepsLifecycle, keep.ID(), lifecycle.AwaitingKeyGeneration)
 
 	logger.Infof(
 		"member [%s] is starting signer generation for keep [%s]...",
@@ -529,46 +696,90 @@ func generateKeyForKeep(
 		keep.ID(),
 	)
 
-	signer, err := generateSignerForKeep(
-		ctx,
-		clientConfig,
-		tssNode,
-		operatorPublicKey,
-		keep,
-		members,
-		keepsRegistry,
-	)
-	if err != nil {
-		logger.Errorf(
-			"failed to generate signer for keep [%s]: [%v]",
+	// Keeps on production chains support only the ECDSA signature scheme and
+	// their handles do not implement the EdDSA keep handle.
+	signatureScheme := chain.ECDSA
+	eddsaKeep, isEdDSAKeep := keep.(chain.EdDSAKeepHandle)
+	if isEdDSAKeep {
+		var err error
+		signatureScheme, err = eddsaKeep.GetSignatureScheme()
+		if err != nil {
+			logger.Errorf(
+				"failed to get signature scheme of keep [%s]: [%v]",
+				keep.ID(),
+				err,
+			)
+			return
+		}
+	}
+
+	if ctx.Err() != nil {
+		logger.Warningf(
+			"client is shutting down; skipping signer generation for keep [%s]",
 			keep.ID(),
-			err,
 		)
 		return
 	}
 
-	logger.Infof("initialized signer for keep [%s]", keep.ID())
-
-	err = keepsRegistry.RegisterSigner(keep.ID(), signer)
+	var err error
+	switch signatureScheme {
+	case chain.EdDSA:
+		err = generateEdDSASignerForKeep(
+			clientConfig,
+			tssNode,
+			operatorPublicKey,
+			eddsaKeep,
+			members,
+			honestThreshold,
+			keepsRegistry,
+		)
+	default:
+		err = generateSignerForKeep(
+			clientConfig,
+			tssNode,
+			operatorPublicKey,
+			keep,
+			members,
+			honestThreshold,
+			keepsRegistry,
+		)
+	}
 	if err != nil {
 		logger.Errorf(
-			"failed to register threshold signer for keep [%s]: [%v]",
+			"failed to initialize signer for keep [%s]: [%v]",
 			keep.ID(),
 			err,
 		)
 
-		// In case of an error during signer registration, we want to avoid
-		// subscribing to the events emitted by the keep. The signer is not
-		// operating so we should stop further processing.
+		// In case of an error during signer generation or registration, we
+		// want to avoid subscribing to the events emitted by the keep.
+		// The signer is not operating so we should stop further processing.
 		return
 	}
 
+	recordTransition(keepsLifecycle, keep.ID(), lifecycle.KeySubmitted)
+
+	// Signatures can be requested only once the public keys of all members
+	// are submitted, so there are no signature requests up to the current
+	// block and the lookup of signature requests after a restart can start
+	// right after it.
+	currentBlock, err := hostChain.BlockCounter().CurrentBlock()
+	if err != nil {
+		logger.Warningf("failed to get current block height: [%v]", err)
+	} else {
+		recordProcessedBlock(keepsLifecycle, keep.ID(), currentBlock)
+	}
+
+	go confirmKeyPublished(ctx, keep, keepsLifecycle)
+
 	subscriptionOnSignatureRequested, err := monitorSigningRequests(
 		hostChain,
+		eventPipeline,
 		clientConfig,
 		tssNode,
 		keep,
-		signer,
+		keepsRegistry,
+		keepsLifecycle,
 		eventDeduplicator,
 	)
 	if err != nil {
@@ -586,16 +797,29 @@ func generateKeyForKeep(
 	}
 
 	go monitorKeepClosedEvents(
+		ctx,
 		hostChain,
+		eventPipeline,
 		keep,
 		keepsRegistry,
+		keepsLifecycle,
 		subscriptionOnSignatureRequested,
 		eventDeduplicator,
 	)
 
+	go monitorKeyShareRefresh(
+		ctx,
+		hostChain,
+		clientConfig,
+		tssNode,
+		keep,
+		keepsRegistry,
+	)
+
 	go monitorKeepTerminatedEvent(
 		ctx,
 		hostChain,
+		eventPipeline,
 		tbtcHandle,
 		networkProvider,
 		clientConfig,
@@ -604,53 +828,130 @@ func generateKeyForKeep(
 		operatorPublicKey,
 		keep,
 		keepsRegistry,
+		keepsLifecycle,
 		derivationIndexStorage,
 		eventDeduplicator,
 		subscriptionOnSignatureRequested,
 	)
 }
 
+// generateSignerForKeep generates an ECDSA signer for the keep and registers
+// it in the keeps registry.
+//
+// The key generation is not interrupted when the client shuts down. The client
+// waits for the signer to be generated and registered before it exits, so that
+// the signer is not lost.
 func generateSignerForKeep(
-	ctx context.Context,
 	clientConfig *Config,
 	tssNode *node.Node,
 	operatorPublicKey *operator.PublicKey,
 	keep chain.BondedECDSAKeepHandle,
 	members []chain.ID,
+	honestThreshold uint64,
+	keepsRegistry *registry.Keeps,
+) error {
+	done := tssNode.BeginProtocol()
+	defer done()
+
+	keygenCtx, cancel := context.WithTimeout(
+		context.Background(),
+		clientConfig.GetKeyGenerationTimeout(),
+	)
+	defer cancel()
+
+	signer, err := tssNode.GenerateSignerForKeep(
+		keygenCtx,
+		operatorPublicKey,
+		keep,
+		members,
+		honestThreshold,
+		keepsRegistry,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate signer: [%v]", err)
+	}
+
+	logger.Infof("initialized signer for keep [%s]", keep.ID())
+
+	if err := keepsRegistry.RegisterSigner(keep.ID(), signer); err != nil {
+		return fmt.Errorf("failed to register threshold signer: [%v]", err)
+	}
+
+	return nil
+}
+
+// generateEdDSASignerForKeep generates an EdDSA signer for the keep and
+// registers it in the keeps registry. The same as for ECDSA signers, the key
+// generation is not interrupted when the client shuts down.
+func generateEdDSASignerForKeep(
+	clientConfig *Config,
+	tssNode *node.Node,
+	operatorPublicKey *operator.PublicKey,
+	keep chain.EdDSAKeepHandle,
+	members []chain.ID,
+	honestThreshold uint64,
 	keepsRegistry *registry.Keeps,
-) (*tss.ThresholdSigner, error) {
-	keygenCtx, cancel := context.WithTimeout(ctx, clientConfig.GetKeyGenerationTimeout())
+) error {
+	done := tssNode.BeginProtocol()
+	defer done()
+
+	keygenCtx, cancel := context.WithTimeout(
+		context.Background(),
+		clientConfig.GetKeyGenerationTimeout(),
+	)
 	defer cancel()
 
-	return tssNode.GenerateSignerForKeep(
+	signer, err := tssNode.GenerateEdDSASignerForKeep(
 		keygenCtx,
 		operatorPublicKey,
 		keep,
 		members,
+		honestThreshold,
 		keepsRegistry,
 	)
+	if err != nil {
+		return fmt.Errorf("failed to generate EdDSA signer: [%v]", err)
+	}
+
+	logger.Infof("initialized EdDSA signer for keep [%s]", keep.ID())
+
+	if err := keepsRegistry.RegisterEdDSASigner(keep.ID(), signer); err != nil {
+		return fmt.Errorf("failed to register EdDSA signer: [%v]", err)
+	}
+
+	return nil
 }
 
 // monitorSigningRequests registers for signature requested events emitted by
-// specific keep contract.
+// specific keep contract. Signing starts once the event is confirmed by
+// the event pipeline.
 func monitorSigningRequests(
 	hostChain chain.Handle,
+	eventPipeline *chain.EventPipeline,
 	clientConfig *Config,
 	tssNode *node.Node,
 	keep chain.BondedECDSAKeepHandle,
-	signer *tss.ThresholdSigner,
+	keepsRegistry *registry.Keeps,
+	keepsLifecycle *lifecycle.Tracker,
 	eventDeduplicator *event.Deduplicator,
 ) (subscription.EventSubscription, error) {
 	go checkAwaitingSignature(
 		hostChain,
+		eventPipeline,
 		clientConfig,
 		tssNode,
 		keep,
-		signer,
+		keepsRegistry,
+		keepsLifecycle,
 		eventDeduplicator,
 	)
 
-	return keep.OnSignatureRequested(
+	confirmedSubscription := eventPipeline.Subscribe(
+		"signature requested",
+		chain.DefaultConfirmationDepth,
+	)
+
+	subscriptionOnSignatureRequested, err := keep.OnSignatureRequested(
 		func(event *chain.SignatureRequestedEvent) {
 			logger.Infof(
 				"new signature requested from keep [%s] for digest [%+x] at block [%d]",
@@ -659,239 +960,508 @@ func monitorSigningRequests(
 				event.BlockNumber,
 			)
 
-			go func(event *chain.SignatureRequestedEvent) {
-				err := wrappers.DoWithDefaultRetry(
-					clientConfig.GetSigningTimeout(),
-					// TODO: extract the code into a separate function and see if
-					// there is a way to deduplicate common parts with
-					// checkAwaitingSignature function.
-					func(ctx context.Context) error {
-						shouldHandle, err := eventDeduplicator.NotifySigningStarted(
-							awaitingSignatureEventCheckTimeout,
-							keep,
-							event.Digest,
-						)
-						if err != nil {
-							logger.Errorf(
-								"could not deduplicate signing request event: [%v]",
-								err,
-							)
-							return err
-						}
-
-						if !shouldHandle {
-							logger.Infof(
-								"signing request for keep [%s] and digest [%+x] already handled",
-								keep.ID(),
-								event.Digest,
-							)
-							// currently handling or already handled in the past
-							// in case this event is a duplicate.
-							return nil
-						}
+			isAwaitingSignature := func() (bool, error) {
+				return keep.IsAwaitingSignature(event.Digest)
+			}
 
-						defer eventDeduplicator.NotifySigningCompleted(keep.ID(), event.Digest)
-
-						isAwaitingSignature, err := ethlike.WaitForBlockConfirmations(
-							hostChain.BlockCounter(),
-							event.BlockNumber,
-							blockConfirmations,
-							func() (bool, error) {
-								return keep.IsAwaitingSignature(event.Digest)
-							},
-						)
-						if err != nil {
-							logger.Errorf(
-								"failed to confirm signing request for keep [%s] and digest [%+x]: [%v]",
-								keep.ID(),
-								event.Digest,
-								err,
-							)
-							return err
-						}
+			// The request is recorded before it is confirmed so that it is
+			// backfilled after a restart if the client stops before
+			// the signature is confirmed on-chain.
+			recordSignatureRequest(
+				keepsLifecycle,
+				keep.ID(),
+				event.Digest,
+				event.BlockNumber,
+			)
 
-						if !isAwaitingSignature {
-							logger.Warningf(
-								"keep [%s] is not awaiting a signature for digest [%+x]",
-								keep.ID(),
-								event.Digest,
-							)
+			confirmedSubscription.Deliver(event.BlockNumber, event.BlockHash, isAwaitingSignature, func() {
+				signRequestedDigest(
+					clientConfig,
+					tssNode,
+					keep,
+					event.Digest,
+					keepsRegistry,
+					keepsLifecycle,
+					eventDeduplicator,
+				)
+			})
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
 
-							// deeper chain reorg, nothing we should do
-							return nil
-						}
+	return confirmedSubscription.With(subscriptionOnSignatureRequested), nil
+}
 
-						if err := tssNode.CalculateSignature(
-							ctx,
-							keep,
-							signer,
-							event.Digest,
-						); err != nil {
-							logger.Errorf(
-								"signature calculation failed for keep [%s]: [%v]",
-								keep.ID(),
-								err,
-							)
-						}
+// signRequestedDigest calculates the signature for the digest requested from
+// the keep. The signature request is completed once the signature is confirmed
+// on-chain. If the signing fails, the request remains unfinished and is
+// backfilled after a restart.
+func signRequestedDigest(
+	clientConfig *Config,
+	tssNode *node.Node,
+	keep chain.BondedECDSAKeepHandle,
+	digest [32]byte,
+	keepsRegistry *registry.Keeps,
+	keepsLifecycle *lifecycle.Tracker,
+	eventDeduplicator *event.Deduplicator,
+) {
+	err := wrappers.DoWithDefaultRetry(
+		clientConfig.GetSigningTimeout(),
+		// TODO: see if there is a way to deduplicate common parts with
+		// signAwaitingDigest function.
+		func(ctx context.Context) error {
+			shouldHandle, err := eventDeduplicator.NotifySigningStarted(
+				awaitingSignatureEventCheckTimeout,
+				keep,
+				digest,
+			)
+			if err != nil {
+				logger.Errorf(
+					"could not deduplicate signing request event: [%v]",
+					err,
+				)
+				return err
+			}
 
-						return err
-					},
+			if !shouldHandle {
+				logger.Infof(
+					"signing request for keep [%s] and digest [%+x] already handled",
+					keep.ID(),
+					digest,
 				)
-				if err != nil {
-					logger.Errorf("failed to generate a signature: [%v]", err)
-				}
-			}(event)
+				// currently handling or already handled in the past
+				// in case this event is a duplicate.
+				return nil
+			}
+
+			defer eventDeduplicator.NotifySigningCompleted(keep.ID(), digest)
+
+			if err := calculateSignature(
+				ctx,
+				tssNode,
+				keep,
+				digest,
+				keepsRegistry,
+				keepsLifecycle,
+			); err != nil {
+				logger.Errorf(
+					"signature calculation failed for keep [%s]: [%v]",
+					keep.ID(),
+					err,
+				)
+				return err
+			}
+
+			// The signature has been published and confirmed on-chain.
+			completeSignatureRequest(keepsLifecycle, keep.ID(), digest)
+
+			return nil
 		},
 	)
+	if err != nil {
+		logger.Errorf("failed to generate a signature: [%v]", err)
+	}
 }
 
+// checkAwaitingSignature resumes signing for digests pending according to
+// the recorded keep state and checks signature requests the keep received
+// since the earliest unfinished request or the last processed block, including
+// those emitted while the client was down. Signatures are calculated for
+// digests the keep still awaits. Requests for digests the keep no longer
+// awaits are completed. Once all awaited requests are recorded as unfinished,
+// the current block is recorded as processed.
 func checkAwaitingSignature(
 	hostChain chain.Handle,
+	eventPipeline *chain.EventPipeline,
 	clientConfig *Config,
 	tssNode *node.Node,
 	keep chain.BondedECDSAKeepHandle,
-	signer *tss.ThresholdSigner,
+	keepsRegistry *registry.Keeps,
+	keepsLifecycle *lifecycle.Tracker,
 	eventDeduplicator *event.Deduplicator,
 ) {
 	logger.Debugf("checking awaiting signature for keep [%s]", keep.ID())
 
-	latestDigest, err := keep.LatestDigest()
+	currentBlock, err := hostChain.BlockCounter().CurrentBlock()
 	if err != nil {
-		logger.Errorf("could not get latest digest for keep [%s]", keep.ID())
+		logger.Errorf("failed to get current block height [%v]", err)
 		return
 	}
 
-	isAwaitingDigest, err := keep.IsAwaitingSignature(latestDigest)
+	unsignedRequests, err := pastUnsignedRequests(
+		keep,
+		signatureRequestsStartBlock(keep, keepsLifecycle),
+	)
 	if err != nil {
 		logger.Errorf(
-			"could not check awaiting signature of "+
-				"digest [%+x] for keep [%s]",
-			latestDigest,
+			"could not backfill signature requests for keep [%s]: [%v]",
 			keep.ID(),
+			err,
 		)
 		return
 	}
 
-	if isAwaitingDigest {
+	var pendingDigests [][32]byte
+	if state, ok := keepsLifecycle.State(keep.ID()); ok {
+		pendingDigests, err = state.PendingDigests()
+		if err != nil {
+			logger.Errorf(
+				"could not get pending digests of keep [%s]: [%v]",
+				keep.ID(),
+				err,
+			)
+			return
+		}
+	}
+
+	unsignedDigests := make([][32]byte, 0, len(unsignedRequests))
+	for _, request := range unsignedRequests {
+		unsignedDigests = append(unsignedDigests, request.Digest)
+	}
+
+	// Digests being signed when the client stopped are pending as well, even
+	// if their requests were not recorded.
+	digests := unsignedDigests
+	for _, digest := range pendingDigests {
+		if !containsDigest(digests, digest) {
+			digests = append(digests, digest)
+		}
+	}
+
+	awaitedDigests := make([][32]byte, 0)
+	for _, digest := range digests {
+		isAwaitingDigest, err := keep.IsAwaitingSignature(digest)
+		if err != nil {
+			logger.Errorf(
+				"could not check awaiting signature of "+
+					"digest [%+x] for keep [%s]",
+				digest,
+				keep.ID(),
+			)
+			return
+		}
+
+		if !isAwaitingDigest {
+			// The signing could have been interrupted after the signature
+			// was submitted.
+			if err := keepsLifecycle.CompleteSigning(keep.ID(), digest); err != nil {
+				logger.Warningf(
+					"could not record state of keep [%s]: [%v]",
+					keep.ID(),
+					err,
+				)
+			}
+
+			if containsDigest(unsignedDigests, digest) {
+				logger.Warningf(
+					"signature requested from keep [%s] for digest [%+x] "+
+						"has not been submitted and is no longer awaited",
+					keep.ID(),
+					digest,
+				)
+			}
+			completeSignatureRequest(keepsLifecycle, keep.ID(), digest)
+			continue
+		}
+
 		logger.Infof(
 			"awaiting a signature from keep [%s] for digest [%+x]",
 			keep.ID(),
-			latestDigest,
+			digest,
 		)
 
-		err := wrappers.DoWithDefaultRetry(
-			clientConfig.GetSigningTimeout(),
-			func(ctx context.Context) error {
-				shouldHandle, err := eventDeduplicator.NotifySigningStarted(
-					awaitingSignatureEventCheckTimeout,
-					keep,
-					latestDigest,
+		// Requests found by the backfill are recorded as unfinished here.
+		// Digests pending according to the keep state may have been
+		// requested before the first block of the backfill; their requests
+		// are recorded by signAwaitingDigest once the signing starts.
+		for _, request := range unsignedRequests {
+			if request.Digest == digest {
+				recordSignatureRequest(
+					keepsLifecycle,
+					keep.ID(),
+					digest,
+					request.BlockNumber,
 				)
-				if err != nil {
-					logger.Errorf(
-						"could not deduplicate signing request event: [%v]",
-						err,
-					)
-					return err
-				}
+				break
+			}
+		}
 
-				if !shouldHandle {
-					logger.Infof(
-						"signing request for keep [%s] and digest [%+x] already handled",
-						keep.ID(),
-						latestDigest,
-					)
-					// currently handling - it is possible that event
-					// subscription also received this event
-					return nil
-				}
+		awaitedDigests = append(awaitedDigests, digest)
+	}
 
-				defer eventDeduplicator.NotifySigningCompleted(keep.ID(), latestDigest)
+	recordProcessedBlock(keepsLifecycle, keep.ID(), currentBlock)
 
-				startBlock, err := keep.SignatureRequestedBlock(latestDigest)
-				if err != nil {
-					logger.Errorf(
-						"failed to get signature request block height for keep [%s] and digest [%x]: [%v]",
-						keep.ID(),
-						latestDigest,
-						err,
-					)
-					return err
-				}
+	for _, digest := range awaitedDigests {
+		signAwaitingDigest(
+			eventPipeline,
+			clientConfig,
+			tssNode,
+			keep,
+			digest,
+			keepsRegistry,
+			keepsLifecycle,
+			eventDeduplicator,
+		)
+	}
+}
 
-				isStillAwaitingSignature, err := ethlike.WaitForBlockConfirmations(
-					hostChain.BlockCounter(),
-					startBlock,
-					blockConfirmations,
-					func() (bool, error) {
-						isAwaitingSignature, err := keep.IsAwaitingSignature(latestDigest)
-						if err != nil {
-							return false, err
-						}
+// signAwaitingDigest confirms the keep awaits a signature for the digest
+// and calculates the signature. The signature request is completed once
+// the signature is confirmed on-chain or the keep is confirmed to no longer
+// await it.
+func signAwaitingDigest(
+	eventPipeline *chain.EventPipeline,
+	clientConfig *Config,
+	tssNode *node.Node,
+	keep chain.BondedECDSAKeepHandle,
+	digest [32]byte,
+	keepsRegistry *registry.Keeps,
+	keepsLifecycle *lifecycle.Tracker,
+	eventDeduplicator *event.Deduplicator,
+) {
+	err := wrappers.DoWithDefaultRetry(
+		clientConfig.GetSigningTimeout(),
+		func(ctx context.Context) error {
+			shouldHandle, err := eventDeduplicator.NotifySigningStarted(
+				awaitingSignatureEventCheckTimeout,
+				keep,
+				digest,
+			)
+			if err != nil {
+				logger.Errorf(
+					"could not deduplicate signing request event: [%v]",
+					err,
+				)
+				return err
+			}
 
-						isActive, err := keep.IsActive()
-						if err != nil {
-							return false, err
-						}
+			if !shouldHandle {
+				logger.Infof(
+					"signing request for keep [%s] and digest [%+x] already handled",
+					keep.ID(),
+					digest,
+				)
+				// currently handling - it is possible that event
+				// subscription also received this event
+				return nil
+			}
 
-						return (isAwaitingSignature && isActive), nil
-					},
+			defer eventDeduplicator.NotifySigningCompleted(keep.ID(), digest)
+
+			startBlock, err := keep.SignatureRequestedBlock(digest)
+			if err != nil {
+				logger.Errorf(
+					"failed to get signature request block height for keep [%s] and digest [%x]: [%v]",
+					keep.ID(),
+					digest,
+					err,
 				)
-				if err != nil {
-					logger.Errorf(
-						"failed to confirm signing request for keep [%s] and digest [%+x]: [%v]",
-						keep.ID(),
-						latestDigest,
-						err,
-					)
-					return err
-				}
+				return err
+			}
 
-				if !isStillAwaitingSignature {
-					logger.Warningf(
-						"keep [%s] is not awaiting a signature for digest [%+x]",
-						keep.ID(),
-						latestDigest,
-					)
+			startBlockHash, err := signatureRequestedBlockHash(
+				keep,
+				digest,
+				startBlock,
+			)
+			if err != nil {
+				logger.Errorf(
+					"failed to get signature request block hash for keep [%s] and digest [%x]: [%v]",
+					keep.ID(),
+					digest,
+					err,
+				)
+				return err
+			}
 
-					// deeper chain reorg, nothing we should do
-					return nil
-				}
+			recordSignatureRequest(keepsLifecycle, keep.ID(), digest, startBlock)
 
-				if err := tssNode.CalculateSignature(
-					ctx,
-					keep,
-					signer,
-					latestDigest,
-				); err != nil {
-					logger.Errorf(
-						"signature calculation failed for keep [%s]: [%v]",
-						keep.ID(),
-						err,
-					)
-				}
+			isStillAwaitingSignature, err := eventPipeline.ConfirmEvent(
+				ctx,
+				startBlock,
+				startBlockHash,
+				chain.DefaultConfirmationDepth,
+				func() (bool, error) {
+					isAwaitingSignature, err := keep.IsAwaitingSignature(digest)
+					if err != nil {
+						return false, err
+					}
 
+					isActive, err := keep.IsActive()
+					if err != nil {
+						return false, err
+					}
+
+					return (isAwaitingSignature && isActive), nil
+				},
+			)
+			if err != nil {
+				logger.Errorf(
+					"failed to confirm signing request for keep [%s] and digest [%+x]: [%v]",
+					keep.ID(),
+					digest,
+					err,
+				)
 				return err
-			},
+			}
+
+			if !isStillAwaitingSignature {
+				logger.Warningf(
+					"keep [%s] is not awaiting a signature for digest [%+x]",
+					keep.ID(),
+					digest,
+				)
+
+				// deeper chain reorg, nothing we should do
+				completeSignatureRequest(keepsLifecycle, keep.ID(), digest)
+				return nil
+			}
+
+			if err := calculateSignature(
+				ctx,
+				tssNode,
+				keep,
+				digest,
+				keepsRegistry,
+				keepsLifecycle,
+			); err != nil {
+				logger.Errorf(
+					"signature calculation failed for keep [%s]: [%v]",
+					keep.ID(),
+					err,
+				)
+				return err
+			}
+
+			// The signature has been published and confirmed on-chain.
+			completeSignatureRequest(keepsLifecycle, keep.ID(), digest)
+
+			return nil
+		},
+	)
+	if err != nil {
+		logger.Errorf("failed to generate a signature: [%v]", err)
+	}
+}
+
+// signatureRequestedBlockHash returns the hash of the block in which
+// the signature for the digest has been requested, as reported with the event
+// emitted in that block.
+func signatureRequestedBlockHash(
+	keep chain.BondedECDSAKeepHandle,
+	digest [32]byte,
+	blockNumber uint64,
+) ([32]byte, error) {
+	events, err := keep.PastSignatureRequestedEvents(blockNumber)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf(
+			"failed to get past signature requested events: [%v]",
+			err,
+		)
+	}
+
+	for _, event := range events {
+		if event.Digest == digest && event.BlockNumber == blockNumber {
+			return event.BlockHash, nil
+		}
+	}
+
+	return [32]byte{}, fmt.Errorf(
+		"no signature requested event for digest [%x] in block [%d]",
+		digest,
+		blockNumber,
+	)
+}
+
+// calculateSignature calculates and publishes a signature over the digest with
+// the signer registered for the keep, either an ECDSA or an EdDSA one. The keep
+// is in the signing state for the digest until the calculation completes.
+func calculateSignature(
+	ctx context.Context,
+	tssNode *node.Node,
+	keep chain.BondedECDSAKeepHandle,
+	digest [32]byte,
+	keepsRegistry *registry.Keeps,
+	keepsLifecycle *lifecycle.Tracker,
+) error {
+	done := tssNode.BeginProtocol()
+	defer done()
+
+	if err := keepsLifecycle.StartSigning(keep.ID(), digest); err != nil {
+		logger.Warningf(
+			"could not record state of keep [%s]: [%v]",
+			keep.ID(),
+			err,
 		)
+	}
+	defer func() {
+		if err := keepsLifecycle.CompleteSigning(keep.ID(), digest); err != nil {
+			logger.Warningf(
+				"could not record state of keep [%s]: [%v]",
+				keep.ID(),
+				err,
+			)
+		}
+	}()
+
+	if keepsRegistry.HasEdDSASigner(keep.ID()) {
+		eddsaKeep, ok := keep.(chain.EdDSAKeepHandle)
+		if !ok {
+			return fmt.Errorf(
+				"keep [%s] does not support EdDSA signatures",
+				keep.ID(),
+			)
+		}
+
+		signer, err := keepsRegistry.GetEdDSASigner(keep.ID())
 		if err != nil {
-			logger.Errorf("failed to generate a signature: [%v]", err)
+			logger.Errorf("no signer for keep [%s]: [%v]", keep.ID(), err)
+			return err
 		}
+
+		return tssNode.CalculateEdDSASignature(ctx, eddsaKeep, signer, digest)
 	}
+
+	// The signer is not used while its key share is being refreshed. The signer
+	// is looked up right before the signature calculation as its key share
+	// could have been refreshed in the meantime.
+	signerLock := keepsRegistry.SignerLock(keep.ID())
+	signerLock.RLock()
+	defer signerLock.RUnlock()
+
+	signer, err := keepsRegistry.GetSigner(keep.ID())
+	if err != nil {
+		logger.Errorf("no signer for keep [%s]: [%v]", keep.ID(), err)
+		return err
+	}
+
+	return tssNode.CalculateSignature(ctx, keep, signer, digest)
 }
 
 // monitorKeepClosedEvent monitors KeepClosed event and if that event happens
 // unsubscribes from signing event for the given keep and unregisters it from
-// the keep registry.
+// the keep registry. It unsubscribes from the events also when the context is
+// done.
 func monitorKeepClosedEvents(
+	ctx context.Context,
 	hostChain chain.Handle,
+	eventPipeline *chain.EventPipeline,
 	keep chain.BondedECDSAKeepHandle,
 	keepsRegistry *registry.Keeps,
+	keepsLifecycle *lifecycle.Tracker,
 	subscriptionOnSignatureRequested subscription.EventSubscription,
 	eventDeduplicator *event.Deduplicator,
 ) {
 	keepClosed := make(chan *chain.KeepClosedEvent)
 
+	confirmedSubscription := eventPipeline.Subscribe(
+		"keep closed",
+		chain.DefaultConfirmationDepth,
+	)
+	defer confirmedSubscription.Unsubscribe()
+
 	subscriptionOnKeepClosed, err := keep.OnKeepClosed(
 		func(event *chain.KeepClosedEvent) {
 			logger.Infof(
@@ -900,7 +1470,11 @@ func monitorKeepClosedEvents(
 				event.BlockNumber,
 			)
 
-			go func(event *chain.KeepClosedEvent) {
+			isInactive := func() (bool, error) {
+				return isKeepInactive(keep)
+			}
+
+			confirmedSubscription.Deliver(event.BlockNumber, event.BlockHash, isInactive, func() {
 				if shouldHandle := eventDeduplicator.NotifyClosingStarted(keep.ID()); !shouldHandle {
 					logger.Infof(
 						"close event for keep [%s] already handled",
@@ -913,33 +1487,17 @@ func monitorKeepClosedEvents(
 				}
 				defer eventDeduplicator.NotifyClosingCompleted(keep.ID())
 
-				isKeepActive, err := ethlike.WaitForBlockConfirmations(
-					hostChain.BlockCounter(),
-					event.BlockNumber,
-					blockConfirmations,
-					func() (bool, error) {
-						return keep.IsActive()
-					},
-				)
-				if err != nil {
-					logger.Errorf(
-						"failed to confirm keep [%s] closed: [%v]",
-						keep.ID(),
-						err,
-					)
-					return
-				}
-
-				if isKeepActive {
-					logger.Warningf("keep [%s] has not been closed", keep.ID())
-					return
-				}
+				recordTransition(keepsLifecycle, keep.ID(), lifecycle.Closing)
 
 				// TODO: Rework how unregistering works in the context of
 				// completing/confirming btc recovery on the bitcoin chain.
 				keepsRegistry.UnregisterKeep(keep.ID())
-				keepClosed <- event
-			}(event)
+				recordTransition(keepsLifecycle, keep.ID(), lifecycle.Archived)
+				select {
+				case keepClosed <- event:
+				case <-ctx.Done():
+				}
+			})
 		},
 	)
 	if err != nil {
@@ -955,17 +1513,22 @@ func monitorKeepClosedEvents(
 	defer subscriptionOnKeepClosed.Unsubscribe()
 	defer subscriptionOnSignatureRequested.Unsubscribe()
 
-	<-keepClosed
-
-	logger.Infof("unsubscribing from events on keep [%s] closed", keep.ID())
+	select {
+	case <-keepClosed:
+		logger.Infof("unsubscribing from events on keep [%s] closed", keep.ID())
+	case <-ctx.Done():
+		logger.Infof("unsubscribing from events on keep [%s]", keep.ID())
+	}
 }
 
 // monitorKeepTerminatedEvent monitors KeepTerminated event and if that event
 // happens unsubscribes from signing event for the given keep and unregisters it
-// from the keep registry.
+// from the keep registry. It unsubscribes from the events also when the context
+// is done.
 func monitorKeepTerminatedEvent(
 	ctx context.Context,
 	hostChain chain.Handle,
+	eventPipeline *chain.EventPipeline,
 	tbtcHandle chain.TBTCHandle,
 	networkProvider net.Provider,
 	clientConfig *Config,
@@ -974,12 +1537,19 @@ func monitorKeepTerminatedEvent(
 	operatorPublicKey *operator.PublicKey,
 	keep chain.BondedECDSAKeepHandle,
 	keepsRegistry *registry.Keeps,
+	keepsLifecycle *lifecycle.Tracker,
 	derivationIndexStorage *recovery.DerivationIndexStorage,
 	eventDeduplicator *event.Deduplicator,
 	subscriptionOnSignatureRequested subscription.EventSubscription,
 ) {
 	keepTerminated := make(chan *chain.KeepTerminatedEvent)
 
+	confirmedSubscription := eventPipeline.Subscribe(
+		"keep terminated",
+		chain.DefaultConfirmationDepth,
+	)
+	defer confirmedSubscription.Unsubscribe()
+
 	subscriptionOnKeepTerminated, err := keep.OnKeepTerminated(
 		func(event *chain.KeepTerminatedEvent) {
 			logger.Infof(
@@ -988,7 +1558,11 @@ func monitorKeepTerminatedEvent(
 				event.BlockNumber,
 			)
 
-			go func(event *chain.KeepTerminatedEvent) {
+			isInactive := func() (bool, error) {
+				return isKeepInactive(keep)
+			}
+
+			confirmedSubscription.Deliver(event.BlockNumber, event.BlockHash, isInactive, func() {
 				err := tbtcConfig.Bitcoin.Validate()
 				if err != nil {
 					if (bitcoin.Config{}) == tbtcConfig.Bitcoin {
@@ -1013,41 +1587,19 @@ func monitorKeepTerminatedEvent(
 						}
 						defer eventDeduplicator.NotifyTerminatingCompleted(keep.ID())
 
-						isKeepActive, err := ethlike.WaitForBlockConfirmations(
-							hostChain.BlockCounter(),
-							event.BlockNumber,
-							blockConfirmations,
-							func() (bool, error) {
-								return keep.IsActive()
-							},
-						)
-						if err != nil {
-							logger.Errorf(
-								"failed to confirm keep [%s] termination: [%v]",
-								keep.ID(),
-								err,
-							)
-							return err
-						}
+						recordTransition(keepsLifecycle, keep.ID(), lifecycle.Terminated)
 
-						if isKeepActive {
-							logger.Warningf("keep [%s] has not been terminated", keep.ID())
-							return err
-						}
-
-						bitcoinHandle := bitcoin.Connect(tbtcConfig.Bitcoin.ElectrsURLWithDefault())
-
-						if err := handleLiquidationRecovery(
+						if err := recoverTerminatedKeep(
 							ctx,
 							hostChain,
 							tbtcHandle,
-							bitcoinHandle,
 							networkProvider,
 							tbtcConfig,
 							tssNode,
 							operatorPublicKey,
 							keep,
 							keepsRegistry,
+							keepsLifecycle,
 							derivationIndexStorage,
 						); err != nil {
 							// If the deposit got liquidated before it had been
@@ -1070,13 +1622,10 @@ func monitorKeepTerminatedEvent(
 							return err
 						}
 
-						logger.Debugf(
-							"unregistering keep [%s] after liquidation recovery",
-							keep.ID(),
-						)
-
-						keepsRegistry.UnregisterKeep(keep.ID())
-						keepTerminated <- event
+						select {
+						case keepTerminated <- event:
+						case <-ctx.Done():
+						}
 
 						return nil
 					},
@@ -1084,7 +1633,7 @@ func monitorKeepTerminatedEvent(
 				if err != nil {
 					logger.Errorf("failed to broadcast the bitcoin recovery transaction: [%v]", err)
 				}
-			}(event)
+			})
 		},
 	)
 	if err != nil {
@@ -1100,7 +1649,75 @@ func monitorKeepTerminatedEvent(
 	defer subscriptionOnKeepTerminated.Unsubscribe()
 	defer subscriptionOnSignatureRequested.Unsubscribe()
 
-	<-keepTerminated
+	select {
+	case <-keepTerminated:
+		logger.Infof("unsubscribing from events on keep [%s] terminated", keep.ID())
+	case <-ctx.Done():
+		logger.Infof("unsubscribing from events on keep [%s]", keep.ID())
+	}
+}
+
+// isKeepInactive checks if the keep has been closed or terminated.
+func isKeepInactive(keep chain.BondedECDSAKeepHandle) (bool, error) {
+	isActive, err := keep.IsActive()
+	if err != nil {
+		return false, err
+	}
+
+	return !isActive, nil
+}
+
+// recoverTerminatedKeep executes the liquidation recovery for the terminated
+// keep and unregisters the keep once the recovery completes. The keep is in
+// the recovering state during the recovery and transitions back to
+// the terminated state if the recovery failed and should be retried.
+func recoverTerminatedKeep(
+	ctx context.Context,
+	hostChain chain.Handle,
+	tbtcHandle chain.TBTCHandle,
+	networkProvider net.Provider,
+	tbtcConfig *tbtc.Config,
+	tssNode *node.Node,
+	operatorPublicKey *operator.PublicKey,
+	keep chain.BondedECDSAKeepHandle,
+	keepsRegistry *registry.Keeps,
+	keepsLifecycle *lifecycle.Tracker,
+	derivationIndexStorage *recovery.DerivationIndexStorage,
+) error {
+	recordTransition(keepsLifecycle, keep.ID(), lifecycle.Recovering)
+
+	bitcoinHandle := bitcoin.Connect(tbtcConfig.Bitcoin.ElectrsURLWithDefault())
 
-	logger.Infof("unsubscribing from events on keep [%s] terminated", keep.ID())
+	if err := handleLiquidationRecovery(
+		ctx,
+		hostChain,
+		tbtcHandle,
+		bitcoinHandle,
+		networkProvider,
+		tbtcConfig,
+		tssNode,
+		operatorPublicKey,
+		keep,
+		keepsRegistry,
+		derivationIndexStorage,
+	); err != nil {
+		// There is nothing to recover if the deposit has not been funded.
+		if errors.Is(err, chain.ErrDepositNotFunded) {
+			recordTransition(keepsLifecycle, keep.ID(), lifecycle.Archived)
+		} else {
+			recordTransition(keepsLifecycle, keep.ID(), lifecycle.Terminated)
+		}
+
+		return err
+	}
+
+	logger.Debugf(
+		"unregistering keep [%s] after liquidation recovery",
+		keep.ID(),
+	)
+
+	keepsRegistry.UnregisterKeep(keep.ID())
+	recordTransition(keepsLifecycle, keep.ID(), lifecycle.Archived)
+
+	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	configtime "github.com/keep-network/keep-ecdsa/config/time"
+	"github.com/keep-network/keep-ecdsa/pkg/utils/backoff"
 )
 
 const (
@@ -20,6 +21,20 @@ const (
 
 	// The default value of a timeout for a signature calculation.
 	defaultSigningTimeout = 2 * time.Hour
+
+	// The default delays between retries of failed actions within the key
+	// generation and signing process. The delay doubles after each failed
+	// attempt, starting from the initial value up to the maximum value.
+	defaultRetryInitialDelay = 1 * time.Second
+	defaultRetryMaxDelay     = 1 * time.Minute
+
+	// Random jitter added to delays between retries so that keep members do
+	// not retry at exactly the same time.
+	retryJitter = 100 * time.Millisecond
+
+	// The default time the client waits for key generation and signing
+	// protocols in progress to complete when it shuts down.
+	defaultShutdownDrainTimeout = 5 * time.Minute
 )
 
 // Config contains configuration for tss protocol execution.
@@ -32,6 +47,20 @@ type Config struct {
 	// Timeout for key generation and signature calculation.
 	KeyGenerationTimeout configtime.Duration
 	SigningTimeout       configtime.Duration
+
+	// Number of blocks between consecutive key share refreshes of a keep.
+	// Key shares are not refreshed periodically if the value is not set.
+	KeyShareRefreshInterval uint64
+
+	// Delays between retries of failed actions within the key generation and
+	// signing process. The delay doubles after each failed attempt, starting
+	// from the initial value up to the maximum value.
+	RetryInitialDelay configtime.Duration
+	RetryMaxDelay     configtime.Duration
+
+	// Time the client waits for key generation and signing protocols in
+	// progress to complete when it shuts down.
+	ShutdownDrainTimeout configtime.Duration
 }
 
 // GetAwaitingKeyGenerationLookback returns a look-back period to check if
@@ -67,3 +96,43 @@ func (c *Config) GetSigningTimeout() time.Duration {
 
 	return timeout
 }
+
+// GetKeyShareRefreshInterval returns the number of blocks between consecutive
+// key share refreshes of a keep. Zero value means key shares should not be
+// refreshed periodically.
+func (c *Config) GetKeyShareRefreshInterval() uint64 {
+	return c.KeyShareRefreshInterval
+}
+
+// GetRetryBackoff returns the policy of delays between retries of failed
+// actions within the key generation and signing process. If delays are not set
+// it uses default values.
+func (c *Config) GetRetryBackoff() backoff.Policy {
+	initialDelay := c.RetryInitialDelay.ToDuration()
+	if initialDelay == 0 {
+		initialDelay = defaultRetryInitialDelay
+	}
+
+	maxDelay := c.RetryMaxDelay.ToDuration()
+	if maxDelay == 0 {
+		maxDelay = defaultRetryMaxDelay
+	}
+
+	return &backoff.Exponential{
+		InitialDelay: initialDelay,
+		MaxDelay:     maxDelay,
+		Jitter:       retryJitter,
+	}
+}
+
+// GetShutdownDrainTimeout returns the time the client waits for protocols in
+// progress to complete when it shuts down. If a value is not set it returns
+// a default value.
+func (c *Config) GetShutdownDrainTimeout() time.Duration {
+	timeout := c.ShutdownDrainTimeout.ToDuration()
+	if timeout == 0 {
+		timeout = defaultShutdownDrainTimeout
+	}
+
+	return timeout
+}
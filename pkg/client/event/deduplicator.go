@@ -156,6 +156,12 @@ func (d *Deduplicator) NotifySigningCompleted(
 	d.requestedSignatures.remove(keepID, digest)
 }
 
+// PendingSignatures returns hex-encoded digests of signatures currently being
+// calculated by the client, indexed by keep ID.
+func (d *Deduplicator) PendingSignatures() map[string][]string {
+	return d.requestedSignatures.digests()
+}
+
 // NotifyClosingStarted notifies the client wants to close a keep upon receiving
 // an event. It returns boolean indicating whether the client should proceed
 // with the execution or ignore the event as a duplicate.
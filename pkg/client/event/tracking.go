@@ -2,6 +2,7 @@ package event
 
 import (
 	"encoding/hex"
+	"sort"
 	"sync"
 
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
@@ -108,3 +109,23 @@ func (rst *requestedSignaturesTrack) remove(keepID chain.ID, digest [32]byte) {
 		}
 	}
 }
+
+// digests returns hex-encoded digests currently tracked for each keep,
+// indexed by keep ID. Digests of each keep are sorted.
+func (rst *requestedSignaturesTrack) digests() map[string][]string {
+	rst.mutex.Lock()
+	defer rst.mutex.Unlock()
+
+	digests := make(map[string][]string, len(rst.data))
+	for keepID, keepSignaturesRequests := range rst.data {
+		keepDigests := make([]string, 0, len(keepSignaturesRequests))
+		for digestString := range keepSignaturesRequests {
+			keepDigests = append(keepDigests, digestString)
+		}
+		sort.Strings(keepDigests)
+
+		digests[keepID] = keepDigests
+	}
+
+	return digests
+}
@@ -2,6 +2,8 @@ package event
 
 import (
 	"context"
+	"encoding/hex"
+	"reflect"
 	"testing"
 
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
@@ -243,3 +245,35 @@ func TestRequestedSignaturesTrackHas(t *testing.T) {
 		t.Errorf("event was removed and should no longer be tracked")
 	}
 }
+
+func TestRequestedSignaturesTrackDigests(t *testing.T) {
+	digest1 := [32]byte{9}
+	digest2 := [32]byte{8}
+	digest3 := [32]byte{10}
+
+	rs := &requestedSignaturesTrack{
+		data: make(map[string]map[string]bool),
+	}
+
+	rs.add(keepID1, digest1)
+	rs.add(keepID1, digest2)
+	rs.add(keepID2, digest3)
+	rs.remove(keepID2, digest3)
+
+	expectedDigests := map[string][]string{
+		keepID1.String(): {
+			hex.EncodeToString(digest2[:]),
+			hex.EncodeToString(digest1[:]),
+		},
+	}
+
+	digests := rs.digests()
+
+	if !reflect.DeepEqual(expectedDigests, digests) {
+		t.Errorf(
+			"unexpected digests\nexpected: [%v]\nactual:   [%v]",
+			expectedDigests,
+			digests,
+		)
+	}
+}
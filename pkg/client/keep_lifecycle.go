@@ -0,0 +1,467 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keep-network/keep-common/pkg/wrappers"
+	corechain "github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/client/event"
+	"github.com/keep-network/keep-ecdsa/pkg/client/lifecycle"
+	"github.com/keep-network/keep-ecdsa/pkg/extensions/tbtc"
+	"github.com/keep-network/keep-ecdsa/pkg/extensions/tbtc/recovery"
+	"github.com/keep-network/keep-ecdsa/pkg/node"
+	"github.com/keep-network/keep-ecdsa/pkg/registry"
+)
+
+// recordTransition records the transition of the keep to the given state.
+// Failures are only logged; the recorded state drives resumption of the keep
+// processing after a restart but does not affect the current processing.
+func recordTransition(
+	keepsLifecycle *lifecycle.Tracker,
+	keepID chain.ID,
+	state lifecycle.State,
+) {
+	if err := keepsLifecycle.Transition(keepID, state); err != nil {
+		logger.Warningf(
+			"could not record state [%s] of keep [%s]: [%v]",
+			state,
+			keepID,
+			err,
+		)
+	}
+}
+
+// recordProcessedBlock records that signature requests for the keep have been
+// recorded up to the given block. Failures are only logged; signature
+// requests after the last recorded block are backfilled after a restart.
+func recordProcessedBlock(
+	keepsLifecycle *lifecycle.Tracker,
+	keepID chain.ID,
+	blockNumber uint64,
+) {
+	if err := keepsLifecycle.RecordProcessedBlock(keepID, blockNumber); err != nil {
+		logger.Warningf(
+			"could not record processed block [%d] of keep [%s]: [%v]",
+			blockNumber,
+			keepID,
+			err,
+		)
+	}
+}
+
+// recordSignatureRequest records the signature request for the digest emitted
+// in the given block as unfinished, so that it is backfilled after a restart
+// until it is completed. Failures are only logged.
+func recordSignatureRequest(
+	keepsLifecycle *lifecycle.Tracker,
+	keepID chain.ID,
+	digest [32]byte,
+	blockNumber uint64,
+) {
+	if err := keepsLifecycle.RecordSignatureRequest(
+		keepID,
+		digest,
+		blockNumber,
+	); err != nil {
+		logger.Warningf(
+			"could not record signature request for digest [%+x] "+
+				"of keep [%s]: [%v]",
+			digest,
+			keepID,
+			err,
+		)
+	}
+}
+
+// completeSignatureRequest records the signature request for the digest as
+// completed. It should be called only once the signature is confirmed
+// on-chain or the keep is confirmed to no longer await it. Failures are only
+// logged; the request is checked again after a restart.
+func completeSignatureRequest(
+	keepsLifecycle *lifecycle.Tracker,
+	keepID chain.ID,
+	digest [32]byte,
+) {
+	if err := keepsLifecycle.CompleteSignatureRequest(keepID, digest); err != nil {
+		logger.Warningf(
+			"could not complete signature request for digest [%+x] "+
+				"of keep [%s]: [%v]",
+			digest,
+			keepID,
+			err,
+		)
+	}
+}
+
+// adoptKeep records the state of a keep registered before the client started
+// tracking keep states. The state is determined from the chain: a keep with
+// the public key published is active, otherwise its public key has been
+// submitted. The second returned value is false if the state could not be
+// recorded.
+//
+// Signature requests of the adopted keep are looked up in the whole keep
+// history once, on adoption. Requests with no signature submitted are recorded
+// as unfinished and the current block is recorded as processed, so that
+// the lookup after a restart does not cover the whole keep history again.
+func adoptKeep(
+	keep chain.BondedECDSAKeepHandle,
+	blockCounter corechain.BlockCounter,
+	keepsLifecycle *lifecycle.Tracker,
+) (lifecycle.KeepState, bool) {
+	publicKey, err := keep.GetPublicKey()
+	if err != nil {
+		logger.Errorf(
+			"failed to get public key of keep [%s]: [%v]",
+			keep.ID(),
+			err,
+		)
+		return lifecycle.KeepState{}, false
+	}
+
+	state := lifecycle.Active
+	if len(publicKey) == 0 {
+		state = lifecycle.KeySubmitted
+	}
+
+	logger.Infof("adopting keep [%s] in state [%s]", keep.ID(), state)
+
+	if err := keepsLifecycle.Adopt(keep.ID(), state); err != nil {
+		logger.Errorf(
+			"could not record state of keep [%s]: [%v]",
+			keep.ID(),
+			err,
+		)
+		return lifecycle.KeepState{}, false
+	}
+
+	recordAdoptedSignatureRequests(keep, blockCounter, keepsLifecycle, state)
+
+	return keepsLifecycle.State(keep.ID())
+}
+
+// recordAdoptedSignatureRequests records signature requests of the keep
+// adopted in the given state. Signatures can be requested only once the public
+// key is published, so there are no requests to look up for a keep with
+// the public key submitted. Failures are only logged; signature requests of
+// the keep are then looked up in the whole keep history until the processed
+// block is recorded.
+func recordAdoptedSignatureRequests(
+	keep chain.BondedECDSAKeepHandle,
+	blockCounter corechain.BlockCounter,
+	keepsLifecycle *lifecycle.Tracker,
+	state lifecycle.State,
+) {
+	// The current block is determined before the lookup so that requests
+	// emitted during the lookup are not skipped.
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		logger.Warningf("failed to get current block height: [%v]", err)
+		return
+	}
+
+	if state == lifecycle.Active {
+		unsignedRequests, err := pastUnsignedRequests(keep, 0)
+		if err != nil {
+			logger.Warningf(
+				"could not look up signature requests of adopted keep [%s]: [%v]",
+				keep.ID(),
+				err,
+			)
+			return
+		}
+
+		for _, request := range unsignedRequests {
+			recordSignatureRequest(
+				keepsLifecycle,
+				keep.ID(),
+				request.Digest,
+				request.BlockNumber,
+			)
+		}
+	}
+
+	recordProcessedBlock(keepsLifecycle, keep.ID(), currentBlock)
+}
+
+// keepsToResume returns IDs of keeps whose processing should be resumed after
+// the client restart according to their recorded states, along with IDs of
+// registered keeps with no recorded state, which have to be adopted first.
+// Keeps awaiting key generation are resumed only if their signer has been
+// registered; otherwise, the key generation is resumed for them.
+func keepsToResume(
+	keepsRegistry *registry.Keeps,
+	keepsLifecycle *lifecycle.Tracker,
+) []chain.ID {
+	keepIDs := keepsLifecycle.KeepsInState(
+		lifecycle.KeySubmitted,
+		lifecycle.Active,
+		lifecycle.Signing,
+		lifecycle.Closing,
+		lifecycle.Terminated,
+		lifecycle.Recovering,
+	)
+
+	for _, keepID := range keepsLifecycle.KeepsInState(
+		lifecycle.AwaitingKeyGeneration,
+	) {
+		if hasSigner(keepsRegistry, keepID) {
+			keepIDs = append(keepIDs, keepID)
+		}
+	}
+
+	for _, keepID := range keepsRegistry.GetKeepsIDs() {
+		if _, ok := keepsLifecycle.State(keepID); !ok {
+			keepIDs = append(keepIDs, keepID)
+		}
+	}
+
+	return keepIDs
+}
+
+func hasSigner(keepsRegistry *registry.Keeps, keepID chain.ID) bool {
+	return keepsRegistry.HasSigner(keepID) ||
+		keepsRegistry.HasEdDSASigner(keepID)
+}
+
+// resumeKeepProcessing resumes processing of an active keep interrupted by
+// the client restart, according to the recorded keep state. Signing of digests
+// pending according to the recorded state is resumed along with the backfill
+// of signature requests.
+func resumeKeepProcessing(
+	ctx context.Context,
+	keep chain.BondedECDSAKeepHandle,
+	keepsLifecycle *lifecycle.Tracker,
+) {
+	state, ok := keepsLifecycle.State(keep.ID())
+	if !ok {
+		return
+	}
+
+	if state.State == lifecycle.KeySubmitted {
+		go confirmKeyPublished(ctx, keep, keepsLifecycle)
+	}
+}
+
+// confirmKeyPublished transitions the keep to the active state once its public
+// key is published on-chain by all keep members.
+func confirmKeyPublished(
+	ctx context.Context,
+	keep chain.BondedECDSAKeepHandle,
+	keepsLifecycle *lifecycle.Tracker,
+) {
+	published := make(chan struct{}, 1)
+
+	subscription, err := keep.OnPublicKeyPublished(
+		func(event *chain.PublicKeyPublishedEvent) {
+			select {
+			case published <- struct{}{}:
+			default:
+			}
+		},
+	)
+	if err != nil {
+		logger.Errorf(
+			"failed on watching public key published event for keep [%s]: [%v]",
+			keep.ID(),
+			err,
+		)
+		return
+	}
+	defer subscription.Unsubscribe()
+
+	// The public key could have been published before the subscription was
+	// installed.
+	publicKey, err := keep.GetPublicKey()
+	if err != nil {
+		logger.Warningf(
+			"could not get public key of keep [%s]: [%v]",
+			keep.ID(),
+			err,
+		)
+	}
+
+	if len(publicKey) == 0 {
+		select {
+		case <-published:
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	logger.Infof("public key of keep [%s] has been published", keep.ID())
+
+	recordTransition(keepsLifecycle, keep.ID(), lifecycle.Active)
+}
+
+// resumeKeyGeneration resumes key generation for keeps which were awaiting
+// key generation when the client stopped.
+func resumeKeyGeneration(
+	ctx context.Context,
+	hostChain chain.Handle,
+	eventPipeline *chain.EventPipeline,
+	tbtcHandle chain.TBTCHandle,
+	networkProvider net.Provider,
+	clientConfig *Config,
+	tbtcConfig *tbtc.Config,
+	tssNode *node.Node,
+	operatorPublicKey *operator.PublicKey,
+	keepsRegistry *registry.Keeps,
+	keepsLifecycle *lifecycle.Tracker,
+	derivationIndexStorage *recovery.DerivationIndexStorage,
+	eventDeduplicator *event.Deduplicator,
+) {
+	for _, keepID := range keepsLifecycle.KeepsInState(
+		lifecycle.AwaitingKeyGeneration,
+	) {
+		// Keeps with the signer registered are resumed along with keeps with
+		// the public key submitted.
+		if hasSigner(keepsRegistry, keepID) {
+			continue
+		}
+
+		logger.Infof("resuming key generation for keep [%s]", keepID)
+
+		keep, err := hostChain.GetKeepWithID(keepID)
+		if err != nil {
+			logger.Errorf(
+				"failed to look up keep [%s] awaiting key generation: [%v]",
+				keepID,
+				err,
+			)
+			continue
+		}
+
+		isActive, err := keep.IsActive()
+		if err != nil {
+			logger.Errorf(
+				"failed to verify if keep [%s] is still active: [%v]",
+				keepID,
+				err,
+			)
+			continue
+		}
+
+		publicKey, err := keep.GetPublicKey()
+		if err != nil {
+			logger.Errorf(
+				"failed to get public key of keep [%s]: [%v]",
+				keepID,
+				err,
+			)
+			continue
+		}
+
+		// The key cannot be generated for an inactive keep and the key
+		// generated without this client will not be generated again.
+		if !isActive || len(publicKey) != 0 {
+			logger.Warningf(
+				"keep [%s] no longer awaits key generation; archiving",
+				keepID,
+			)
+			recordTransition(keepsLifecycle, keepID, lifecycle.Archived)
+			continue
+		}
+
+		if err := checkAwaitingKeyGenerationForKeep(
+			ctx,
+			hostChain,
+			eventPipeline,
+			tbtcHandle,
+			networkProvider,
+			clientConfig,
+			tbtcConfig,
+			tssNode,
+			operatorPublicKey,
+			keepsRegistry,
+			keepsLifecycle,
+			derivationIndexStorage,
+			eventDeduplicator,
+			keep,
+		); err != nil {
+			logger.Errorf(
+				"could not resume key generation for keep [%s]: [%v]",
+				keepID,
+				err,
+			)
+		}
+	}
+}
+
+// resumeLiquidationRecovery resumes the liquidation recovery for a terminated
+// keep which has not completed before the client restart.
+func resumeLiquidationRecovery(
+	ctx context.Context,
+	hostChain chain.Handle,
+	tbtcHandle chain.TBTCHandle,
+	networkProvider net.Provider,
+	tbtcConfig *tbtc.Config,
+	tssNode *node.Node,
+	operatorPublicKey *operator.PublicKey,
+	keep chain.BondedECDSAKeepHandle,
+	keepsRegistry *registry.Keeps,
+	keepsLifecycle *lifecycle.Tracker,
+	derivationIndexStorage *recovery.DerivationIndexStorage,
+	eventDeduplicator *event.Deduplicator,
+) {
+	if err := tbtcConfig.Bitcoin.Validate(); err != nil {
+		logger.Errorf(
+			"could not resume liquidation recovery for keep [%s]; "+
+				"invalid bitcoin configuration: [%v]",
+			keep.ID(),
+			err,
+		)
+		return
+	}
+
+	logger.Infof("resuming liquidation recovery for keep [%s]", keep.ID())
+
+	err := wrappers.DoWithDefaultRetry(
+		tbtcConfig.GetLiquidationRecoveryTimeout(),
+		func(ctx context.Context) error {
+			if shouldHandle := eventDeduplicator.NotifyTerminatingStarted(keep.ID()); !shouldHandle {
+				logger.Infof(
+					"liquidation recovery for keep [%s] already handled",
+					keep.ID(),
+				)
+				return nil
+			}
+			defer eventDeduplicator.NotifyTerminatingCompleted(keep.ID())
+
+			err := recoverTerminatedKeep(
+				ctx,
+				hostChain,
+				tbtcHandle,
+				networkProvider,
+				tbtcConfig,
+				tssNode,
+				operatorPublicKey,
+				keep,
+				keepsRegistry,
+				keepsLifecycle,
+				derivationIndexStorage,
+			)
+			if errors.Is(err, chain.ErrDepositNotFunded) {
+				logger.Warnf(
+					"aborted liquidation recovery for keep [%s]: [%v]",
+					keep.ID(),
+					err,
+				)
+				// Exit without an error to abort retries.
+				return nil
+			}
+
+			return err
+		},
+	)
+	if err != nil {
+		logger.Errorf(
+			"failed to resume liquidation recovery for keep [%s]: [%v]",
+			keep.ID(),
+			err,
+		)
+	}
+}
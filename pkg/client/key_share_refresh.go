@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/node"
+	"github.com/keep-network/keep-ecdsa/pkg/registry"
+)
+
+// monitorKeyShareRefresh periodically refreshes the key share of the signer
+// registered for the given keep. Key shares are refreshed every
+// `KeyShareRefreshInterval` blocks. All keep members need to take part in the
+// refresh so the block at which the refresh starts is determined only by the
+// block number and the keep ID. Refreshes of different keeps are spread over
+// the interval to not execute all of them at the same block.
+//
+// The monitoring stops when the signer is no longer registered for the keep,
+// e.g. when the keep has been closed.
+func monitorKeyShareRefresh(
+	ctx context.Context,
+	hostChain chain.Handle,
+	clientConfig *Config,
+	tssNode *node.Node,
+	keep chain.BondedECDSAKeepHandle,
+	keepsRegistry *registry.Keeps,
+) {
+	interval := clientConfig.GetKeyShareRefreshInterval()
+	if interval == 0 {
+		return
+	}
+
+	members, err := keep.GetMembers()
+	if err != nil {
+		logger.Errorf(
+			"failed to get members of keep [%s]; key share will not be refreshed: [%v]",
+			keep.ID(),
+			err,
+		)
+		return
+	}
+
+	// Single-member keeps have no key shares to refresh.
+	if len(members) < 2 {
+		return
+	}
+
+	offset := keyShareRefreshOffset(keep.ID(), interval)
+
+	newBlockChan := hostChain.BlockCounter().WatchBlocks(ctx)
+	for {
+		select {
+		case block := <-newBlockChan:
+			if !keepsRegistry.HasSigner(keep.ID()) {
+				logger.Debugf(
+					"no signer for keep [%s]; stopping key share refresh monitoring",
+					keep.ID(),
+				)
+				return
+			}
+
+			if (block+offset)%interval != 0 {
+				continue
+			}
+
+			refreshKeyShareAtBlock(tssNode, keep, keepsRegistry, block)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// refreshKeyShareAtBlock refreshes the key share of the signer registered for
+// the given keep unless the keep is awaiting a signature.
+//
+// Key share refresh is never executed concurrently with signing. Pre-parameters
+// for the refresh are taken before the signer is locked, so that signing is
+// not blocked while they are generated. Signing requests received during
+// the refresh preempt it and wait only until the signer is unlocked.
+func refreshKeyShareAtBlock(
+	tssNode *node.Node,
+	keep chain.BondedECDSAKeepHandle,
+	keepsRegistry *registry.Keeps,
+	block uint64,
+) {
+	preParamsBox, err := tssNode.TSSPreParams()
+	if err != nil {
+		logger.Errorf(
+			"could not get tss pre parameters for keep [%s]; "+
+				"skipping key share refresh at block [%d]: [%v]",
+			keep.ID(),
+			block,
+			err,
+		)
+		return
+	}
+
+	signerLock := keepsRegistry.SignerLock(keep.ID())
+	signerLock.Lock()
+	defer signerLock.Unlock()
+
+	latestDigest, err := keep.LatestDigest()
+	if err != nil {
+		logger.Errorf(
+			"could not get latest digest for keep [%s]: [%v]",
+			keep.ID(),
+			err,
+		)
+		return
+	}
+
+	isAwaitingSignature, err := keep.IsAwaitingSignature(latestDigest)
+	if err != nil {
+		logger.Errorf(
+			"could not check awaiting signature of "+
+				"digest [%+x] for keep [%s]: [%v]",
+			latestDigest,
+			keep.ID(),
+			err,
+		)
+		return
+	}
+
+	// Signing takes precedence over key share refresh. The refresh
+	// will be attempted again after the next interval.
+	if isAwaitingSignature {
+		logger.Warningf(
+			"keep [%s] is awaiting a signature for digest [%+x]; "+
+				"skipping key share refresh at block [%d]",
+			keep.ID(),
+			latestDigest,
+			block,
+		)
+		return
+	}
+
+	// The key share refresh is not interrupted when the client shuts down;
+	// the client waits for it to complete before it exits.
+	done := tssNode.BeginProtocol()
+	defer done()
+
+	err = tssNode.RefreshSignerForKeep(
+		context.Background(),
+		keep,
+		keepsRegistry,
+		preParamsBox,
+	)
+	if err != nil {
+		logger.Errorf(
+			"failed to refresh key share for keep [%s] at block [%d]: [%v]",
+			keep.ID(),
+			block,
+			err,
+		)
+	}
+}
+
+// keyShareRefreshOffset calculates a keep-specific offset of the key share
+// refresh block within the refresh interval.
+func keyShareRefreshOffset(keepID chain.ID, interval uint64) uint64 {
+	keepIDHash := sha256.Sum256([]byte(keepID.String()))
+	return binary.BigEndian.Uint64(keepIDHash[:8]) % interval
+}
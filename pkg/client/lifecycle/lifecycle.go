@@ -0,0 +1,594 @@
+// Package lifecycle tracks the lifecycle of keeps the client is a member of.
+//
+// The state of each keep is persisted on every transition, so that after
+// a restart the client knows whether the key generation was in progress,
+// the public key was submitted but not yet published, signatures for which
+// digests were being calculated or the liquidation recovery was in progress,
+// and can resume processing of the keep accordingly. Along with the state, signature requests
+// for the keep which have not been completed yet and the block up to which
+// signature requests have been recorded are persisted, so that requests not
+// completed before the restart and requests emitted while the client was down
+// can be backfilled.
+package lifecycle
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+)
+
+var logger = log.Logger("keep-lifecycle")
+
+// Name of the file under which the state of a keep is persisted in the keep's
+// directory.
+const stateFileName = "state"
+
+// State is a state of the keep from the perspective of the client.
+type State string
+
+// Keep states tracked by the client.
+const (
+	// AwaitingKeyGeneration is the state of a keep the client has to generate
+	// a signer for.
+	AwaitingKeyGeneration State = "awaiting key generation"
+	// KeySubmitted is the state of a keep for which the client generated
+	// a signer and submitted its public key to the chain. The public key has
+	// not been confirmed to be published by all keep members yet.
+	KeySubmitted State = "key submitted"
+	// Active is the state of a keep with the public key published on-chain,
+	// awaiting signature requests.
+	Active State = "active"
+	// Signing is the state of a keep for which the client calculates
+	// signatures. Digests being signed are recorded along with the state.
+	Signing State = "signing"
+	// Closing is the state of a keep confirmed to be closed which is being
+	// unregistered by the client.
+	Closing State = "closing"
+	// Terminated is the state of a keep whose termination has been confirmed
+	// but the liquidation recovery has not been started yet.
+	Terminated State = "terminated"
+	// Recovering is the state of a terminated keep for which the client
+	// executes the liquidation recovery.
+	Recovering State = "recovering"
+	// Archived is the final state of a keep no longer processed by the client.
+	// A keep in any state can be archived.
+	Archived State = "archived"
+)
+
+// transitions lists states other than archived a keep in the given state can
+// transition to.
+var transitions = map[State][]State{
+	AwaitingKeyGeneration: {KeySubmitted},
+	// A signature can be requested only once the public key is published,
+	// so it is enough to transition the keep to the signing state.
+	KeySubmitted: {Active, Signing, Closing, Terminated},
+	Active:       {Signing, Closing, Terminated},
+	// The keep transitions back to the active state once signatures for all
+	// digests being signed are completed.
+	Signing:    {Active, Closing, Terminated},
+	Terminated: {Recovering},
+	// The keep transitions back to the terminated state if the liquidation
+	// recovery failed and should be retried.
+	Recovering: {Terminated},
+}
+
+// KeepState is the state of a keep persisted by the tracker.
+type KeepState struct {
+	State State `json:"state"`
+	// SigningDigests are hex-encoded digests being signed, ordered
+	// lexicographically. They are set only in the signing state.
+	SigningDigests []string `json:"signingDigests,omitempty"`
+	// ProcessedBlock is the block up to which signature requests for the keep
+	// have been recorded. It is retained across state transitions.
+	ProcessedBlock uint64 `json:"processedBlock,omitempty"`
+	// UnfinishedRequests maps hex-encoded digests of signature requests for
+	// the keep which have not been completed yet to blocks the signatures
+	// were requested in. A request is completed once its signature is
+	// confirmed on-chain or the keep no longer awaits it. Unfinished requests
+	// are retained across state transitions.
+	UnfinishedRequests map[string]uint64 `json:"unfinishedRequests,omitempty"`
+	UpdatedAt          time.Time         `json:"updatedAt"`
+}
+
+// PendingDigests returns digests being signed and digests of unfinished
+// signature requests for the keep, ordered lexicographically and without
+// duplicates.
+func (ks KeepState) PendingDigests() ([][32]byte, error) {
+	digestsHex := make(map[string]bool)
+	for _, digestHex := range ks.SigningDigests {
+		digestsHex[digestHex] = true
+	}
+	for digestHex := range ks.UnfinishedRequests {
+		digestsHex[digestHex] = true
+	}
+
+	digests := make([][32]byte, 0, len(digestsHex))
+	for digestHex := range digestsHex {
+		digestBytes, err := hex.DecodeString(digestHex)
+		if err != nil || len(digestBytes) != 32 {
+			return nil, fmt.Errorf("invalid digest [%s]", digestHex)
+		}
+
+		var digest [32]byte
+		copy(digest[:], digestBytes)
+		digests = append(digests, digest)
+	}
+
+	sort.Slice(digests, func(i, j int) bool {
+		return hex.EncodeToString(digests[i][:]) <
+			hex.EncodeToString(digests[j][:])
+	})
+
+	return digests, nil
+}
+
+// SignatureRequestsResumeBlock returns the block from which signature requests
+// for the keep should be looked up after a restart: the block of the earliest
+// unfinished request or, if there are none, the block right after the last
+// processed one. The second returned value is false if neither is recorded.
+func (ks KeepState) SignatureRequestsResumeBlock() (uint64, bool) {
+	if len(ks.UnfinishedRequests) > 0 {
+		resumeBlock := uint64(math.MaxUint64)
+		for _, blockNumber := range ks.UnfinishedRequests {
+			if blockNumber < resumeBlock {
+				resumeBlock = blockNumber
+			}
+		}
+
+		return resumeBlock, true
+	}
+
+	if ks.ProcessedBlock > 0 {
+		return ks.ProcessedBlock + 1, true
+	}
+
+	return 0, false
+}
+
+// Tracker tracks and persists states of keeps. Keeps are removed from
+// the tracker once they transition to the archived state.
+type Tracker struct {
+	mutex  sync.RWMutex
+	states map[string]*KeepState // keep ID -> state
+	keeps  map[string]chain.ID   // keep ID string -> keep ID
+
+	handle      persistence.Handle
+	unmarshalID func(string) (chain.ID, error)
+}
+
+// NewTracker creates a tracker and loads states of keeps persisted with
+// the given handle. The handle should not be used to persist any other data.
+func NewTracker(
+	handle persistence.Handle,
+	unmarshalID func(string) (chain.ID, error),
+) *Tracker {
+	tracker := &Tracker{
+		states:      make(map[string]*KeepState),
+		keeps:       make(map[string]chain.ID),
+		handle:      handle,
+		unmarshalID: unmarshalID,
+	}
+
+	tracker.load()
+
+	return tracker
+}
+
+// State returns the current state of the keep. The second returned value is
+// false if no state is recorded for the keep.
+func (t *Tracker) State(keepID chain.ID) (KeepState, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	state, ok := t.states[keepID.String()]
+	if !ok {
+		return KeepState{}, false
+	}
+
+	result := *state
+	result.SigningDigests = append([]string{}, state.SigningDigests...)
+	result.UnfinishedRequests = copyRequests(state.UnfinishedRequests)
+
+	return result, true
+}
+
+// Adopt sets the initial state of a keep the client was a member of before
+// it started tracking keep states. It has no effect if a state is already
+// recorded for the keep.
+func (t *Tracker) Adopt(keepID chain.ID, state State) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, ok := t.states[keepID.String()]; ok {
+		return nil
+	}
+
+	return t.set(keepID, &KeepState{State: state})
+}
+
+// Transition moves the keep to the given state. A keep without a recorded
+// state can transition only to the awaiting key generation state. Transition
+// to the current state has no effect. The signing state can be entered only
+// with StartSigning and left for the active state only with CompleteSigning.
+func (t *Tracker) Transition(keepID chain.ID, state State) error {
+	if state == Signing {
+		return fmt.Errorf("signing state requires a digest")
+	}
+
+	return t.transition(keepID, &KeepState{State: state})
+}
+
+// StartSigning adds the digest to digests being signed for the keep, moving
+// the keep to the signing state if it is not in that state yet. It has no
+// effect if the digest is already being signed.
+func (t *Tracker) StartSigning(keepID chain.ID, digest [32]byte) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	current, ok := t.states[keepID.String()]
+	if !ok {
+		return fmt.Errorf("keep [%s] has no recorded state", keepID)
+	}
+
+	digestHex := hex.EncodeToString(digest[:])
+
+	if current.State != Signing {
+		if !isAllowed(current.State, Signing) {
+			return fmt.Errorf(
+				"keep [%s] cannot transition from [%s] to [%s]",
+				keepID,
+				current.State,
+				Signing,
+			)
+		}
+
+		return t.set(keepID, &KeepState{
+			State:          Signing,
+			SigningDigests: []string{digestHex},
+		})
+	}
+
+	index := sort.SearchStrings(current.SigningDigests, digestHex)
+	if index < len(current.SigningDigests) &&
+		current.SigningDigests[index] == digestHex {
+		return nil
+	}
+
+	digests := make([]string, 0, len(current.SigningDigests)+1)
+	digests = append(digests, current.SigningDigests[:index]...)
+	digests = append(digests, digestHex)
+	digests = append(digests, current.SigningDigests[index:]...)
+
+	return t.set(keepID, &KeepState{
+		State:          Signing,
+		SigningDigests: digests,
+	})
+}
+
+// CompleteSigning removes the digest from digests being signed for the keep.
+// Once no digest is being signed, the keep moves back to the active state.
+// It has no effect if the digest is not being signed, for example, when
+// the keep has been closed in the meantime.
+func (t *Tracker) CompleteSigning(keepID chain.ID, digest [32]byte) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	current, ok := t.states[keepID.String()]
+	if !ok || current.State != Signing {
+		return nil
+	}
+
+	digestHex := hex.EncodeToString(digest[:])
+
+	digests := make([]string, 0, len(current.SigningDigests))
+	for _, signingDigest := range current.SigningDigests {
+		if signingDigest != digestHex {
+			digests = append(digests, signingDigest)
+		}
+	}
+
+	if len(digests) == len(current.SigningDigests) {
+		return nil
+	}
+
+	if len(digests) == 0 {
+		return t.set(keepID, &KeepState{State: Active})
+	}
+
+	return t.set(keepID, &KeepState{
+		State:          Signing,
+		SigningDigests: digests,
+	})
+}
+
+// RecordProcessedBlock records that signature requests for the keep have been
+// processed up to the given block. It has no effect if a later block is
+// already recorded.
+func (t *Tracker) RecordProcessedBlock(keepID chain.ID, blockNumber uint64) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	current, ok := t.states[keepID.String()]
+	if !ok {
+		return fmt.Errorf("keep [%s] has no recorded state", keepID)
+	}
+
+	if blockNumber <= current.ProcessedBlock {
+		return nil
+	}
+
+	next := *current
+	next.ProcessedBlock = blockNumber
+
+	return t.set(keepID, &next)
+}
+
+// RecordSignatureRequest records the signature request for the digest emitted
+// in the given block as unfinished. If the request is already recorded,
+// the earlier of the blocks is retained.
+func (t *Tracker) RecordSignatureRequest(
+	keepID chain.ID,
+	digest [32]byte,
+	blockNumber uint64,
+) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	current, ok := t.states[keepID.String()]
+	if !ok {
+		return fmt.Errorf("keep [%s] has no recorded state", keepID)
+	}
+
+	digestHex := hex.EncodeToString(digest[:])
+
+	if recordedBlock, ok := current.UnfinishedRequests[digestHex]; ok &&
+		recordedBlock <= blockNumber {
+		return nil
+	}
+
+	next := *current
+	next.UnfinishedRequests = copyRequests(current.UnfinishedRequests)
+	next.UnfinishedRequests[digestHex] = blockNumber
+
+	return t.set(keepID, &next)
+}
+
+// CompleteSignatureRequest removes the signature request for the digest from
+// unfinished requests of the keep. It has no effect if the request is not
+// recorded.
+func (t *Tracker) CompleteSignatureRequest(
+	keepID chain.ID,
+	digest [32]byte,
+) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	current, ok := t.states[keepID.String()]
+	if !ok {
+		return nil
+	}
+
+	digestHex := hex.EncodeToString(digest[:])
+
+	if _, ok := current.UnfinishedRequests[digestHex]; !ok {
+		return nil
+	}
+
+	next := *current
+	next.UnfinishedRequests = copyRequests(current.UnfinishedRequests)
+	delete(next.UnfinishedRequests, digestHex)
+
+	return t.set(keepID, &next)
+}
+
+// KeepsInState returns IDs of keeps which are in one of the given states,
+// ordered by the keep ID.
+func (t *Tracker) KeepsInState(states ...State) []chain.ID {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	keepIDs := []chain.ID{}
+	for keepID, keepState := range t.states {
+		for _, state := range states {
+			if keepState.State == state {
+				keepIDs = append(keepIDs, t.keeps[keepID])
+				break
+			}
+		}
+	}
+
+	sort.Slice(keepIDs, func(i, j int) bool {
+		return keepIDs[i].String() < keepIDs[j].String()
+	})
+
+	return keepIDs
+}
+
+func (t *Tracker) transition(keepID chain.ID, next *KeepState) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	current, ok := t.states[keepID.String()]
+	if !ok {
+		if next.State != AwaitingKeyGeneration {
+			return fmt.Errorf(
+				"keep [%s] with no recorded state cannot transition to [%s]",
+				keepID,
+				next.State,
+			)
+		}
+
+		return t.set(keepID, next)
+	}
+
+	if current.State == next.State {
+		return nil
+	}
+
+	// The keep in the signing state has its public key published already and
+	// returns to the active state only once all signatures are completed.
+	if current.State == Signing && next.State == Active {
+		return nil
+	}
+
+	if !isAllowed(current.State, next.State) {
+		return fmt.Errorf(
+			"keep [%s] cannot transition from [%s] to [%s]",
+			keepID,
+			current.State,
+			next.State,
+		)
+	}
+
+	return t.set(keepID, next)
+}
+
+func isAllowed(from State, to State) bool {
+	if to == Archived {
+		return true
+	}
+
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// set records and persists the state of the keep. Once the keep is archived,
+// its directory is archived as well and the keep is removed from the tracker.
+// It should be called with the mutex locked.
+func (t *Tracker) set(keepID chain.ID, state *KeepState) error {
+	if current, ok := t.states[keepID.String()]; ok {
+		if current.ProcessedBlock > state.ProcessedBlock {
+			state.ProcessedBlock = current.ProcessedBlock
+		}
+		if state.UnfinishedRequests == nil {
+			state.UnfinishedRequests = current.UnfinishedRequests
+		}
+	}
+
+	state.UpdatedAt = time.Now()
+
+	stateBytes, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal keep state: [%v]", err)
+	}
+
+	if err := t.handle.Save(
+		stateBytes,
+		keepID.String(),
+		stateFileName,
+	); err != nil {
+		return fmt.Errorf(
+			"could not persist state of keep [%s]: [%v]",
+			keepID,
+			err,
+		)
+	}
+
+	logger.Debugf("keep [%s] transitioned to [%s]", keepID, state.State)
+
+	if state.State == Archived {
+		delete(t.states, keepID.String())
+		delete(t.keeps, keepID.String())
+
+		if err := t.handle.Archive(keepID.String()); err != nil {
+			return fmt.Errorf(
+				"could not archive state of keep [%s]: [%v]",
+				keepID,
+				err,
+			)
+		}
+
+		return nil
+	}
+
+	t.states[keepID.String()] = state
+	t.keeps[keepID.String()] = keepID
+
+	return nil
+}
+
+func copyRequests(requests map[string]uint64) map[string]uint64 {
+	result := make(map[string]uint64, len(requests))
+	for digest, blockNumber := range requests {
+		result[digest] = blockNumber
+	}
+
+	return result
+}
+
+func (t *Tracker) load() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	dataChannel, errorsChannel := t.handle.ReadAll()
+
+	// Channels are not buffered and we do not know in what order
+	// information is written to them so they are read at the same time.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for descriptor := range dataChannel {
+			content, err := descriptor.Content()
+			if err != nil {
+				logger.Errorf(
+					"failed to decode keep state from directory [%v]: [%v]",
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			keepID, err := t.unmarshalID(descriptor.Directory())
+			if err != nil {
+				logger.Errorf(
+					"directory name [%v] could not be converted to a keep ID: [%v]",
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			state := &KeepState{}
+			if err := json.Unmarshal(content, state); err != nil {
+				logger.Errorf(
+					"failed to unmarshal keep state from directory [%v]: [%v]",
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			t.states[keepID.String()] = state
+			t.keeps[keepID.String()] = keepID
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for err := range errorsChannel {
+			logger.Errorf("could not load keep state from storage: [%v]", err)
+		}
+	}()
+
+	wg.Wait()
+
+	logger.Infof("loaded states of [%d] keeps from the storage", len(t.states))
+}
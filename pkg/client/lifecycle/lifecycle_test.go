@@ -0,0 +1,406 @@
+package lifecycle
+
+import (
+	"context"
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/chain/local"
+)
+
+var unmarshalID = local.Connect(context.Background()).UnmarshalID
+
+func newTestTracker(t *testing.T) (*Tracker, persistence.Handle) {
+	handle, err := persistence.NewDiskHandle(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create disk handle: [%v]", err)
+	}
+
+	return NewTracker(handle, unmarshalID), handle
+}
+
+func newTestKeepID(t *testing.T, id string) chain.ID {
+	keepID, err := unmarshalID(id)
+	if err != nil {
+		t.Fatalf("failed to unmarshal keep ID: [%v]", err)
+	}
+
+	return keepID
+}
+
+func TestTransition(t *testing.T) {
+	var tests = map[string]struct {
+		transitions   []State
+		expectedState State
+		expectedError bool
+	}{
+		"key generation": {
+			transitions:   []State{AwaitingKeyGeneration, KeySubmitted, Active},
+			expectedState: Active,
+		},
+		"liquidation recovery retried": {
+			transitions: []State{
+				AwaitingKeyGeneration,
+				KeySubmitted,
+				Active,
+				Terminated,
+				Recovering,
+				Terminated,
+				Recovering,
+			},
+			expectedState: Recovering,
+		},
+		"closed keep activated": {
+			transitions: []State{
+				AwaitingKeyGeneration,
+				KeySubmitted,
+				Active,
+				Closing,
+				Active,
+			},
+			expectedState: Closing,
+			expectedError: true,
+		},
+		"transition to the current state": {
+			transitions:   []State{AwaitingKeyGeneration, AwaitingKeyGeneration},
+			expectedState: AwaitingKeyGeneration,
+		},
+		"no recorded state": {
+			transitions:   []State{Active},
+			expectedError: true,
+		},
+		"key generation skipped": {
+			transitions:   []State{AwaitingKeyGeneration, Active},
+			expectedState: AwaitingKeyGeneration,
+			expectedError: true,
+		},
+		"recovery of active keep": {
+			transitions: []State{
+				AwaitingKeyGeneration,
+				KeySubmitted,
+				Active,
+				Recovering,
+			},
+			expectedState: Active,
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			tracker, _ := newTestTracker(t)
+			keepID := newTestKeepID(t, "0x770a9E2F2Aa1eC2d3Ca916Fc3e6A55058A898632")
+
+			var err error
+			for _, state := range test.transitions {
+				if err = tracker.Transition(keepID, state); err != nil {
+					break
+				}
+			}
+
+			if test.expectedError != (err != nil) {
+				t.Errorf(
+					"unexpected transition result\nexpected error: [%v]\nactual error:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+
+			state, _ := tracker.State(keepID)
+			if state.State != test.expectedState {
+				t.Errorf(
+					"unexpected state\nexpected: [%v]\nactual:   [%v]",
+					test.expectedState,
+					state.State,
+				)
+			}
+		})
+	}
+}
+
+func TestSigning(t *testing.T) {
+	tracker, _ := newTestTracker(t)
+	keepID := newTestKeepID(t, "0x770a9E2F2Aa1eC2d3Ca916Fc3e6A55058A898632")
+
+	if err := tracker.Adopt(keepID, Active); err != nil {
+		t.Fatal(err)
+	}
+
+	digest1 := [32]byte{1}
+	digest2 := [32]byte{2}
+
+	if err := tracker.Transition(keepID, Signing); err == nil {
+		t.Errorf("expected error on signing without a digest")
+	}
+
+	if err := tracker.StartSigning(keepID, digest1); err != nil {
+		t.Fatal(err)
+	}
+
+	// Completing signing of a digest not being signed should have no effect.
+	if err := tracker.CompleteSigning(keepID, digest2); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tracker.StartSigning(keepID, digest2); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.StartSigning(keepID, digest1); err != nil {
+		t.Fatal(err)
+	}
+
+	assertSigning := func(expectedDigests ...[32]byte) {
+		expectedDigestsHex := []string{}
+		for _, digest := range expectedDigests {
+			expectedDigestsHex = append(
+				expectedDigestsHex,
+				hex.EncodeToString(digest[:]),
+			)
+		}
+
+		state, _ := tracker.State(keepID)
+		if state.State != Signing ||
+			!reflect.DeepEqual(expectedDigestsHex, state.SigningDigests) {
+			t.Errorf(
+				"unexpected state\nexpected: [%v %v]\nactual:   [%v %v]",
+				Signing,
+				expectedDigestsHex,
+				state.State,
+				state.SigningDigests,
+			)
+		}
+	}
+
+	assertSigning(digest1, digest2)
+
+	// The keep should remain in the signing state until all signatures are
+	// completed, even if its public key is confirmed to be published.
+	if err := tracker.Transition(keepID, Active); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.CompleteSigning(keepID, digest1); err != nil {
+		t.Fatal(err)
+	}
+
+	assertSigning(digest2)
+
+	if err := tracker.CompleteSigning(keepID, digest2); err != nil {
+		t.Fatal(err)
+	}
+	if state, _ := tracker.State(keepID); state.State != Active {
+		t.Errorf(
+			"unexpected state\nexpected: [%v]\nactual:   [%v]",
+			Active,
+			state.State,
+		)
+	}
+}
+
+func TestAdopt(t *testing.T) {
+	tracker, _ := newTestTracker(t)
+	keepID := newTestKeepID(t, "0x770a9E2F2Aa1eC2d3Ca916Fc3e6A55058A898632")
+
+	if err := tracker.Transition(keepID, AwaitingKeyGeneration); err != nil {
+		t.Fatal(err)
+	}
+
+	// The keep should not be adopted as its state is already recorded.
+	if err := tracker.Adopt(keepID, Active); err != nil {
+		t.Fatal(err)
+	}
+
+	if state, _ := tracker.State(keepID); state.State != AwaitingKeyGeneration {
+		t.Errorf(
+			"unexpected state\nexpected: [%v]\nactual:   [%v]",
+			AwaitingKeyGeneration,
+			state.State,
+		)
+	}
+}
+
+func TestProcessedBlock(t *testing.T) {
+	tracker, handle := newTestTracker(t)
+	keepID := newTestKeepID(t, "0x770a9E2F2Aa1eC2d3Ca916Fc3e6A55058A898632")
+
+	if err := tracker.RecordProcessedBlock(keepID, 10); err == nil {
+		t.Errorf("expected error on recording block for unknown keep")
+	}
+
+	if err := tracker.Adopt(keepID, Active); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tracker.RecordProcessedBlock(keepID, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	// An earlier block should not be recorded.
+	if err := tracker.RecordProcessedBlock(keepID, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	// The processed block should be retained across state transitions.
+	if err := tracker.StartSigning(keepID, [32]byte{1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.CompleteSigning(keepID, [32]byte{1}); err != nil {
+		t.Fatal(err)
+	}
+
+	loadedTracker := NewTracker(handle, unmarshalID)
+
+	for _, tracker := range []*Tracker{tracker, loadedTracker} {
+		state, _ := tracker.State(keepID)
+		if state.State != Active || state.ProcessedBlock != 10 {
+			t.Errorf(
+				"unexpected state\nexpected: [%v at block %v]\nactual:   [%v at block %v]",
+				Active,
+				10,
+				state.State,
+				state.ProcessedBlock,
+			)
+		}
+	}
+}
+
+func TestSignatureRequests(t *testing.T) {
+	tracker, handle := newTestTracker(t)
+	keepID := newTestKeepID(t, "0x770a9E2F2Aa1eC2d3Ca916Fc3e6A55058A898632")
+
+	digest1 := [32]byte{1}
+	digest2 := [32]byte{2}
+
+	if err := tracker.RecordSignatureRequest(keepID, digest1, 10); err == nil {
+		t.Errorf("expected error on recording request for unknown keep")
+	}
+
+	if err := tracker.Adopt(keepID, Active); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tracker.RecordProcessedBlock(keepID, 30); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.RecordSignatureRequest(keepID, digest1, 10); err != nil {
+		t.Fatal(err)
+	}
+	// A later block of the same request should not be recorded.
+	if err := tracker.RecordSignatureRequest(keepID, digest1, 15); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.RecordSignatureRequest(keepID, digest2, 20); err != nil {
+		t.Fatal(err)
+	}
+
+	// Unfinished requests should be retained across state transitions.
+	if err := tracker.StartSigning(keepID, digest1); err != nil {
+		t.Fatal(err)
+	}
+
+	state, _ := tracker.State(keepID)
+	pendingDigests, err := state.PendingDigests()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedPendingDigests := [][32]byte{digest1, digest2}
+	if !reflect.DeepEqual(expectedPendingDigests, pendingDigests) {
+		t.Errorf(
+			"unexpected pending digests\nexpected: [%x]\nactual:   [%x]",
+			expectedPendingDigests,
+			pendingDigests,
+		)
+	}
+
+	if err := tracker.CompleteSigning(keepID, digest1); err != nil {
+		t.Fatal(err)
+	}
+
+	assertResumeBlock := func(tracker *Tracker, expectedBlock uint64) {
+		state, _ := tracker.State(keepID)
+		resumeBlock, ok := state.SignatureRequestsResumeBlock()
+		if !ok || resumeBlock != expectedBlock {
+			t.Errorf(
+				"unexpected resume block\nexpected: [%v]\nactual:   [%v]",
+				expectedBlock,
+				resumeBlock,
+			)
+		}
+	}
+
+	assertResumeBlock(tracker, 10)
+	assertResumeBlock(NewTracker(handle, unmarshalID), 10)
+
+	if err := tracker.CompleteSignatureRequest(keepID, digest1); err != nil {
+		t.Fatal(err)
+	}
+	assertResumeBlock(tracker, 20)
+
+	if err := tracker.CompleteSignatureRequest(keepID, digest2); err != nil {
+		t.Fatal(err)
+	}
+	assertResumeBlock(tracker, 31)
+	assertResumeBlock(NewTracker(handle, unmarshalID), 31)
+}
+
+func TestPersistence(t *testing.T) {
+	tracker, handle := newTestTracker(t)
+
+	keepID1 := newTestKeepID(t, "0x770a9E2F2Aa1eC2d3Ca916Fc3e6A55058A898632")
+	keepID2 := newTestKeepID(t, "0x4e09cadc7037afa36603138d1c0b76fe2aa5039c")
+	keepID3 := newTestKeepID(t, "0x0000000000000000000000000000000000000003")
+
+	digest := [32]byte{1}
+
+	if err := tracker.Transition(keepID1, AwaitingKeyGeneration); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.Adopt(keepID2, Active); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.StartSigning(keepID2, digest); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.Adopt(keepID3, Active); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.Transition(keepID3, Closing); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.Transition(keepID3, Archived); err != nil {
+		t.Fatal(err)
+	}
+
+	loadedTracker := NewTracker(handle, unmarshalID)
+
+	state1, ok := loadedTracker.State(keepID1)
+	if !ok || state1.State != AwaitingKeyGeneration {
+		t.Errorf("unexpected state of the first keep: [%+v]", state1)
+	}
+
+	state2, ok := loadedTracker.State(keepID2)
+	if !ok ||
+		state2.State != Signing ||
+		!reflect.DeepEqual(
+			[]string{hex.EncodeToString(digest[:])},
+			state2.SigningDigests,
+		) {
+		t.Errorf("unexpected state of the second keep: [%+v]", state2)
+	}
+
+	if state3, ok := loadedTracker.State(keepID3); ok {
+		t.Errorf("archived keep should not be loaded: [%+v]", state3)
+	}
+
+	expectedKeepIDs := []chain.ID{keepID2}
+	keepIDs := loadedTracker.KeepsInState(Active, Signing)
+	if !reflect.DeepEqual(expectedKeepIDs, keepIDs) {
+		t.Errorf(
+			"unexpected keeps\nexpected: [%v]\nactual:   [%v]",
+			expectedKeepIDs,
+			keepIDs,
+		)
+	}
+}
@@ -48,13 +48,25 @@ func handleLiquidationRecovery(
 		)
 	}
 
+	honestThreshold, err := keep.GetHonestThreshold()
+	if err != nil {
+		return fmt.Errorf(
+			"failed to retrieve honest threshold from keep [%s]: [%w]",
+			keep.ID(),
+			err,
+		)
+	}
+
 	memberID := tss.MemberIDFromPublicKey(operatorPublicKey)
 
+	// The recovery transaction can be signed by any `honestThreshold` members
+	// so there is no need to wait for all of them.
 	memberIDs, err := tssNode.AnnounceSignerPresence(
 		ctx,
 		operatorPublicKey,
 		keep.ID(),
 		members,
+		int(honestThreshold),
 	)
 	if err != nil {
 		return fmt.Errorf(
@@ -121,6 +133,7 @@ func handleLiquidationRecovery(
 		networkProvider,
 		hostChain.Signing().PublicKeyToAddress,
 		chainParams,
+		tssNode.TSSConfig().GetRecoveryReadyTimeout(),
 	)
 	if err != nil {
 		return fmt.Errorf(
@@ -154,6 +167,7 @@ func handleLiquidationRecovery(
 		chainParams,
 		btcAddresses,
 		maxFeePerVByte,
+		tssNode.TSSConfig().GetSigningProtocolTimeout(),
 	)
 	if err != nil {
 		return fmt.Errorf(
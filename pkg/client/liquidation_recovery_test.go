@@ -28,6 +28,7 @@ import (
 	"github.com/keep-network/keep-ecdsa/pkg/extensions/tbtc/recovery"
 	"github.com/keep-network/keep-ecdsa/pkg/node"
 	"github.com/keep-network/keep-ecdsa/pkg/registry"
+	"github.com/keep-network/keep-ecdsa/pkg/utils/backoff"
 )
 
 func TestHandleLiquidationRecovery(t *testing.T) {
@@ -257,7 +258,16 @@ func TestHandleLiquidationRecovery(t *testing.T) {
 
 						networkProvider := networkProviders[memberID.String()]
 
-						tssNode := node.NewNode(localChain, networkProvider, &tss.Config{})
+						tssNode := node.NewNode(
+							localChain,
+							chain.NewEventPipeline(
+								localChain.BlockCounter(),
+								localChain.BlockHash,
+							),
+							networkProvider,
+							&tss.Config{},
+							backoff.Constant(time.Second),
+						)
 
 						signer, ok := signers[memberID.String()]
 						if !ok {
@@ -610,6 +620,7 @@ func initializeSigners(
 					networkProvider,
 					pubKeyToAddressFn,
 					params.NewBox(&testData[index].LocalPreParams),
+					8*time.Minute,
 				)
 				if err != nil {
 					errChan <- err
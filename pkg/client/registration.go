@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/keep-network/keep-common/pkg/chain/ethlike"
-
 	corechain "github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
 )
@@ -34,6 +32,7 @@ const eligibilityRetryDelay = 20 * time.Minute
 func checkStatusAndRegisterForApplication(
 	ctx context.Context,
 	blockCounter corechain.BlockCounter,
+	eventPipeline *chain.EventPipeline,
 	application chain.BondedECDSAKeepApplicationHandle,
 ) {
 RegistrationLoop:
@@ -62,7 +61,12 @@ RegistrationLoop:
 
 			// once the registration is confirmed or if the client is already
 			// registered, we can start to monitor the status
-			if err := monitorSignerPoolStatus(ctx, blockCounter, application); err != nil {
+			if err := monitorSignerPoolStatus(
+				ctx,
+				blockCounter,
+				eventPipeline,
+				application,
+			); err != nil {
 				logger.Errorf(
 					"failed on signer pool status monitoring; please inspect "+
 						"signer's unbonded value and stake: [%v]",
@@ -230,6 +234,7 @@ func waitUntilRegistered(
 func monitorSignerPoolStatus(
 	ctx context.Context,
 	blockCounter corechain.BlockCounter,
+	eventPipeline *chain.EventPipeline,
 	application chain.BondedECDSAKeepApplicationHandle,
 ) error {
 	logger.Infof(
@@ -288,13 +293,10 @@ func monitorSignerPoolStatus(
 					)
 				}
 
-				isRegistered, err := ethlike.WaitForBlockConfirmations(
-					blockCounter,
-					statusCheckBlock,
-					blockConfirmations,
-					func() (bool, error) {
-						return application.IsRegisteredForApplication()
-					},
+				isRegistered, err := eventPipeline.ConfirmState(
+					ctx,
+					chain.DefaultConfirmationDepth,
+					application.IsRegisteredForApplication,
 				)
 				if err != nil {
 					return fmt.Errorf(
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/client/lifecycle"
+)
+
+// signatureRequestsStartBlock determines the first block of the signature
+// requests lookup for the keep from its recorded state. The lookup starts at
+// the block of the earliest unfinished signature request, so that requests
+// whose signing failed or was interrupted are not skipped, or right after
+// the last processed block if all requests are finished. If none of them is
+// recorded, which is the case only for keeps whose signature requests could
+// not be looked up on adoption, the lookup covers the whole keep history.
+func signatureRequestsStartBlock(
+	keep chain.BondedECDSAKeepHandle,
+	keepsLifecycle *lifecycle.Tracker,
+) uint64 {
+	if state, ok := keepsLifecycle.State(keep.ID()); ok {
+		if resumeBlock, ok := state.SignatureRequestsResumeBlock(); ok {
+			return resumeBlock
+		}
+	}
+
+	return 0
+}
+
+// pastUnsignedRequests returns signature requests the keep received since
+// the given start block for digests with no signature submitted, in the order
+// they were requested. Only the first request for each digest is returned.
+func pastUnsignedRequests(
+	keep chain.BondedECDSAKeepHandle,
+	startBlock uint64,
+) ([]*chain.SignatureRequestedEvent, error) {
+	requestedEvents, err := keep.PastSignatureRequestedEvents(startBlock)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not get past signature requested events: [%v]",
+			err,
+		)
+	}
+
+	submittedEvents, err := keep.PastSignatureSubmittedEvents(startBlock)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not get past signature submitted events: [%v]",
+			err,
+		)
+	}
+
+	submittedDigests := make([][32]byte, 0, len(submittedEvents))
+	for _, event := range submittedEvents {
+		submittedDigests = append(submittedDigests, event.Digest)
+	}
+
+	unsignedRequests := make([]*chain.SignatureRequestedEvent, 0)
+	unsignedDigests := make([][32]byte, 0)
+	for _, event := range requestedEvents {
+		if containsDigest(submittedDigests, event.Digest) ||
+			containsDigest(unsignedDigests, event.Digest) {
+			continue
+		}
+
+		logger.Infof(
+			"found signature request from keep [%s] for digest [%+x] "+
+				"at block [%d] with no signature submitted",
+			keep.ID(),
+			event.Digest,
+			event.BlockNumber,
+		)
+
+		unsignedRequests = append(unsignedRequests, event)
+		unsignedDigests = append(unsignedDigests, event.Digest)
+	}
+
+	return unsignedRequests, nil
+}
+
+func containsDigest(digests [][32]byte, digest [32]byte) bool {
+	for _, d := range digests {
+		if d == digest {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,348 @@
+package client
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	configtime "github.com/keep-network/keep-ecdsa/config/time"
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	chainLocal "github.com/keep-network/keep-ecdsa/pkg/chain/local"
+	"github.com/keep-network/keep-ecdsa/pkg/client/event"
+	"github.com/keep-network/keep-ecdsa/pkg/client/lifecycle"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss"
+	"github.com/keep-network/keep-ecdsa/pkg/node"
+	"github.com/keep-network/keep-ecdsa/pkg/utils/backoff"
+)
+
+func TestPastUnsignedRequests(t *testing.T) {
+	digest1 := [32]byte{1}
+	digest2 := [32]byte{2}
+
+	var tests = map[string]struct {
+		requestedDigests        [][32]byte
+		signedDigests           [][32]byte
+		expectedUnsignedDigests [][32]byte
+	}{
+		"no signature requested": {
+			expectedUnsignedDigests: [][32]byte{},
+		},
+		"signature submitted": {
+			requestedDigests:        [][32]byte{digest1},
+			signedDigests:           [][32]byte{digest1},
+			expectedUnsignedDigests: [][32]byte{},
+		},
+		"signature not submitted": {
+			requestedDigests:        [][32]byte{digest1},
+			expectedUnsignedDigests: [][32]byte{digest1},
+		},
+		"signature submitted only for the first digest": {
+			requestedDigests:        [][32]byte{digest1, digest2},
+			signedDigests:           [][32]byte{digest1},
+			expectedUnsignedDigests: [][32]byte{digest2},
+		},
+		"signature requested twice for the same digest": {
+			requestedDigests:        [][32]byte{digest1, digest2, digest1},
+			expectedUnsignedDigests: [][32]byte{digest1, digest2},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx, cancelCtx := context.WithCancel(context.Background())
+			defer cancelCtx()
+
+			localChain := chainLocal.Connect(ctx)
+
+			keepAddress := common.HexToAddress("0x4e09cadc7037afa36603138d1c0b76fe2aa5039c")
+			keep := localChain.OpenKeep(keepAddress, common.Address{}, []common.Address{})
+
+			if err := keep.SubmitKeepPublicKey([64]byte{1}); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, digest := range test.requestedDigests {
+				if err := localChain.RequestSignature(keepAddress, digest); err != nil {
+					t.Fatal(err)
+				}
+
+				for _, signedDigest := range test.signedDigests {
+					if signedDigest != digest {
+						continue
+					}
+
+					if err := keep.SubmitSignature(&ecdsa.Signature{
+						R: big.NewInt(1),
+						S: big.NewInt(2),
+					}); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			unsignedRequests, err := pastUnsignedRequests(keep, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			unsignedDigests := make([][32]byte, 0, len(unsignedRequests))
+			for _, request := range unsignedRequests {
+				unsignedDigests = append(unsignedDigests, request.Digest)
+			}
+
+			if !reflect.DeepEqual(test.expectedUnsignedDigests, unsignedDigests) {
+				t.Errorf(
+					"unexpected unsigned digests\nexpected: [%x]\nactual:   [%x]",
+					test.expectedUnsignedDigests,
+					unsignedDigests,
+				)
+			}
+		})
+	}
+}
+
+func TestFailedSignatureRequestBackfilledAfterRestart(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := chainLocal.Connect(ctx)
+
+	keepAddress := common.HexToAddress("0x4e09cadc7037afa36603138d1c0b76fe2aa5039c")
+	keep := localChain.OpenKeep(keepAddress, common.Address{}, []common.Address{})
+
+	if err := keep.SubmitKeepPublicKey([64]byte{1}); err != nil {
+		t.Fatal(err)
+	}
+
+	lifecycleHandle, err := persistence.NewDiskHandle(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keepsLifecycle := lifecycle.NewTracker(lifecycleHandle, localChain.UnmarshalID)
+	if err := keepsLifecycle.Adopt(keep.ID(), lifecycle.Active); err != nil {
+		t.Fatal(err)
+	}
+
+	digest := [32]byte{1}
+
+	if err := localChain.RequestSignature(keepAddress, digest); err != nil {
+		t.Fatal(err)
+	}
+
+	requestBlock, err := keep.SignatureRequestedBlock(digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recordSignatureRequest(keepsLifecycle, keep.ID(), digest, requestBlock)
+
+	// The signing fails as there is no signer registered for the keep.
+	_, keepsRegistry := newTestKeepsRegistry(localChain)
+
+	signRequestedDigest(
+		&Config{
+			SigningTimeout: configtime.Duration{Duration: 100 * time.Millisecond},
+		},
+		node.NewNode(
+			localChain,
+			nil,
+			nil,
+			&tss.Config{},
+			backoff.Constant(10*time.Millisecond),
+		),
+		keep,
+		digest,
+		keepsRegistry,
+		keepsLifecycle,
+		event.NewDeduplicator(keepsRegistry, localChain),
+	)
+
+	// Signature requests are processed up to a block later than the block
+	// of the failed request.
+	blockCounter := localChain.BlockCounter()
+	if err := blockCounter.WaitForBlockHeight(requestBlock + 1); err != nil {
+		t.Fatal(err)
+	}
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recordProcessedBlock(keepsLifecycle, keep.ID(), currentBlock)
+
+	// The client restarts and loads keep states from the storage.
+	restartedLifecycle := lifecycle.NewTracker(
+		lifecycleHandle,
+		localChain.UnmarshalID,
+	)
+
+	unsignedRequests, err := pastUnsignedRequests(
+		keep,
+		signatureRequestsStartBlock(keep, restartedLifecycle),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(unsignedRequests) != 1 || unsignedRequests[0].Digest != digest {
+		t.Errorf(
+			"unexpected backfilled requests\nexpected: [%x]\nactual:   [%v]",
+			digest,
+			unsignedRequests,
+		)
+	}
+}
+
+func TestPendingDigestsResumedFromKeepState(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := chainLocal.Connect(ctx)
+
+	keepAddress := common.HexToAddress("0x4e09cadc7037afa36603138d1c0b76fe2aa5039c")
+	keep := localChain.OpenKeep(keepAddress, common.Address{}, []common.Address{})
+
+	if err := keep.SubmitKeepPublicKey([64]byte{1}); err != nil {
+		t.Fatal(err)
+	}
+
+	lifecycleHandle, err := persistence.NewDiskHandle(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The client stops while calculating signatures for two digests.
+	keepsLifecycle := lifecycle.NewTracker(lifecycleHandle, localChain.UnmarshalID)
+	if err := keepsLifecycle.Adopt(keep.ID(), lifecycle.Active); err != nil {
+		t.Fatal(err)
+	}
+	for _, digest := range [][32]byte{{1}, {2}} {
+		if err := keepsLifecycle.StartSigning(keep.ID(), digest); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The client restarts and loads keep states from the storage. None of
+	// the digests is awaited by the keep anymore.
+	restartedLifecycle := lifecycle.NewTracker(
+		lifecycleHandle,
+		localChain.UnmarshalID,
+	)
+
+	_, keepsRegistry := newTestKeepsRegistry(localChain)
+
+	checkAwaitingSignature(
+		localChain,
+		chain.NewEventPipeline(localChain.BlockCounter(), localChain.BlockHash),
+		&Config{},
+		nil,
+		keep,
+		keepsRegistry,
+		restartedLifecycle,
+		event.NewDeduplicator(keepsRegistry, localChain),
+	)
+
+	state, ok := restartedLifecycle.State(keep.ID())
+	if !ok || state.State != lifecycle.Active || len(state.SigningDigests) != 0 {
+		t.Errorf(
+			"unexpected keep state\nexpected: [%v]\nactual:   [%+v]",
+			lifecycle.Active,
+			state,
+		)
+	}
+}
+
+func TestAdoptedKeepSignatureRequestsRecorded(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := chainLocal.Connect(ctx)
+
+	keepAddress := common.HexToAddress("0x4e09cadc7037afa36603138d1c0b76fe2aa5039c")
+	keep := localChain.OpenKeep(keepAddress, common.Address{}, []common.Address{})
+
+	if err := keep.SubmitKeepPublicKey([64]byte{1}); err != nil {
+		t.Fatal(err)
+	}
+
+	signedDigest := [32]byte{1}
+	unsignedDigest := [32]byte{2}
+
+	if err := localChain.RequestSignature(keepAddress, signedDigest); err != nil {
+		t.Fatal(err)
+	}
+	if err := keep.SubmitSignature(&ecdsa.Signature{
+		R: big.NewInt(1),
+		S: big.NewInt(2),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := localChain.RequestSignature(keepAddress, unsignedDigest); err != nil {
+		t.Fatal(err)
+	}
+
+	requestBlock, err := keep.SignatureRequestedBlock(unsignedDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lifecycleHandle, err := persistence.NewDiskHandle(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keepsLifecycle := lifecycle.NewTracker(lifecycleHandle, localChain.UnmarshalID)
+
+	// The keep is adopted in a block later than the block of the request.
+	if err := localChain.BlockCounter().WaitForBlockHeight(
+		requestBlock + 1,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := adoptKeep(
+		keep,
+		localChain.BlockCounter(),
+		keepsLifecycle,
+	); !ok {
+		t.Fatal("keep has not been adopted")
+	}
+
+	// The client restarts and loads keep states from the storage. Signature
+	// requests are looked up from the unsigned request instead of the whole
+	// keep history.
+	restartedLifecycle := lifecycle.NewTracker(
+		lifecycleHandle,
+		localChain.UnmarshalID,
+	)
+
+	startBlock := signatureRequestsStartBlock(keep, restartedLifecycle)
+	if startBlock != requestBlock {
+		t.Errorf(
+			"unexpected start block\nexpected: [%v]\nactual:   [%v]",
+			requestBlock,
+			startBlock,
+		)
+	}
+
+	// Once the request is completed, signature requests are looked up after
+	// the block processed on adoption.
+	completeSignatureRequest(restartedLifecycle, keep.ID(), unsignedDigest)
+
+	startBlock = signatureRequestsStartBlock(keep, restartedLifecycle)
+	if startBlock <= requestBlock {
+		t.Errorf(
+			"unexpected start block\nexpected: [> %v]\nactual:   [%v]",
+			requestBlock,
+			startBlock,
+		)
+	}
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"sort"
+	"time"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/client/lifecycle"
+	"github.com/keep-network/keep-ecdsa/pkg/node"
+)
+
+// keepStatusCachePeriod is the time the cache maintains information about
+// active and inactive keeps served with the status of keeps. We use the cache
+// to minimize calls to the chain when the status is requested frequently.
+const keepStatusCachePeriod = 1 * time.Minute
+
+// KeepStatus describes a keep the client holds a signer for.
+type KeepStatus struct {
+	KeepID          string `json:"keepID"`
+	SignatureScheme string `json:"signatureScheme"`
+	// Active is not set if the on-chain status of the keep could not be
+	// checked. The reason is described by StatusError in such a case.
+	Active      *bool  `json:"active,omitempty"`
+	StatusError string `json:"statusError,omitempty"`
+	// State is the state of the keep tracked by the client as it observes
+	// chain events. State and StateUpdatedAt are not set if no state has been
+	// recorded for the keep.
+	State             lifecycle.State `json:"state,omitempty"`
+	StateUpdatedAt    *time.Time      `json:"stateUpdatedAt,omitempty"`
+	PendingSignatures []string        `json:"pendingSignatures"`
+}
+
+// Keeps returns the status of all keeps registered in the client, along with
+// their states recorded by the keeps lifecycle tracker. The on-chain status of
+// each keep is cached for a short period, so the chain is queried only if
+// the status is not cached. Keeps are ordered by their IDs.
+func (h *Handle) Keeps() []*KeepStatus {
+	pendingSignatures := h.eventDeduplicator.PendingSignatures()
+
+	h.activeKeepsCache.Sweep()
+	h.inactiveKeepsCache.Sweep()
+
+	keepIDs := h.keepsRegistry.GetKeepsIDs()
+
+	statuses := make([]*KeepStatus, 0, len(keepIDs))
+	for _, keepID := range keepIDs {
+		status := &KeepStatus{
+			KeepID:            keepID.String(),
+			SignatureScheme:   "ECDSA",
+			PendingSignatures: pendingSignatures[keepID.String()],
+		}
+
+		if h.keepsRegistry.HasEdDSASigner(keepID) {
+			status.SignatureScheme = "EdDSA"
+		}
+
+		if status.PendingSignatures == nil {
+			status.PendingSignatures = []string{}
+		}
+
+		isActive, err := h.isKeepActive(keepID)
+		if err != nil {
+			status.StatusError = err.Error()
+		} else {
+			status.Active = &isActive
+		}
+
+		if state, ok := h.keepsLifecycle.State(keepID); ok {
+			status.State = state.State
+			status.StateUpdatedAt = &state.UpdatedAt
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].KeepID < statuses[j].KeepID
+	})
+
+	return statuses
+}
+
+// isKeepActive checks the on-chain status of the keep unless it is cached.
+// Errors are not cached, so the status is checked again with the next call.
+func (h *Handle) isKeepActive(keepID chain.ID) (bool, error) {
+	if h.activeKeepsCache.Has(keepID.String()) {
+		return true, nil
+	}
+
+	if h.inactiveKeepsCache.Has(keepID.String()) {
+		return false, nil
+	}
+
+	keep, err := h.hostChain.GetKeepWithID(keepID)
+	if err != nil {
+		return false, err
+	}
+
+	isActive, err := keep.IsActive()
+	if err != nil {
+		return false, err
+	}
+
+	if isActive {
+		h.activeKeepsCache.Add(keepID.String())
+	} else {
+		h.inactiveKeepsCache.Add(keepID.String())
+	}
+
+	return isActive, nil
+}
+
+// ProtocolExecutions returns key generation and signing protocol executions
+// currently in progress.
+func (h *Handle) ProtocolExecutions() []*node.ProtocolExecution {
+	return h.tssNode.ProtocolExecutions()
+}
+
+// ProtocolsInProgress returns the number of protocols in progress the client
+// waits for when it shuts down.
+func (h *Handle) ProtocolsInProgress() int {
+	return h.tssNode.ProtocolsInProgress()
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-common/pkg/cache"
+	chainLocal "github.com/keep-network/keep-ecdsa/pkg/chain/local"
+)
+
+func TestIsKeepActiveCached(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := chainLocal.Connect(ctx)
+
+	keepAddress := common.HexToAddress("0x4e09cadc7037afa36603138d1c0b76fe2aa5039c")
+	keep := localChain.OpenKeep(keepAddress, common.Address{}, []common.Address{})
+
+	handle := &Handle{
+		hostChain:          localChain,
+		activeKeepsCache:   cache.NewTimeCache(keepStatusCachePeriod),
+		inactiveKeepsCache: cache.NewTimeCache(keepStatusCachePeriod),
+	}
+
+	assertActive := func(expectedActive bool) {
+		isActive, err := handle.isKeepActive(keep.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if isActive != expectedActive {
+			t.Errorf(
+				"unexpected keep status\nexpected: [%v]\nactual:   [%v]",
+				expectedActive,
+				isActive,
+			)
+		}
+	}
+
+	assertActive(true)
+
+	if err := localChain.CloseKeep(keepAddress); err != nil {
+		t.Fatal(err)
+	}
+
+	// The status is served from the cache until it expires.
+	assertActive(true)
+
+	handle.activeKeepsCache = cache.NewTimeCache(keepStatusCachePeriod)
+
+	assertActive(false)
+}
@@ -0,0 +1,106 @@
+package ecdsa
+
+import (
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+const (
+	// SchnorrPublicKeySize is the size of an x-only public key in bytes.
+	SchnorrPublicKeySize = 32
+	// SchnorrSignatureSize is the size of a Schnorr signature in bytes.
+	SchnorrSignatureSize = 64
+)
+
+// TaggedHash calculates a tagged hash of the given data as defined in
+// [BIP-340]: sha256(sha256(tag) || sha256(tag) || data).
+//
+// Schnorr signatures defined in BIP-340 are calculated over the secp256k1
+// curve with the same key material as ECDSA signatures.
+//
+// [BIP-340]: https://github.com/bitcoin/bips/blob/master/bip-0340.mediawiki
+func TaggedHash(tag string, data ...[]byte) [32]byte {
+	tagHash := sha256.Sum256([]byte(tag))
+
+	hash := sha256.New()
+	hash.Write(tagHash[:])
+	hash.Write(tagHash[:])
+	for _, d := range data {
+		hash.Write(d)
+	}
+
+	var result [32]byte
+	copy(result[:], hash.Sum(nil))
+
+	return result
+}
+
+// SchnorrChallenge calculates the challenge of a Schnorr signature as defined
+// in [BIP-340] for the given `x` coordinate of the nonce point, x-only public
+// key and message. The challenge is reduced modulo the curve order.
+func SchnorrChallenge(r []byte, publicKey []byte, message []byte) *big.Int {
+	hash := TaggedHash("BIP0340/challenge", r, publicKey, message)
+
+	return new(big.Int).Mod(new(big.Int).SetBytes(hash[:]), btcec.S256().N)
+}
+
+// VerifySchnorrSignature verifies a 64-byte Schnorr signature over the message
+// with the 32-byte x-only public key according to [BIP-340].
+func VerifySchnorrSignature(
+	publicKey []byte,
+	message []byte,
+	signature []byte,
+) bool {
+	curve := btcec.S256()
+
+	if len(publicKey) != SchnorrPublicKeySize ||
+		len(signature) != SchnorrSignatureSize {
+		return false
+	}
+
+	point, err := btcec.ParsePubKey(
+		append([]byte{0x02}, publicKey...),
+		curve,
+	)
+	if err != nil {
+		return false
+	}
+
+	r := new(big.Int).SetBytes(signature[:32])
+	if r.Cmp(curve.P) >= 0 {
+		return false
+	}
+
+	s := new(big.Int).SetBytes(signature[32:])
+	if s.Cmp(curve.N) >= 0 {
+		return false
+	}
+
+	e := SchnorrChallenge(signature[:32], publicKey, message)
+
+	// R = s⋅G - e⋅P
+	sGx, sGy := curve.ScalarBaseMult(s.Bytes())
+	minusE := new(big.Int).Sub(curve.N, e)
+	ePx, ePy := curve.ScalarMult(point.X, point.Y, minusE.Bytes())
+	rx, ry := curve.Add(sGx, sGy, ePx, ePy)
+
+	if rx.Sign() == 0 && ry.Sign() == 0 {
+		return false
+	}
+
+	return ry.Bit(0) == 0 && rx.Cmp(r) == 0
+}
+
+// TaprootTweak calculates the tweak of the x-only internal public key for
+// a taproot output which can be spent only with the key path, i.e. the output
+// commits to no script tree, as recommended in [BIP-341]. The output key is
+// the internal key tweaked with the result.
+//
+// [BIP-341]: https://github.com/bitcoin/bips/blob/master/bip-0341.mediawiki
+func TaprootTweak(internalPublicKey []byte) []byte {
+	hash := TaggedHash("TapTweak", internalPublicKey)
+
+	return hash[:]
+}
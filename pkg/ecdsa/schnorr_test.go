@@ -0,0 +1,84 @@
+package ecdsa
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// Test vectors from BIP-340:
+// https://github.com/bitcoin/bips/blob/master/bip-0340/test-vectors.csv
+func TestVerifySchnorrSignature(t *testing.T) {
+	var tests = map[string]struct {
+		publicKey     string
+		message       string
+		signature     string
+		expectedValid bool
+	}{
+		"vector 0": {
+			publicKey:     "F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+			message:       "0000000000000000000000000000000000000000000000000000000000000000",
+			signature:     "E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+			expectedValid: true,
+		},
+		"vector 1": {
+			publicKey:     "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			message:       "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			signature:     "6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+			expectedValid: true,
+		},
+		"vector 2": {
+			publicKey:     "DD308AFEC5777E13121FA72B9CC1B7CC0139715309B086C960E18FD969774EB8",
+			message:       "7E2D58D8B3BCDF1ABADEC7829054F90DDA9805AAB56C77333024B9D0A508B75C",
+			signature:     "5831AAEED7B44BB74E5EAB94BA9D4294C49BCF2A60728D8B4C200F50DD313C1BAB745879A5AD954A72C45A91C3A51D3C7ADEA98D82F8481E0E1E03674A6F3FB7",
+			expectedValid: true,
+		},
+		"public key not on the curve": {
+			publicKey:     "EEFDEA4CDB677750A420FEE807EACF21EB9898AE79B9768766E4FAA04A2D4A34",
+			message:       "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			signature:     "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E17776969E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+			expectedValid: false,
+		},
+		"odd y coordinate of nonce point": {
+			publicKey:     "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			message:       "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			signature:     "FFF97BD5755EEEA420453A14355235D382F6472F8568A18B2F057A14602975563CC27944640AC607CD107AE10923D9EF7A73C643E166BE5EBEAFA34B1AC553E2",
+			expectedValid: false,
+		},
+		"negated message": {
+			publicKey:     "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			message:       "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			signature:     "1FA62E331EDBC21C394792D2AB1100A7B432B013DF3F6FF4F99FCB33E0E1515F28890B3EDB6E7189B630448B515CE4F8622A954CFE545735AAEA5134FCCDB2BD",
+			expectedValid: false,
+		},
+		"s equal to curve order": {
+			publicKey:     "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			message:       "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			signature:     "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141",
+			expectedValid: false,
+		},
+		"truncated signature": {
+			publicKey:     "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			message:       "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			signature:     "6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B",
+			expectedValid: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			publicKey, _ := hex.DecodeString(test.publicKey)
+			message, _ := hex.DecodeString(test.message)
+			signature, _ := hex.DecodeString(test.signature)
+
+			valid := VerifySchnorrSignature(publicKey, message, signature)
+
+			if valid != test.expectedValid {
+				t.Errorf(
+					"unexpected verification result\nexpected: [%v]\nactual:   [%v]",
+					test.expectedValid,
+					valid,
+				)
+			}
+		})
+	}
+}
@@ -1,14 +1,26 @@
 package tss
 
 import (
+	"fmt"
+	"runtime"
 	"time"
 
 	configtime "github.com/keep-network/keep-ecdsa/config/time"
+	"github.com/keep-network/keep-ecdsa/pkg/chain/bitcoin"
 )
 
 const (
 	defaultPreParamsGenerationTimeout = 2 * time.Minute
 	defaultPreParamsTargetPoolSize    = 20
+	defaultPreParamsGenerationWorkers = 1
+
+	defaultKeyGenerationProtocolTimeout = 8 * time.Minute
+	defaultSigningProtocolTimeout       = 10 * time.Minute
+	defaultSchnorrSigningTimeout        = 5 * time.Minute
+	defaultKeyShareRefreshTimeout       = 8 * time.Minute
+	defaultSigningBatchConcurrency      = 4
+	defaultProtocolAnnounceTimeout      = 2 * time.Minute
+	defaultRecoveryReadyTimeout         = 2 * time.Minute
 )
 
 // Config contains configuration for tss protocol execution.
@@ -18,6 +30,43 @@ type Config struct {
 
 	// Target size of the TSS pre params pool.
 	PreParamsTargetPoolSize int
+
+	// Number of TSS pre params generated in parallel.
+	PreParamsGenerationWorkers int
+
+	// Maximum number of CPUs used by TSS pre params generation, shared by
+	// all the workers.
+	PreParamsGenerationCPUs int
+
+	// Determines if transcripts of failed protocol executions are recorded.
+	RecordTranscripts bool
+
+	// Timeout for the key generation protocol, including readiness signaling.
+	KeyGenerationProtocolTimeout configtime.Duration
+
+	// Timeout for the signing protocol, including readiness signaling.
+	SigningProtocolTimeout configtime.Duration
+
+	// Maximum number of signatures calculated at the same time when a batch
+	// of digests is signed.
+	SigningBatchConcurrency int
+
+	// Timeout for the Schnorr signing protocol used by taproot liquidation
+	// recovery, including readiness signaling.
+	SchnorrSigningProtocolTimeout configtime.Duration
+
+	// Timeout for the key share refresh protocol, including readiness
+	// signaling. All keep members have to use the same value.
+	KeyShareRefreshProtocolTimeout configtime.Duration
+
+	// Timeout for keep members to announce their presence before key
+	// generation.
+	ProtocolAnnounceTimeout configtime.Duration
+
+	// Timeout for keep members to exchange their BTC recovery addresses
+	// during liquidation recovery. It has to be greater than the Electrs
+	// connection timeout.
+	RecoveryReadyTimeout configtime.Duration
 }
 
 // GetPreParamsGenerationTimeout returns pre-parameters generation timeout. If
@@ -41,3 +90,199 @@ func (c *Config) GetPreParamsTargetPoolSize() int {
 
 	return poolSize
 }
+
+// GetPreParamsGenerationWorkers returns the number of pre-parameters generated
+// in parallel. If a value is not set it returns a default value.
+func (c *Config) GetPreParamsGenerationWorkers() int {
+	workers := c.PreParamsGenerationWorkers
+	if workers <= 0 {
+		workers = defaultPreParamsGenerationWorkers
+	}
+
+	return workers
+}
+
+// GetPreParamsGenerationCPUs returns the maximum number of CPUs used by
+// pre-parameters generation. If a value is not set it returns the number of
+// CPUs available.
+func (c *Config) GetPreParamsGenerationCPUs() int {
+	cpus := c.PreParamsGenerationCPUs
+	if cpus <= 0 {
+		cpus = runtime.NumCPU()
+	}
+
+	return cpus
+}
+
+// GetPreParamsGenerationConcurrency returns the number of concurrent routines
+// used by a single pre-parameters generation worker so that all the workers
+// together do not use more CPUs than configured. Each worker uses at least
+// one routine.
+func (c *Config) GetPreParamsGenerationConcurrency() int {
+	concurrency := c.GetPreParamsGenerationCPUs() / c.GetPreParamsGenerationWorkers()
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return concurrency
+}
+
+// GetKeyGenerationProtocolTimeout returns the key generation protocol timeout.
+// If a value is not set it returns a default value.
+func (c *Config) GetKeyGenerationProtocolTimeout() time.Duration {
+	timeout := c.KeyGenerationProtocolTimeout.ToDuration()
+	if timeout == 0 {
+		timeout = defaultKeyGenerationProtocolTimeout
+	}
+
+	return timeout
+}
+
+// GetSigningProtocolTimeout returns the signing protocol timeout. If a value
+// is not set it returns a default value.
+func (c *Config) GetSigningProtocolTimeout() time.Duration {
+	timeout := c.SigningProtocolTimeout.ToDuration()
+	if timeout == 0 {
+		timeout = defaultSigningProtocolTimeout
+	}
+
+	return timeout
+}
+
+// GetSigningBatchConcurrency returns the maximum number of signatures
+// calculated at the same time when a batch of digests is signed. If a value is
+// not set it returns a default value.
+func (c *Config) GetSigningBatchConcurrency() int {
+	concurrency := c.SigningBatchConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultSigningBatchConcurrency
+	}
+
+	return concurrency
+}
+
+// GetSchnorrSigningProtocolTimeout returns the Schnorr signing protocol
+// timeout. If a value is not set it returns a default value.
+func (c *Config) GetSchnorrSigningProtocolTimeout() time.Duration {
+	timeout := c.SchnorrSigningProtocolTimeout.ToDuration()
+	if timeout == 0 {
+		timeout = defaultSchnorrSigningTimeout
+	}
+
+	return timeout
+}
+
+// GetKeyShareRefreshProtocolTimeout returns the key share refresh protocol
+// timeout. If a value is not set it returns a default value.
+func (c *Config) GetKeyShareRefreshProtocolTimeout() time.Duration {
+	timeout := c.KeyShareRefreshProtocolTimeout.ToDuration()
+	if timeout == 0 {
+		timeout = defaultKeyShareRefreshTimeout
+	}
+
+	return timeout
+}
+
+// GetProtocolAnnounceTimeout returns the announce protocol timeout. If a value
+// is not set it returns a default value.
+func (c *Config) GetProtocolAnnounceTimeout() time.Duration {
+	timeout := c.ProtocolAnnounceTimeout.ToDuration()
+	if timeout == 0 {
+		timeout = defaultProtocolAnnounceTimeout
+	}
+
+	return timeout
+}
+
+// GetRecoveryReadyTimeout returns the liquidation recovery ready timeout. If
+// a value is not set it returns a default value.
+func (c *Config) GetRecoveryReadyTimeout() time.Duration {
+	timeout := c.RecoveryReadyTimeout.ToDuration()
+	if timeout == 0 {
+		timeout = defaultRecoveryReadyTimeout
+	}
+
+	return timeout
+}
+
+// Validate returns nil if the configured protocol timeouts are consistent with
+// each other, and an error detailing what went wrong if not.
+func (c *Config) Validate() error {
+	timeouts := []struct {
+		name    string
+		timeout configtime.Duration
+	}{
+		{"KeyGenerationProtocolTimeout", c.KeyGenerationProtocolTimeout},
+		{"SigningProtocolTimeout", c.SigningProtocolTimeout},
+		{"SchnorrSigningProtocolTimeout", c.SchnorrSigningProtocolTimeout},
+		{"KeyShareRefreshProtocolTimeout", c.KeyShareRefreshProtocolTimeout},
+		{"ProtocolAnnounceTimeout", c.ProtocolAnnounceTimeout},
+		{"RecoveryReadyTimeout", c.RecoveryReadyTimeout},
+	}
+	for _, t := range timeouts {
+		if t.timeout.ToDuration() < 0 {
+			return fmt.Errorf(
+				"timeout configured at [TSS.%s] must not be negative",
+				t.name,
+			)
+		}
+	}
+
+	// Readiness signaling is executed within the protocol timeout, so there
+	// has to be some time left for the protocol itself. Readiness is
+	// signaled in two phases, announcing the readiness and confirming
+	// the set of ready members, each limited by the readiness timeout.
+	readinessTimeout := 2 * protocolReadyTimeout
+	protocolTimeouts := []struct {
+		name     string
+		protocol string
+		timeout  time.Duration
+	}{
+		{
+			"KeyGenerationProtocolTimeout",
+			"key generation protocol",
+			c.GetKeyGenerationProtocolTimeout(),
+		},
+		{
+			"SigningProtocolTimeout",
+			"signing protocol",
+			c.GetSigningProtocolTimeout(),
+		},
+		{
+			"SchnorrSigningProtocolTimeout",
+			"Schnorr signing protocol",
+			c.GetSchnorrSigningProtocolTimeout(),
+		},
+		{
+			"KeyShareRefreshProtocolTimeout",
+			"key share refresh protocol",
+			c.GetKeyShareRefreshProtocolTimeout(),
+		},
+	}
+	for _, t := range protocolTimeouts {
+		if t.timeout <= readinessTimeout {
+			return fmt.Errorf(
+				"%s timeout [%v] must be greater than the readiness "+
+					"signaling timeout [%v]; configure it at [TSS.%s]",
+				t.protocol,
+				t.timeout,
+				readinessTimeout,
+				t.name,
+			)
+		}
+	}
+
+	// Members call Electrs right before exchanging the recovery addresses, so
+	// the exchange has to wait at least as long as an Electrs call may take.
+	if c.GetRecoveryReadyTimeout() <= bitcoin.ElectrsTimeout {
+		return fmt.Errorf(
+			"recovery ready timeout [%v] must be greater than "+
+				"the Electrs connection timeout [%v]; configure it at "+
+				"[TSS.RecoveryReadyTimeout]",
+			c.GetRecoveryReadyTimeout(),
+			bitcoin.ElectrsTimeout,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package tss
+
+import (
+	"testing"
+	"time"
+
+	configtime "github.com/keep-network/keep-ecdsa/config/time"
+)
+
+func TestConfigValidate(t *testing.T) {
+	var tests = map[string]struct {
+		config        *Config
+		expectedError bool
+	}{
+		"default timeouts": {
+			config:        &Config{},
+			expectedError: false,
+		},
+		"custom timeouts": {
+			config: &Config{
+				KeyGenerationProtocolTimeout:   configtime.Duration{Duration: 20 * time.Minute},
+				SigningProtocolTimeout:         configtime.Duration{Duration: 5 * time.Minute},
+				SchnorrSigningProtocolTimeout:  configtime.Duration{Duration: 6 * time.Minute},
+				KeyShareRefreshProtocolTimeout: configtime.Duration{Duration: 15 * time.Minute},
+				ProtocolAnnounceTimeout:        configtime.Duration{Duration: 30 * time.Second},
+				RecoveryReadyTimeout:           configtime.Duration{Duration: 5 * time.Minute},
+			},
+			expectedError: false,
+		},
+		"negative announce timeout": {
+			config: &Config{
+				ProtocolAnnounceTimeout: configtime.Duration{Duration: -time.Minute},
+			},
+			expectedError: true,
+		},
+		"key generation timeout not greater than readiness timeout": {
+			config: &Config{
+				KeyGenerationProtocolTimeout: configtime.Duration{Duration: protocolReadyTimeout},
+			},
+			expectedError: true,
+		},
+		"signing timeout not greater than readiness timeout": {
+			config: &Config{
+				SigningProtocolTimeout: configtime.Duration{Duration: time.Minute},
+			},
+			expectedError: true,
+		},
+		"signing timeout not greater than both readiness phases": {
+			config: &Config{
+				SigningProtocolTimeout: configtime.Duration{Duration: 2 * protocolReadyTimeout},
+			},
+			expectedError: true,
+		},
+		"Schnorr signing timeout not greater than readiness timeout": {
+			config: &Config{
+				SchnorrSigningProtocolTimeout: configtime.Duration{Duration: protocolReadyTimeout},
+			},
+			expectedError: true,
+		},
+		"key share refresh timeout not greater than readiness timeout": {
+			config: &Config{
+				KeyShareRefreshProtocolTimeout: configtime.Duration{Duration: time.Minute},
+			},
+			expectedError: true,
+		},
+		"recovery ready timeout not greater than Electrs timeout": {
+			config: &Config{
+				RecoveryReadyTimeout: configtime.Duration{Duration: 30 * time.Second},
+			},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := test.config.Validate()
+
+			if test.expectedError != (err != nil) {
+				t.Errorf(
+					"unexpected validation result\nexpected error: [%v]\nactual error:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,375 @@
+//+build eddsa
+
+package tss
+
+import (
+	"context"
+	cecdsa "crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/eddsa/keygen"
+	"github.com/binance-chain/tss-lib/eddsa/signing"
+	"github.com/binance-chain/tss-lib/tss"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// eddsaWireMessageTypes are types of messages of EdDSA key generation and
+// signing protocols.
+var eddsaWireMessageTypes = newWireMessageTypes(
+	&keygen.KGRound1Message{},
+	&keygen.KGRound2Message1{},
+	&keygen.KGRound2Message2{},
+	&signing.SignRound1Message{},
+	&signing.SignRound2Message{},
+	&signing.SignRound3Message{},
+)
+
+// Suffix of the protocol session ID of the EdDSA signing protocol. The session
+// ID includes also a hash of the message so that messages of signing protocols
+// executed concurrently for different messages are not mixed.
+const eddsaSigningSessionSuffix = "-eddsa-sign-"
+
+// GenerateEdDSAThresholdSigner executes a threshold multi-party key generation
+// protocol for the EdDSA signature scheme over Ed25519 curve.
+//
+// It expects unique identifiers of the current member as well as identifiers of
+// all members of the signing group. Group ID should be unique for each concurrent
+// execution.
+//
+// Dishonest threshold `t` defines a maximum number of signers controlled by the
+// adversary such that the adversary still cannot produce a signature. Any subset
+// of `t + 1` players can jointly sign, but any smaller subset cannot.
+//
+// Contrary to ECDSA, EdDSA key generation does not require pre-parameters.
+// Key generation, including readiness signaling, has to complete within
+// the protocol timeout.
+//
+// As a result a signer will be returned or an error, if key generation failed.
+func GenerateEdDSAThresholdSigner(
+	parentCtx context.Context,
+	groupID string,
+	memberID MemberID,
+	groupMemberIDs []MemberID,
+	dishonestThreshold uint,
+	networkProvider net.Provider,
+	pubKeyToAddressFn func(cecdsa.PublicKey) []byte,
+	protocolTimeout time.Duration,
+) (*EdDSAThresholdSigner, error) {
+	group, err := newThresholdGroupInfo(
+		groupID,
+		memberID,
+		groupMemberIDs,
+		dishonestThreshold,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	netBridge, err := newNetworkBridge(
+		group,
+		networkProvider,
+		transcriptFromContext(parentCtx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize network bridge: [%v]", err)
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, protocolTimeout)
+	defer cancel()
+
+	party, endChan, err := initializeEdDSAKeyGenerationParty(ctx, group, netBridge)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to initialize key generation member: [%v]",
+			err,
+		)
+	}
+	logger.Infof("[party:%s]: initialized EdDSA key generation", party.PartyID())
+
+	broadcastChannel, err := netBridge.getBroadcastChannel()
+	if err != nil {
+		return nil, err
+	}
+
+	// Key generation requires all group members to be ready.
+	if _, err := readyProtocol(
+		ctx,
+		group,
+		group.groupID,
+		broadcastChannel,
+		pubKeyToAddressFn,
+		len(groupMemberIDs),
+	); err != nil {
+		return nil, fmt.Errorf("readiness signaling protocol failed: [%w]", err)
+	}
+
+	logger.Infof("[party:%s]: starting EdDSA key generation", party.PartyID())
+
+	if err := party.Start(); err != nil {
+		return nil, fmt.Errorf(
+			"failed to start key generation: [%v]",
+			party.WrapError(err),
+		)
+	}
+
+	select {
+	case keygenData := <-endChan:
+		logger.Infof("[party:%s]: completed EdDSA key generation", party.PartyID())
+
+		return &EdDSAThresholdSigner{
+			groupInfo:    group,
+			thresholdKey: EdDSAThresholdKey(keygenData),
+		}, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf(
+			"failed to generate key: [%w]",
+			timeoutError{
+				protocolTimeout,
+				"key generation",
+				netBridge.blamedCulprits(party),
+			},
+		)
+	}
+}
+
+func initializeEdDSAKeyGenerationParty(
+	ctx context.Context,
+	group *groupInfo,
+	bridge *networkBridge,
+) (
+	tss.Party,
+	<-chan keygen.LocalPartySaveData,
+	error,
+) {
+	tssMessageChan := make(chan tss.Message, len(group.groupMemberIDs))
+	endChan := make(chan keygen.LocalPartySaveData)
+
+	currentPartyID, groupPartiesIDs, err := generatePartiesIDs(
+		group.memberID,
+		group.groupMemberIDs,
+		MemberID.bigInt,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate parties IDs: [%v]", err)
+	}
+
+	params := tss.NewParameters(
+		tss.Edwards(),
+		tss.NewPeerContext(tss.SortPartyIDs(groupPartiesIDs)),
+		currentPartyID,
+		len(groupPartiesIDs),
+		group.dishonestThreshold,
+	)
+
+	party := keygen.NewLocalParty(params, tssMessageChan, endChan)
+
+	// Key generation requires all group members to participate.
+	if err := bridge.connect(ctx, len(group.groupMemberIDs)); err != nil {
+		return nil, nil, fmt.Errorf("failed to connect bridge network: [%v]", err)
+	}
+
+	bridge.bind(
+		ctx,
+		tssMessageChan,
+		party,
+		params.Parties().IDs(),
+		eddsaWireMessageTypes,
+	)
+
+	return party, endChan, nil
+}
+
+// CalculateSignature executes a threshold multi-party signature calculation
+// protocol for the given message. As a result the calculated 64-byte Ed25519
+// signature will be returned or an error, if the signature generation failed.
+//
+// The signature is calculated by all members who signalled their readiness
+// within the readiness timeout. The protocol can proceed without some of the
+// members as long as at least `t + 1` of them, where `t` is the dishonest
+// threshold of the group, are ready. Signing, including readiness signaling,
+// has to complete within the protocol timeout. The signature is verified
+// against the group public key and if it is invalid, the peer members who
+// calculated it are blamed.
+//
+// The TSS library treats the message as a number, so messages which are empty
+// or start with a zero byte cannot be signed and are rejected.
+func (s *EdDSAThresholdSigner) CalculateSignature(
+	parentCtx context.Context,
+	message []byte,
+	networkProvider net.Provider,
+	pubKeyToAddressFn func(cecdsa.PublicKey) []byte,
+	protocolTimeout time.Duration,
+) ([]byte, error) {
+	if len(message) == 0 || message[0] == 0 {
+		return nil, fmt.Errorf(
+			"message [%x] is empty or starts with a zero byte",
+			message,
+		)
+	}
+
+	netBridge, err := newNetworkBridge(
+		s.groupInfo,
+		networkProvider,
+		transcriptFromContext(parentCtx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize network bridge: [%v]", err)
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, protocolTimeout)
+	defer cancel()
+
+	// Connect to peer members before signalling readiness so that messages
+	// of members who start the signing earlier are not lost.
+	if err := netBridge.connect(ctx, s.signingQuorum()); err != nil {
+		return nil, fmt.Errorf("failed to connect bridge network: [%v]", err)
+	}
+
+	broadcastChannel, err := netBridge.getBroadcastChannel()
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID := s.signingSessionID(message)
+
+	signingMemberIDs, err := readyProtocol(
+		ctx,
+		s.groupInfo,
+		sessionID,
+		broadcastChannel,
+		pubKeyToAddressFn,
+		s.signingQuorum(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readiness signaling protocol failed: [%w]", err)
+	}
+
+	party, endChan, err := s.initializeSigningParty(
+		ctx,
+		sessionID,
+		new(big.Int).SetBytes(message),
+		signingMemberIDs,
+		netBridge,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize signing party: [%v]", err)
+	}
+
+	if err := party.Start(); err != nil {
+		return nil, fmt.Errorf(
+			"failed to start signing: [%v]",
+			party.WrapError(err),
+		)
+	}
+
+	select {
+	case signature := <-endChan:
+		return s.verifySignature(
+			message,
+			signature.GetSignature(),
+			signingMemberIDs,
+		)
+	case <-ctx.Done():
+		return nil, fmt.Errorf(
+			"failed to sign: [%w]",
+			timeoutError{
+				protocolTimeout,
+				"signing",
+				netBridge.blamedCulprits(party),
+			},
+		)
+	}
+}
+
+// signingSessionID returns ID of the protocol session in which the signature
+// for the given message is calculated.
+func (s *EdDSAThresholdSigner) signingSessionID(message []byte) string {
+	messageHash := sha256.Sum256(message)
+	return s.groupID + eddsaSigningSessionSuffix + hex.EncodeToString(messageHash[:])
+}
+
+func (s *EdDSAThresholdSigner) initializeSigningParty(
+	ctx context.Context,
+	sessionID string,
+	message *big.Int,
+	signingMemberIDs []MemberID,
+	netBridge *networkBridge,
+) (
+	tss.Party,
+	<-chan common.SignatureData,
+	error,
+) {
+	tssMessageChan := make(chan tss.Message, len(signingMemberIDs))
+	endChan := make(chan common.SignatureData)
+
+	currentPartyID, groupPartiesIDs, err := generatePartiesIDs(
+		s.memberID,
+		signingMemberIDs,
+		MemberID.bigInt,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate parties IDs: [%v]", err)
+	}
+
+	params := tss.NewParameters(
+		tss.Edwards(),
+		tss.NewPeerContext(tss.SortPartyIDs(groupPartiesIDs)),
+		currentPartyID,
+		len(groupPartiesIDs),
+		s.dishonestThreshold,
+	)
+
+	party := signing.NewLocalParty(
+		message,
+		params,
+		keygen.LocalPartySaveData(s.thresholdKey),
+		tssMessageChan,
+		endChan,
+	)
+
+	netBridge.bindSession(
+		ctx,
+		sessionID,
+		tssMessageChan,
+		party,
+		params.Parties().IDs(),
+		eddsaWireMessageTypes,
+		func(tss.Message) []string { return []string{sessionID} },
+	)
+
+	return party, endChan, nil
+}
+
+// verifySignature verifies the Ed25519 signature calculated by the given
+// signing members over the given message before it is used anywhere.
+//
+// An invalid signature means one of the peer members who took part in
+// the signing contributed an invalid share. The member cannot tell which one
+// so all peer signing members are blamed for the failure.
+func (s *EdDSAThresholdSigner) verifySignature(
+	message []byte,
+	signature []byte,
+	signingMemberIDs []MemberID,
+) ([]byte, error) {
+	if len(signature) != ed25519.SignatureSize {
+		return nil, invalidSignatureError{
+			fmt.Sprintf("invalid signature length [%d]", len(signature)),
+			s.peerCulpritsAmong(signingMemberIDs, InvalidSignature),
+		}
+	}
+
+	if !ed25519.Verify(s.PublicKey(), message, signature) {
+		return nil, invalidSignatureError{
+			"verification against the group public key failed",
+			s.peerCulpritsAmong(signingMemberIDs, InvalidSignature),
+		}
+	}
+
+	return signature, nil
+}
@@ -0,0 +1,141 @@
+//+build eddsa
+
+package tss
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	eddsaKeygen "github.com/binance-chain/tss-lib/eddsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss/gen/pb"
+)
+
+// Marshal converts EdDSAThresholdSigner to byte array. The signer is
+// serialized with the current version of the EdDSA signer format and wrapped
+// in a checksummed envelope.
+func (s *EdDSAThresholdSigner) Marshal() ([]byte, error) {
+	keygenData, err := s.thresholdKey.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := (&pb.ThresholdSigner{
+		GroupInfo:    s.groupInfo.marshal(),
+		ThresholdKey: keygenData,
+	}).Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return sealEnvelope(CurrentEdDSASignerFormat, payload), nil
+}
+
+// Unmarshal converts a byte array back to EdDSAThresholdSigner.
+func (s *EdDSAThresholdSigner) Unmarshal(bytes []byte) error {
+	version, payload, err := openEnvelope(bytes)
+	if err != nil {
+		return fmt.Errorf("failed to open signer envelope: [%v]", err)
+	}
+
+	if version != CurrentEdDSASignerFormat {
+		return fmt.Errorf(
+			"unsupported EdDSA signer format version [%d]",
+			version,
+		)
+	}
+
+	pbSigner := pb.ThresholdSigner{
+		GroupInfo: &pb.ThresholdSigner_GroupInfo{},
+	}
+	if err := pbSigner.Unmarshal(payload); err != nil {
+		return fmt.Errorf("failed to unmarshal signer: [%v]", err)
+	}
+
+	s.thresholdKey = EdDSAThresholdKey{}
+	if err := s.thresholdKey.Unmarshal(pbSigner.GetThresholdKey()); err != nil {
+		return fmt.Errorf("failed to unmarshal signer: [%v]", err)
+	}
+
+	s.groupInfo = unmarshalGroupInfo(pbSigner.GetGroupInfo())
+
+	return nil
+}
+
+// Marshal converts EdDSAThresholdKey to byte array.
+func (tk *EdDSAThresholdKey) Marshal() ([]byte, error) {
+	localSecrets := &pb.LocalPartySaveData_LocalSecrets{
+		Xi:      tk.LocalSecrets.Xi.Bytes(),
+		ShareID: tk.LocalSecrets.ShareID.Bytes(),
+	}
+
+	ks := make([][]byte, len(tk.Ks))
+	for i, k := range tk.Ks {
+		ks[i] = k.Bytes()
+	}
+
+	bigXj := make([]*pb.LocalPartySaveData_ECPoint, len(tk.BigXj))
+	for i, bigX := range tk.BigXj {
+		bigXj[i] = &pb.LocalPartySaveData_ECPoint{
+			X: bigX.X().Bytes(),
+			Y: bigX.Y().Bytes(),
+		}
+	}
+
+	eddsaPub := &pb.LocalPartySaveData_ECPoint{
+		X: tk.EDDSAPub.X().Bytes(),
+		Y: tk.EDDSAPub.Y().Bytes(),
+	}
+
+	return (&pb.EdDSALocalPartySaveData{
+		LocalSecrets: localSecrets,
+		Ks:           ks,
+		BigXj:        bigXj,
+		EddsaPub:     eddsaPub,
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array back to EdDSAThresholdKey.
+func (tk *EdDSAThresholdKey) Unmarshal(bytes []byte) error {
+	pbData := pb.EdDSALocalPartySaveData{}
+	if err := pbData.Unmarshal(bytes); err != nil {
+		return fmt.Errorf("failed to unmarshal signer: [%v]", err)
+	}
+
+	tk.LocalSecrets = eddsaKeygen.LocalSecrets{
+		Xi:      new(big.Int).SetBytes(pbData.GetLocalSecrets().GetXi()),
+		ShareID: new(big.Int).SetBytes(pbData.GetLocalSecrets().GetShareID()),
+	}
+
+	tk.Ks = make([]*big.Int, len(pbData.GetKs()))
+	for i, k := range pbData.GetKs() {
+		tk.Ks[i] = new(big.Int).SetBytes(k)
+	}
+
+	tk.BigXj = make([]*crypto.ECPoint, len(pbData.GetBigXj()))
+	for i, bigX := range pbData.GetBigXj() {
+		decoded, err := crypto.NewECPoint(
+			tss.Edwards(),
+			new(big.Int).SetBytes(bigX.X),
+			new(big.Int).SetBytes(bigX.Y),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to decode BigXj: [%v]", err)
+		}
+
+		tk.BigXj[i] = decoded
+	}
+
+	decoded, err := crypto.NewECPoint(
+		tss.Edwards(),
+		new(big.Int).SetBytes(pbData.GetEddsaPub().GetX()),
+		new(big.Int).SetBytes(pbData.GetEddsaPub().GetY()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to decode EDDSAPub: [%v]", err)
+	}
+	tk.EDDSAPub = decoded
+
+	return nil
+}
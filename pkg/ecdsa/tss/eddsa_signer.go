@@ -0,0 +1,46 @@
+//+build eddsa
+
+package tss
+
+import (
+	"crypto/ed25519"
+
+	"github.com/binance-chain/tss-lib/eddsa/keygen"
+	tssLib "github.com/binance-chain/tss-lib/tss"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+)
+
+// EdDSAThresholdSigner is a threshold signer of the EdDSA signature scheme over
+// Ed25519 curve who completed key generation stage.
+type EdDSAThresholdSigner struct {
+	*groupInfo
+
+	// thresholdKey contains a signer's key generated for a threshold signing
+	// scheme. This data should be persisted to a local storage.
+	thresholdKey EdDSAThresholdKey
+}
+
+// EdDSAThresholdKey contains data of signer's EdDSA threshold key.
+type EdDSAThresholdKey keygen.LocalPartySaveData
+
+// MemberID returns member's unique identifer.
+func (s *EdDSAThresholdSigner) MemberID() MemberID {
+	return s.memberID
+}
+
+// GroupID return signing group unique identifer.
+func (s *EdDSAThresholdSigner) GroupID() string {
+	return s.groupID
+}
+
+// PublicKey returns signer's Ed25519 public key which is also the signing
+// group's public key.
+func (s *EdDSAThresholdSigner) PublicKey() ed25519.PublicKey {
+	publicKey := edwards.PublicKey{
+		Curve: tssLib.Edwards(),
+		X:     s.thresholdKey.EDDSAPub.X(),
+		Y:     s.thresholdKey.EDDSAPub.Y(),
+	}
+
+	return ed25519.PublicKey(publicKey.Serialize())
+}
@@ -0,0 +1,51 @@
+//+build eddsa
+
+package tss
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/keep-network/keep-ecdsa/internal/testdata"
+)
+
+func TestEdDSASignerGoldenFile(t *testing.T) {
+	golden, err := testdata.LoadEdDSASignerGoldenFile(CurrentEdDSASignerFormat)
+	if err != nil {
+		t.Fatalf("failed to load golden file: [%v]", err)
+	}
+
+	actualVersion, err := SignerFormatVersion(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if CurrentEdDSASignerFormat != actualVersion {
+		t.Errorf(
+			"unexpected format version\nexpected: [%v]\nactual:   [%v]",
+			CurrentEdDSASignerFormat,
+			actualVersion,
+		)
+	}
+
+	signer := &EdDSAThresholdSigner{}
+	if err := signer.Unmarshal(golden); err != nil {
+		t.Fatalf("failed to unmarshal signer: [%v]", err)
+	}
+
+	if signer.GroupID() != "test-group-id-1" {
+		t.Errorf(
+			"unexpected group ID\nexpected: [%v]\nactual:   [%v]",
+			"test-group-id-1",
+			signer.GroupID(),
+		)
+	}
+
+	marshaled, err := signer.Marshal()
+	if err != nil {
+		t.Fatalf("failed to marshal signer: [%v]", err)
+	}
+
+	if !bytes.Equal(golden, marshaled) {
+		t.Errorf("marshaled signer does not match the golden file")
+	}
+}
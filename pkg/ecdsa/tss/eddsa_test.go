@@ -0,0 +1,209 @@
+//+build eddsa
+
+package tss
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-ecdsa/internal/testhelper/netsim"
+)
+
+func TestSimulatedEdDSAKeyGenerationAndSigning(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 180*time.Second)
+	defer cancel()
+
+	simulation := newSimulation(t, simulationSeed, 3, 1)
+
+	for i := range simulation.memberIDs {
+		simulation.setFaults(i, netsim.Faults{
+			DuplicateRate: 0.2,
+			ReorderRate:   0.2,
+			MaxDelay:      20 * time.Millisecond,
+		})
+	}
+
+	signers := simulation.generateEdDSAKeys(ctx, t)
+
+	for i, signer := range signers {
+		if !signer.PublicKey().Equal(signers[0].PublicKey()) {
+			t.Errorf(
+				"unexpected public key of signer [%d]\n"+
+					"expected: [%x]\nactual:   [%x]",
+				i,
+				signers[0].PublicKey(),
+				signer.PublicKey(),
+			)
+		}
+	}
+
+	message := []byte("message to sign")
+
+	signatures := make([][]byte, len(signers))
+	signingErrors := make(chan error, len(signers))
+
+	var signingWait sync.WaitGroup
+	signingWait.Add(len(signers))
+
+	for i := range signers {
+		go func(index int) {
+			defer signingWait.Done()
+
+			signature, err := signers[index].CalculateSignature(
+				ctx,
+				message,
+				simulation.providers[index],
+				simulationPubKeyToAddress,
+				defaultSigningProtocolTimeout,
+			)
+			if err != nil {
+				signingErrors <- fmt.Errorf("failed to sign: [%v]", err)
+				return
+			}
+
+			signatures[index] = signature
+		}(i)
+	}
+
+	signingWait.Wait()
+	close(signingErrors)
+
+	for err := range signingErrors {
+		t.Fatalf("unexpected error on signing: [%v]", err)
+	}
+
+	for i, signature := range signatures {
+		if len(signature) != ed25519.SignatureSize {
+			t.Fatalf(
+				"unexpected signature length of signer [%d]\n"+
+					"expected: [%v]\nactual:   [%v]",
+				i,
+				ed25519.SignatureSize,
+				len(signature),
+			)
+		}
+
+		if !ed25519.Verify(signers[0].PublicKey(), message, signature) {
+			t.Errorf("invalid signature of signer [%d]: [%x]", i, signature)
+		}
+
+		if _, err := signers[i].verifySignature(
+			message,
+			signature,
+			signers[i].groupMemberIDs,
+		); err != nil {
+			t.Errorf("unexpected verification error: [%v]", err)
+		}
+	}
+
+	// The persisted signer has to be able to verify the signature with
+	// the restored key.
+	bytes, err := signers[0].Marshal()
+	if err != nil {
+		t.Fatalf("failed to marshal signer: [%v]", err)
+	}
+
+	unmarshalled := &EdDSAThresholdSigner{}
+	if err := unmarshalled.Unmarshal(bytes); err != nil {
+		t.Fatalf("failed to unmarshal signer: [%v]", err)
+	}
+
+	if !reflect.DeepEqual(signers[0], unmarshalled) {
+		t.Errorf(
+			"unexpected content of unmarshalled signer\n"+
+				"expected: [%+v]\nactual:   [%+v]",
+			signers[0],
+			unmarshalled,
+		)
+	}
+
+	if _, err := unmarshalled.verifySignature(
+		message,
+		signatures[0],
+		unmarshalled.groupMemberIDs,
+	); err != nil {
+		t.Errorf("unexpected verification error: [%v]", err)
+	}
+}
+
+func TestEdDSASignerRejectsUnsignableMessage(t *testing.T) {
+	signer := &EdDSAThresholdSigner{groupInfo: &groupInfo{groupID: "group-1"}}
+
+	var tests = map[string]struct {
+		message []byte
+	}{
+		"empty message": {
+			message: []byte{},
+		},
+		"message starting with zero byte": {
+			message: []byte{0x00, 0x01, 0x02},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, err := signer.CalculateSignature(
+				context.Background(),
+				test.message,
+				nil,
+				simulationPubKeyToAddress,
+				defaultSigningProtocolTimeout,
+			)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+// generateEdDSAKeys executes EdDSA key generation with all the members of
+// the group.
+func (s *simulation) generateEdDSAKeys(
+	ctx context.Context,
+	t *testing.T,
+) []*EdDSAThresholdSigner {
+	groupSize := len(s.memberIDs)
+
+	signers := make([]*EdDSAThresholdSigner, groupSize)
+	keyGenErrors := make(chan error, groupSize)
+
+	var keyGenWait sync.WaitGroup
+	keyGenWait.Add(groupSize)
+
+	for i, memberID := range s.memberIDs {
+		go func(memberID MemberID, index int) {
+			defer keyGenWait.Done()
+
+			signer, err := GenerateEdDSAThresholdSigner(
+				ctx,
+				s.groupID,
+				memberID,
+				s.memberIDs,
+				s.dishonestThreshold,
+				s.providers[index],
+				simulationPubKeyToAddress,
+				defaultKeyGenerationProtocolTimeout,
+			)
+			if err != nil {
+				keyGenErrors <- fmt.Errorf("failed to generate signer: [%v]", err)
+				return
+			}
+
+			signers[index] = signer
+		}(memberID, i)
+	}
+
+	keyGenWait.Wait()
+	close(keyGenErrors)
+
+	for err := range keyGenErrors {
+		t.Fatalf("unexpected error on key generation: [%v]", err)
+	}
+
+	return signers
+}
@@ -0,0 +1,84 @@
+//+build !eddsa
+
+package tss
+
+import (
+	"context"
+	cecdsa "crypto/ecdsa"
+	"crypto/ed25519"
+	"fmt"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// tss-lib registers messages of ECDSA and EdDSA protocols in the global
+// protobuf registry under the same names, which is a registration conflict.
+// EdDSA protocols are supported only by keeps of the local chain, so they are
+// linked only into clients built with the `eddsa` build tag. In other clients
+// EdDSA operations fail with this error.
+var errEdDSANotSupported = fmt.Errorf(
+	"EdDSA is not supported by this client; build it with the eddsa tag",
+)
+
+// EdDSAThresholdSigner is a threshold signer of the EdDSA signature scheme over
+// Ed25519 curve. Clients built without the `eddsa` build tag cannot generate,
+// load or use EdDSA signers.
+type EdDSAThresholdSigner struct {
+	*groupInfo
+}
+
+// GenerateEdDSAThresholdSigner fails as EdDSA is not supported by clients
+// built without the `eddsa` build tag.
+func GenerateEdDSAThresholdSigner(
+	parentCtx context.Context,
+	groupID string,
+	memberID MemberID,
+	groupMemberIDs []MemberID,
+	dishonestThreshold uint,
+	networkProvider net.Provider,
+	pubKeyToAddressFn func(cecdsa.PublicKey) []byte,
+	protocolTimeout time.Duration,
+) (*EdDSAThresholdSigner, error) {
+	return nil, errEdDSANotSupported
+}
+
+// MemberID returns member's unique identifer.
+func (s *EdDSAThresholdSigner) MemberID() MemberID {
+	return s.memberID
+}
+
+// GroupID return signing group unique identifer.
+func (s *EdDSAThresholdSigner) GroupID() string {
+	return s.groupID
+}
+
+// PublicKey returns no key as EdDSA signers cannot be generated or loaded by
+// clients built without the `eddsa` build tag.
+func (s *EdDSAThresholdSigner) PublicKey() ed25519.PublicKey {
+	return nil
+}
+
+// CalculateSignature fails as EdDSA is not supported by clients built without
+// the `eddsa` build tag.
+func (s *EdDSAThresholdSigner) CalculateSignature(
+	parentCtx context.Context,
+	message []byte,
+	networkProvider net.Provider,
+	pubKeyToAddressFn func(cecdsa.PublicKey) []byte,
+	protocolTimeout time.Duration,
+) ([]byte, error) {
+	return nil, errEdDSANotSupported
+}
+
+// Marshal fails as EdDSA is not supported by clients built without the `eddsa`
+// build tag.
+func (s *EdDSAThresholdSigner) Marshal() ([]byte, error) {
+	return nil, errEdDSANotSupported
+}
+
+// Unmarshal fails as EdDSA is not supported by clients built without
+// the `eddsa` build tag.
+func (s *EdDSAThresholdSigner) Unmarshal(bytes []byte) error {
+	return errEdDSANotSupported
+}
@@ -0,0 +1,59 @@
+//+build eddsa
+
+package tss
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/crypto/schnorr"
+	ecdsaKeygen "github.com/binance-chain/tss-lib/ecdsa/keygen"
+	eddsaKeygen "github.com/binance-chain/tss-lib/eddsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+func TestParseEdDSAWireMessage(t *testing.T) {
+	from := newTestWireMessageSender()
+
+	deCommitment := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+
+	alpha, err := crypto.NewECPoint(
+		tss.Edwards(),
+		tss.Edwards().Params().Gx,
+		tss.Edwards().Params().Gy,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proof := paillier.Proof{}
+	for i := range proof {
+		proof[i] = big.NewInt(int64(i + 1))
+	}
+
+	// ECDSA and EdDSA round two messages share the same name.
+	ecdsaMessage := ecdsaKeygen.NewKGRound2Message2(from, deCommitment)
+	eddsaMessage := eddsaKeygen.NewKGRound2Message2(
+		from,
+		deCommitment,
+		&schnorr.ZKProof{Alpha: alpha, T: big.NewInt(4)},
+	)
+
+	runParseWireMessageTests(t, from, map[string]parseWireMessageTest{
+		"ECDSA message parsed with ECDSA types": {
+			messageTypes: ecdsaWireMessageTypes,
+			message:      ecdsaMessage,
+		},
+		"EdDSA message parsed with EdDSA types": {
+			messageTypes: eddsaWireMessageTypes,
+			message:      eddsaMessage,
+		},
+		"ECDSA-only message parsed with EdDSA types": {
+			messageTypes:  eddsaWireMessageTypes,
+			message:       ecdsaKeygen.NewKGRound3Message(from, proof),
+			expectedError: true,
+		},
+	})
+}
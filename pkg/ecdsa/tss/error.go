@@ -1,31 +1,98 @@
 package tss
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 )
 
+// CulpritReason describes why a member has been blamed for a failure of
+// the protocol execution.
+type CulpritReason string
+
+const (
+	// NoMessage means the member did not send a message expected from them
+	// within the protocol timeout.
+	NoMessage CulpritReason = "no message"
+	// MalformedMessage means the member sent a message which could not be
+	// parsed.
+	MalformedMessage CulpritReason = "malformed message"
+	// InvalidMessage means the member sent a message which has been rejected
+	// by the protocol, e.g. because of an invalid proof or commitment.
+	InvalidMessage CulpritReason = "invalid message"
+	// InvalidSignature means the member took part in calculating a signature
+	// which turned out to be invalid for the group public key.
+	InvalidSignature CulpritReason = "invalid signature"
+)
+
+// Culprit is a member blamed for a failure of the protocol execution.
+type Culprit struct {
+	MemberID MemberID
+	Reason   CulpritReason
+}
+
+func (c Culprit) String() string {
+	return fmt.Sprintf("%s (%s)", c.MemberID, c.Reason)
+}
+
+// Culprits returns members blamed for a failure of the protocol execution
+// resulting with the given error. It returns nil if the failure cannot be
+// attributed to any of the members.
+func Culprits(err error) []Culprit {
+	var timeoutErr timeoutError
+	if errors.As(err, &timeoutErr) {
+		return timeoutErr.culprits
+	}
+
+	var signatureErr invalidSignatureError
+	if errors.As(err, &signatureErr) {
+		return signatureErr.culprits
+	}
+
+	return nil
+}
+
 type timeoutError struct {
-	timeout   time.Duration
-	stage     string
-	memberIDs []MemberID
+	timeout  time.Duration
+	stage    string
+	culprits []Culprit
 }
 
 func (t timeoutError) Error() string {
-	if len(t.memberIDs) > 0 {
-		stringIDs := []string{}
+	if len(t.culprits) > 0 {
+		waitingFor := []string{}
+		misbehaving := []string{}
 
-		for _, memberID := range t.memberIDs {
-			stringIDs = append(stringIDs, memberID.String())
+		for _, culprit := range t.culprits {
+			if culprit.Reason == NoMessage {
+				waitingFor = append(waitingFor, culprit.MemberID.String())
+			} else {
+				misbehaving = append(misbehaving, culprit.String())
+			}
 		}
 
-		return fmt.Sprintf(
-			"timeout [%s] exceeded on stage [%s] - still waiting for members: [%s]",
+		message := fmt.Sprintf(
+			"timeout [%s] exceeded on stage [%s]",
 			t.timeout,
 			t.stage,
-			strings.Join(stringIDs, ", "),
 		)
+
+		if len(waitingFor) > 0 {
+			message += fmt.Sprintf(
+				" - still waiting for members: [%s]",
+				strings.Join(waitingFor, ", "),
+			)
+		}
+
+		if len(misbehaving) > 0 {
+			message += fmt.Sprintf(
+				" - misbehaving members: [%s]",
+				strings.Join(misbehaving, ", "),
+			)
+		}
+
+		return message
 	}
 
 	return fmt.Sprintf(
@@ -34,3 +101,12 @@ func (t timeoutError) Error() string {
 		t.stage,
 	)
 }
+
+type invalidSignatureError struct {
+	reason   string
+	culprits []Culprit
+}
+
+func (i invalidSignatureError) Error() string {
+	return fmt.Sprintf("invalid signature: %s", i.reason)
+}
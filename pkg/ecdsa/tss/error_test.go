@@ -0,0 +1,79 @@
+package tss
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCulprits(t *testing.T) {
+	culprits := []Culprit{
+		{MemberID: MemberID([]byte{0x01}), Reason: NoMessage},
+		{MemberID: MemberID([]byte{0x02}), Reason: InvalidMessage},
+	}
+
+	var tests = map[string]struct {
+		err              error
+		expectedCulprits []Culprit
+		expectedMessage  string
+	}{
+		"timeout error": {
+			err:              timeoutError{time.Minute, "signing", culprits},
+			expectedCulprits: culprits,
+			expectedMessage: "timeout [1m0s] exceeded on stage [signing] - " +
+				"still waiting for members: [01] - " +
+				"misbehaving members: [02 (invalid message)]",
+		},
+		"wrapped timeout error": {
+			err: fmt.Errorf(
+				"failed to sign: [%w]",
+				timeoutError{time.Minute, "signing", culprits},
+			),
+			expectedCulprits: culprits,
+			expectedMessage: "failed to sign: [timeout [1m0s] exceeded on stage " +
+				"[signing] - still waiting for members: [01] - " +
+				"misbehaving members: [02 (invalid message)]]",
+		},
+		"timeout error without culprits": {
+			err:              timeoutError{time.Minute, "signing", nil},
+			expectedCulprits: nil,
+			expectedMessage:  "timeout [1m0s] exceeded on stage [signing]",
+		},
+		"invalid signature error": {
+			err: fmt.Errorf(
+				"failed to verify signature: [%w]",
+				invalidSignatureError{"verification failed", culprits},
+			),
+			expectedCulprits: culprits,
+			expectedMessage: "failed to verify signature: " +
+				"[invalid signature: verification failed]",
+		},
+		"other error": {
+			err:              fmt.Errorf("some error"),
+			expectedCulprits: nil,
+			expectedMessage:  "some error",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			actualCulprits := Culprits(test.err)
+			if !reflect.DeepEqual(test.expectedCulprits, actualCulprits) {
+				t.Errorf(
+					"unexpected culprits\nexpected: [%v]\nactual:   [%v]",
+					test.expectedCulprits,
+					actualCulprits,
+				)
+			}
+
+			if test.expectedMessage != test.err.Error() {
+				t.Errorf(
+					"unexpected error message\nexpected: [%v]\nactual:   [%v]",
+					test.expectedMessage,
+					test.err.Error(),
+				)
+			}
+		})
+	}
+}
@@ -94,7 +94,8 @@ func (m *TSSProtocolMessage) GetSessionID() string {
 }
 
 type ReadyMessage struct {
-	SenderID []byte `protobuf:"bytes,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	SenderID  []byte `protobuf:"bytes,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	SessionID string `protobuf:"bytes,2,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
 }
 
 func (m *ReadyMessage) Reset()      { *m = ReadyMessage{} }
@@ -136,6 +137,13 @@ func (m *ReadyMessage) GetSenderID() []byte {
 	return nil
 }
 
+func (m *ReadyMessage) GetSessionID() string {
+	if m != nil {
+		return m.SessionID
+	}
+	return ""
+}
+
 type AnnounceMessage struct {
 	SenderID []byte `protobuf:"bytes,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
 }
@@ -238,37 +246,161 @@ func (m *LiquidationRecoveryAnnounceMessage) GetMaxFeePerVByte() int32 {
 	return 0
 }
 
+type ReadyConfirmationMessage struct {
+	SenderID       []byte   `protobuf:"bytes,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	ReadyMemberIDs [][]byte `protobuf:"bytes,2,rep,name=readyMemberIDs,proto3" json:"readyMemberIDs,omitempty"`
+	SessionID      string   `protobuf:"bytes,3,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+}
+
+func (m *ReadyConfirmationMessage) Reset()      { *m = ReadyConfirmationMessage{} }
+func (*ReadyConfirmationMessage) ProtoMessage() {}
+func (*ReadyConfirmationMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{4}
+}
+func (m *ReadyConfirmationMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReadyConfirmationMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReadyConfirmationMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReadyConfirmationMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadyConfirmationMessage.Merge(m, src)
+}
+func (m *ReadyConfirmationMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReadyConfirmationMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadyConfirmationMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadyConfirmationMessage proto.InternalMessageInfo
+
+func (m *ReadyConfirmationMessage) GetSenderID() []byte {
+	if m != nil {
+		return m.SenderID
+	}
+	return nil
+}
+
+func (m *ReadyConfirmationMessage) GetReadyMemberIDs() [][]byte {
+	if m != nil {
+		return m.ReadyMemberIDs
+	}
+	return nil
+}
+
+func (m *ReadyConfirmationMessage) GetSessionID() string {
+	if m != nil {
+		return m.SessionID
+	}
+	return ""
+}
+
+type KeyShareEpochsMessage struct {
+	SenderID  []byte   `protobuf:"bytes,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	SessionID string   `protobuf:"bytes,2,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+	Epochs    []uint64 `protobuf:"varint,3,rep,packed,name=epochs,proto3" json:"epochs,omitempty"`
+}
+
+func (m *KeyShareEpochsMessage) Reset()      { *m = KeyShareEpochsMessage{} }
+func (*KeyShareEpochsMessage) ProtoMessage() {}
+func (*KeyShareEpochsMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{5}
+}
+func (m *KeyShareEpochsMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KeyShareEpochsMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KeyShareEpochsMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *KeyShareEpochsMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyShareEpochsMessage.Merge(m, src)
+}
+func (m *KeyShareEpochsMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *KeyShareEpochsMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyShareEpochsMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyShareEpochsMessage proto.InternalMessageInfo
+
+func (m *KeyShareEpochsMessage) GetSenderID() []byte {
+	if m != nil {
+		return m.SenderID
+	}
+	return nil
+}
+
+func (m *KeyShareEpochsMessage) GetSessionID() string {
+	if m != nil {
+		return m.SessionID
+	}
+	return ""
+}
+
+func (m *KeyShareEpochsMessage) GetEpochs() []uint64 {
+	if m != nil {
+		return m.Epochs
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*TSSProtocolMessage)(nil), "tss.TSSProtocolMessage")
 	proto.RegisterType((*ReadyMessage)(nil), "tss.ReadyMessage")
 	proto.RegisterType((*AnnounceMessage)(nil), "tss.AnnounceMessage")
 	proto.RegisterType((*LiquidationRecoveryAnnounceMessage)(nil), "tss.LiquidationRecoveryAnnounceMessage")
+	proto.RegisterType((*ReadyConfirmationMessage)(nil), "tss.ReadyConfirmationMessage")
+	proto.RegisterType((*KeyShareEpochsMessage)(nil), "tss.KeyShareEpochsMessage")
 }
 
 func init() { proto.RegisterFile("pb/message.proto", fileDescriptor_8447775385e7eb85) }
 
 var fileDescriptor_8447775385e7eb85 = []byte{
-	// 308 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0x3f, 0x4b, 0x03, 0x41,
-	0x10, 0xc5, 0x6f, 0x12, 0xff, 0x24, 0x6b, 0x50, 0xd9, 0xea, 0x10, 0x19, 0x8e, 0x2b, 0x24, 0x08,
-	0xc6, 0xc2, 0xc6, 0xd6, 0x10, 0x84, 0x80, 0x42, 0xd8, 0x88, 0x85, 0xdd, 0xde, 0xed, 0x20, 0x07,
-	0xc9, 0xed, 0x79, 0xbb, 0x11, 0xaf, 0xb3, 0xb6, 0xb2, 0xf4, 0x23, 0xf8, 0x51, 0x2c, 0x53, 0xa6,
-	0x34, 0x9b, 0xc6, 0x32, 0x1f, 0x41, 0x3c, 0x31, 0x8a, 0x58, 0xc4, 0xf2, 0xfd, 0xde, 0x9b, 0x61,
-	0x78, 0xc3, 0xb6, 0xb3, 0xe8, 0x70, 0x48, 0xc6, 0xc8, 0x6b, 0x6a, 0x65, 0xb9, 0xb6, 0x9a, 0x57,
-	0xad, 0x31, 0xe1, 0x03, 0x30, 0x7e, 0xd1, 0xef, 0xf7, 0x3e, 0x48, 0xac, 0x07, 0xe7, 0x9f, 0x09,
-	0xbe, 0xc3, 0x6a, 0x86, 0x52, 0x45, 0x79, 0xb7, 0xe3, 0x43, 0x00, 0xcd, 0x86, 0x58, 0x68, 0xee,
-	0xb3, 0xf5, 0x4c, 0x16, 0x03, 0x2d, 0x95, 0x5f, 0x29, 0xad, 0x2f, 0xc9, 0x03, 0xb6, 0x91, 0x98,
-	0x76, 0xae, 0xa5, 0x8a, 0xa5, 0xb1, 0x7e, 0x35, 0x80, 0x66, 0x4d, 0xfc, 0x44, 0x7c, 0x97, 0xd5,
-	0x0d, 0x19, 0x93, 0xe8, 0xb4, 0xdb, 0xf1, 0x57, 0x02, 0x68, 0xd6, 0xc5, 0x37, 0x08, 0xf7, 0x59,
-	0x43, 0x90, 0x54, 0xc5, 0x12, 0x57, 0x84, 0x07, 0x6c, 0xeb, 0x24, 0x4d, 0xf5, 0x28, 0x8d, 0x69,
-	0x99, 0xf8, 0x13, 0xb0, 0xf0, 0x2c, 0xb9, 0x19, 0x25, 0x4a, 0xda, 0x44, 0xa7, 0x82, 0x62, 0x7d,
-	0x4b, 0x79, 0xf1, 0x8f, 0x15, 0xbc, 0xc5, 0x78, 0x64, 0xe3, 0xc5, 0xa4, 0x52, 0x39, 0x19, 0x53,
-	0x56, 0x50, 0x17, 0x7f, 0x38, 0x7c, 0x8f, 0x6d, 0x0e, 0xe5, 0xdd, 0x29, 0x51, 0x8f, 0xf2, 0xcb,
-	0x76, 0x61, 0xa9, 0x2c, 0x64, 0x55, 0xfc, 0xa2, 0xed, 0xe3, 0xf1, 0x14, 0xbd, 0xc9, 0x14, 0xbd,
-	0xf9, 0x14, 0xe1, 0xde, 0x21, 0x3c, 0x3b, 0x84, 0x17, 0x87, 0x30, 0x76, 0x08, 0xaf, 0x0e, 0xe1,
-	0xcd, 0xa1, 0x37, 0x77, 0x08, 0x8f, 0x33, 0xf4, 0xc6, 0x33, 0xf4, 0x26, 0x33, 0xf4, 0xae, 0x2a,
-	0x59, 0x14, 0xad, 0x95, 0x8f, 0x3c, 0x7a, 0x0f, 0x00, 0x00, 0xff, 0xff, 0xaf, 0x77, 0x62, 0x02,
-	0xdc, 0x01, 0x00, 0x00,
+	// 382 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x92, 0x31, 0x4f, 0xe3, 0x30,
+	0x18, 0x86, 0xe3, 0xa6, 0xd7, 0x6b, 0x7d, 0x55, 0xef, 0x64, 0xe9, 0x4e, 0xd1, 0xe9, 0x64, 0x45,
+	0x19, 0xaa, 0x2c, 0x57, 0x06, 0x16, 0x56, 0x4a, 0x41, 0x54, 0x80, 0x54, 0xb9, 0x88, 0x81, 0xcd,
+	0x49, 0x3e, 0x68, 0xa4, 0x26, 0x0e, 0x76, 0x8a, 0x88, 0xc4, 0xc0, 0xcc, 0xc4, 0xc8, 0x4f, 0xe0,
+	0xa7, 0x30, 0x76, 0xec, 0x48, 0xd3, 0x85, 0xb1, 0x3f, 0x01, 0x35, 0x2d, 0x85, 0x46, 0x0c, 0x45,
+	0x8c, 0xdf, 0xf3, 0xd9, 0xaf, 0x5e, 0x3d, 0x36, 0xfe, 0x15, 0x39, 0x1b, 0x01, 0x28, 0xc5, 0xcf,
+	0xa1, 0x11, 0x49, 0x11, 0x0b, 0xa2, 0xc7, 0x4a, 0x59, 0xb7, 0x08, 0x93, 0xe3, 0x6e, 0xb7, 0x33,
+	0x23, 0xae, 0xe8, 0x1f, 0xcd, 0x4f, 0x90, 0xbf, 0xb8, 0xac, 0x20, 0xf4, 0x40, 0xb6, 0x5b, 0x06,
+	0x32, 0x91, 0x5d, 0x65, 0xcb, 0x99, 0x18, 0xf8, 0x7b, 0xc4, 0x93, 0xbe, 0xe0, 0x9e, 0x51, 0xc8,
+	0x56, 0xaf, 0x23, 0x31, 0xf1, 0x0f, 0x5f, 0x35, 0xa5, 0xe0, 0x9e, 0xcb, 0x55, 0x6c, 0xe8, 0x26,
+	0xb2, 0xcb, 0xec, 0x3d, 0x22, 0xff, 0x70, 0x45, 0x81, 0x52, 0xbe, 0x08, 0xdb, 0x2d, 0xa3, 0x68,
+	0x22, 0xbb, 0xc2, 0xde, 0x80, 0xb5, 0x8f, 0xab, 0x0c, 0xb8, 0x97, 0xac, 0xd3, 0x62, 0x25, 0xa9,
+	0x90, 0x4f, 0xfa, 0x8f, 0x7f, 0x6e, 0x87, 0xa1, 0x18, 0x84, 0x2e, 0xac, 0x11, 0x66, 0xdd, 0x23,
+	0x6c, 0x1d, 0xfa, 0x17, 0x03, 0xdf, 0xe3, 0xb1, 0x2f, 0x42, 0x06, 0xae, 0xb8, 0x04, 0x99, 0x7c,
+	0x22, 0x82, 0x34, 0x30, 0x71, 0x62, 0x77, 0x79, 0xd3, 0xf3, 0x24, 0x28, 0xb5, 0x28, 0xf6, 0xc1,
+	0x86, 0xd4, 0x71, 0x2d, 0xe0, 0x57, 0x7b, 0x00, 0x1d, 0x90, 0x27, 0xcd, 0x24, 0x86, 0x4c, 0xd7,
+	0x37, 0x96, 0xa3, 0xd6, 0x35, 0x36, 0x32, 0x27, 0x3b, 0x22, 0x3c, 0xf3, 0x65, 0x90, 0xf5, 0x5b,
+	0xa7, 0x4f, 0x1d, 0xd7, 0xe4, 0xdc, 0x65, 0xe0, 0xcc, 0xc0, 0xac, 0x8b, 0x6e, 0x57, 0x59, 0x8e,
+	0xae, 0x7a, 0xd4, 0xf3, 0x1e, 0x7d, 0xfc, 0xfb, 0x00, 0x92, 0x6e, 0x8f, 0x4b, 0xd8, 0x8d, 0x84,
+	0xdb, 0x53, 0x5f, 0x7e, 0x1a, 0xf2, 0x07, 0x97, 0x20, 0x8b, 0x32, 0x74, 0x53, 0xb7, 0x8b, 0x6c,
+	0x31, 0x35, 0xb7, 0x86, 0x63, 0xaa, 0x8d, 0xc6, 0x54, 0x9b, 0x8e, 0x29, 0xba, 0x49, 0x29, 0x7a,
+	0x48, 0x29, 0x7a, 0x4c, 0x29, 0x1a, 0xa6, 0x14, 0x3d, 0xa5, 0x14, 0x3d, 0xa7, 0x54, 0x9b, 0xa6,
+	0x14, 0xdd, 0x4d, 0xa8, 0x36, 0x9c, 0x50, 0x6d, 0x34, 0xa1, 0xda, 0x69, 0x21, 0x72, 0x9c, 0x52,
+	0xf6, 0x9f, 0x37, 0x5f, 0x06, 0x00, 0xdb, 0xf7, 0xa5, 0xbf, 0xe3, 0x02, 0x00, 0x00,
 }
 
 func (this *TSSProtocolMessage) Equal(that interface{}) bool {
@@ -326,6 +458,9 @@ func (this *ReadyMessage) Equal(that interface{}) bool {
 	if !bytes.Equal(this.SenderID, that1.SenderID) {
 		return false
 	}
+	if this.SessionID != that1.SessionID {
+		return false
+	}
 	return true
 }
 func (this *AnnounceMessage) Equal(that interface{}) bool {
@@ -382,6 +517,76 @@ func (this *LiquidationRecoveryAnnounceMessage) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *ReadyConfirmationMessage) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ReadyConfirmationMessage)
+	if !ok {
+		that2, ok := that.(ReadyConfirmationMessage)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.SenderID, that1.SenderID) {
+		return false
+	}
+	if len(this.ReadyMemberIDs) != len(that1.ReadyMemberIDs) {
+		return false
+	}
+	for i := range this.ReadyMemberIDs {
+		if !bytes.Equal(this.ReadyMemberIDs[i], that1.ReadyMemberIDs[i]) {
+			return false
+		}
+	}
+	if this.SessionID != that1.SessionID {
+		return false
+	}
+	return true
+}
+func (this *KeyShareEpochsMessage) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*KeyShareEpochsMessage)
+	if !ok {
+		that2, ok := that.(KeyShareEpochsMessage)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.SenderID, that1.SenderID) {
+		return false
+	}
+	if this.SessionID != that1.SessionID {
+		return false
+	}
+	if len(this.Epochs) != len(that1.Epochs) {
+		return false
+	}
+	for i := range this.Epochs {
+		if this.Epochs[i] != that1.Epochs[i] {
+			return false
+		}
+	}
+	return true
+}
 func (this *TSSProtocolMessage) GoString() string {
 	if this == nil {
 		return "nil"
@@ -399,9 +604,10 @@ func (this *ReadyMessage) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 5)
+	s := make([]string, 0, 6)
 	s = append(s, "&pb.ReadyMessage{")
 	s = append(s, "SenderID: "+fmt.Sprintf("%#v", this.SenderID)+",\n")
+	s = append(s, "SessionID: "+fmt.Sprintf("%#v", this.SessionID)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -427,6 +633,30 @@ func (this *LiquidationRecoveryAnnounceMessage) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *ReadyConfirmationMessage) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.ReadyConfirmationMessage{")
+	s = append(s, "SenderID: "+fmt.Sprintf("%#v", this.SenderID)+",\n")
+	s = append(s, "ReadyMemberIDs: "+fmt.Sprintf("%#v", this.ReadyMemberIDs)+",\n")
+	s = append(s, "SessionID: "+fmt.Sprintf("%#v", this.SessionID)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *KeyShareEpochsMessage) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.KeyShareEpochsMessage{")
+	s = append(s, "SenderID: "+fmt.Sprintf("%#v", this.SenderID)+",\n")
+	s = append(s, "SessionID: "+fmt.Sprintf("%#v", this.SessionID)+",\n")
+	s = append(s, "Epochs: "+fmt.Sprintf("%#v", this.Epochs)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func valueToGoStringMessage(v interface{}, typ string) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -509,6 +739,13 @@ func (m *ReadyMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.SessionID) > 0 {
+		i -= len(m.SessionID)
+		copy(dAtA[i:], m.SessionID)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.SessionID)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.SenderID) > 0 {
 		i -= len(m.SenderID)
 		copy(dAtA[i:], m.SenderID)
@@ -591,6 +828,107 @@ func (m *LiquidationRecoveryAnnounceMessage) MarshalToSizedBuffer(dAtA []byte) (
 	return len(dAtA) - i, nil
 }
 
+func (m *ReadyConfirmationMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReadyConfirmationMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReadyConfirmationMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.SessionID) > 0 {
+		i -= len(m.SessionID)
+		copy(dAtA[i:], m.SessionID)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.SessionID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.ReadyMemberIDs) > 0 {
+		for iNdEx := len(m.ReadyMemberIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ReadyMemberIDs[iNdEx])
+			copy(dAtA[i:], m.ReadyMemberIDs[iNdEx])
+			i = encodeVarintMessage(dAtA, i, uint64(len(m.ReadyMemberIDs[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.SenderID) > 0 {
+		i -= len(m.SenderID)
+		copy(dAtA[i:], m.SenderID)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.SenderID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *KeyShareEpochsMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KeyShareEpochsMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *KeyShareEpochsMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Epochs) > 0 {
+		dAtA2 := make([]byte, len(m.Epochs)*10)
+		var j1 int
+		for _, num := range m.Epochs {
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintMessage(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.SessionID) > 0 {
+		i -= len(m.SessionID)
+		copy(dAtA[i:], m.SessionID)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.SessionID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.SenderID) > 0 {
+		i -= len(m.SenderID)
+		copy(dAtA[i:], m.SenderID)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.SenderID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintMessage(dAtA []byte, offset int, v uint64) int {
 	offset -= sovMessage(v)
 	base := offset
@@ -636,6 +974,10 @@ func (m *ReadyMessage) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovMessage(uint64(l))
 	}
+	l = len(m.SessionID)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
 	return n
 }
 
@@ -672,16 +1014,63 @@ func (m *LiquidationRecoveryAnnounceMessage) Size() (n int) {
 	return n
 }
 
-func sovMessage(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozMessage(x uint64) (n int) {
-	return sovMessage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (this *TSSProtocolMessage) String() string {
-	if this == nil {
-		return "nil"
-	}
+func (m *ReadyConfirmationMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SenderID)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if len(m.ReadyMemberIDs) > 0 {
+		for _, b := range m.ReadyMemberIDs {
+			l = len(b)
+			n += 1 + l + sovMessage(uint64(l))
+		}
+	}
+	l = len(m.SessionID)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	return n
+}
+
+func (m *KeyShareEpochsMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SenderID)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	l = len(m.SessionID)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if len(m.Epochs) > 0 {
+		l = 0
+		for _, e := range m.Epochs {
+			l += sovMessage(uint64(e))
+		}
+		n += 1 + sovMessage(uint64(l)) + l
+	}
+	return n
+}
+
+func sovMessage(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozMessage(x uint64) (n int) {
+	return sovMessage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *TSSProtocolMessage) String() string {
+	if this == nil {
+		return "nil"
+	}
 	s := strings.Join([]string{`&TSSProtocolMessage{`,
 		`SenderID:` + fmt.Sprintf("%v", this.SenderID) + `,`,
 		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
@@ -697,6 +1086,7 @@ func (this *ReadyMessage) String() string {
 	}
 	s := strings.Join([]string{`&ReadyMessage{`,
 		`SenderID:` + fmt.Sprintf("%v", this.SenderID) + `,`,
+		`SessionID:` + fmt.Sprintf("%v", this.SessionID) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -723,6 +1113,30 @@ func (this *LiquidationRecoveryAnnounceMessage) String() string {
 	}, "")
 	return s
 }
+func (this *ReadyConfirmationMessage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ReadyConfirmationMessage{`,
+		`SenderID:` + fmt.Sprintf("%v", this.SenderID) + `,`,
+		`ReadyMemberIDs:` + fmt.Sprintf("%v", this.ReadyMemberIDs) + `,`,
+		`SessionID:` + fmt.Sprintf("%v", this.SessionID) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *KeyShareEpochsMessage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&KeyShareEpochsMessage{`,
+		`SenderID:` + fmt.Sprintf("%v", this.SenderID) + `,`,
+		`SessionID:` + fmt.Sprintf("%v", this.SessionID) + `,`,
+		`Epochs:` + fmt.Sprintf("%v", this.Epochs) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringMessage(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -964,6 +1378,38 @@ func (m *ReadyMessage) Unmarshal(dAtA []byte) error {
 				m.SenderID = []byte{}
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SessionID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SessionID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMessage(dAtA[iNdEx:])
@@ -1204,6 +1650,346 @@ func (m *LiquidationRecoveryAnnounceMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ReadyConfirmationMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReadyConfirmationMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReadyConfirmationMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SenderID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SenderID = append(m.SenderID[:0], dAtA[iNdEx:postIndex]...)
+			if m.SenderID == nil {
+				m.SenderID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadyMemberIDs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReadyMemberIDs = append(m.ReadyMemberIDs, make([]byte, postIndex-iNdEx))
+			copy(m.ReadyMemberIDs[len(m.ReadyMemberIDs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SessionID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SessionID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KeyShareEpochsMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KeyShareEpochsMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KeyShareEpochsMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SenderID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SenderID = append(m.SenderID[:0], dAtA[iNdEx:postIndex]...)
+			if m.SenderID == nil {
+				m.SenderID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SessionID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SessionID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowMessage
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Epochs = append(m.Epochs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowMessage
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthMessage
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthMessage
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Epochs) == 0 {
+					m.Epochs = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowMessage
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Epochs = append(m.Epochs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Epochs", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipMessage(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
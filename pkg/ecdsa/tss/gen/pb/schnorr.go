@@ -0,0 +1,16 @@
+package pb
+
+import "github.com/binance-chain/tss-lib/common"
+
+// ValidateBasic checks if the message carries both nonce commitments, each
+// of them as a pair of point coordinates.
+func (m *SchnorrSigningRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetNonceCommitmentD(), 2) &&
+		common.NonEmptyMultiBytes(m.GetNonceCommitmentE(), 2)
+}
+
+// ValidateBasic checks if the message carries a signature share.
+func (m *SchnorrSigningRound2Message) ValidateBasic() bool {
+	return m != nil && common.NonEmptyBytes(m.GetSignatureShare())
+}
@@ -6,12 +6,13 @@ package pb
 import (
 	bytes "bytes"
 	fmt "fmt"
-	proto "github.com/gogo/protobuf/proto"
 	io "io"
 	math "math"
 	math_bits "math/bits"
 	reflect "reflect"
 	strings "strings"
+
+	proto "github.com/gogo/protobuf/proto"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -26,8 +27,10 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type ThresholdSigner struct {
-	GroupInfo    *ThresholdSigner_GroupInfo `protobuf:"bytes,1,opt,name=groupInfo,proto3" json:"groupInfo,omitempty"`
-	ThresholdKey []byte                     `protobuf:"bytes,2,opt,name=thresholdKey,proto3" json:"thresholdKey,omitempty"`
+	GroupInfo            *ThresholdSigner_GroupInfo `protobuf:"bytes,1,opt,name=groupInfo,proto3" json:"groupInfo,omitempty"`
+	ThresholdKey         []byte                     `protobuf:"bytes,2,opt,name=thresholdKey,proto3" json:"thresholdKey,omitempty"`
+	RefreshEpoch         uint64                     `protobuf:"varint,3,opt,name=refreshEpoch,proto3" json:"refreshEpoch,omitempty"`
+	PreviousThresholdKey []byte                     `protobuf:"bytes,4,opt,name=previousThresholdKey,proto3" json:"previousThresholdKey,omitempty"`
 }
 
 func (m *ThresholdSigner) Reset()      { *m = ThresholdSigner{} }
@@ -76,6 +79,20 @@ func (m *ThresholdSigner) GetThresholdKey() []byte {
 	return nil
 }
 
+func (m *ThresholdSigner) GetRefreshEpoch() uint64 {
+	if m != nil {
+		return m.RefreshEpoch
+	}
+	return 0
+}
+
+func (m *ThresholdSigner) GetPreviousThresholdKey() []byte {
+	if m != nil {
+		return m.PreviousThresholdKey
+	}
+	return nil
+}
+
 type ThresholdSigner_GroupInfo struct {
 	GroupID            string   `protobuf:"bytes,1,opt,name=groupID,proto3" json:"groupID,omitempty"`
 	MemberID           []byte   `protobuf:"bytes,2,opt,name=memberID,proto3" json:"memberID,omitempty"`
@@ -512,6 +529,73 @@ func (m *LocalPartySaveData_ECPoint) GetY() []byte {
 	return nil
 }
 
+type EdDSALocalPartySaveData struct {
+	LocalSecrets *LocalPartySaveData_LocalSecrets `protobuf:"bytes,1,opt,name=localSecrets,proto3" json:"localSecrets,omitempty"`
+	Ks           [][]byte                         `protobuf:"bytes,2,rep,name=ks,proto3" json:"ks,omitempty"`
+	BigXj        []*LocalPartySaveData_ECPoint    `protobuf:"bytes,3,rep,name=bigXj,proto3" json:"bigXj,omitempty"`
+	EddsaPub     *LocalPartySaveData_ECPoint      `protobuf:"bytes,4,opt,name=eddsaPub,proto3" json:"eddsaPub,omitempty"`
+}
+
+func (m *EdDSALocalPartySaveData) Reset()      { *m = EdDSALocalPartySaveData{} }
+func (*EdDSALocalPartySaveData) ProtoMessage() {}
+func (*EdDSALocalPartySaveData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_362f9e86e7c5d639, []int{2}
+}
+func (m *EdDSALocalPartySaveData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EdDSALocalPartySaveData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EdDSALocalPartySaveData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EdDSALocalPartySaveData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EdDSALocalPartySaveData.Merge(m, src)
+}
+func (m *EdDSALocalPartySaveData) XXX_Size() int {
+	return m.Size()
+}
+func (m *EdDSALocalPartySaveData) XXX_DiscardUnknown() {
+	xxx_messageInfo_EdDSALocalPartySaveData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EdDSALocalPartySaveData proto.InternalMessageInfo
+
+func (m *EdDSALocalPartySaveData) GetLocalSecrets() *LocalPartySaveData_LocalSecrets {
+	if m != nil {
+		return m.LocalSecrets
+	}
+	return nil
+}
+
+func (m *EdDSALocalPartySaveData) GetKs() [][]byte {
+	if m != nil {
+		return m.Ks
+	}
+	return nil
+}
+
+func (m *EdDSALocalPartySaveData) GetBigXj() []*LocalPartySaveData_ECPoint {
+	if m != nil {
+		return m.BigXj
+	}
+	return nil
+}
+
+func (m *EdDSALocalPartySaveData) GetEddsaPub() *LocalPartySaveData_ECPoint {
+	if m != nil {
+		return m.EddsaPub
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ThresholdSigner)(nil), "tss.ThresholdSigner")
 	proto.RegisterType((*ThresholdSigner_GroupInfo)(nil), "tss.ThresholdSigner.GroupInfo")
@@ -520,51 +604,56 @@ func init() {
 	proto.RegisterType((*LocalPartySaveData_LocalPreParams_PrivateKey)(nil), "tss.LocalPartySaveData.LocalPreParams.PrivateKey")
 	proto.RegisterType((*LocalPartySaveData_LocalSecrets)(nil), "tss.LocalPartySaveData.LocalSecrets")
 	proto.RegisterType((*LocalPartySaveData_ECPoint)(nil), "tss.LocalPartySaveData.ECPoint")
+	proto.RegisterType((*EdDSALocalPartySaveData)(nil), "tss.EdDSALocalPartySaveData")
 }
 
 func init() { proto.RegisterFile("pb/signer.proto", fileDescriptor_362f9e86e7c5d639) }
 
 var fileDescriptor_362f9e86e7c5d639 = []byte{
-	// 618 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xcf, 0x6f, 0xd3, 0x30,
-	0x14, 0xc7, 0xeb, 0x74, 0xfd, 0xf5, 0x5a, 0x75, 0xc8, 0x42, 0xc8, 0xaa, 0x90, 0x89, 0x26, 0x98,
-	0x7a, 0x0a, 0x5a, 0x11, 0xd2, 0x24, 0x38, 0xc1, 0x10, 0x4c, 0x1d, 0x53, 0x48, 0x77, 0x98, 0xb8,
-	0x39, 0xad, 0x59, 0xdc, 0xa5, 0x4d, 0x66, 0x67, 0x53, 0x7b, 0xe3, 0x3f, 0x80, 0x2b, 0xff, 0x01,
-	0xff, 0x00, 0x67, 0xae, 0x1c, 0x77, 0xdc, 0x91, 0x65, 0x17, 0x8e, 0xfb, 0x13, 0x50, 0x1c, 0xa7,
-	0xdb, 0xca, 0xcf, 0x9b, 0xbf, 0x9f, 0xbc, 0xf7, 0xfc, 0xfc, 0x7d, 0x76, 0x60, 0x35, 0xf6, 0x1f,
-	0x2a, 0x71, 0x30, 0xe5, 0xd2, 0x89, 0x65, 0x94, 0x44, 0xb8, 0x9c, 0x28, 0xb5, 0xf6, 0xc1, 0x82,
-	0xd5, 0xbd, 0x40, 0x72, 0x15, 0x44, 0xe1, 0x68, 0xa0, 0x3f, 0xe3, 0xa7, 0xd0, 0x38, 0x90, 0xd1,
-	0x71, 0xbc, 0x3d, 0x7d, 0x17, 0x11, 0x64, 0xa3, 0x6e, 0xb3, 0x47, 0x9d, 0x44, 0x29, 0x67, 0x29,
-	0xd0, 0x79, 0x59, 0x44, 0x79, 0x57, 0x09, 0x78, 0x0d, 0x5a, 0x49, 0x11, 0xd7, 0xe7, 0x73, 0x62,
-	0xd9, 0xa8, 0xdb, 0xf2, 0x6e, 0xb0, 0xce, 0x27, 0x04, 0x8d, 0x45, 0x32, 0x26, 0x50, 0xcb, 0xd3,
-	0xb7, 0xf4, 0x6e, 0x0d, 0xaf, 0x90, 0xb8, 0x03, 0xf5, 0x09, 0x9f, 0xf8, 0x5c, 0x6e, 0x6f, 0x99,
-	0x3a, 0x0b, 0x8d, 0xd7, 0xa1, 0xad, 0xc3, 0x5e, 0x1b, 0xa0, 0x48, 0xd9, 0x2e, 0x77, 0x5b, 0xde,
-	0x12, 0xc5, 0x0e, 0xe0, 0x91, 0x50, 0x41, 0x34, 0xe5, 0x2a, 0x59, 0x1c, 0x80, 0xac, 0xd8, 0xa8,
-	0x5b, 0xf1, 0x7e, 0xf3, 0x65, 0xed, 0x4b, 0x15, 0xf0, 0x4e, 0x34, 0x64, 0xa1, 0xcb, 0x64, 0x32,
-	0x1f, 0xb0, 0x13, 0xbe, 0xc5, 0x12, 0x86, 0x77, 0xa1, 0x1d, 0x6a, 0x2a, 0xb9, 0xcb, 0x24, 0x9b,
-	0x28, 0xe3, 0xcc, 0xba, 0x76, 0xe6, 0xd7, 0x04, 0x83, 0x8a, 0x68, 0x6f, 0x29, 0x1b, 0xbf, 0x82,
-	0x96, 0x26, 0x03, 0x3e, 0x94, 0x3c, 0x51, 0xfa, 0x78, 0xcd, 0xde, 0xfd, 0xbf, 0x56, 0x33, 0xb1,
-	0xde, 0x8d, 0x4c, 0xdc, 0x06, 0xeb, 0xb0, 0x38, 0xbc, 0x75, 0xa8, 0x32, 0x3b, 0xa7, 0x7b, 0x22,
-	0x1c, 0xf1, 0x31, 0x59, 0xd1, 0xb0, 0x90, 0xf8, 0x16, 0x94, 0x83, 0x8d, 0x31, 0xa9, 0x68, 0x9a,
-	0x2d, 0x35, 0xe9, 0x8d, 0x49, 0xd5, 0x90, 0xde, 0x18, 0x3f, 0x86, 0x8a, 0x2f, 0x0e, 0xf6, 0xc7,
-	0xa4, 0x66, 0x97, 0xbb, 0xcd, 0xde, 0xbd, 0x3f, 0x35, 0xf4, 0xe2, 0xb9, 0x1b, 0x89, 0x69, 0xe2,
-	0xe5, 0xd1, 0xd8, 0x86, 0x66, 0xcc, 0x44, 0x18, 0x0a, 0x2e, 0xdd, 0xbe, 0x22, 0x75, 0x5d, 0xf0,
-	0x3a, 0xc2, 0x4f, 0xa0, 0xce, 0x87, 0x23, 0xc5, 0xdc, 0x63, 0x9f, 0x34, 0xf4, 0x61, 0xff, 0x59,
-	0x7b, 0x91, 0xd0, 0xf9, 0x6a, 0x41, 0xfb, 0xa6, 0xa1, 0xf8, 0x0d, 0x40, 0x51, 0x7e, 0xd0, 0x37,
-	0xc3, 0xd8, 0xf8, 0xbf, 0x61, 0x38, 0xae, 0x14, 0x27, 0x2c, 0xe1, 0x7d, 0x3e, 0xf7, 0xae, 0x15,
-	0xc1, 0x77, 0xa0, 0x9a, 0x5b, 0x65, 0x2e, 0x9b, 0x51, 0xb9, 0x6f, 0x82, 0x94, 0x35, 0xcc, 0x96,
-	0xb9, 0x6f, 0x42, 0xdf, 0x22, 0xed, 0x9b, 0xc0, 0xb7, 0xa1, 0xc2, 0xc2, 0x38, 0x60, 0xa4, 0xa2,
-	0x59, 0x2e, 0x30, 0x86, 0x15, 0x9f, 0x27, 0x8c, 0x54, 0x35, 0xd4, 0x6b, 0xdc, 0x02, 0x14, 0x93,
-	0x9a, 0x06, 0x28, 0xce, 0xd4, 0x11, 0xa9, 0xe7, 0xea, 0xa8, 0xb3, 0x0f, 0x70, 0xd5, 0x1b, 0xbe,
-	0x0b, 0x8d, 0xf8, 0xd8, 0x0f, 0xc5, 0x30, 0x7b, 0x47, 0x48, 0xc7, 0x5c, 0x81, 0x6c, 0xce, 0x21,
-	0x9b, 0xf8, 0x23, 0xb6, 0x6b, 0xda, 0x2d, 0x64, 0xb6, 0x6b, 0x1c, 0x88, 0x5d, 0xd3, 0xb0, 0x5e,
-	0x77, 0x36, 0xa1, 0xb5, 0xb3, 0x74, 0x6b, 0x66, 0xc2, 0x14, 0xb5, 0x66, 0x22, 0xab, 0xa6, 0x02,
-	0x26, 0xf9, 0xe2, 0xa5, 0x15, 0xb2, 0xf3, 0x00, 0x6a, 0x66, 0x20, 0x59, 0xb3, 0x33, 0x93, 0x83,
-	0x66, 0x99, 0x2a, 0x9e, 0x37, 0x9a, 0x3f, 0xdb, 0x3c, 0x3d, 0xa7, 0xa5, 0xb3, 0x73, 0x5a, 0xba,
-	0x3c, 0xa7, 0xe8, 0x7d, 0x4a, 0xd1, 0xe7, 0x94, 0xa2, 0x6f, 0x29, 0x45, 0xa7, 0x29, 0x45, 0xdf,
-	0x53, 0x8a, 0x7e, 0xa4, 0xb4, 0x74, 0x99, 0x52, 0xf4, 0xf1, 0x82, 0x96, 0x4e, 0x2f, 0x68, 0xe9,
-	0xec, 0x82, 0x96, 0xde, 0x5a, 0xb1, 0xef, 0x57, 0xf5, 0xff, 0xe8, 0xd1, 0xcf, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x2a, 0x91, 0x3c, 0xcc, 0xa2, 0x04, 0x00, 0x00,
+	// 688 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xcd, 0x6e, 0x13, 0x3b,
+	0x18, 0x8d, 0x27, 0xff, 0x5f, 0xa2, 0xf4, 0xca, 0xaa, 0xee, 0xb5, 0xa2, 0x2b, 0xdf, 0x28, 0xba,
+	0x54, 0x59, 0x05, 0x35, 0x08, 0xa9, 0x12, 0x6c, 0x80, 0x54, 0x50, 0xa5, 0x54, 0x61, 0xd2, 0x45,
+	0xc5, 0xce, 0x93, 0xb8, 0x1d, 0xa7, 0x93, 0xcc, 0xd4, 0x9e, 0x54, 0xc9, 0x8e, 0x47, 0x60, 0xcb,
+	0x1b, 0xf0, 0x02, 0xac, 0xd9, 0xb2, 0xec, 0xb2, 0x4b, 0x9a, 0x2e, 0xca, 0xb2, 0x8f, 0x80, 0xc6,
+	0xf1, 0x4c, 0x9b, 0x50, 0x50, 0x11, 0x3b, 0x9f, 0xe3, 0xef, 0x3b, 0x73, 0xfc, 0xf9, 0x78, 0x60,
+	0x2d, 0x70, 0x1e, 0x2a, 0x71, 0x34, 0xe6, 0xb2, 0x19, 0x48, 0x3f, 0xf4, 0x71, 0x3a, 0x54, 0xaa,
+	0x7e, 0x65, 0xc1, 0xda, 0xbe, 0x2b, 0xb9, 0x72, 0x7d, 0x6f, 0xd0, 0xd3, 0xdb, 0xf8, 0x29, 0x14,
+	0x8f, 0xa4, 0x3f, 0x09, 0x76, 0xc6, 0x87, 0x3e, 0x41, 0x35, 0xd4, 0x28, 0xb5, 0x68, 0x33, 0x54,
+	0xaa, 0xb9, 0x52, 0xd8, 0x7c, 0x19, 0x57, 0xd9, 0x37, 0x0d, 0xb8, 0x0e, 0xe5, 0x30, 0xae, 0xeb,
+	0xf0, 0x19, 0xb1, 0x6a, 0xa8, 0x51, 0xb6, 0x97, 0xb8, 0xa8, 0x46, 0xf2, 0xc3, 0x88, 0xd8, 0x0e,
+	0xfc, 0xbe, 0x4b, 0xd2, 0x35, 0xd4, 0xc8, 0xd8, 0x4b, 0x1c, 0x6e, 0xc1, 0x7a, 0x20, 0xf9, 0xa9,
+	0xf0, 0x27, 0x6a, 0xff, 0xb6, 0x5e, 0x46, 0xeb, 0xdd, 0xb9, 0x57, 0xfd, 0x80, 0xa0, 0x98, 0x98,
+	0xc2, 0x04, 0xf2, 0x0b, 0x5b, 0x6d, 0x7d, 0x8a, 0xa2, 0x1d, 0x43, 0x5c, 0x85, 0xc2, 0x88, 0x8f,
+	0x1c, 0x2e, 0x77, 0xda, 0xc6, 0x5f, 0x82, 0xf1, 0x06, 0x54, 0x74, 0xd9, 0x6b, 0x43, 0x28, 0x92,
+	0xae, 0xa5, 0x1b, 0x65, 0x7b, 0x85, 0xc5, 0x4d, 0xc0, 0x03, 0xa1, 0x5c, 0x7f, 0xcc, 0x55, 0x98,
+	0x98, 0xd0, 0xee, 0xb2, 0xf6, 0x1d, 0x3b, 0xf5, 0x4f, 0x39, 0xc0, 0xbb, 0x7e, 0x9f, 0x79, 0x5d,
+	0x26, 0xc3, 0x59, 0x8f, 0x9d, 0xf2, 0x36, 0x0b, 0x19, 0xde, 0x83, 0x8a, 0xa7, 0x59, 0xc9, 0xbb,
+	0x4c, 0xb2, 0x91, 0x32, 0x13, 0xdf, 0xd0, 0x13, 0xff, 0xb1, 0xa1, 0xb9, 0xbb, 0x54, 0x6d, 0xaf,
+	0x74, 0xe3, 0x57, 0x50, 0xd6, 0x4c, 0x8f, 0xf7, 0x25, 0x0f, 0x95, 0x3e, 0x5e, 0xa9, 0xf5, 0xff,
+	0x2f, 0xd5, 0x4c, 0xad, 0xbd, 0xd4, 0x89, 0x2b, 0x60, 0x1d, 0xc7, 0x87, 0xb7, 0x8e, 0x55, 0x34,
+	0xce, 0xf1, 0xbe, 0xf0, 0x06, 0x7c, 0x48, 0x32, 0x9a, 0x8c, 0x21, 0xfe, 0x0b, 0xd2, 0xee, 0xe6,
+	0x90, 0x64, 0x35, 0x1b, 0x2d, 0x35, 0xd3, 0x1a, 0x92, 0x9c, 0x61, 0x5a, 0x43, 0xfc, 0x18, 0xb2,
+	0x8e, 0x38, 0x3a, 0x18, 0x92, 0x7c, 0x2d, 0xdd, 0x28, 0xb5, 0xfe, 0xfb, 0x99, 0xa1, 0xed, 0x17,
+	0x5d, 0x5f, 0x8c, 0x43, 0x7b, 0x51, 0x8d, 0x6b, 0x50, 0x0a, 0x98, 0xf0, 0x3c, 0xc1, 0x65, 0xb7,
+	0xa3, 0x48, 0x41, 0x0b, 0xde, 0xa6, 0xf0, 0x13, 0x28, 0xf0, 0xfe, 0x40, 0xb1, 0xee, 0xc4, 0x21,
+	0xc5, 0x1a, 0xba, 0x8f, 0x76, 0xd2, 0x50, 0xfd, 0x6c, 0x41, 0x65, 0x79, 0xa0, 0xf8, 0x0d, 0x40,
+	0x2c, 0xdf, 0xeb, 0x98, 0xcb, 0xd8, 0xbc, 0xdf, 0x65, 0x34, 0xbb, 0x52, 0x9c, 0xb2, 0x90, 0x77,
+	0xf8, 0xcc, 0xbe, 0x25, 0x82, 0xff, 0x86, 0xdc, 0x62, 0x54, 0x26, 0x6c, 0x06, 0x2d, 0xe6, 0x26,
+	0x74, 0xfa, 0xf5, 0xdc, 0xc4, 0x62, 0x6e, 0xc2, 0x64, 0x3c, 0x5a, 0xe2, 0x75, 0xc8, 0x32, 0x2f,
+	0x70, 0x19, 0xc9, 0x6a, 0x6e, 0x01, 0x30, 0x86, 0x8c, 0xc3, 0x43, 0x46, 0x72, 0x9a, 0xd4, 0x6b,
+	0x5c, 0x06, 0x14, 0x90, 0xbc, 0x26, 0x50, 0x10, 0xa1, 0x13, 0x52, 0x58, 0xa0, 0x93, 0xea, 0x01,
+	0xc0, 0x8d, 0x37, 0xfc, 0x2f, 0x14, 0x83, 0x89, 0xe3, 0x89, 0x7e, 0xf4, 0x9e, 0x90, 0xae, 0xb9,
+	0x21, 0xa2, 0x7b, 0xf6, 0xd8, 0xc8, 0x19, 0xb0, 0x3d, 0x63, 0x37, 0x86, 0xd1, 0x57, 0x03, 0x57,
+	0xec, 0x19, 0xc3, 0x7a, 0x5d, 0xdd, 0x82, 0xf2, 0xee, 0x4a, 0x6a, 0xa6, 0xc2, 0x88, 0x5a, 0x53,
+	0x11, 0xa9, 0x29, 0x97, 0x49, 0x9e, 0xbc, 0xb4, 0x18, 0x56, 0x1f, 0x40, 0xde, 0x5c, 0x48, 0x64,
+	0x76, 0x6a, 0x7a, 0xd0, 0x34, 0x42, 0xf1, 0x6f, 0x03, 0xcd, 0xea, 0x57, 0x08, 0xfe, 0xd9, 0x1e,
+	0xb4, 0x7b, 0xcf, 0xee, 0x78, 0x3c, 0xab, 0x61, 0x47, 0x7f, 0x18, 0x76, 0x2b, 0x09, 0x7b, 0x12,
+	0xd7, 0xf4, 0x6f, 0xc5, 0x35, 0x0a, 0xe3, 0xc0, 0x84, 0x31, 0x73, 0xdf, 0x30, 0x9a, 0x86, 0xe7,
+	0x5b, 0x67, 0x17, 0x34, 0x75, 0x7e, 0x41, 0x53, 0xd7, 0x17, 0x14, 0xbd, 0x9b, 0x53, 0xf4, 0x71,
+	0x4e, 0xd1, 0x97, 0x39, 0x45, 0x67, 0x73, 0x8a, 0xbe, 0xce, 0x29, 0xfa, 0x36, 0xa7, 0xa9, 0xeb,
+	0x39, 0x45, 0xef, 0x2f, 0x69, 0xea, 0xec, 0x92, 0xa6, 0xce, 0x2f, 0x69, 0xea, 0xad, 0x15, 0x38,
+	0x4e, 0x4e, 0xff, 0xd1, 0x1f, 0x7d, 0x1f, 0x00, 0x22, 0x56, 0xa8, 0xcd, 0xe4, 0x05, 0x00, 0x00,
 }
 
 func (this *ThresholdSigner) Equal(that interface{}) bool {
@@ -592,6 +681,12 @@ func (this *ThresholdSigner) Equal(that interface{}) bool {
 	if !bytes.Equal(this.ThresholdKey, that1.ThresholdKey) {
 		return false
 	}
+	if this.RefreshEpoch != that1.RefreshEpoch {
+		return false
+	}
+	if !bytes.Equal(this.PreviousThresholdKey, that1.PreviousThresholdKey) {
+		return false
+	}
 	return true
 }
 func (this *ThresholdSigner_GroupInfo) Equal(that interface{}) bool {
@@ -839,16 +934,61 @@ func (this *LocalPartySaveData_ECPoint) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *EdDSALocalPartySaveData) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*EdDSALocalPartySaveData)
+	if !ok {
+		that2, ok := that.(EdDSALocalPartySaveData)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.LocalSecrets.Equal(that1.LocalSecrets) {
+		return false
+	}
+	if len(this.Ks) != len(that1.Ks) {
+		return false
+	}
+	for i := range this.Ks {
+		if !bytes.Equal(this.Ks[i], that1.Ks[i]) {
+			return false
+		}
+	}
+	if len(this.BigXj) != len(that1.BigXj) {
+		return false
+	}
+	for i := range this.BigXj {
+		if !this.BigXj[i].Equal(that1.BigXj[i]) {
+			return false
+		}
+	}
+	if !this.EddsaPub.Equal(that1.EddsaPub) {
+		return false
+	}
+	return true
+}
 func (this *ThresholdSigner) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 6)
+	s := make([]string, 0, 8)
 	s = append(s, "&pb.ThresholdSigner{")
 	if this.GroupInfo != nil {
 		s = append(s, "GroupInfo: "+fmt.Sprintf("%#v", this.GroupInfo)+",\n")
 	}
 	s = append(s, "ThresholdKey: "+fmt.Sprintf("%#v", this.ThresholdKey)+",\n")
+	s = append(s, "RefreshEpoch: "+fmt.Sprintf("%#v", this.RefreshEpoch)+",\n")
+	s = append(s, "PreviousThresholdKey: "+fmt.Sprintf("%#v", this.PreviousThresholdKey)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -944,6 +1084,25 @@ func (this *LocalPartySaveData_ECPoint) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *EdDSALocalPartySaveData) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.EdDSALocalPartySaveData{")
+	if this.LocalSecrets != nil {
+		s = append(s, "LocalSecrets: "+fmt.Sprintf("%#v", this.LocalSecrets)+",\n")
+	}
+	s = append(s, "Ks: "+fmt.Sprintf("%#v", this.Ks)+",\n")
+	if this.BigXj != nil {
+		s = append(s, "BigXj: "+fmt.Sprintf("%#v", this.BigXj)+",\n")
+	}
+	if this.EddsaPub != nil {
+		s = append(s, "EddsaPub: "+fmt.Sprintf("%#v", this.EddsaPub)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func valueToGoStringSigner(v interface{}, typ string) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -972,6 +1131,18 @@ func (m *ThresholdSigner) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.PreviousThresholdKey) > 0 {
+		i -= len(m.PreviousThresholdKey)
+		copy(dAtA[i:], m.PreviousThresholdKey)
+		i = encodeVarintSigner(dAtA, i, uint64(len(m.PreviousThresholdKey)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.RefreshEpoch != 0 {
+		i = encodeVarintSigner(dAtA, i, uint64(m.RefreshEpoch))
+		i--
+		dAtA[i] = 0x18
+	}
 	if len(m.ThresholdKey) > 0 {
 		i -= len(m.ThresholdKey)
 		copy(dAtA[i:], m.ThresholdKey)
@@ -1365,6 +1536,76 @@ func (m *LocalPartySaveData_ECPoint) MarshalToSizedBuffer(dAtA []byte) (int, err
 	return len(dAtA) - i, nil
 }
 
+func (m *EdDSALocalPartySaveData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EdDSALocalPartySaveData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EdDSALocalPartySaveData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.EddsaPub != nil {
+		{
+			size, err := m.EddsaPub.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSigner(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.BigXj) > 0 {
+		for iNdEx := len(m.BigXj) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.BigXj[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSigner(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Ks) > 0 {
+		for iNdEx := len(m.Ks) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Ks[iNdEx])
+			copy(dAtA[i:], m.Ks[iNdEx])
+			i = encodeVarintSigner(dAtA, i, uint64(len(m.Ks[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.LocalSecrets != nil {
+		{
+			size, err := m.LocalSecrets.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSigner(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintSigner(dAtA []byte, offset int, v uint64) int {
 	offset -= sovSigner(v)
 	base := offset
@@ -1390,6 +1631,13 @@ func (m *ThresholdSigner) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovSigner(uint64(l))
 	}
+	if m.RefreshEpoch != 0 {
+		n += 1 + sovSigner(uint64(m.RefreshEpoch))
+	}
+	l = len(m.PreviousThresholdKey)
+	if l > 0 {
+		n += 1 + l + sovSigner(uint64(l))
+	}
 	return n
 }
 
@@ -1572,6 +1820,35 @@ func (m *LocalPartySaveData_ECPoint) Size() (n int) {
 	return n
 }
 
+func (m *EdDSALocalPartySaveData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LocalSecrets != nil {
+		l = m.LocalSecrets.Size()
+		n += 1 + l + sovSigner(uint64(l))
+	}
+	if len(m.Ks) > 0 {
+		for _, b := range m.Ks {
+			l = len(b)
+			n += 1 + l + sovSigner(uint64(l))
+		}
+	}
+	if len(m.BigXj) > 0 {
+		for _, e := range m.BigXj {
+			l = e.Size()
+			n += 1 + l + sovSigner(uint64(l))
+		}
+	}
+	if m.EddsaPub != nil {
+		l = m.EddsaPub.Size()
+		n += 1 + l + sovSigner(uint64(l))
+	}
+	return n
+}
+
 func sovSigner(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1585,6 +1862,8 @@ func (this *ThresholdSigner) String() string {
 	s := strings.Join([]string{`&ThresholdSigner{`,
 		`GroupInfo:` + strings.Replace(fmt.Sprintf("%v", this.GroupInfo), "ThresholdSigner_GroupInfo", "ThresholdSigner_GroupInfo", 1) + `,`,
 		`ThresholdKey:` + fmt.Sprintf("%v", this.ThresholdKey) + `,`,
+		`RefreshEpoch:` + fmt.Sprintf("%v", this.RefreshEpoch) + `,`,
+		`PreviousThresholdKey:` + fmt.Sprintf("%v", this.PreviousThresholdKey) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1676,6 +1955,24 @@ func (this *LocalPartySaveData_ECPoint) String() string {
 	}, "")
 	return s
 }
+func (this *EdDSALocalPartySaveData) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForBigXj := "[]*LocalPartySaveData_ECPoint{"
+	for _, f := range this.BigXj {
+		repeatedStringForBigXj += strings.Replace(fmt.Sprintf("%v", f), "LocalPartySaveData_ECPoint", "LocalPartySaveData_ECPoint", 1) + ","
+	}
+	repeatedStringForBigXj += "}"
+	s := strings.Join([]string{`&EdDSALocalPartySaveData{`,
+		`LocalSecrets:` + strings.Replace(fmt.Sprintf("%v", this.LocalSecrets), "LocalPartySaveData_LocalSecrets", "LocalPartySaveData_LocalSecrets", 1) + `,`,
+		`Ks:` + fmt.Sprintf("%v", this.Ks) + `,`,
+		`BigXj:` + repeatedStringForBigXj + `,`,
+		`EddsaPub:` + strings.Replace(fmt.Sprintf("%v", this.EddsaPub), "LocalPartySaveData_ECPoint", "LocalPartySaveData_ECPoint", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringSigner(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -1783,6 +2080,59 @@ func (m *ThresholdSigner) Unmarshal(dAtA []byte) error {
 				m.ThresholdKey = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefreshEpoch", wireType)
+			}
+			m.RefreshEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSigner
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RefreshEpoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreviousThresholdKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSigner
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSigner
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSigner
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PreviousThresholdKey = append(m.PreviousThresholdKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PreviousThresholdKey == nil {
+				m.PreviousThresholdKey = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSigner(dAtA[iNdEx:])
@@ -3035,6 +3385,194 @@ func (m *LocalPartySaveData_ECPoint) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *EdDSALocalPartySaveData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSigner
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EdDSALocalPartySaveData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EdDSALocalPartySaveData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LocalSecrets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSigner
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSigner
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSigner
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LocalSecrets == nil {
+				m.LocalSecrets = &LocalPartySaveData_LocalSecrets{}
+			}
+			if err := m.LocalSecrets.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ks", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSigner
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSigner
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSigner
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ks = append(m.Ks, make([]byte, postIndex-iNdEx))
+			copy(m.Ks[len(m.Ks)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BigXj", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSigner
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSigner
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSigner
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BigXj = append(m.BigXj, &LocalPartySaveData_ECPoint{})
+			if err := m.BigXj[len(m.BigXj)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EddsaPub", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSigner
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSigner
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSigner
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EddsaPub == nil {
+				m.EddsaPub = &LocalPartySaveData_ECPoint{}
+			}
+			if err := m.EddsaPub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSigner(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSigner
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipSigner(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -14,10 +14,17 @@ import (
 // It times out after defined period if the required parameters could not be generated.
 // It is possible to generate the parameters way ahead of the TSS protocol
 // execution.
+//
+// Concurrency determines the number of concurrent routines used to generate
+// the parameters, roughly corresponding to the number of CPUs used.
 func GenerateTSSPreParams(
 	preParamsGenerationTimeout time.Duration,
+	concurrency int,
 ) (*keygen.LocalPreParams, error) {
-	preParams, err := keygen.GeneratePreParams(preParamsGenerationTimeout)
+	preParams, err := keygen.GeneratePreParams(
+		preParamsGenerationTimeout,
+		concurrency,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate tss pre-params: [%v]", err)
 	}
@@ -76,7 +83,10 @@ type member struct {
 // needs to be executed only after all members finished the initialization stage.
 // As a result it will return a Signer who has completed key generation, or error
 // if the key generation failed.
-func (s *member) generateKey(ctx context.Context) (*ThresholdSigner, error) {
+func (s *member) generateKey(
+	ctx context.Context,
+	timeout time.Duration,
+) (*ThresholdSigner, error) {
 	if err := s.keygenParty.Start(); err != nil {
 		return nil, fmt.Errorf(
 			"failed to start key generation: [%v]",
@@ -94,32 +104,22 @@ func (s *member) generateKey(ctx context.Context) (*ThresholdSigner, error) {
 
 			return signer, nil
 		case <-ctx.Done():
-			memberIDs := []MemberID{}
-
-			if s.keygenParty.WaitingFor() != nil {
-				for _, partyID := range s.keygenParty.WaitingFor() {
-					memberID, err := MemberIDFromString(partyID.GetId())
-					if err != nil {
-						logger.Errorf(
-							"cannot get member id from string [%v]: [%v]",
-							partyID.GetId(),
-							err,
-						)
-						continue
-					}
-
-					memberIDs = append(memberIDs, memberID)
-				}
+			return nil, timeoutError{
+				timeout,
+				"key generation",
+				s.networkBridge.blamedCulprits(s.keygenParty),
 			}
-
-			return nil, timeoutError{KeyGenerationProtocolTimeout, "key generation", memberIDs}
 		}
 	}
 }
 
+// generatePartiesIDs generates identifiers of parties for members of the group.
+// Each party is identified by a key returned by the provided function for
+// the member.
 func generatePartiesIDs(
 	thisMemberID MemberID,
 	groupMemberIDs []MemberID,
+	partyKeyFn partyKeyFn,
 ) (
 	*tss.PartyID,
 	[]*tss.PartyID,
@@ -134,9 +134,9 @@ func generatePartiesIDs(
 		}
 
 		newPartyID := tss.NewPartyID(
-			memberID.String(), // id - unique string representing this party in the network
-			"",                // moniker - can be anything (even left blank)
-			memberID.bigInt(), // key - unique identifying key
+			memberID.String(),    // id - unique string representing this party in the network
+			"",                   // moniker - can be anything (even left blank)
+			partyKeyFn(memberID), // key - unique identifying key
 		)
 
 		if thisMemberID.Equal(memberID) {
@@ -165,6 +165,7 @@ func initializeKeyGenerationParty(
 	currentPartyID, groupPartiesIDs, err := generatePartiesIDs(
 		groupInfo.memberID,
 		groupInfo.groupMemberIDs,
+		MemberID.bigInt,
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to generate parties IDs: [%v]", err)
@@ -180,14 +181,18 @@ func initializeKeyGenerationParty(
 
 	party := keygen.NewLocalParty(params, tssMessageChan, endChan, *tssPreParams)
 
-	if err := bridge.connect(
+	// Key generation requires all group members to participate.
+	if err := bridge.connect(ctx, len(groupInfo.groupMemberIDs)); err != nil {
+		return nil, nil, fmt.Errorf("failed to connect bridge network: [%v]", err)
+	}
+
+	bridge.bind(
 		ctx,
 		tssMessageChan,
 		party,
 		params.Parties().IDs(),
-	); err != nil {
-		return nil, nil, fmt.Errorf("failed to connect bridge network: [%v]", err)
-	}
+		ecdsaWireMessageTypes,
+	)
 
 	return party, endChan, nil
 }
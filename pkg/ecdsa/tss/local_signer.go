@@ -0,0 +1,103 @@
+package tss
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	tssLib "github.com/binance-chain/tss-lib/tss"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
+)
+
+// GenerateLocalSigner generates a signer for a group consisting of just one
+// member. There is no other member to run the threshold key generation protocol
+// with so a plain ECDSA key is generated locally and wrapped in a threshold key
+// with a single share. Such a key does not require any pre-parameters.
+//
+// The returned signer can be persisted and restored in the same way as signers
+// generated with GenerateThresholdSigner. Signatures are calculated locally,
+// without any network communication.
+func GenerateLocalSigner(
+	groupID string,
+	memberID MemberID,
+) (*ThresholdSigner, error) {
+	privateKey, err := btcec.NewPrivateKey(tssLib.EC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: [%v]", err)
+	}
+
+	publicKey, err := crypto.NewECPoint(
+		tssLib.EC(),
+		privateKey.PublicKey.X,
+		privateKey.PublicKey.Y,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode public key: [%v]", err)
+	}
+
+	shareID := memberID.bigInt()
+
+	// With a single share the secret sharing polynomial is of degree zero and
+	// the share is equal to the private key.
+	thresholdKey := ThresholdKey{
+		LocalSecrets: keygen.LocalSecrets{
+			Xi:      privateKey.D,
+			ShareID: shareID,
+		},
+		Ks:          []*big.Int{shareID},
+		NTildej:     []*big.Int{},
+		H1j:         []*big.Int{},
+		H2j:         []*big.Int{},
+		BigXj:       []*crypto.ECPoint{publicKey},
+		PaillierPKs: []*paillier.PublicKey{},
+		ECDSAPub:    publicKey,
+	}
+
+	return &ThresholdSigner{
+		groupInfo: &groupInfo{
+			groupID:            groupID,
+			memberID:           memberID,
+			groupMemberIDs:     []MemberID{memberID},
+			dishonestThreshold: 0,
+		},
+		thresholdKey: thresholdKey,
+	}, nil
+}
+
+// isLocal returns true if the signer is the only member of the signing group
+// and it can calculate signatures on its own.
+func (s *ThresholdSigner) isLocal() bool {
+	return len(s.groupMemberIDs) == 1
+}
+
+// calculateSignatureLocally calculates a signature over the given digest with
+// the key of a single-member group. The result is the same as for the threshold
+// signing protocol: a canonical signature with a low `s` value and a recovery
+// ID.
+func (s *ThresholdSigner) calculateSignatureLocally(
+	digest []byte,
+) (*ecdsa.Signature, error) {
+	curve := btcec.S256()
+
+	privateKey, _ := btcec.PrivKeyFromBytes(
+		curve,
+		s.thresholdKey.LocalSecrets.Xi.Bytes(),
+	)
+
+	// Compact signature consists of a header byte followed by 32-byte `r` and
+	// 32-byte `s` values. The header byte is `27 + recovery ID` for signatures
+	// created with an uncompressed public key.
+	compactSignature, err := btcec.SignCompact(curve, privateKey, digest, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign digest: [%v]", err)
+	}
+
+	return &ecdsa.Signature{
+		R:          new(big.Int).SetBytes(compactSignature[1:33]),
+		S:          new(big.Int).SetBytes(compactSignature[33:65]),
+		RecoveryID: int(compactSignature[0] - 27),
+	}, nil
+}
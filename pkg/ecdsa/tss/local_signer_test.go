@@ -0,0 +1,174 @@
+package tss
+
+import (
+	"context"
+	cecdsa "crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/keep-network/keep-ecdsa/pkg/utils/pbutils"
+)
+
+func TestGenerateLocalSignerAndSign(t *testing.T) {
+	memberID := MemberID([]byte("member-1"))
+
+	signer, err := GenerateLocalSigner("test-group-id-1", memberID)
+	if err != nil {
+		t.Fatalf("failed to generate local signer: [%v]", err)
+	}
+
+	if !reflect.DeepEqual([]MemberID{memberID}, signer.groupMemberIDs) {
+		t.Errorf(
+			"unexpected group members\nexpected: [%v]\nactual:   [%v]",
+			[]MemberID{memberID},
+			signer.groupMemberIDs,
+		)
+	}
+
+	digest := sha256.Sum256([]byte("test message"))
+
+	// Local signer does not need any network communication.
+	signature, err := signer.CalculateSignature(
+		context.Background(),
+		digest[:],
+		nil,
+		nil,
+		defaultSigningProtocolTimeout,
+	)
+	if err != nil {
+		t.Fatalf("failed to calculate signature: [%v]", err)
+	}
+
+	if !cecdsa.Verify(signer.PublicKey(), digest[:], signature.R, signature.S) {
+		t.Errorf("invalid signature: [%+v]", signature)
+	}
+
+	halfOrder := new(big.Int).Rsh(btcec.S256().N, 1)
+	if signature.S.Cmp(halfOrder) > 0 {
+		t.Errorf("signature is not canonical: [%+v]", signature)
+	}
+
+	compactSignature := make([]byte, 65)
+	compactSignature[0] = byte(27 + signature.RecoveryID)
+	signature.R.FillBytes(compactSignature[1:33])
+	signature.S.FillBytes(compactSignature[33:65])
+
+	recoveredPublicKey, _, err := btcec.RecoverCompact(
+		btcec.S256(),
+		compactSignature,
+		digest[:],
+	)
+	if err != nil {
+		t.Fatalf("failed to recover public key: [%v]", err)
+	}
+
+	if !reflect.DeepEqual(
+		signer.PublicKey(),
+		recoveredPublicKey.ToECDSA(),
+	) {
+		t.Errorf(
+			"unexpected recovered public key\nexpected: [%v]\nactual:   [%v]",
+			signer.PublicKey(),
+			recoveredPublicKey.ToECDSA(),
+		)
+	}
+}
+
+func TestLocalSignerCalculateSignatures(t *testing.T) {
+	signer, err := GenerateLocalSigner(
+		"test-group-id-1",
+		MemberID([]byte("member-1")),
+	)
+	if err != nil {
+		t.Fatalf("failed to generate local signer: [%v]", err)
+	}
+
+	digest1 := sha256.Sum256([]byte("test message 1"))
+	digest2 := sha256.Sum256([]byte("test message 2"))
+
+	var tests = map[string]struct {
+		digests       [][]byte
+		expectedError bool
+	}{
+		"unique digests": {
+			digests: [][]byte{digest1[:], digest2[:]},
+		},
+		"duplicated digests": {
+			digests:       [][]byte{digest1[:], digest2[:], digest1[:]},
+			expectedError: true,
+		},
+		"no digests": {
+			digests:       [][]byte{},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			signatures, err := signer.CalculateSignatures(
+				context.Background(),
+				test.digests,
+				nil,
+				nil,
+				defaultSigningProtocolTimeout,
+				defaultSigningBatchConcurrency,
+			)
+
+			if test.expectedError {
+				if err == nil {
+					t.Errorf("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed to calculate signatures: [%v]", err)
+			}
+
+			if len(test.digests) != len(signatures) {
+				t.Fatalf(
+					"unexpected number of signatures\nexpected: [%v]\nactual:   [%v]",
+					len(test.digests),
+					len(signatures),
+				)
+			}
+
+			for i, digest := range test.digests {
+				if !cecdsa.Verify(
+					signer.PublicKey(),
+					digest,
+					signatures[i].R,
+					signatures[i].S,
+				) {
+					t.Errorf("invalid signature [%v]: [%+v]", i, signatures[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLocalSignerMarshalling(t *testing.T) {
+	signer, err := GenerateLocalSigner(
+		"test-group-id-1",
+		MemberID([]byte("member-1")),
+	)
+	if err != nil {
+		t.Fatalf("failed to generate local signer: [%v]", err)
+	}
+
+	unmarshaled := &ThresholdSigner{}
+
+	if err := pbutils.RoundTrip(signer, unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(signer, unmarshaled) {
+		t.Fatalf(
+			"unexpected content of unmarshaled signer\nexpected: [%+v]\nactual:   [%+v]\n",
+			signer,
+			unmarshaled,
+		)
+	}
+}
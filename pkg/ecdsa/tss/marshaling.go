@@ -11,7 +11,9 @@ import (
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss/gen/pb"
 )
 
-// Marshal converts ThresholdSigner to byte array.
+// Marshal converts ThresholdSigner to byte array. The signer is serialized
+// with the current version of the format and wrapped in a checksummed
+// envelope.
 func (s *ThresholdSigner) Marshal() ([]byte, error) {
 	// Threshold key
 	keygenData, err := s.thresholdKey.Marshal()
@@ -19,31 +21,41 @@ func (s *ThresholdSigner) Marshal() ([]byte, error) {
 		return nil, err
 	}
 
-	// Group Info
-	groupMemberIDs := make([][]byte, len(s.groupMemberIDs))
-	for i, memberID := range s.groupMemberIDs {
-		groupMemberIDs[i] = memberID
+	// Threshold key of the previous refresh epoch
+	var previousKeygenData []byte
+	if s.previousKey != nil {
+		previousKeygenData, err = s.previousKey.Marshal()
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	group := &pb.ThresholdSigner_GroupInfo{
-		GroupID:            s.groupID,
-		MemberID:           s.memberID,
-		GroupMemberIDs:     groupMemberIDs,
-		DishonestThreshold: int32(s.dishonestThreshold),
+	payload, err := (&pb.ThresholdSigner{
+		GroupInfo:            s.groupInfo.marshal(),
+		ThresholdKey:         keygenData,
+		RefreshEpoch:         s.epoch,
+		PreviousThresholdKey: previousKeygenData,
+	}).Marshal()
+	if err != nil {
+		return nil, err
 	}
 
-	return (&pb.ThresholdSigner{
-		GroupInfo:    group,
-		ThresholdKey: keygenData,
-	}).Marshal()
+	return sealSigner(payload), nil
 }
 
-// Unmarshal converts a byte array back to ThresholdSigner.
+// Unmarshal converts a byte array back to ThresholdSigner. Signers serialized
+// with any of the previous versions of the format are migrated to the current
+// version.
 func (s *ThresholdSigner) Unmarshal(bytes []byte) error {
+	payload, err := openSigner(bytes)
+	if err != nil {
+		return fmt.Errorf("failed to open signer envelope: [%v]", err)
+	}
+
 	pbSigner := pb.ThresholdSigner{
 		GroupInfo: &pb.ThresholdSigner_GroupInfo{},
 	}
-	if err := pbSigner.Unmarshal(bytes); err != nil {
+	if err := pbSigner.Unmarshal(payload); err != nil {
 		return fmt.Errorf("failed to unmarshal signer: [%v]", err)
 	}
 
@@ -53,39 +65,81 @@ func (s *ThresholdSigner) Unmarshal(bytes []byte) error {
 		return fmt.Errorf("failed to unmarshal signer: [%v]", err)
 	}
 
+	// Threshold key of the previous refresh epoch
+	s.epoch = pbSigner.GetRefreshEpoch()
+	s.previousKey = nil
+	if len(pbSigner.GetPreviousThresholdKey()) > 0 {
+		if s.epoch == 0 {
+			return fmt.Errorf(
+				"failed to unmarshal signer: previous key share of " +
+					"a never refreshed key share",
+			)
+		}
+
+		previousKey := ThresholdKey{}
+		if err := previousKey.Unmarshal(
+			pbSigner.GetPreviousThresholdKey(),
+		); err != nil {
+			return fmt.Errorf("failed to unmarshal signer: [%v]", err)
+		}
+		s.previousKey = &previousKey
+	}
+
 	// Group Info
-	pbGroupInfo := pbSigner.GetGroupInfo()
+	s.groupInfo = unmarshalGroupInfo(pbSigner.GetGroupInfo())
 
+	return nil
+}
+
+// marshal converts groupInfo to its protobuf representation.
+func (gi *groupInfo) marshal() *pb.ThresholdSigner_GroupInfo {
+	groupMemberIDs := make([][]byte, len(gi.groupMemberIDs))
+	for i, memberID := range gi.groupMemberIDs {
+		groupMemberIDs[i] = memberID
+	}
+
+	return &pb.ThresholdSigner_GroupInfo{
+		GroupID:            gi.groupID,
+		MemberID:           gi.memberID,
+		GroupMemberIDs:     groupMemberIDs,
+		DishonestThreshold: int32(gi.dishonestThreshold),
+	}
+}
+
+// unmarshalGroupInfo converts protobuf representation of groupInfo back to
+// groupInfo.
+func unmarshalGroupInfo(pbGroupInfo *pb.ThresholdSigner_GroupInfo) *groupInfo {
 	groupMemberIDs := make([]MemberID, len(pbGroupInfo.GetGroupMemberIDs()))
 	for i, memberID := range pbGroupInfo.GetGroupMemberIDs() {
 		groupMemberIDs[i] = memberID
 	}
 
-	s.groupInfo = &groupInfo{
+	return &groupInfo{
 		groupID:            pbGroupInfo.GetGroupID(),
 		memberID:           pbGroupInfo.GetMemberID(),
 		groupMemberIDs:     groupMemberIDs,
 		dishonestThreshold: int(pbGroupInfo.GetDishonestThreshold()),
 	}
-
-	return nil
 }
 
 // Marshal converts thresholdKey to byte array.
 func (tk *ThresholdKey) Marshal() ([]byte, error) {
-	localPreParams := &pb.LocalPartySaveData_LocalPreParams{
-		PaillierSK: &pb.LocalPartySaveData_LocalPreParams_PrivateKey{
-			PublicKey: tk.LocalPreParams.PaillierSK.PublicKey.N.Bytes(),
-			LambdaN:   tk.LocalPreParams.PaillierSK.LambdaN.Bytes(),
-			PhiN:      tk.LocalPreParams.PaillierSK.PhiN.Bytes(),
-		},
-		NTilde: tk.LocalPreParams.NTildei.Bytes(),
-		H1I:    tk.LocalPreParams.H1i.Bytes(),
-		H2I:    tk.LocalPreParams.H2i.Bytes(),
-		Alpha:  tk.LocalPreParams.Alpha.Bytes(),
-		Beta:   tk.LocalPreParams.Beta.Bytes(),
-		P:      tk.LocalPreParams.P.Bytes(),
-		Q:      tk.LocalPreParams.Q.Bytes(),
+	// Pre-parameters are not available for keys of single-member groups
+	// which are generated locally.
+	//
+	// Only the Paillier private key is used by the signing protocol. Remaining
+	// pre-parameters are needed only to generate the key and are not
+	// persisted. `NTilde`, `h1` and `h2` of the member are also available in
+	// `NTildej`, `H1j` and `H2j`.
+	var localPreParams *pb.LocalPartySaveData_LocalPreParams
+	if tk.LocalPreParams.PaillierSK != nil {
+		localPreParams = &pb.LocalPartySaveData_LocalPreParams{
+			PaillierSK: &pb.LocalPartySaveData_LocalPreParams_PrivateKey{
+				PublicKey: tk.LocalPreParams.PaillierSK.PublicKey.N.Bytes(),
+				LambdaN:   tk.LocalPreParams.PaillierSK.LambdaN.Bytes(),
+				PhiN:      tk.LocalPreParams.PaillierSK.PhiN.Bytes(),
+			},
+		}
 	}
 
 	localSecrets := &pb.LocalPartySaveData_LocalSecrets{
@@ -139,23 +193,18 @@ func (tk *ThresholdKey) Unmarshal(bytes []byte) error {
 		return fmt.Errorf("failed to unmarshal signer: [%v]", err)
 	}
 
-	paillierSK := &paillier.PrivateKey{
-		PublicKey: paillier.PublicKey{
-			N: new(big.Int).SetBytes(pbData.GetLocalPreParams().GetPaillierSK().GetPublicKey()),
-		},
-		LambdaN: new(big.Int).SetBytes(pbData.GetLocalPreParams().GetPaillierSK().GetLambdaN()),
-		PhiN:    new(big.Int).SetBytes(pbData.GetLocalPreParams().GetPaillierSK().GetPhiN()),
-	}
+	if pbData.GetLocalPreParams() != nil {
+		paillierSK := &paillier.PrivateKey{
+			PublicKey: paillier.PublicKey{
+				N: new(big.Int).SetBytes(pbData.GetLocalPreParams().GetPaillierSK().GetPublicKey()),
+			},
+			LambdaN: new(big.Int).SetBytes(pbData.GetLocalPreParams().GetPaillierSK().GetLambdaN()),
+			PhiN:    new(big.Int).SetBytes(pbData.GetLocalPreParams().GetPaillierSK().GetPhiN()),
+		}
 
-	tk.LocalPreParams = keygen.LocalPreParams{
-		PaillierSK: paillierSK,
-		NTildei:    new(big.Int).SetBytes(pbData.GetLocalPreParams().GetNTilde()),
-		H1i:        new(big.Int).SetBytes(pbData.GetLocalPreParams().GetH1I()),
-		H2i:        new(big.Int).SetBytes(pbData.GetLocalPreParams().GetH2I()),
-		Alpha:      new(big.Int).SetBytes(pbData.GetLocalPreParams().GetAlpha()),
-		Beta:       new(big.Int).SetBytes(pbData.GetLocalPreParams().GetBeta()),
-		P:          new(big.Int).SetBytes(pbData.GetLocalPreParams().GetP()),
-		Q:          new(big.Int).SetBytes(pbData.GetLocalPreParams().GetQ()),
+		tk.LocalPreParams = keygen.LocalPreParams{
+			PaillierSK: paillierSK,
+		}
 	}
 
 	tk.LocalSecrets = keygen.LocalSecrets{
@@ -238,7 +287,8 @@ func (m *ProtocolMessage) Unmarshal(bytes []byte) error {
 // Marshal converts this message to a byte array suitable for network communication.
 func (m *ReadyMessage) Marshal() ([]byte, error) {
 	return (&pb.ReadyMessage{
-		SenderID: m.SenderID,
+		SenderID:  m.SenderID,
+		SessionID: m.SessionID,
 	}).Marshal()
 }
 
@@ -250,6 +300,39 @@ func (m *ReadyMessage) Unmarshal(bytes []byte) error {
 	}
 
 	m.SenderID = pbMsg.SenderID
+	m.SessionID = pbMsg.SessionID
+
+	return nil
+}
+
+// Marshal converts this message to a byte array suitable for network communication.
+func (m *ReadyConfirmationMessage) Marshal() ([]byte, error) {
+	readyMemberIDs := make([][]byte, len(m.ReadyMemberIDs))
+	for i, memberID := range m.ReadyMemberIDs {
+		readyMemberIDs[i] = memberID
+	}
+
+	return (&pb.ReadyConfirmationMessage{
+		SenderID:       m.SenderID,
+		SessionID:      m.SessionID,
+		ReadyMemberIDs: readyMemberIDs,
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to a message.
+func (m *ReadyConfirmationMessage) Unmarshal(bytes []byte) error {
+	pbMsg := &pb.ReadyConfirmationMessage{}
+	if err := pbMsg.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	m.SenderID = pbMsg.SenderID
+	m.SessionID = pbMsg.SessionID
+
+	m.ReadyMemberIDs = make([]MemberID, len(pbMsg.ReadyMemberIDs))
+	for i, memberID := range pbMsg.ReadyMemberIDs {
+		m.ReadyMemberIDs[i] = memberID
+	}
 
 	return nil
 }
@@ -295,3 +378,26 @@ func (m *LiquidationRecoveryAnnounceMessage) Unmarshal(bytes []byte) error {
 
 	return nil
 }
+
+// Marshal converts this message to a byte array suitable for network communication.
+func (m *KeyShareEpochsMessage) Marshal() ([]byte, error) {
+	return (&pb.KeyShareEpochsMessage{
+		SenderID:  m.SenderID,
+		SessionID: m.SessionID,
+		Epochs:    m.Epochs,
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to a message.
+func (m *KeyShareEpochsMessage) Unmarshal(bytes []byte) error {
+	pbMsg := &pb.KeyShareEpochsMessage{}
+	if err := pbMsg.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	m.SenderID = pbMsg.SenderID
+	m.SessionID = pbMsg.SessionID
+	m.Epochs = pbMsg.Epochs
+
+	return nil
+}
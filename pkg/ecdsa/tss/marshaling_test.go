@@ -7,6 +7,8 @@ import (
 
 	fuzz "github.com/google/gofuzz"
 
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+
 	"github.com/keep-network/keep-ecdsa/internal/testdata"
 	"github.com/keep-network/keep-ecdsa/pkg/utils/pbutils"
 )
@@ -42,10 +44,68 @@ func TestSignerMarshalling(t *testing.T) {
 	if err := pbutils.RoundTrip(signer, unmarshaled); err != nil {
 		t.Fatal(err)
 	}
-	if !reflect.DeepEqual(signer, unmarshaled) {
+
+	expectedSigner := &ThresholdSigner{
+		groupInfo:    signer.groupInfo,
+		thresholdKey: persistedThresholdKey(signer.thresholdKey),
+	}
+
+	if !reflect.DeepEqual(expectedSigner, unmarshaled) {
 		t.Fatalf(
 			"unexpected content of unmarshaled signer\nexpected: [%+v]\nactual:   [%+v]\n",
-			signer,
+			expectedSigner,
+			unmarshaled,
+		)
+	}
+}
+
+func TestRefreshedSignerMarshalling(t *testing.T) {
+	groupSize := 3
+	signerIndex := 1
+
+	testData, err := testdata.LoadKeygenTestFixtures(groupSize)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	groupMembersIDs := make([]MemberID, groupSize)
+
+	for i := range groupMembersIDs {
+		groupMembersIDs[i] = MemberID([]byte(fmt.Sprintf("member-%d", i)))
+	}
+
+	previousKey := ThresholdKey(testData[signerIndex-1])
+
+	signer := &ThresholdSigner{
+		groupInfo: &groupInfo{
+			groupID:            "test-group-id-1",
+			memberID:           groupMembersIDs[signerIndex],
+			groupMemberIDs:     groupMembersIDs,
+			dishonestThreshold: 1,
+		},
+		thresholdKey: ThresholdKey(testData[signerIndex]),
+		epoch:        3,
+		previousKey:  &previousKey,
+	}
+
+	unmarshaled := &ThresholdSigner{}
+
+	if err := pbutils.RoundTrip(signer, unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPreviousKey := persistedThresholdKey(previousKey)
+	expectedSigner := &ThresholdSigner{
+		groupInfo:    signer.groupInfo,
+		thresholdKey: persistedThresholdKey(signer.thresholdKey),
+		epoch:        signer.epoch,
+		previousKey:  &expectedPreviousKey,
+	}
+
+	if !reflect.DeepEqual(expectedSigner, unmarshaled) {
+		t.Fatalf(
+			"unexpected content of unmarshaled signer\nexpected: [%+v]\nactual:   [%+v]\n",
+			expectedSigner,
 			unmarshaled,
 		)
 	}
@@ -64,15 +124,27 @@ func TestThresholdKeyMarshalling(t *testing.T) {
 	if err := pbutils.RoundTrip(&key, unmarshaled); err != nil {
 		t.Fatal(err)
 	}
-	if !reflect.DeepEqual(&key, unmarshaled) {
+
+	expectedKey := persistedThresholdKey(key)
+
+	if !reflect.DeepEqual(&expectedKey, unmarshaled) {
 		t.Fatalf(
 			"unexpected content of unmarshaled signer\nexpected: [%+v]\nactual:   [%+v]\n",
-			&key,
+			&expectedKey,
 			unmarshaled,
 		)
 	}
 }
 
+// persistedThresholdKey returns the given key with pre-parameters not
+// persisted by the marshaler stripped.
+func persistedThresholdKey(key ThresholdKey) ThresholdKey {
+	key.LocalPreParams = keygen.LocalPreParams{
+		PaillierSK: key.LocalPreParams.PaillierSK,
+	}
+	return key
+}
+
 func TestTSSProtocolMessageMarshalling(t *testing.T) {
 	msg := &ProtocolMessage{
 		SenderID:    MemberID([]byte("member-1")),
@@ -112,7 +184,8 @@ func TestFuzzTSSProtocolMessageUnmarshaler(t *testing.T) {
 
 func TestReadyMessageMarshalling(t *testing.T) {
 	msg := &ReadyMessage{
-		SenderID: MemberID([]byte("member-1")),
+		SenderID:  MemberID([]byte("member-1")),
+		SessionID: "session-1",
 	}
 
 	unmarshaled := &ReadyMessage{}
@@ -144,6 +217,45 @@ func TestFuzzReadyMessageUnmarshaler(t *testing.T) {
 	pbutils.FuzzUnmarshaler(&ReadyMessage{})
 }
 
+func TestReadyConfirmationMessageMarshalling(t *testing.T) {
+	msg := &ReadyConfirmationMessage{
+		SenderID:  MemberID([]byte("member-1")),
+		SessionID: "session-1",
+		ReadyMemberIDs: []MemberID{
+			MemberID([]byte("member-1")),
+			MemberID([]byte("member-2")),
+		},
+	}
+
+	unmarshaled := &ReadyConfirmationMessage{}
+
+	if err := pbutils.RoundTrip(msg, unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf(
+			"unexpected content of unmarshaled message\nexpected: [%+v]\nactual:   [%+v]\n",
+			msg,
+			unmarshaled,
+		)
+	}
+}
+
+func TestFuzzReadyConfirmationMessageRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var message ReadyConfirmationMessage
+
+		f := fuzz.New().NilChance(0.1).NumElements(0, 512)
+		f.Fuzz(&message)
+
+		_ = pbutils.RoundTrip(&message, &ReadyConfirmationMessage{})
+	}
+}
+
+func TestFuzzReadyConfirmationMessageUnmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&ReadyConfirmationMessage{})
+}
+
 func TestAnnounceMessageMarshalling(t *testing.T) {
 	msg := &AnnounceMessage{
 		SenderID: MemberID([]byte("member-1")),
@@ -213,3 +325,39 @@ func TestLiquidationRecoveryAnnounceMessageMarshalling(t *testing.T) {
 func TestFuzzLiquidationRecoveryAnnounceMessageUnmarshaler(t *testing.T) {
 	pbutils.FuzzUnmarshaler(&LiquidationRecoveryAnnounceMessage{})
 }
+
+func TestKeyShareEpochsMessageMarshalling(t *testing.T) {
+	msg := &KeyShareEpochsMessage{
+		SenderID:  MemberID([]byte("member-1")),
+		SessionID: "session-1",
+		Epochs:    []uint64{4, 5},
+	}
+
+	unmarshaled := &KeyShareEpochsMessage{}
+
+	if err := pbutils.RoundTrip(msg, unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf(
+			"unexpected content of unmarshaled message\nexpected: [%+v]\nactual:   [%+v]\n",
+			msg,
+			unmarshaled,
+		)
+	}
+}
+
+func TestFuzzKeyShareEpochsMessageRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var message KeyShareEpochsMessage
+
+		f := fuzz.New().NilChance(0.1).NumElements(0, 512)
+		f.Fuzz(&message)
+
+		_ = pbutils.RoundTrip(&message, &KeyShareEpochsMessage{})
+	}
+}
+
+func TestFuzzKeyShareEpochsMessageUnmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&KeyShareEpochsMessage{})
+}
@@ -2,7 +2,9 @@ package tss
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"math/big"
 
 	"github.com/keep-network/keep-core/pkg/operator"
@@ -36,6 +38,16 @@ func (id MemberID) bigInt() *big.Int {
 	return new(big.Int).SetBytes(id)
 }
 
+// alternativeBigInt returns a big.Int derived from MemberID which is different
+// from the one returned by bigInt.
+func (id MemberID) alternativeBigInt() *big.Int {
+	digest := sha256.Sum256(id)
+	return new(big.Int).SetBytes(digest[:])
+}
+
+// partyKeyFn returns a key identifying the member in the TSS protocol.
+type partyKeyFn func(MemberID) *big.Int
+
 // Equal checks if member IDs are equal.
 func (id MemberID) Equal(memberID MemberID) bool {
 	return bytes.Equal(id, memberID)
@@ -51,3 +63,72 @@ type groupInfo struct {
 	// of `t + 1` players can jointly sign, but any smaller subset cannot.
 	dishonestThreshold int
 }
+
+// signingQuorum returns the minimum number of members required to calculate
+// a signature.
+func (gi *groupInfo) signingQuorum() int {
+	return gi.dishonestThreshold + 1
+}
+
+// peerCulprits returns all members of the group other than the current one
+// blamed for the given reason.
+func (gi *groupInfo) peerCulprits(reason CulpritReason) []Culprit {
+	return gi.peerCulpritsAmong(gi.groupMemberIDs, reason)
+}
+
+// peerCulpritsAmong returns the given members other than the current one
+// blamed for the given reason.
+func (gi *groupInfo) peerCulpritsAmong(
+	memberIDs []MemberID,
+	reason CulpritReason,
+) []Culprit {
+	culprits := []Culprit{}
+	for _, memberID := range memberIDs {
+		if !memberID.Equal(gi.memberID) {
+			culprits = append(culprits, Culprit{
+				MemberID: memberID,
+				Reason:   reason,
+			})
+		}
+	}
+
+	return culprits
+}
+
+// newThresholdGroupInfo validates parameters of the group executing
+// a threshold multi-party protocol and returns information about the group.
+func newThresholdGroupInfo(
+	groupID string,
+	memberID MemberID,
+	groupMemberIDs []MemberID,
+	dishonestThreshold uint,
+) (*groupInfo, error) {
+	if len(groupMemberIDs) < 2 {
+		return nil, fmt.Errorf(
+			"group should have at least 2 members but got: [%d]",
+			len(groupMemberIDs),
+		)
+	}
+
+	if dishonestThreshold < 1 {
+		return nil, fmt.Errorf(
+			"dishonest threshold should be at least 1 but got: [%d]",
+			dishonestThreshold,
+		)
+	}
+
+	if len(groupMemberIDs) <= int(dishonestThreshold) {
+		return nil, fmt.Errorf(
+			"group size [%d], should be greater than dishonest threshold [%d]",
+			len(groupMemberIDs),
+			dishonestThreshold,
+		)
+	}
+
+	return &groupInfo{
+		groupID:            groupID,
+		memberID:           memberID,
+		groupMemberIDs:     groupMemberIDs,
+		dishonestThreshold: int(dishonestThreshold),
+	}, nil
+}
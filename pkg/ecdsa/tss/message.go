@@ -19,9 +19,12 @@ func (m *ProtocolMessage) Type() string {
 }
 
 // ReadyMessage is a network message used to notify peer members about readiness
-// to start protocol execution.
+// to start protocol execution. The message is bound to the protocol session
+// so that readiness signalled for one protocol execution is not mistaken for
+// readiness to execute another one running concurrently in the same group.
 type ReadyMessage struct {
-	SenderID MemberID
+	SenderID  MemberID
+	SessionID string
 }
 
 // Type returns a string type of the `ReadyMessage`.
@@ -29,6 +32,21 @@ func (m *ReadyMessage) Type() string {
 	return "ecdsa/ready_message"
 }
 
+// ReadyConfirmationMessage is a network message used to confirm the set of
+// members ready to start protocol execution. Each member proposes the set of
+// members from which it received readiness notifications and the protocol
+// starts only if all proposed sets are the same.
+type ReadyConfirmationMessage struct {
+	SenderID       MemberID
+	SessionID      string
+	ReadyMemberIDs []MemberID
+}
+
+// Type returns a string type of the `ReadyConfirmationMessage`.
+func (m *ReadyConfirmationMessage) Type() string {
+	return "ecdsa/ready_confirmation_message"
+}
+
 // AnnounceMessage is a network message used to announce peer's presence.
 type AnnounceMessage struct {
 	SenderID MemberID
@@ -53,6 +71,21 @@ func (m *LiquidationRecoveryAnnounceMessage) Type() string {
 	return "ecdsa/liquidation_recovery_message"
 }
 
+// KeyShareEpochsMessage is a network message used to report refresh epochs of
+// key shares held by the member to peer members, so that members who completed
+// a key share refresh can find out if the refreshed key shares are held by all
+// members.
+type KeyShareEpochsMessage struct {
+	SenderID  MemberID
+	SessionID string
+	Epochs    []uint64
+}
+
+// Type returns a string type of the `KeyShareEpochsMessage`.
+func (m *KeyShareEpochsMessage) Type() string {
+	return "ecdsa/key_share_epochs_message"
+}
+
 // RegisterUnmarshalers is a boilerplate method to register unmarshaling on a broadcast channel
 func RegisterUnmarshalers(broadcastChannel net.BroadcastChannel) {
 	broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
@@ -63,6 +96,10 @@ func RegisterUnmarshalers(broadcastChannel net.BroadcastChannel) {
 		return &ReadyMessage{}
 	})
 
+	broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &ReadyConfirmationMessage{}
+	})
+
 	broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
 		return &ProtocolMessage{}
 	})
@@ -70,4 +107,8 @@ func RegisterUnmarshalers(broadcastChannel net.BroadcastChannel) {
 	broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
 		return &LiquidationRecoveryAnnounceMessage{}
 	})
+
+	broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &KeyShareEpochsMessage{}
+	})
 }
@@ -28,15 +28,43 @@ type networkBridge struct {
 	unicastChannels  map[net.TransportIdentifier]net.UnicastChannel
 
 	tssMessageHandlersMutex *sync.Mutex
-	tssMessageHandlers      []tssMessageHandler
+	// Message handlers of bound parties indexed by protocol session ID.
+	tssMessageHandlers map[string][]tssMessageHandler
+	// Protocol messages received before a handler for their session has been
+	// registered. They are delivered to the first handler registered for
+	// the session.
+	pendingTSSMessages []*receivedProtocolMessage
+
+	culpritsMutex *sync.Mutex
+	// Reasons of blaming members who misbehaved during the protocol execution
+	// indexed by member ID string.
+	culprits map[string]CulpritReason
+
+	// Transcript messages sent and received through the bridge are recorded
+	// in. Messages are not recorded if it is nil.
+	transcript *Transcript
 }
 
-type tssMessageHandler func(netMsg *ProtocolMessage) error
+type tssMessageHandler func(msg *receivedProtocolMessage) error
+
+// receivedProtocolMessage is a protocol message along with the member who sent
+// it, as authenticated by the network layer.
+type receivedProtocolMessage struct {
+	*ProtocolMessage
+	senderID MemberID
+}
+
+// sessionRouter returns IDs of protocol sessions the given message produced by
+// a party should be delivered to.
+type sessionRouter func(tssLibMsg tss.Message) []string
 
 // newNetworkBridge initializes a new network bridge for the given network provider.
+// If the transcript is not nil, messages sent and received through the bridge
+// are recorded in it.
 func newNetworkBridge(
 	groupInfo *groupInfo,
 	networkProvider net.Provider,
+	transcript *Transcript,
 ) (*networkBridge, error) {
 	networkBridge := &networkBridge{
 		networkProvider: networkProvider,
@@ -46,29 +74,41 @@ func newNetworkBridge(
 		unicastChannels: make(map[net.TransportIdentifier]net.UnicastChannel),
 
 		tssMessageHandlersMutex: &sync.Mutex{},
-		tssMessageHandlers:      []tssMessageHandler{},
+		tssMessageHandlers:      make(map[string][]tssMessageHandler),
+
+		culpritsMutex: &sync.Mutex{},
+		culprits:      make(map[string]CulpritReason),
+
+		transcript: transcript,
+	}
+
+	if transcript != nil {
+		transcript.recordGroup(groupInfo)
 	}
 
 	return networkBridge, nil
 }
 
-func (b *networkBridge) connect(
-	ctx context.Context,
-	tssOutChan <-chan tss.Message,
-	party tss.Party,
-	sortedPartyIDs tss.SortedPartyIDs,
-) error {
-	netInChan := make(chan *ProtocolMessage, len(b.groupInfo.groupMemberIDs))
+// connect initializes broadcast and unicast channels with peer members and
+// starts receiving protocol messages from them. Messages received before
+// a party is bound to the bridge are retained and delivered to the party once
+// it is bound.
+//
+// Peer members who cannot be reached do not make the connection fail as long
+// as at least `quorum` members, including the current one, are reachable.
+func (b *networkBridge) connect(ctx context.Context, quorum int) error {
+	netInChan := make(
+		chan *receivedProtocolMessage,
+		len(b.groupInfo.groupMemberIDs),
+	)
 
-	if err := b.initializeChannels(ctx, netInChan); err != nil {
+	if err := b.initializeChannels(ctx, netInChan, quorum); err != nil {
 		return fmt.Errorf("failed to initialize channels: [%v]", err)
 	}
 
 	go func() {
 		for {
 			select {
-			case tssLibMsg := <-tssOutChan:
-				go b.sendTSSMessage(ctx, tssLibMsg)
 			case msg := <-netInChan:
 				go b.handleTSSProtocolMessage(msg)
 			case <-ctx.Done():
@@ -77,31 +117,83 @@ func (b *networkBridge) connect(
 		}
 	}()
 
-	b.registerProtocolMessageHandler(party, sortedPartyIDs)
-
 	return nil
 }
 
-func (b *networkBridge) initializeChannels(
+// bind binds the party to the bridge. Messages produced by the party are sent
+// to peer members and messages received from peer members are delivered to
+// the party. Received messages are parsed as messages of the given types.
+func (b *networkBridge) bind(
 	ctx context.Context,
-	netInChan chan *ProtocolMessage,
-) error {
-	handleFn := func(msg net.Message) {
-		switch protocolMessage := msg.Payload().(type) {
-		case *ProtocolMessage:
-			netInChan <- protocolMessage
+	tssOutChan <-chan tss.Message,
+	party tss.Party,
+	sortedPartyIDs tss.SortedPartyIDs,
+	messageTypes wireMessageTypes,
+) {
+	sessionID := b.groupInfo.groupID
+
+	b.bindSession(
+		ctx,
+		sessionID,
+		tssOutChan,
+		party,
+		sortedPartyIDs,
+		messageTypes,
+		func(tss.Message) []string { return []string{sessionID} },
+	)
+}
+
+// bindSession binds the party to the bridge within the given protocol session.
+// The party receives only messages sent within its session. Messages produced
+// by the party are sent within sessions determined by the router. This way
+// more than one party of the current member can be bound to the same bridge.
+// Received messages are parsed as messages of the given types.
+func (b *networkBridge) bindSession(
+	ctx context.Context,
+	sessionID string,
+	tssOutChan <-chan tss.Message,
+	party tss.Party,
+	sortedPartyIDs tss.SortedPartyIDs,
+	messageTypes wireMessageTypes,
+	router sessionRouter,
+) {
+	go func() {
+		for {
+			select {
+			case tssLibMsg := <-tssOutChan:
+				go b.sendTSSMessage(ctx, tssLibMsg, router(tssLibMsg))
+			case <-ctx.Done():
+				return
+			}
 		}
-	}
+	}()
 
+	b.registerProtocolMessageHandler(
+		sessionID,
+		party,
+		sortedPartyIDs,
+		messageTypes,
+	)
+}
+
+func (b *networkBridge) initializeChannels(
+	ctx context.Context,
+	netInChan chan *receivedProtocolMessage,
+	quorum int,
+) error {
 	// Initialize broadcast channel.
 	broadcastChannel, err := b.getBroadcastChannel()
 	if err != nil {
 		return fmt.Errorf("failed to get broadcast channel: [%v]", err)
 	}
 
-	broadcastChannel.Recv(ctx, handleFn)
+	broadcastChannel.Recv(
+		ctx,
+		receiveProtocolMessages(netInChan, broadcastSender),
+	)
 
 	// Initialize unicast channels.
+	reachableMembersCount := 1 // the current member
 	for _, peerMemberID := range b.groupInfo.groupMemberIDs {
 		if peerMemberID.Equal(b.groupInfo.memberID) {
 			continue
@@ -118,15 +210,67 @@ func (b *networkBridge) initializeChannels(
 			unicastChannelRetryWaitTime,
 		)
 		if err != nil {
-			return fmt.Errorf("failed to get unicast channel: [%v]", err)
+			logger.Warningf(
+				"failed to get unicast channel with member [%s]: [%v]",
+				peerMemberID,
+				err,
+			)
+			continue
 		}
 
-		unicastChannel.Recv(ctx, handleFn)
+		unicastChannel.Recv(
+			ctx,
+			receiveProtocolMessages(netInChan, unicastSender(peerMemberID)),
+		)
+		reachableMembersCount++
+	}
+
+	if reachableMembersCount < quorum {
+		return fmt.Errorf(
+			"could not get unicast channels; [%d] members are reachable "+
+				"but at least [%d] are required",
+			reachableMembersCount,
+			quorum,
+		)
 	}
 
 	return nil
 }
 
+// receiveProtocolMessages returns a handler passing protocol messages received
+// from the network to the given channel along with their senders, as
+// authenticated by the network layer.
+func receiveProtocolMessages(
+	netInChan chan<- *receivedProtocolMessage,
+	authenticatedSender func(msg net.Message) MemberID,
+) func(msg net.Message) {
+	return func(msg net.Message) {
+		switch protocolMessage := msg.Payload().(type) {
+		case *ProtocolMessage:
+			netInChan <- &receivedProtocolMessage{
+				ProtocolMessage: protocolMessage,
+				senderID:        authenticatedSender(msg),
+			}
+		}
+	}
+}
+
+// broadcastSender returns the sender of a broadcast message as authenticated
+// by the network layer.
+func broadcastSender(msg net.Message) MemberID {
+	return msg.SenderPublicKey()
+}
+
+// unicastSender returns a function returning the sender of messages received
+// through the unicast channel with the given peer member. The channel is
+// established with the peer's network key, so all its messages come from
+// that peer.
+func unicastSender(peerMemberID MemberID) func(msg net.Message) MemberID {
+	return func(net.Message) MemberID {
+		return peerMemberID
+	}
+}
+
 func (b *networkBridge) getUnicastChannel(
 	peerTransportID net.TransportIdentifier,
 	retryCount int,
@@ -192,9 +336,12 @@ func (b *networkBridge) getBroadcastChannel() (net.BroadcastChannel, error) {
 		return nil, fmt.Errorf("failed to set broadcast channel filter: [%v]", err)
 	}
 
-	b.broadcastChannel = broadcastChannel
+	b.broadcastChannel = newRecordingBroadcastChannel(
+		broadcastChannel,
+		b.transcript,
+	)
 
-	return broadcastChannel, nil
+	return b.broadcastChannel, nil
 }
 
 func createMemberIDFilter(
@@ -240,6 +387,8 @@ func (b *networkBridge) getUnicastChannelWith(
 		return &ProtocolMessage{}
 	})
 
+	unicastChannel = newRecordingUnicastChannel(unicastChannel, b.transcript)
+
 	b.unicastChannels[peerTransportID] = unicastChannel
 
 	return unicastChannel, nil
@@ -248,6 +397,7 @@ func (b *networkBridge) getUnicastChannelWith(
 func (b *networkBridge) sendTSSMessage(
 	ctx context.Context,
 	tssLibMsg tss.Message,
+	sessionIDs []string,
 ) {
 	bytes, routing, err := tssLibMsg.WireBytes()
 	if err != nil {
@@ -255,39 +405,51 @@ func (b *networkBridge) sendTSSMessage(
 		return
 	}
 
-	protocolMessage := &ProtocolMessage{
-		SenderID:    routing.From.GetKey(),
-		Payload:     bytes,
-		IsBroadcast: routing.IsBroadcast,
-		SessionID:   b.groupInfo.groupID,
-	}
-
-	if routing.To == nil {
-		err = b.broadcast(ctx, protocolMessage)
-		if err != nil {
-			logger.Errorf("could not broadcast message: [%v]", err)
+	for _, sessionID := range sessionIDs {
+		protocolMessage := &ProtocolMessage{
+			SenderID:    routing.From.GetKey(),
+			Payload:     bytes,
+			IsBroadcast: routing.IsBroadcast,
+			SessionID:   sessionID,
 		}
-	} else {
-		for _, destination := range routing.To {
-			destinationMemberID, err := MemberIDFromString(destination.GetId())
-			if err != nil {
-				logger.Errorf("failed to get destination member id: [%v]", err)
-				return
-			}
 
-			destinationTransportID, err := b.getTransportIdentifier(destinationMemberID)
+		if routing.To == nil {
+			err = b.broadcast(ctx, protocolMessage)
 			if err != nil {
-				logger.Errorf("failed to get transport identifier: [%v]", err)
-				return
+				logger.Errorf("could not broadcast message: [%v]", err)
 			}
-
-			err = b.sendTo(destinationTransportID, protocolMessage)
-			if err != nil {
-				logger.Errorf(
-					"could not send message to [%v]: [%v]",
-					destinationTransportID.String(),
-					err,
-				)
+		} else {
+			for _, destination := range routing.To {
+				destinationMemberID, err := MemberIDFromString(destination.GetId())
+				if err != nil {
+					logger.Errorf("failed to get destination member id: [%v]", err)
+					return
+				}
+
+				// Message addressed to another party of the current member
+				// is delivered directly.
+				if destinationMemberID.Equal(b.groupInfo.memberID) {
+					b.handleTSSProtocolMessage(&receivedProtocolMessage{
+						ProtocolMessage: protocolMessage,
+						senderID:        b.groupInfo.memberID,
+					})
+					continue
+				}
+
+				destinationTransportID, err := b.getTransportIdentifier(destinationMemberID)
+				if err != nil {
+					logger.Errorf("failed to get transport identifier: [%v]", err)
+					return
+				}
+
+				err = b.sendTo(destinationTransportID, protocolMessage)
+				if err != nil {
+					logger.Errorf(
+						"could not send message to [%v]: [%v]",
+						destinationTransportID.String(),
+						err,
+					)
+				}
 			}
 		}
 	}
@@ -338,27 +500,63 @@ func (b *networkBridge) sendTo(
 }
 
 func (b *networkBridge) registerProtocolMessageHandler(
+	sessionID string,
 	party tss.Party,
 	sortedPartyIDs tss.SortedPartyIDs,
+	messageTypes wireMessageTypes,
 ) {
-	handler := func(protocolMessage *ProtocolMessage) error {
-		if protocolMessage.SessionID != b.groupInfo.groupID {
-			return nil
+	handler := func(protocolMessage *receivedProtocolMessage) error {
+		senderPartyID := sortedPartyIDs.FindByKey(protocolMessage.SenderID.bigInt())
+
+		if senderPartyID == nil {
+			return fmt.Errorf(
+				"sender [%s] does not participate in the protocol",
+				protocolMessage.SenderID,
+			)
 		}
 
-		senderPartyID := sortedPartyIDs.FindByKey(protocolMessage.SenderID.bigInt())
+		// The sender ID is the key of the sender's party and the party is
+		// identified with the member ID. A message sent in the name of
+		// a member other than the one authenticated by the network layer
+		// is dropped before it reaches the party, so that a member cannot
+		// have another member blamed for malformed or invalid messages.
+		if senderPartyID.GetId() != protocolMessage.senderID.String() {
+			return fmt.Errorf(
+				"dropping message of party [%s] sent by member [%s] "+
+					"authenticated by the network layer",
+				senderPartyID.GetId(),
+				protocolMessage.senderID,
+			)
+		}
 
 		if senderPartyID == party.PartyID() {
 			return nil
 		}
 
-		_, err := party.UpdateFromBytes(
+		parsedMessage, err := messageTypes.parseWireMessage(
 			protocolMessage.Payload,
 			senderPartyID,
 			protocolMessage.IsBroadcast,
 		)
 		if err != nil {
-			return fmt.Errorf("failed to update party: [%v]", party.WrapError(err))
+			b.blame(senderPartyID.GetId(), MalformedMessage)
+			return fmt.Errorf(
+				"failed to parse message from [%s]: [%v]",
+				senderPartyID.GetId(),
+				err,
+			)
+		}
+
+		if _, err := party.Update(parsedMessage); err != nil {
+			// Party could reject the message because of the message content
+			// itself or because the message completed a round and some of the
+			// messages received in that round turned out to be invalid.
+			// In both cases the library points the culprits.
+			for _, culpritPartyID := range err.Culprits() {
+				b.blame(culpritPartyID.GetId(), InvalidMessage)
+			}
+
+			return fmt.Errorf("failed to update party: [%v]", err)
 		}
 
 		return nil
@@ -367,16 +565,85 @@ func (b *networkBridge) registerProtocolMessageHandler(
 	b.tssMessageHandlersMutex.Lock()
 	defer b.tssMessageHandlersMutex.Unlock()
 
-	b.tssMessageHandlers = append(b.tssMessageHandlers, handler)
+	b.tssMessageHandlers[sessionID] = append(
+		b.tssMessageHandlers[sessionID],
+		handler,
+	)
+
+	pendingTSSMessages := []*receivedProtocolMessage{}
+	for _, protocolMessage := range b.pendingTSSMessages {
+		if protocolMessage.SessionID != sessionID {
+			pendingTSSMessages = append(pendingTSSMessages, protocolMessage)
+			continue
+		}
+
+		if err := handler(protocolMessage); err != nil {
+			logger.Errorf("failed to handle protocol message: [%v]", err)
+		}
+	}
+	b.pendingTSSMessages = pendingTSSMessages
 }
 
-func (b *networkBridge) handleTSSProtocolMessage(protocolMessage *ProtocolMessage) {
+func (b *networkBridge) handleTSSProtocolMessage(
+	protocolMessage *receivedProtocolMessage,
+) {
 	b.tssMessageHandlersMutex.Lock()
 	defer b.tssMessageHandlersMutex.Unlock()
 
-	for _, handler := range b.tssMessageHandlers {
+	handlers, ok := b.tssMessageHandlers[protocolMessage.SessionID]
+	if !ok {
+		b.pendingTSSMessages = append(b.pendingTSSMessages, protocolMessage)
+		return
+	}
+
+	for _, handler := range handlers {
 		if err := handler(protocolMessage); err != nil {
 			logger.Errorf("failed to handle protocol message: [%v]", err)
 		}
 	}
 }
+
+// blame records the member with the given ID string as misbehaving during
+// the protocol execution for the given reason.
+func (b *networkBridge) blame(memberID string, reason CulpritReason) {
+	b.culpritsMutex.Lock()
+	defer b.culpritsMutex.Unlock()
+
+	b.culprits[memberID] = reason
+}
+
+// blamedCulprits returns members blamed for a failure of the protocol
+// execution. These are all members recorded as misbehaving and members
+// the parties are still waiting for messages from, if they have not been
+// recorded as misbehaving.
+func (b *networkBridge) blamedCulprits(parties ...tss.Party) []Culprit {
+	b.culpritsMutex.Lock()
+	defer b.culpritsMutex.Unlock()
+
+	reasons := make(map[string]CulpritReason)
+	for memberID, reason := range b.culprits {
+		reasons[memberID] = reason
+	}
+
+	for _, party := range parties {
+		for _, partyID := range party.WaitingFor() {
+			if _, ok := reasons[partyID.GetId()]; !ok {
+				reasons[partyID.GetId()] = NoMessage
+			}
+		}
+	}
+
+	culprits := []Culprit{}
+
+	// Culprits are returned in the order members are listed in the group.
+	for _, memberID := range b.groupInfo.groupMemberIDs {
+		if reason, ok := reasons[memberID.String()]; ok {
+			culprits = append(culprits, Culprit{
+				MemberID: memberID,
+				Reason:   reason,
+			})
+		}
+	}
+
+	return culprits
+}
@@ -0,0 +1,168 @@
+package tss
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+func TestBlameMalformedMessage(t *testing.T) {
+	netBridge, group := newTestKeyGenerationBridge(t)
+
+	netBridge.handleTSSProtocolMessage(&receivedProtocolMessage{
+		ProtocolMessage: &ProtocolMessage{
+			SenderID:    group.groupMemberIDs[2],
+			Payload:     []byte{0x01, 0x02, 0x03},
+			IsBroadcast: true,
+			SessionID:   group.groupID,
+		},
+		senderID: group.groupMemberIDs[2],
+	})
+
+	expectedCulprits := []Culprit{
+		{MemberID: group.groupMemberIDs[2], Reason: MalformedMessage},
+	}
+
+	culprits := netBridge.blamedCulprits()
+	if !reflect.DeepEqual(expectedCulprits, culprits) {
+		t.Errorf(
+			"unexpected culprits\nexpected: [%v]\nactual:   [%v]",
+			expectedCulprits,
+			culprits,
+		)
+	}
+}
+
+func TestDropMessageWithSpoofedSenderID(t *testing.T) {
+	netBridge, group := newTestKeyGenerationBridge(t)
+
+	maliciousMemberID := group.groupMemberIDs[1]
+	honestMemberID := group.groupMemberIDs[2]
+
+	netInChan := make(chan *receivedProtocolMessage, 2)
+	receive := receiveProtocolMessages(netInChan, broadcastSender)
+
+	// The malicious member sends a malformed message in the name of
+	// the honest member.
+	receive(&testNetMessage{
+		senderPublicKey: maliciousMemberID,
+		payload: &ProtocolMessage{
+			SenderID:    honestMemberID,
+			Payload:     []byte{0x01, 0x02, 0x03},
+			IsBroadcast: true,
+			SessionID:   group.groupID,
+		},
+	})
+
+	// The malicious member sends a malformed message in their own name.
+	receive(&testNetMessage{
+		senderPublicKey: maliciousMemberID,
+		payload: &ProtocolMessage{
+			SenderID:    maliciousMemberID,
+			Payload:     []byte{0x04, 0x05, 0x06},
+			IsBroadcast: true,
+			SessionID:   group.groupID,
+		},
+	})
+
+	close(netInChan)
+	for protocolMessage := range netInChan {
+		netBridge.handleTSSProtocolMessage(protocolMessage)
+	}
+
+	expectedCulprits := []Culprit{
+		{MemberID: maliciousMemberID, Reason: MalformedMessage},
+	}
+
+	culprits := netBridge.blamedCulprits()
+	if !reflect.DeepEqual(expectedCulprits, culprits) {
+		t.Errorf(
+			"unexpected culprits\nexpected: [%v]\nactual:   [%v]",
+			expectedCulprits,
+			culprits,
+		)
+	}
+}
+
+// newTestKeyGenerationBridge creates a network bridge of the first member of
+// a three-member group with a key generation party bound to it.
+func newTestKeyGenerationBridge(t *testing.T) (*networkBridge, *groupInfo) {
+	groupMemberIDs, err := generateMemberKeys(3)
+	if err != nil {
+		t.Fatalf("failed to generate members keys: [%v]", err)
+	}
+
+	group := &groupInfo{
+		groupID:            "test-group-1",
+		memberID:           groupMemberIDs[0],
+		groupMemberIDs:     groupMemberIDs,
+		dishonestThreshold: 1,
+	}
+
+	netBridge, err := newNetworkBridge(group, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to initialize network bridge: [%v]", err)
+	}
+
+	partyID, partiesIDs, err := generatePartiesIDs(
+		group.memberID,
+		group.groupMemberIDs,
+		MemberID.bigInt,
+	)
+	if err != nil {
+		t.Fatalf("failed to generate parties IDs: [%v]", err)
+	}
+
+	sortedPartiesIDs := tss.SortPartyIDs(partiesIDs)
+
+	party := keygen.NewLocalParty(
+		tss.NewParameters(
+			tss.EC(),
+			tss.NewPeerContext(sortedPartiesIDs),
+			partyID,
+			len(sortedPartiesIDs),
+			int(group.dishonestThreshold),
+		),
+		make(chan tss.Message, len(groupMemberIDs)),
+		make(chan keygen.LocalPartySaveData, 1),
+	)
+
+	netBridge.registerProtocolMessageHandler(
+		group.groupID,
+		party,
+		sortedPartiesIDs,
+		ecdsaWireMessageTypes,
+	)
+
+	return netBridge, group
+}
+
+// testNetMessage is a network message sent by the member with the given
+// public key, as authenticated by the network layer.
+type testNetMessage struct {
+	senderPublicKey []byte
+	payload         interface{}
+}
+
+func (tnm *testNetMessage) TransportSenderID() net.TransportIdentifier {
+	return nil
+}
+
+func (tnm *testNetMessage) SenderPublicKey() []byte {
+	return tnm.senderPublicKey
+}
+
+func (tnm *testNetMessage) Payload() interface{} {
+	return tnm.payload
+}
+
+func (tnm *testNetMessage) Type() string {
+	return "tss/test_message"
+}
+
+func (tnm *testNetMessage) Seqno() uint64 {
+	return 0
+}
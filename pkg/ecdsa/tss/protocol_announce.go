@@ -5,6 +5,7 @@ import (
 	cecdsa "crypto/ecdsa"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/keep-network/keep-core/pkg/net"
@@ -12,23 +13,36 @@ import (
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
 )
 
-const protocolAnnounceTimeout = 2 * time.Minute
-
-// AnnounceProtocol announces a client to the other clients in the keep network
+// AnnounceProtocol announces a client to the other clients in the keep network.
+//
+// The protocol completes as soon as all keep members announced their presence.
+// If the announce timeout is reached before that, the protocol still succeeds when at
+// least `quorum` keep members, including the current one, announced their
+// presence. In such case only the members who announced are returned.
 func AnnounceProtocol(
 	parentCtx context.Context,
 	publicKey *operator.PublicKey,
 	keepID chain.ID,
 	keepMemberIDs []chain.ID,
+	quorum int,
 	broadcastChannel net.BroadcastChannel,
 	publicKeyToOperatorIDFunc func(*cecdsa.PublicKey) chain.ID,
+	announceTimeout time.Duration,
 ) (
 	[]MemberID,
 	error,
 ) {
 	logger.Infof("announcing presence")
 
-	ctx, cancel := context.WithTimeout(parentCtx, protocolAnnounceTimeout)
+	if transcript := transcriptFromContext(parentCtx); transcript != nil {
+		transcript.recordMember(MemberIDFromPublicKey(publicKey))
+		broadcastChannel = newRecordingBroadcastChannel(
+			broadcastChannel,
+			transcript,
+		)
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, announceTimeout)
 	defer cancel()
 
 	announceInChan := make(chan *AnnounceMessage, len(keepMemberIDs))
@@ -40,15 +54,33 @@ func AnnounceProtocol(
 	}
 	broadcastChannel.Recv(ctx, handleAnnounceMessage)
 
+	receivedMemberIDsMutex := &sync.Mutex{}
 	receivedMemberIDs := make(map[string]MemberID) // member address -> memberID
 
-	markAnnounced := func(memberID MemberID, operatorID chain.ID) {
+	markAnnounced := func(memberID MemberID, operatorID chain.ID) int {
+		receivedMemberIDsMutex.Lock()
+		defer receivedMemberIDsMutex.Unlock()
+
 		receivedMemberIDs[strings.ToLower(operatorID.String())] = memberID
+		return len(receivedMemberIDs)
 	}
 	hasAnnounced := func(keepMemberID chain.ID) bool {
+		receivedMemberIDsMutex.Lock()
+		defer receivedMemberIDsMutex.Unlock()
+
 		_, ok := receivedMemberIDs[strings.ToLower(keepMemberID.String())]
 		return ok
 	}
+	collectMemberIDs := func() []MemberID {
+		receivedMemberIDsMutex.Lock()
+		defer receivedMemberIDsMutex.Unlock()
+
+		memberIDs := make([]MemberID, 0)
+		for _, memberID := range receivedMemberIDs {
+			memberIDs = append(memberIDs, memberID)
+		}
+		return memberIDs
+	}
 
 	go func() {
 		for {
@@ -76,8 +108,7 @@ func AnnounceProtocol(
 					keepID,
 				)
 
-				markAnnounced(msg.SenderID, operatorID)
-				if len(receivedMemberIDs) == len(keepMemberIDs) {
+				if markAnnounced(msg.SenderID, operatorID) == len(keepMemberIDs) {
 					cancel()
 				}
 			}
@@ -123,19 +154,31 @@ func AnnounceProtocol(
 				)
 			}
 		}
+
+		announcedMemberIDs := collectMemberIDs()
+		if len(announcedMemberIDs) >= quorum {
+			logger.Infof(
+				"announce protocol completed with [%d] out of [%d] members "+
+					"of keep [%s]",
+				len(announcedMemberIDs),
+				len(keepMemberIDs),
+				keepID,
+			)
+
+			return announcedMemberIDs, nil
+		}
+
 		return nil, fmt.Errorf(
-			"waiting for announcements timed out after: [%v]",
-			protocolAnnounceTimeout,
+			"waiting for announcements timed out after: [%v]; "+
+				"[%d] members announced but at least [%d] are required",
+			announceTimeout,
+			len(announcedMemberIDs),
+			quorum,
 		)
 	case context.Canceled:
 		logger.Infof("announce protocol completed successfully")
 
-		memberIDs := make([]MemberID, 0)
-		for _, memberID := range receivedMemberIDs {
-			memberIDs = append(memberIDs, memberID)
-		}
-
-		return memberIDs, nil
+		return collectMemberIDs(), nil
 	default:
 		return nil, fmt.Errorf("unexpected context error: [%v]", ctx.Err())
 	}
@@ -95,8 +95,10 @@ func TestAnnounceProtocol(t *testing.T) {
 				memberPublicKey,
 				keep.ID(),
 				keepMembers,
+				len(keepMembers),
 				broadcastChannel,
 				localChain.PublicKeyToOperatorID,
+				defaultProtocolAnnounceTimeout,
 			)
 			if err != nil {
 				errChan <- err
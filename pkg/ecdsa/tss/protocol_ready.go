@@ -5,6 +5,7 @@ import (
 	cecdsa "crypto/ecdsa"
 	"encoding/hex"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/keep-network/keep-core/pkg/net"
@@ -13,35 +14,95 @@ import (
 // protocolReadyTimeout defines a period within which the member sends and receives
 // notifications from peer members about their readiness to begin the protocol
 // execution. If the time limit is reached the ready protocol stage fails.
-const protocolReadyTimeout = 2 * time.Minute
+var protocolReadyTimeout = 2 * time.Minute
 
 // readyProtocol exchanges messages with peer members about readiness to start
 // the protocol execution. The member keeps sending the message in intervals
 // until they receive messages from all peer members. Function exits without an
 // error if messages were received from all peer members. If the timeout is
-// reached before receiving messages from all peer members the function returns
-// an error.
+// reached before receiving messages from all peer members, the function exits
+// without an error only if at least `quorum` members, including the current
+// one, signalled their readiness. Otherwise, it returns an error.
+//
+// Readiness is signalled within the given protocol session. Messages sent
+// within other sessions are ignored so that more than one protocol can be
+// executed concurrently by the same group.
+//
+// Members may end up with different sets of ready members, e.g. when some
+// readiness notifications arrived just before the timeout. Before the protocol
+// starts, members have to agree on the set of members executing it. Each
+// member broadcasts the set of members it considers ready and waits for
+// the same set confirmed by all members from that set. If any of them
+// confirmed a different set, or not all of them confirmed within the timeout,
+// the function returns an error and the protocol has to be retried.
+//
+// As a result, identifiers of all members ready to execute the protocol are
+// returned in the order they are listed in the group.
 func readyProtocol(
 	parentCtx context.Context,
 	group *groupInfo,
+	sessionID string,
 	broadcastChannel net.BroadcastChannel,
 	publicKeyToAddressFn func(cecdsa.PublicKey) []byte,
-) error {
-	logger.Infof("signalling readiness")
+	quorum int,
+) ([]MemberID, error) {
+	logger.Infof("signalling readiness for session [%s]", sessionID)
 
 	ctx, cancel := context.WithTimeout(parentCtx, protocolReadyTimeout)
 	defer cancel()
 
+	// Peer members may confirm their sets of ready members before the current
+	// member completes readiness signalling so messages are received for
+	// the entire lifetime of the protocol.
+	recvCtx, cancelRecv := context.WithCancel(parentCtx)
+	defer cancelRecv()
+
 	readyInChan := make(chan *ReadyMessage, len(group.groupMemberIDs))
-	handleReadyMessage := func(netMsg net.Message) {
+	confirmations := newReadyConfirmations()
+	// Messages whose sender ID does not match the sender authenticated by
+	// the network layer are ignored so that a member cannot signal
+	// readiness or confirm a set of ready members in the name of another
+	// member.
+	handleMessage := func(netMsg net.Message) {
 		switch msg := netMsg.Payload().(type) {
 		case *ReadyMessage:
-			readyInChan <- msg
+			if msg.SessionID != sessionID ||
+				!msg.SenderID.Equal(netMsg.SenderPublicKey()) {
+				return
+			}
+
+			select {
+			case readyInChan <- msg:
+			case <-ctx.Done():
+			}
+		case *ReadyConfirmationMessage:
+			if msg.SessionID != sessionID ||
+				!msg.SenderID.Equal(netMsg.SenderPublicKey()) {
+				return
+			}
+
+			confirmations.add(msg)
 		}
 	}
-	broadcastChannel.Recv(ctx, handleReadyMessage)
+	broadcastChannel.Recv(recvCtx, handleMessage)
 
+	readyMembersMutex := &sync.Mutex{}
 	readyMembers := make(map[string]bool) // member address -> ready?
+
+	markReady := func(memberAddress string) int {
+		readyMembersMutex.Lock()
+		defer readyMembersMutex.Unlock()
+
+		readyMembers[memberAddress] = true
+		return len(readyMembers)
+	}
+	isReady := func(memberAddress string) bool {
+		readyMembersMutex.Lock()
+		defer readyMembersMutex.Unlock()
+
+		return readyMembers[memberAddress]
+	}
+
 	go func() {
 
 		for {
@@ -49,6 +110,8 @@ func readyProtocol(
 			case <-ctx.Done():
 				return
 			case msg := <-readyInChan:
+				readyCount := 0
+
 				for _, memberID := range group.groupMemberIDs {
 					if msg.SenderID.Equal(memberID) {
 						memberAddress, err := memberIDToAddress(
@@ -64,7 +127,7 @@ func readyProtocol(
 							)
 							break
 						}
-						readyMembers[memberAddress] = true
+						readyCount = markReady(memberAddress)
 
 						logger.Infof(
 							"member [%s] from keep [%s] announced its readiness",
@@ -76,7 +139,7 @@ func readyProtocol(
 					}
 				}
 
-				if len(readyMembers) == len(group.groupMemberIDs) {
+				if readyCount == len(group.groupMemberIDs) {
 					cancel()
 				}
 			}
@@ -86,7 +149,10 @@ func readyProtocol(
 	go func() {
 		sendMessage := func() {
 			if err := broadcastChannel.Send(ctx,
-				&ReadyMessage{SenderID: group.memberID},
+				&ReadyMessage{
+					SenderID:  group.memberID,
+					SessionID: sessionID,
+				},
 			); err != nil {
 				logger.Errorf("failed to send readiness notification: [%v]", err)
 			}
@@ -107,8 +173,8 @@ func readyProtocol(
 
 	<-ctx.Done()
 
-	switch ctx.Err() {
-	case context.DeadlineExceeded:
+	collectReadyMembers := func() []MemberID {
+		readyMemberIDs := make([]MemberID, 0)
 		for _, memberID := range group.groupMemberIDs {
 			memberAddress, err := memberIDToAddress(memberID, publicKeyToAddressFn)
 			if err != nil {
@@ -120,7 +186,7 @@ func readyProtocol(
 				)
 				continue
 			}
-			if !readyMembers[memberAddress] {
+			if !isReady(memberAddress) {
 				logger.Errorf(
 					"member [%s] has not announced its readiness for keep [%s]; "+
 						"check if keep client for that operator is active and "+
@@ -128,20 +194,204 @@ func readyProtocol(
 					memberAddress,
 					group.groupID,
 				)
+				continue
 			}
+
+			readyMemberIDs = append(readyMemberIDs, memberID)
 		}
-		return fmt.Errorf(
-			"waiting for readiness timed out after: [%v]", protocolReadyTimeout,
+		return readyMemberIDs
+	}
+
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		readyMemberIDs := collectReadyMembers()
+		if len(readyMemberIDs) >= quorum {
+			logger.Infof(
+				"signalled readiness with [%d] out of [%d] members of keep [%s]",
+				len(readyMemberIDs),
+				len(group.groupMemberIDs),
+				group.groupID,
+			)
+
+			return confirmReadyMembers(
+				parentCtx,
+				group,
+				sessionID,
+				broadcastChannel,
+				confirmations,
+				readyMemberIDs,
+			)
+		}
+
+		logger.Errorf(
+			"[%d] members of keep [%s] are ready but at least [%d] are required",
+			len(readyMemberIDs),
+			group.groupID,
+			quorum,
 		)
+
+		culprits := []Culprit{}
+		for _, memberID := range group.groupMemberIDs {
+			if !containsMemberID(readyMemberIDs, memberID) {
+				culprits = append(culprits, Culprit{
+					MemberID: memberID,
+					Reason:   NoMessage,
+				})
+			}
+		}
+
+		return nil, timeoutError{protocolReadyTimeout, "readiness", culprits}
 	case context.Canceled:
 		logger.Infof("successfully signalled readiness")
 
-		return nil
+		return confirmReadyMembers(
+			parentCtx,
+			group,
+			sessionID,
+			broadcastChannel,
+			confirmations,
+			group.groupMemberIDs,
+		)
 	default:
-		return fmt.Errorf("unexpected context error: [%v]", ctx.Err())
+		return nil, fmt.Errorf("unexpected context error: [%v]", ctx.Err())
 	}
 }
 
+// confirmReadyMembers broadcasts the set of members the current member
+// considers ready to execute the protocol and waits until all members from
+// that set confirm exactly the same set. It returns an error if any member
+// from the set confirmed a different one or if not all of them confirmed
+// the set within the timeout.
+func confirmReadyMembers(
+	parentCtx context.Context,
+	group *groupInfo,
+	sessionID string,
+	broadcastChannel net.BroadcastChannel,
+	confirmations *readyConfirmations,
+	readyMemberIDs []MemberID,
+) ([]MemberID, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, protocolReadyTimeout)
+	defer cancel()
+
+	sendMessage := func() {
+		if err := broadcastChannel.Send(ctx,
+			&ReadyConfirmationMessage{
+				SenderID:       group.memberID,
+				SessionID:      sessionID,
+				ReadyMemberIDs: readyMemberIDs,
+			},
+		); err != nil {
+			logger.Errorf("failed to send ready members confirmation: [%v]", err)
+		}
+	}
+
+	// Send the message first time. It will be periodically retransmitted
+	// by the broadcast channel for the entire lifetime of the context.
+	sendMessage()
+	// Send the message once again on exit as some peer member could start
+	// waiting for confirmations after the member sent the last message.
+	defer sendMessage()
+
+	for {
+		confirmed, err := confirmations.check(readyMemberIDs)
+		if err != nil {
+			return nil, err
+		}
+		if confirmed {
+			logger.Infof(
+				"[%d] members of keep [%s] confirmed the set of ready members",
+				len(readyMemberIDs),
+				group.groupID,
+			)
+
+			return readyMemberIDs, nil
+		}
+
+		select {
+		case <-confirmations.updated:
+		case <-ctx.Done():
+			culprits := []Culprit{}
+			for _, memberID := range readyMemberIDs {
+				if !confirmations.contains(memberID) {
+					culprits = append(culprits, Culprit{
+						MemberID: memberID,
+						Reason:   NoMessage,
+					})
+				}
+			}
+
+			return nil, timeoutError{
+				protocolReadyTimeout,
+				"ready members confirmation",
+				culprits,
+			}
+		}
+	}
+}
+
+// readyConfirmations collects sets of ready members confirmed by peer members
+// within one protocol session.
+type readyConfirmations struct {
+	mutex        sync.Mutex
+	readyMembers map[string][]MemberID // sender member ID -> confirmed set
+	updated      chan struct{}
+}
+
+func newReadyConfirmations() *readyConfirmations {
+	return &readyConfirmations{
+		readyMembers: make(map[string][]MemberID),
+		updated:      make(chan struct{}, 1),
+	}
+}
+
+func (rc *readyConfirmations) add(msg *ReadyConfirmationMessage) {
+	rc.mutex.Lock()
+	rc.readyMembers[msg.SenderID.String()] = msg.ReadyMemberIDs
+	rc.mutex.Unlock()
+
+	select {
+	case rc.updated <- struct{}{}:
+	default:
+	}
+}
+
+func (rc *readyConfirmations) contains(memberID MemberID) bool {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	_, ok := rc.readyMembers[memberID.String()]
+	return ok
+}
+
+// check returns true if all the given members confirmed exactly the given set
+// of members. It returns an error if any of them confirmed a different set.
+// Confirmations of members from outside of the set are not taken into account
+// as the set confirmed by those members necessarily differs from the one
+// confirmed by members from the set.
+func (rc *readyConfirmations) check(readyMemberIDs []MemberID) (bool, error) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	confirmed := true
+	for _, memberID := range readyMemberIDs {
+		confirmedMemberIDs, ok := rc.readyMembers[memberID.String()]
+		if !ok {
+			confirmed = false
+			continue
+		}
+
+		if !equalMemberIDs(confirmedMemberIDs, readyMemberIDs) {
+			return false, fmt.Errorf(
+				"member [%s] confirmed a different set of ready members "+
+					"than the current member",
+				memberID,
+			)
+		}
+	}
+
+	return confirmed, nil
+}
+
 func memberIDToAddress(
 	memberID MemberID,
 	publicKeyToAddressFn func(cecdsa.PublicKey) []byte,
@@ -157,3 +407,27 @@ func memberIDToAddress(
 
 	return "0x" + hex.EncodeToString(publicKeyToAddressFn(*pubKey)), nil
 }
+
+func containsMemberID(memberIDs []MemberID, memberID MemberID) bool {
+	for _, id := range memberIDs {
+		if id.Equal(memberID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func equalMemberIDs(memberIDs1, memberIDs2 []MemberID) bool {
+	if len(memberIDs1) != len(memberIDs2) {
+		return false
+	}
+
+	for i := range memberIDs1 {
+		if !memberIDs1[i].Equal(memberIDs2[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	cecdsa "crypto/ecdsa"
 	"crypto/elliptic"
+	"fmt"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
@@ -68,18 +70,34 @@ func TestReadyProtocol(t *testing.T) {
 				return &ReadyMessage{}
 			})
 
+			broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+				return &ReadyConfirmationMessage{}
+			})
+
 			defer waitGroup.Done()
 
-			if err := readyProtocol(
+			readyMembers, err := readyProtocol(
 				ctx,
 				groupInfo,
+				groupInfo.groupID,
 				broadcastChannel,
 				pubKeyToAddressFn,
-			); err != nil {
+				groupSize,
+			)
+			if err != nil {
 				errChan <- err
 				return
 			}
 
+			if len(readyMembers) != groupSize {
+				errChan <- fmt.Errorf(
+					"invalid number of ready members\nexpected: [%d]\nactual:   [%d]",
+					groupSize,
+					len(readyMembers),
+				)
+				return
+			}
+
 			mutex.Lock()
 			readyCount++
 			mutex.Unlock()
@@ -105,3 +123,464 @@ func TestReadyProtocol(t *testing.T) {
 	}
 
 }
+
+func TestReadyProtocolWithQuorum(t *testing.T) {
+	defaultProtocolReadyTimeout := protocolReadyTimeout
+	protocolReadyTimeout = 1 * time.Second
+	defer func() {
+		protocolReadyTimeout = defaultProtocolReadyTimeout
+	}()
+
+	groupSize := 5
+	quorum := 3
+
+	groupMembers, err := generateMemberKeys(groupSize)
+	if err != nil {
+		t.Fatalf("failed to generate members keys: [%v]", err)
+	}
+
+	pubKeyToAddressFn := func(publicKey cecdsa.PublicKey) []byte {
+		return elliptic.Marshal(publicKey.Curve, publicKey.X, publicKey.Y)
+	}
+
+	var tests = map[string]struct {
+		onlineMembersCount int
+		expectedError      bool
+	}{
+		"all members are online": {
+			onlineMembersCount: groupSize,
+		},
+		"quorum of members is online": {
+			onlineMembersCount: quorum,
+		},
+		"less than quorum of members is online": {
+			onlineMembersCount: quorum - 1,
+			expectedError:      true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			groupID := fmt.Sprintf("test-group-%s", testName)
+			onlineMembers := groupMembers[:test.onlineMembersCount]
+
+			type readyOutcome struct {
+				readyMembers []MemberID
+				err          error
+			}
+
+			outcomes := make(chan *readyOutcome, len(onlineMembers))
+
+			for _, memberID := range onlineMembers {
+				go func(memberID MemberID) {
+					groupInfo := &groupInfo{
+						groupID:        groupID,
+						memberID:       memberID,
+						groupMemberIDs: groupMembers,
+					}
+
+					memberPublicKey, err := memberID.PublicKey()
+					if err != nil {
+						outcomes <- &readyOutcome{err: err}
+						return
+					}
+
+					memberNetworkKey := key.NetworkPublic(*memberPublicKey)
+					networkProvider := newTestNetProvider(&memberNetworkKey)
+
+					broadcastChannel, err := networkProvider.BroadcastChannelFor(groupID)
+					if err != nil {
+						outcomes <- &readyOutcome{err: err}
+						return
+					}
+
+					broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+						return &ReadyMessage{}
+					})
+
+					broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+						return &ReadyConfirmationMessage{}
+					})
+
+					readyMembers, err := readyProtocol(
+						ctx,
+						groupInfo,
+						groupID,
+						broadcastChannel,
+						pubKeyToAddressFn,
+						quorum,
+					)
+
+					outcomes <- &readyOutcome{readyMembers, err}
+				}(memberID)
+			}
+
+			for range onlineMembers {
+				outcome := <-outcomes
+
+				if test.expectedError {
+					if outcome.err == nil {
+						t.Errorf("expected error")
+					}
+					continue
+				}
+
+				if outcome.err != nil {
+					t.Errorf("unexpected error: [%v]", outcome.err)
+					continue
+				}
+
+				if !reflect.DeepEqual(onlineMembers, outcome.readyMembers) {
+					t.Errorf(
+						"unexpected ready members\nexpected: [%v]\nactual:   [%v]",
+						onlineMembers,
+						outcome.readyMembers,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestReadyProtocolSessionIsolation(t *testing.T) {
+	defaultProtocolReadyTimeout := protocolReadyTimeout
+	protocolReadyTimeout = 1 * time.Second
+	defer func() {
+		protocolReadyTimeout = defaultProtocolReadyTimeout
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	groupID := "test-group-1"
+	groupSize := 3
+	quorum := 2
+
+	groupMembers, err := generateMemberKeys(groupSize)
+	if err != nil {
+		t.Fatalf("failed to generate members keys: [%v]", err)
+	}
+
+	pubKeyToAddressFn := func(publicKey cecdsa.PublicKey) []byte {
+		return elliptic.Marshal(publicKey.Curve, publicKey.X, publicKey.Y)
+	}
+
+	// All members signal readiness in the first session but only a quorum of
+	// them in the second one. Readiness signalled in the first session must
+	// not be counted in the second one.
+	sessionMembers := map[string][]MemberID{
+		"session-1": groupMembers,
+		"session-2": groupMembers[:quorum],
+	}
+
+	type readyOutcome struct {
+		sessionID    string
+		readyMembers []MemberID
+		err          error
+	}
+
+	outcomes := make(chan *readyOutcome, 2*groupSize)
+	outcomesCount := 0
+
+	for sessionID, members := range sessionMembers {
+		for _, memberID := range members {
+			outcomesCount++
+
+			go func(sessionID string, memberID MemberID) {
+				groupInfo := &groupInfo{
+					groupID:        groupID,
+					memberID:       memberID,
+					groupMemberIDs: groupMembers,
+				}
+
+				memberPublicKey, err := memberID.PublicKey()
+				if err != nil {
+					outcomes <- &readyOutcome{sessionID: sessionID, err: err}
+					return
+				}
+
+				memberNetworkKey := key.NetworkPublic(*memberPublicKey)
+				networkProvider := newTestNetProvider(&memberNetworkKey)
+
+				broadcastChannel, err := networkProvider.BroadcastChannelFor(groupID)
+				if err != nil {
+					outcomes <- &readyOutcome{sessionID: sessionID, err: err}
+					return
+				}
+
+				broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+					return &ReadyMessage{}
+				})
+
+				broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+					return &ReadyConfirmationMessage{}
+				})
+
+				readyMembers, err := readyProtocol(
+					ctx,
+					groupInfo,
+					sessionID,
+					broadcastChannel,
+					pubKeyToAddressFn,
+					quorum,
+				)
+
+				outcomes <- &readyOutcome{sessionID, readyMembers, err}
+			}(sessionID, memberID)
+		}
+	}
+
+	for i := 0; i < outcomesCount; i++ {
+		outcome := <-outcomes
+
+		if outcome.err != nil {
+			t.Errorf(
+				"unexpected error in session [%s]: [%v]",
+				outcome.sessionID,
+				outcome.err,
+			)
+			continue
+		}
+
+		expectedReadyMembers := sessionMembers[outcome.sessionID]
+		if !reflect.DeepEqual(expectedReadyMembers, outcome.readyMembers) {
+			t.Errorf(
+				"unexpected ready members in session [%s]\n"+
+					"expected: [%v]\nactual:   [%v]",
+				outcome.sessionID,
+				expectedReadyMembers,
+				outcome.readyMembers,
+			)
+		}
+	}
+}
+
+func TestReadyProtocolDifferentReadyMembers(t *testing.T) {
+	defaultProtocolReadyTimeout := protocolReadyTimeout
+	protocolReadyTimeout = 1 * time.Second
+	defer func() {
+		protocolReadyTimeout = defaultProtocolReadyTimeout
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	groupID := "test-group-different-ready-members"
+	groupSize := 3
+	quorum := 2
+
+	groupMembers, err := generateMemberKeys(groupSize)
+	if err != nil {
+		t.Fatalf("failed to generate members keys: [%v]", err)
+	}
+
+	pubKeyToAddressFn := func(publicKey cecdsa.PublicKey) []byte {
+		return elliptic.Marshal(publicKey.Curve, publicKey.X, publicKey.Y)
+	}
+
+	newBroadcastChannel := func(memberID MemberID) (net.BroadcastChannel, error) {
+		memberPublicKey, err := memberID.PublicKey()
+		if err != nil {
+			return nil, err
+		}
+
+		memberNetworkKey := key.NetworkPublic(*memberPublicKey)
+		networkProvider := newTestNetProvider(&memberNetworkKey)
+
+		broadcastChannel, err := networkProvider.BroadcastChannelFor(groupID)
+		if err != nil {
+			return nil, err
+		}
+
+		broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+			return &ReadyMessage{}
+		})
+
+		broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+			return &ReadyConfirmationMessage{}
+		})
+
+		return broadcastChannel, nil
+	}
+
+	// The last member signals readiness but confirms a set of ready members
+	// different from the one seen by the other members.
+	lastMember := groupMembers[groupSize-1]
+	lastMemberChannel, err := newBroadcastChannel(lastMember)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		// Give other members time to start listening for messages.
+		time.Sleep(100 * time.Millisecond)
+
+		if err := lastMemberChannel.Send(ctx, &ReadyMessage{
+			SenderID:  lastMember,
+			SessionID: groupID,
+		}); err != nil {
+			t.Error(err)
+		}
+
+		if err := lastMemberChannel.Send(ctx, &ReadyConfirmationMessage{
+			SenderID:       lastMember,
+			SessionID:      groupID,
+			ReadyMemberIDs: []MemberID{groupMembers[0], lastMember},
+		}); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	errs := make(chan error, groupSize-1)
+
+	for _, memberID := range groupMembers[:groupSize-1] {
+		go func(memberID MemberID) {
+			groupInfo := &groupInfo{
+				groupID:        groupID,
+				memberID:       memberID,
+				groupMemberIDs: groupMembers,
+			}
+
+			broadcastChannel, err := newBroadcastChannel(memberID)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			_, err = readyProtocol(
+				ctx,
+				groupInfo,
+				groupID,
+				broadcastChannel,
+				pubKeyToAddressFn,
+				quorum,
+			)
+
+			errs <- err
+		}(memberID)
+	}
+
+	for range groupMembers[:groupSize-1] {
+		if err := <-errs; err == nil {
+			t.Errorf("expected error")
+		}
+	}
+}
+
+func TestReadyProtocolSpoofedSenderID(t *testing.T) {
+	defaultProtocolReadyTimeout := protocolReadyTimeout
+	protocolReadyTimeout = 1 * time.Second
+	defer func() {
+		protocolReadyTimeout = defaultProtocolReadyTimeout
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	groupID := "test-group-spoofed-sender-id"
+	groupSize := 3
+
+	groupMembers, err := generateMemberKeys(groupSize)
+	if err != nil {
+		t.Fatalf("failed to generate members keys: [%v]", err)
+	}
+
+	pubKeyToAddressFn := func(publicKey cecdsa.PublicKey) []byte {
+		return elliptic.Marshal(publicKey.Curve, publicKey.X, publicKey.Y)
+	}
+
+	newBroadcastChannel := func(memberID MemberID) (net.BroadcastChannel, error) {
+		memberPublicKey, err := memberID.PublicKey()
+		if err != nil {
+			return nil, err
+		}
+
+		memberNetworkKey := key.NetworkPublic(*memberPublicKey)
+		networkProvider := newTestNetProvider(&memberNetworkKey)
+
+		broadcastChannel, err := networkProvider.BroadcastChannelFor(groupID)
+		if err != nil {
+			return nil, err
+		}
+
+		broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+			return &ReadyMessage{}
+		})
+
+		broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+			return &ReadyConfirmationMessage{}
+		})
+
+		return broadcastChannel, nil
+	}
+
+	honestMember := groupMembers[0]
+	maliciousMember := groupMembers[1]
+	absentMember := groupMembers[2]
+
+	// The malicious member signals readiness and confirms the set of all
+	// members both in their own name and in the name of the absent member.
+	maliciousMemberChannel, err := newBroadcastChannel(maliciousMember)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		// Give the honest member time to start listening for messages.
+		time.Sleep(100 * time.Millisecond)
+
+		for _, senderID := range []MemberID{maliciousMember, absentMember} {
+			if err := maliciousMemberChannel.Send(ctx, &ReadyMessage{
+				SenderID:  senderID,
+				SessionID: groupID,
+			}); err != nil {
+				t.Error(err)
+			}
+
+			if err := maliciousMemberChannel.Send(ctx, &ReadyConfirmationMessage{
+				SenderID:       senderID,
+				SessionID:      groupID,
+				ReadyMemberIDs: groupMembers,
+			}); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+
+	broadcastChannel, err := newBroadcastChannel(honestMember)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = readyProtocol(
+		ctx,
+		&groupInfo{
+			groupID:        groupID,
+			memberID:       honestMember,
+			groupMemberIDs: groupMembers,
+		},
+		groupID,
+		broadcastChannel,
+		pubKeyToAddressFn,
+		groupSize,
+	)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	expectedCulprits := []Culprit{
+		{MemberID: absentMember, Reason: NoMessage},
+	}
+
+	culprits := Culprits(err)
+	if !reflect.DeepEqual(expectedCulprits, culprits) {
+		t.Errorf(
+			"unexpected culprits\nexpected: [%v]\nactual:   [%v]",
+			expectedCulprits,
+			culprits,
+		)
+	}
+}
@@ -0,0 +1,293 @@
+package tss
+
+import (
+	"context"
+	cecdsa "crypto/ecdsa"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// keyShareReconciliationTimeout defines a period within which the member
+// reports refresh epochs of key shares it holds and receives reports from
+// peer members.
+var keyShareReconciliationTimeout = 2 * time.Minute
+
+// Suffix of the session ID in which members reconcile their key shares.
+// Reconciliation is executed for key shares of different epochs, so the session
+// ID is not prefixed with the epoch. Reports about key shares of epochs older
+// than the ones being reconciled are rejected instead.
+const reconcileSessionSuffix = "-reconcile"
+
+// reconcileProtocol exchanges refresh epochs of key shares held by members
+// until the context is done.
+//
+// A signer who completed a key share refresh holds key shares of two epochs:
+// the refreshed one and the one of the previous epoch. If any peer member
+// reports it does not hold the refreshed key share, the signer returns to
+// the key share of the previous epoch. A member who did not complete
+// the refresh never holds the refreshed key share, so no other member can
+// drop the key share of the previous epoch in the meantime. If all peer
+// members report they hold the refreshed key share, the signer drops the key
+// share of the previous epoch and the function returns immediately.
+//
+// Reports of key shares older than the oldest key share held by the member
+// are rejected. Such reports were sent within an earlier reconciliation,
+// either retransmitted or replayed, and must not make the member return to
+// the key share of the previous epoch. A peer member taking part in the current
+// reconciliation holds key shares of the same epochs as the member or newer.
+//
+// The member keeps reporting key shares it holds until the context is done, so
+// that peer members reconciling later receive the report. The function returns
+// earlier only if all peer members reported they hold the same key shares as
+// the member, as there is nothing left to reconcile for any of them.
+//
+// As a result the reconciled signer is returned, or the given signer if key
+// shares it holds have not changed.
+func reconcileProtocol(
+	ctx context.Context,
+	signer *ThresholdSigner,
+	broadcastChannel net.BroadcastChannel,
+	publicKeyToAddressFn func(cecdsa.PublicKey) []byte,
+) *ThresholdSigner {
+	sessionID := signer.groupID + reconcileSessionSuffix
+
+	logger.Infof(
+		"reconciling key shares of epochs [%v] for session [%s]",
+		signer.heldEpochs(),
+		sessionID,
+	)
+
+	oldestEpoch := signer.heldEpochs()[0]
+
+	reports := newKeyShareEpochsReports(signer.epoch)
+	handleMessage := func(netMsg net.Message) {
+		if msg, ok := netMsg.Payload().(*KeyShareEpochsMessage); ok {
+			if msg.SessionID != sessionID ||
+				msg.SenderID.Equal(signer.memberID) ||
+				!msg.SenderID.Equal(netMsg.SenderPublicKey()) {
+				return
+			}
+
+			if !epochsNotOlderThan(msg.Epochs, oldestEpoch) {
+				logger.Warningf(
+					"rejecting report of key share epochs [%v] of member [%s] "+
+						"of keep [%s] older than epoch [%d]",
+					msg.Epochs,
+					memberAddress(msg.SenderID, publicKeyToAddressFn),
+					signer.groupID,
+					oldestEpoch,
+				)
+				return
+			}
+
+			reports.add(msg)
+		}
+	}
+	broadcastChannel.Recv(ctx, handleMessage)
+
+	// Reports are retransmitted by the broadcast channel for the entire
+	// lifetime of the context used to send them. The report is replaced once
+	// the key shares held by the member change and sent once again when
+	// the member returns before the context is done, as peer members could
+	// start receiving reports after the member sent the last message.
+	cancelSend := func() {}
+	sendReport := func(signer *ThresholdSigner) {
+		cancelSend()
+
+		var sendCtx context.Context
+		sendCtx, cancelSend = context.WithCancel(ctx)
+
+		if err := broadcastChannel.Send(sendCtx, &KeyShareEpochsMessage{
+			SenderID:  signer.memberID,
+			SessionID: sessionID,
+			Epochs:    signer.heldEpochs(),
+		}); err != nil {
+			logger.Errorf("failed to send key share epochs: [%v]", err)
+		}
+	}
+	defer func() { cancelSend() }()
+
+	peerMembersCount := len(signer.groupMemberIDs) - 1
+
+	sendReport(signer)
+
+	for {
+		if !signer.IsReconciled() {
+			if lackingMemberID, ok := reports.lackingNewestEpoch(); ok {
+				logger.Warningf(
+					"member [%s] of keep [%s] does not hold the key share "+
+						"of epoch [%d]; returning to the key share of "+
+						"epoch [%d]",
+					memberAddress(lackingMemberID, publicKeyToAddressFn),
+					signer.groupID,
+					signer.epoch,
+					signer.epoch-1,
+				)
+
+				signer = signer.previous()
+				sendReport(signer)
+			} else if reports.count() == peerMembersCount {
+				logger.Infof(
+					"all members of keep [%s] hold the key share of "+
+						"epoch [%d]; dropping the key share of epoch [%d]",
+					signer.groupID,
+					signer.epoch,
+					signer.epoch-1,
+				)
+
+				signer = signer.withoutPrevious()
+				sendReport(signer)
+
+				return signer
+			}
+		} else if reports.allHold(signer.heldEpochs(), peerMembersCount) {
+			logger.Infof(
+				"all members of keep [%s] hold only the key share of "+
+					"epoch [%d]",
+				signer.groupID,
+				signer.epoch,
+			)
+
+			sendReport(signer)
+
+			return signer
+		}
+
+		select {
+		case <-reports.updated:
+		case <-ctx.Done():
+			if !signer.IsReconciled() {
+				logger.Warningf(
+					"[%d] out of [%d] peer members of keep [%s] reported "+
+						"key shares they hold; keeping key shares of "+
+						"epochs [%v]",
+					reports.count(),
+					peerMembersCount,
+					signer.groupID,
+					signer.heldEpochs(),
+				)
+			}
+
+			return signer
+		}
+	}
+}
+
+// keyShareEpochsReports collects refresh epochs of key shares reported by peer
+// members within one reconciliation session.
+type keyShareEpochsReports struct {
+	mutex sync.Mutex
+	// Refresh epoch of the newest key share held by the current member.
+	newestEpoch uint64
+	// Refresh epochs of key shares most recently reported by each member.
+	epochs map[string][]uint64 // sender member ID -> held epochs
+	// Member who reported it does not hold the key share of the newest epoch,
+	// if any. Retransmissions may deliver reports out of order, so the first
+	// such member is retained even if it later reports different epochs.
+	lackingMemberID MemberID
+	updated         chan struct{}
+}
+
+func newKeyShareEpochsReports(newestEpoch uint64) *keyShareEpochsReports {
+	return &keyShareEpochsReports{
+		newestEpoch: newestEpoch,
+		epochs:      make(map[string][]uint64),
+		updated:     make(chan struct{}, 1),
+	}
+}
+
+func (ker *keyShareEpochsReports) add(msg *KeyShareEpochsMessage) {
+	ker.mutex.Lock()
+	ker.epochs[msg.SenderID.String()] = msg.Epochs
+	if ker.lackingMemberID == nil && !containsEpoch(msg.Epochs, ker.newestEpoch) {
+		ker.lackingMemberID = msg.SenderID
+	}
+	ker.mutex.Unlock()
+
+	select {
+	case ker.updated <- struct{}{}:
+	default:
+	}
+}
+
+func (ker *keyShareEpochsReports) count() int {
+	ker.mutex.Lock()
+	defer ker.mutex.Unlock()
+
+	return len(ker.epochs)
+}
+
+// lackingNewestEpoch returns ID of a member who reported it does not hold
+// the key share of the newest epoch held by the current member.
+func (ker *keyShareEpochsReports) lackingNewestEpoch() (MemberID, bool) {
+	ker.mutex.Lock()
+	defer ker.mutex.Unlock()
+
+	return ker.lackingMemberID, ker.lackingMemberID != nil
+}
+
+// allHold returns true if the given number of members reported exactly
+// the given epochs.
+func (ker *keyShareEpochsReports) allHold(epochs []uint64, count int) bool {
+	ker.mutex.Lock()
+	defer ker.mutex.Unlock()
+
+	if len(ker.epochs) != count {
+		return false
+	}
+
+	for _, reportedEpochs := range ker.epochs {
+		if len(reportedEpochs) != len(epochs) {
+			return false
+		}
+		for i := range epochs {
+			if reportedEpochs[i] != epochs[i] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func containsEpoch(epochs []uint64, epoch uint64) bool {
+	for _, e := range epochs {
+		if e == epoch {
+			return true
+		}
+	}
+
+	return false
+}
+
+// epochsNotOlderThan returns true if the given epochs are not empty and none of
+// them is older than the given one.
+func epochsNotOlderThan(epochs []uint64, epoch uint64) bool {
+	if len(epochs) == 0 {
+		return false
+	}
+
+	for _, e := range epochs {
+		if e < epoch {
+			return false
+		}
+	}
+
+	return true
+}
+
+// memberAddress returns the address of the member for logging purposes, or
+// the member ID if the address cannot be determined.
+func memberAddress(
+	memberID MemberID,
+	publicKeyToAddressFn func(cecdsa.PublicKey) []byte,
+) string {
+	address, err := memberIDToAddress(memberID, publicKeyToAddressFn)
+	if err != nil {
+		return memberID.String()
+	}
+
+	return address
+}
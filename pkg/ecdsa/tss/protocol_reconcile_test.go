@@ -0,0 +1,205 @@
+package tss
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-ecdsa/internal/testdata"
+)
+
+func TestReconcileKeyShare(t *testing.T) {
+	defaultReconciliationTimeout := keyShareReconciliationTimeout
+	keyShareReconciliationTimeout = 3 * time.Second
+	defer func() {
+		keyShareReconciliationTimeout = defaultReconciliationTimeout
+	}()
+
+	groupSize := 3
+
+	testData, err := testdata.LoadKeygenTestFixtures(groupSize)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	var tests = map[string]struct {
+		// Indexes of members who completed the refresh of key shares of
+		// epoch 4.
+		refreshedMembers []int
+		// Indexes of members who execute the reconciliation.
+		reconcilingMembers []int
+		// Indexes of members who, instead of reconciling, report key shares
+		// of epochs 3 and 4 as if they were reconciling the previous
+		// refresh.
+		staleReportingMembers []int
+		// Epochs of key shares held by reconciling members after
+		// the reconciliation.
+		expectedEpochs [][]uint64
+	}{
+		"all members refreshed key shares": {
+			refreshedMembers:   []int{0, 1, 2},
+			reconcilingMembers: []int{0, 1, 2},
+			expectedEpochs:     [][]uint64{{5}, {5}, {5}},
+		},
+		"one member did not refresh key share": {
+			refreshedMembers:   []int{0, 1},
+			reconcilingMembers: []int{0, 1, 2},
+			expectedEpochs:     [][]uint64{{4}, {4}, {4}},
+		},
+		"no member refreshed key share": {
+			refreshedMembers:   []int{},
+			reconcilingMembers: []int{0, 1, 2},
+			expectedEpochs:     [][]uint64{{4}, {4}, {4}},
+		},
+		"one member does not reconcile": {
+			refreshedMembers:   []int{0, 1, 2},
+			reconcilingMembers: []int{0, 1},
+			expectedEpochs:     [][]uint64{{4, 5}, {4, 5}},
+		},
+		"one member reports key shares of the previous refresh": {
+			refreshedMembers:      []int{0, 1, 2},
+			reconcilingMembers:    []int{0, 1},
+			staleReportingMembers: []int{2},
+			expectedEpochs:        [][]uint64{{4, 5}, {4, 5}},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			simulation := newSimulation(t, simulationSeed, groupSize, 1)
+
+			signers := make([]*ThresholdSigner, groupSize)
+			for i, memberID := range simulation.memberIDs {
+				signers[i] = &ThresholdSigner{
+					groupInfo: &groupInfo{
+						groupID:            simulation.groupID,
+						memberID:           memberID,
+						groupMemberIDs:     simulation.memberIDs,
+						dishonestThreshold: 1,
+					},
+					thresholdKey: ThresholdKey(testData[i]),
+					epoch:        4,
+				}
+			}
+			for _, i := range test.refreshedMembers {
+				previousKey := signers[i].thresholdKey
+				signers[i].thresholdKey = ThresholdKey(testData[(i+1)%groupSize])
+				signers[i].epoch = 5
+				signers[i].previousKey = &previousKey
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			for _, memberIndex := range test.staleReportingMembers {
+				netBridge, err := newNetworkBridge(
+					signers[memberIndex].groupInfo,
+					simulation.providers[memberIndex],
+					nil,
+				)
+				if err != nil {
+					t.Fatalf("failed to initialize network bridge: [%v]", err)
+				}
+
+				broadcastChannel, err := netBridge.getBroadcastChannel()
+				if err != nil {
+					t.Fatalf("failed to get broadcast channel: [%v]", err)
+				}
+
+				if err := broadcastChannel.Send(ctx, &KeyShareEpochsMessage{
+					SenderID:  signers[memberIndex].memberID,
+					SessionID: simulation.groupID + reconcileSessionSuffix,
+					Epochs:    []uint64{3, 4},
+				}); err != nil {
+					t.Fatalf("failed to send key share epochs: [%v]", err)
+				}
+			}
+
+			reconciledSigners := make([]*ThresholdSigner, len(test.reconcilingMembers))
+			reconcileErrors := make(chan error, len(test.reconcilingMembers))
+
+			var reconcileWait sync.WaitGroup
+			reconcileWait.Add(len(test.reconcilingMembers))
+
+			for i, memberIndex := range test.reconcilingMembers {
+				go func(index int, memberIndex int) {
+					defer reconcileWait.Done()
+
+					reconciledSigner, err := signers[memberIndex].ReconcileKeyShare(
+						ctx,
+						simulation.providers[memberIndex],
+						simulationPubKeyToAddress,
+						time.Now(),
+					)
+					if err != nil {
+						reconcileErrors <- fmt.Errorf("failed to reconcile: [%v]", err)
+						return
+					}
+
+					reconciledSigners[index] = reconciledSigner
+				}(i, memberIndex)
+			}
+
+			reconcileWait.Wait()
+			close(reconcileErrors)
+
+			for err := range reconcileErrors {
+				t.Fatalf("unexpected error on reconciliation: [%v]", err)
+			}
+
+			for i, reconciledSigner := range reconciledSigners {
+				if !reflect.DeepEqual(
+					test.expectedEpochs[i],
+					reconciledSigner.heldEpochs(),
+				) {
+					t.Errorf(
+						"unexpected epochs of member [%d]\n"+
+							"expected: [%v]\nactual:   [%v]",
+						test.reconcilingMembers[i],
+						test.expectedEpochs[i],
+						reconciledSigner.heldEpochs(),
+					)
+				}
+
+				// Key share of the epoch held by the signer after
+				// the reconciliation.
+				memberIndex := test.reconcilingMembers[i]
+				expectedKey := testData[memberIndex]
+				if reconciledSigner.RefreshEpoch() == 5 {
+					expectedKey = testData[(memberIndex+1)%groupSize]
+				}
+				if reconciledSigner.thresholdKey.Xi.Cmp(expectedKey.Xi) != 0 {
+					t.Errorf(
+						"unexpected key share of member [%d]",
+						memberIndex,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestEpochSessionPrefix(t *testing.T) {
+	signer := &ThresholdSigner{
+		groupInfo: &groupInfo{groupID: "test-group-id-1"},
+	}
+
+	if signer.epochSessionPrefix() != "test-group-id-1" {
+		t.Errorf(
+			"unexpected session prefix of epoch zero: [%s]",
+			signer.epochSessionPrefix(),
+		)
+	}
+
+	signer.epoch = 2
+
+	if signer.epochSessionPrefix() != "test-group-id-1-epoch-2" {
+		t.Errorf(
+			"unexpected session prefix of epoch two: [%s]",
+			signer.epochSessionPrefix(),
+		)
+	}
+}
@@ -12,18 +12,6 @@ import (
 	"github.com/keep-network/keep-ecdsa/pkg/chain/bitcoin"
 )
 
-const (
-	// recoveryProtocolReadyTimeout defines a period within which the member sends
-	// and receives notifications from peer members about their readiness to begin
-	// the broadcast recovery address protocol execution. If the time limit is
-	// reached the ready protocol stage fails.
-	//
-	// It is important that this value is greater than the timeout defined for
-	// Electrs connection, so the nodes/ can correctly synchronize liquidation
-	// protocol execution.
-	recoveryProtocolReadyTimeout = 2 * time.Minute
-)
-
 // recoveryInfo represents the broadcasted information needed from the other
 // signers to complete liquidation recovery.
 type recoveryInfo struct {
@@ -34,6 +22,13 @@ type recoveryInfo struct {
 // BroadcastRecoveryAddress broadcasts and receives the BTC recovery addresses
 // of each client so that each client can retrieve the underlying bitcoin in
 // the case that a keep is terminated.
+//
+// The ready timeout defines a period within which the member sends and receives
+// notifications from peer members about their readiness to begin the broadcast
+// recovery address protocol execution. If the time limit is reached the ready
+// protocol stage fails. It is important that the timeout is greater than
+// the timeout defined for Electrs connection, so the nodes can correctly
+// synchronize liquidation protocol execution.
 func BroadcastRecoveryAddress(
 	parentCtx context.Context,
 	btcRecoveryAddress string,
@@ -45,9 +40,8 @@ func BroadcastRecoveryAddress(
 	networkProvider net.Provider,
 	pubKeyToAddressFn func(cecdsa.PublicKey) []byte,
 	chainParams *chaincfg.Params,
+	protocolReadyTimeout time.Duration,
 ) ([]string, int32, error) {
-	protocolReadyTimeout := recoveryProtocolReadyTimeout
-
 	group := &groupInfo{
 		groupID:            groupID,
 		memberID:           memberID,
@@ -55,7 +49,11 @@ func BroadcastRecoveryAddress(
 		dishonestThreshold: int(dishonestThreshold),
 	}
 
-	netBridge, _ := newNetworkBridge(group, networkProvider)
+	netBridge, _ := newNetworkBridge(
+		group,
+		networkProvider,
+		transcriptFromContext(parentCtx),
+	)
 	broadcastChannel, _ := netBridge.getBroadcastChannel()
 	ctx, cancel := context.WithTimeout(parentCtx, protocolReadyTimeout)
 	defer cancel()
@@ -0,0 +1,250 @@
+package tss
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// roundProtocol is a multi-round protocol implemented in this package on top
+// of the party and round lifecycle of the TSS library. This way the protocol
+// parties can be bound to the network bridge the same way as parties of
+// protocols implemented in the library.
+type roundProtocol interface {
+	// lastRound returns the number of the last round of the protocol. Rounds
+	// are numbered starting from 1.
+	lastRound() int
+	// startRound executes the round with the given number and sends messages
+	// produced in it.
+	startRound(round *protocolRound) *tss.Error
+	// acceptsMessage returns true if the message is expected in the round
+	// with the given number.
+	acceptsMessage(roundNumber int, msg tss.ParsedMessage) bool
+	// roundMessages returns messages received in the round with the given
+	// number from the party with the given index. Some of the messages can
+	// be nil if they have not been received yet.
+	roundMessages(roundNumber int, partyIndex int) []tss.ParsedMessage
+	// storeMessage stores the message received from another party. It returns
+	// false if the message is not recognized by the protocol.
+	storeMessage(msg tss.ParsedMessage) bool
+}
+
+// protocolParty is a party executing the roundProtocol.
+type protocolParty struct {
+	*tss.BaseParty
+
+	params       *tss.Parameters
+	task         string
+	protocol     roundProtocol
+	messageTypes wireMessageTypes
+}
+
+func newProtocolParty(
+	params *tss.Parameters,
+	task string,
+	protocol roundProtocol,
+	messageTypes wireMessageTypes,
+) *protocolParty {
+	return &protocolParty{
+		BaseParty:    new(tss.BaseParty),
+		params:       params,
+		task:         task,
+		protocol:     protocol,
+		messageTypes: messageTypes,
+	}
+}
+
+func (p *protocolParty) Start() *tss.Error {
+	return tss.BaseStart(p, p.task)
+}
+
+func (p *protocolParty) Update(msg tss.ParsedMessage) (bool, *tss.Error) {
+	return tss.BaseUpdate(p, msg, p.task)
+}
+
+func (p *protocolParty) UpdateFromBytes(
+	wireBytes []byte,
+	from *tss.PartyID,
+	isBroadcast bool,
+) (bool, *tss.Error) {
+	msg, err := p.messageTypes.parseWireMessage(
+		wireBytes,
+		from,
+		isBroadcast,
+	)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+
+	return p.Update(msg)
+}
+
+func (p *protocolParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+
+	if maxFromIndex := len(p.params.Parties().IDs()) - 1; msg.GetFrom().Index > maxFromIndex {
+		return false, p.WrapError(
+			fmt.Errorf(
+				"received message with a sender index too great [%d > %d]",
+				msg.GetFrom().Index,
+				maxFromIndex,
+			),
+			msg.GetFrom(),
+		)
+	}
+
+	return true, nil
+}
+
+func (p *protocolParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+
+	if !p.protocol.storeMessage(msg) {
+		logger.Warningf("[party:%s]: ignoring unrecognized message: [%v]", p.PartyID(), msg)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (p *protocolParty) FirstRound() tss.Round {
+	return newFirstProtocolRound(p.params, p.task, p.protocol)
+}
+
+func (p *protocolParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *protocolParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+// protocolRound is a round of the roundProtocol. The round can proceed once
+// the messages expected in it have been received from all the parties.
+type protocolRound struct {
+	params   *tss.Parameters
+	task     string
+	protocol roundProtocol
+
+	number  int
+	started bool
+	// ok tracks parties whose messages expected in the round have been
+	// received.
+	ok []bool
+}
+
+func newFirstProtocolRound(
+	params *tss.Parameters,
+	task string,
+	protocol roundProtocol,
+) *protocolRound {
+	return &protocolRound{
+		params:   params,
+		task:     task,
+		protocol: protocol,
+		number:   1,
+		ok:       make([]bool, len(params.Parties().IDs())),
+	}
+}
+
+func (r *protocolRound) Params() *tss.Parameters {
+	return r.params
+}
+
+func (r *protocolRound) Start() *tss.Error {
+	if r.started {
+		return r.WrapError(errors.New("round already started"))
+	}
+
+	r.started = true
+	for j := range r.ok {
+		r.ok[j] = false
+	}
+	r.ok[r.params.PartyID().Index] = true
+
+	return r.protocol.startRound(r)
+}
+
+func (r *protocolRound) Update() (bool, *tss.Error) {
+	for j := range r.ok {
+		if r.ok[j] {
+			continue
+		}
+
+		for _, msg := range r.protocol.roundMessages(r.number, j) {
+			if msg == nil || !r.CanAccept(msg) {
+				return false, nil
+			}
+		}
+
+		r.ok[j] = true
+	}
+
+	return true, nil
+}
+
+func (r *protocolRound) RoundNumber() int {
+	return r.number
+}
+
+func (r *protocolRound) CanAccept(msg tss.ParsedMessage) bool {
+	return r.protocol.acceptsMessage(r.number, msg)
+}
+
+func (r *protocolRound) CanProceed() bool {
+	if !r.started {
+		return false
+	}
+
+	for _, ok := range r.ok {
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *protocolRound) NextRound() tss.Round {
+	if r.number == r.protocol.lastRound() {
+		return nil
+	}
+
+	return &protocolRound{
+		params:   r.params,
+		task:     r.task,
+		protocol: r.protocol,
+		number:   r.number + 1,
+		ok:       make([]bool, len(r.ok)),
+	}
+}
+
+func (r *protocolRound) WaitingFor() []*tss.PartyID {
+	parties := r.params.Parties().IDs()
+
+	waitingFor := make([]*tss.PartyID, 0, len(parties))
+	for j, ok := range r.ok {
+		if !ok {
+			waitingFor = append(waitingFor, parties[j])
+		}
+	}
+
+	return waitingFor
+}
+
+func (r *protocolRound) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, r.task, r.number, r.params.PartyID(), culprits...)
+}
+
+// markAllOK marks messages from all the parties as received. It should be used
+// by rounds which do not expect any messages.
+func (r *protocolRound) markAllOK() {
+	for j := range r.ok {
+		r.ok[j] = true
+	}
+}
@@ -0,0 +1,223 @@
+package tss
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/ecdsa/resharing"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// Suffixes of protocol session IDs of the old and new committee in the key
+// share refresh protocol and of the session in which members signal their
+// readiness to refresh key shares. Session IDs are prefixed with the refresh
+// epoch of the key share being refreshed.
+const (
+	oldCommitteeSessionSuffix = "-refresh-old"
+	newCommitteeSessionSuffix = "-refresh-new"
+	refreshSessionSuffix      = "-refresh"
+)
+
+// initializeKeyShareRefresh initializes a signer to run a key share refresh
+// protocol. The signer is a member of both the old committee, holding the
+// current key shares, and the new committee, receiving the refreshed key
+// shares. Each committee is represented by a separate party.
+//
+// TSS protocol requires pre-parameters such as safe primes to be generated for
+// execution. The parameters are used by the new committee party.
+func (s *ThresholdSigner) initializeKeyShareRefresh(
+	ctx context.Context,
+	tssPreParams *keygen.LocalPreParams,
+	netBridge *networkBridge,
+) (*refreshingSigner, error) {
+	oldPartyID, oldPartiesIDs, err := generatePartiesIDs(
+		s.memberID,
+		s.groupMemberIDs,
+		s.partyKeyFn(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate old parties IDs: [%v]", err)
+	}
+
+	newPartyID, newPartiesIDs, err := generatePartiesIDs(
+		s.memberID,
+		s.groupMemberIDs,
+		s.refreshedPartyKeyFn(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate new parties IDs: [%v]", err)
+	}
+
+	oldPeerContext := tss.NewPeerContext(tss.SortPartyIDs(oldPartiesIDs))
+	newPeerContext := tss.NewPeerContext(tss.SortPartyIDs(newPartiesIDs))
+
+	newReSharingParameters := func(partyID *tss.PartyID) *tss.ReSharingParameters {
+		return tss.NewReSharingParameters(
+			tss.EC(),
+			oldPeerContext,
+			newPeerContext,
+			partyID,
+			len(oldPartiesIDs),
+			s.dishonestThreshold,
+			len(newPartiesIDs),
+			s.dishonestThreshold,
+		)
+	}
+
+	// The old committee party zeroes the share it holds once the protocol
+	// completes. The share is copied so that the current signer remains
+	// usable if the protocol fails.
+	oldKey := keygen.LocalPartySaveData(s.thresholdKey)
+	oldKey.Xi = new(big.Int).Set(s.thresholdKey.Xi)
+
+	oldTSSMessageChan := make(chan tss.Message, len(s.groupMemberIDs))
+	oldEndChan := make(chan keygen.LocalPartySaveData, 1)
+	oldParty := resharing.NewLocalParty(
+		newReSharingParameters(oldPartyID),
+		oldKey,
+		oldTSSMessageChan,
+		oldEndChan,
+	)
+
+	newKey := keygen.NewLocalPartySaveData(len(newPartiesIDs))
+	newKey.LocalPreParams = *tssPreParams
+
+	newTSSMessageChan := make(chan tss.Message, len(s.groupMemberIDs))
+	newEndChan := make(chan keygen.LocalPartySaveData, 1)
+	newParty := resharing.NewLocalParty(
+		newReSharingParameters(newPartyID),
+		newKey,
+		newTSSMessageChan,
+		newEndChan,
+	)
+
+	oldSessionID := s.epochSessionPrefix() + oldCommitteeSessionSuffix
+	newSessionID := s.epochSessionPrefix() + newCommitteeSessionSuffix
+
+	router := func(tssLibMsg tss.Message) []string {
+		if tssLibMsg.IsToOldAndNewCommittees() {
+			return []string{oldSessionID, newSessionID}
+		}
+
+		if tssLibMsg.IsToOldCommittee() {
+			return []string{oldSessionID}
+		}
+
+		return []string{newSessionID}
+	}
+
+	// Parties of both committees exchange messages with each other so each of
+	// them needs to recognize senders from both committees.
+	allPartiesIDs := tss.SortedPartyIDs{}
+	allPartiesIDs = append(allPartiesIDs, oldPeerContext.IDs()...)
+	allPartiesIDs = append(allPartiesIDs, newPeerContext.IDs()...)
+
+	netBridge.bindSession(
+		ctx,
+		oldSessionID,
+		oldTSSMessageChan,
+		oldParty,
+		allPartiesIDs,
+		ecdsaWireMessageTypes,
+		router,
+	)
+	netBridge.bindSession(
+		ctx,
+		newSessionID,
+		newTSSMessageChan,
+		newParty,
+		allPartiesIDs,
+		ecdsaWireMessageTypes,
+		router,
+	)
+
+	return &refreshingSigner{
+		ThresholdSigner: s,
+		networkBridge:   netBridge,
+		oldParty:        oldParty,
+		oldEndChan:      oldEndChan,
+		newParty:        newParty,
+		newEndChan:      newEndChan,
+	}, nil
+}
+
+// refreshingSigner represents Signer who initialized key share refresh stage
+// and is ready to start the protocol.
+type refreshingSigner struct {
+	*ThresholdSigner
+
+	// Network bridge used for messages transport.
+	networkBridge *networkBridge
+	// Party of the old committee, holding the current key share.
+	oldParty tss.Party
+	// Channel where a result of the old committee party will be written to.
+	oldEndChan <-chan keygen.LocalPartySaveData
+	// Party of the new committee, receiving the refreshed key share.
+	newParty tss.Party
+	// Channel where a result of the new committee party will be written to.
+	newEndChan <-chan keygen.LocalPartySaveData
+}
+
+// refreshKeyShare executes the protocol to refresh the key share. This function
+// needs to be executed only after all members finished the initialization
+// stage. As a result it will return a Signer holding the refreshed key share
+// of the next refresh epoch along with the current key share, or error if
+// the key share refresh failed.
+func (s *refreshingSigner) refreshKeyShare(
+	ctx context.Context,
+	protocolTimeout time.Duration,
+) (*ThresholdSigner, error) {
+	// The new committee party has to be started first as it waits for
+	// messages from the old committee.
+	if err := s.newParty.Start(); err != nil {
+		return nil, fmt.Errorf(
+			"failed to start new committee party: [%v]",
+			s.newParty.WrapError(err),
+		)
+	}
+
+	if err := s.oldParty.Start(); err != nil {
+		return nil, fmt.Errorf(
+			"failed to start old committee party: [%v]",
+			s.oldParty.WrapError(err),
+		)
+	}
+
+	var (
+		refreshedKey  *keygen.LocalPartySaveData
+		oldPartyEnded bool
+	)
+
+	for refreshedKey == nil || !oldPartyEnded {
+		select {
+		case keyData := <-s.newEndChan:
+			refreshedKey = &keyData
+		case <-s.oldEndChan:
+			oldPartyEnded = true
+		case <-ctx.Done():
+			// Parties of both committees of the same member have the same ID
+			// so each member is blamed only once.
+			return nil, timeoutError{
+				protocolTimeout,
+				"key share refresh",
+				s.networkBridge.blamedCulprits(s.oldParty, s.newParty),
+			}
+		}
+	}
+
+	if !refreshedKey.ECDSAPub.Equals(s.thresholdKey.ECDSAPub) {
+		return nil, fmt.Errorf("refreshed key share has a different public key")
+	}
+
+	previousKey := s.thresholdKey
+
+	return &ThresholdSigner{
+		groupInfo:    s.groupInfo,
+		thresholdKey: ThresholdKey(*refreshedKey),
+		epoch:        s.epoch + 1,
+		previousKey:  &previousKey,
+	}, nil
+}
@@ -0,0 +1,333 @@
+package tss
+
+import (
+	"context"
+	cecdsa "crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-ecdsa/internal/testdata"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss/params"
+)
+
+func TestRefreshKeyShareAndSign(t *testing.T) {
+	defaultProtocolReadyTimeout := protocolReadyTimeout
+	protocolReadyTimeout = 5 * time.Second
+	defaultReconciliationTimeout := keyShareReconciliationTimeout
+	keyShareReconciliationTimeout = 5 * time.Second
+	defer func() {
+		protocolReadyTimeout = defaultProtocolReadyTimeout
+		keyShareReconciliationTimeout = defaultReconciliationTimeout
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Second)
+	defer cancel()
+
+	groupSize := 3
+	dishonestThreshold := uint(1)
+	groupID := fmt.Sprintf("tss-test-%d", rand.Int())
+
+	pubKeyToAddressFn := func(publicKey cecdsa.PublicKey) []byte {
+		return elliptic.Marshal(publicKey.Curve, publicKey.X, publicKey.Y)
+	}
+
+	groupMemberIDs, err := generateMemberKeys(groupSize)
+	if err != nil {
+		t.Fatalf("failed to generate members keys: [%v]", err)
+	}
+
+	testData, err := testdata.LoadKeygenTestFixtures(5)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	networkProviders := make([]net.Provider, groupSize)
+	for i, memberID := range groupMemberIDs {
+		memberPublicKey, err := memberID.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		networkPublicKey := key.NetworkPublic(*memberPublicKey)
+		networkProviders[i] = newTestNetProvider(&networkPublicKey)
+	}
+
+	signers := make([]*ThresholdSigner, groupSize)
+	keyGenErrors := make(chan error, groupSize)
+
+	var keyGenWait sync.WaitGroup
+	keyGenWait.Add(groupSize)
+
+	for i, memberID := range groupMemberIDs {
+		go func(memberID MemberID, index int) {
+			defer keyGenWait.Done()
+
+			preParams := testData[index].LocalPreParams
+
+			signer, err := GenerateThresholdSigner(
+				ctx,
+				groupID,
+				memberID,
+				groupMemberIDs,
+				dishonestThreshold,
+				networkProviders[index],
+				pubKeyToAddressFn,
+				params.NewBox(&preParams),
+				defaultKeyGenerationProtocolTimeout,
+			)
+			if err != nil {
+				keyGenErrors <- fmt.Errorf("failed to generate signer: [%v]", err)
+				return
+			}
+
+			signers[index] = signer
+		}(memberID, i)
+	}
+
+	keyGenWait.Wait()
+	close(keyGenErrors)
+
+	for err := range keyGenErrors {
+		t.Fatalf("unexpected error on key generation: [%v]", err)
+	}
+
+	publicKey := signers[0].PublicKey()
+
+	// The second refresh switches members back to the original party keys.
+	for refresh := 1; refresh <= 2; refresh++ {
+		refreshedSigners := make([]*ThresholdSigner, groupSize)
+		refreshErrors := make(chan error, groupSize)
+
+		var refreshWait sync.WaitGroup
+		refreshWait.Add(groupSize)
+
+		for i := range signers {
+			go func(index int) {
+				defer refreshWait.Done()
+
+				preParams := testData[(index+refresh)%len(testData)].LocalPreParams
+
+				refreshedSigner, err := signers[index].RefreshKeyShare(
+					ctx,
+					networkProviders[index],
+					pubKeyToAddressFn,
+					params.NewBox(&preParams),
+					time.Minute,
+				)
+				if err != nil {
+					refreshErrors <- fmt.Errorf("failed to refresh key share: [%v]", err)
+					return
+				}
+
+				refreshedSigners[index] = refreshedSigner
+			}(i)
+		}
+
+		refreshWait.Wait()
+		close(refreshErrors)
+
+		for err := range refreshErrors {
+			t.Fatalf("unexpected error on key share refresh [%d]: [%v]", refresh, err)
+		}
+
+		for i, refreshedSigner := range refreshedSigners {
+			if !reflect.DeepEqual(publicKey, refreshedSigner.PublicKey()) {
+				t.Errorf(
+					"unexpected public key after refresh [%d]\nexpected: [%v]\nactual:   [%v]",
+					refresh,
+					publicKey,
+					refreshedSigner.PublicKey(),
+				)
+			}
+
+			if signers[i].thresholdKey.Xi.Cmp(refreshedSigner.thresholdKey.Xi) == 0 {
+				t.Errorf("key share has not changed after refresh [%d]", refresh)
+			}
+
+			if signers[i].thresholdKey.Xi.Sign() == 0 {
+				t.Errorf("key share of the previous signer has been cleared")
+			}
+
+			if refreshedSigner.RefreshEpoch() != uint64(refresh) {
+				t.Errorf(
+					"unexpected refresh epoch\nexpected: [%v]\nactual:   [%v]",
+					refresh,
+					refreshedSigner.RefreshEpoch(),
+				)
+			}
+
+			if refreshedSigner.IsReconciled() ||
+				refreshedSigner.previousKey.Xi.Cmp(signers[i].thresholdKey.Xi) != 0 {
+				t.Errorf("refreshed signer does not hold the previous key share")
+			}
+		}
+
+		reconciledSigners := make([]*ThresholdSigner, groupSize)
+		reconcileErrors := make(chan error, groupSize)
+
+		var reconcileWait sync.WaitGroup
+		reconcileWait.Add(groupSize)
+
+		for i := range refreshedSigners {
+			go func(index int) {
+				defer reconcileWait.Done()
+
+				reconciledSigner, err := refreshedSigners[index].ReconcileKeyShare(
+					ctx,
+					networkProviders[index],
+					pubKeyToAddressFn,
+					time.Now(),
+				)
+				if err != nil {
+					reconcileErrors <- fmt.Errorf("failed to reconcile key share: [%v]", err)
+					return
+				}
+
+				reconciledSigners[index] = reconciledSigner
+			}(i)
+		}
+
+		reconcileWait.Wait()
+		close(reconcileErrors)
+
+		for err := range reconcileErrors {
+			t.Fatalf("unexpected error on key share reconciliation [%d]: [%v]", refresh, err)
+		}
+
+		for _, reconciledSigner := range reconciledSigners {
+			if !reconciledSigner.IsReconciled() ||
+				reconciledSigner.RefreshEpoch() != uint64(refresh) {
+				t.Errorf(
+					"unexpected epochs of key shares after refresh [%d]: [%v]",
+					refresh,
+					reconciledSigner.heldEpochs(),
+				)
+			}
+		}
+
+		signers = reconciledSigners
+
+		// Signing with `t + 1` members while the last member is offline.
+		digest := sha256.Sum256([]byte(fmt.Sprintf("message to sign %d", refresh)))
+		signingMembersCount := int(dishonestThreshold) + 1
+
+		signatures := make([]*ecdsa.Signature, signingMembersCount)
+		signingErrors := make(chan error, signingMembersCount)
+
+		var signingWait sync.WaitGroup
+		signingWait.Add(signingMembersCount)
+
+		for i := 0; i < signingMembersCount; i++ {
+			go func(index int) {
+				defer signingWait.Done()
+
+				signature, err := signers[index].CalculateSignature(
+					ctx,
+					digest[:],
+					networkProviders[index],
+					pubKeyToAddressFn,
+					defaultSigningProtocolTimeout,
+				)
+				if err != nil {
+					signingErrors <- fmt.Errorf("failed to sign: [%v]", err)
+					return
+				}
+
+				signatures[index] = signature
+			}(i)
+		}
+
+		signingWait.Wait()
+		close(signingErrors)
+
+		for err := range signingErrors {
+			t.Fatalf("unexpected error on signing after refresh [%d]: [%v]", refresh, err)
+		}
+
+		if !cecdsa.Verify(publicKey, digest[:], signatures[0].R, signatures[0].S) {
+			t.Errorf("invalid signature after refresh [%d]: [%+v]", refresh, signatures[0])
+		}
+	}
+}
+
+func TestRefreshKeyShareOfLocalSigner(t *testing.T) {
+	signer, err := GenerateLocalSigner(
+		"test-group-id-1",
+		MemberID([]byte("member-1")),
+	)
+	if err != nil {
+		t.Fatalf("failed to generate local signer: [%v]", err)
+	}
+
+	_, err = signer.RefreshKeyShare(
+		context.Background(),
+		nil,
+		nil,
+		nil,
+		time.Minute,
+	)
+
+	expectedError := fmt.Errorf(
+		"key share of a single-member group cannot be refreshed",
+	)
+	if !reflect.DeepEqual(expectedError, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestRefreshKeyShareOfUnreconciledSigner(t *testing.T) {
+	testData, err := testdata.LoadKeygenTestFixtures(2)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	groupMemberIDs := []MemberID{
+		MemberID([]byte("member-1")),
+		MemberID([]byte("member-2")),
+	}
+
+	previousKey := ThresholdKey(testData[0])
+	signer := &ThresholdSigner{
+		groupInfo: &groupInfo{
+			groupID:            "test-group-id-1",
+			memberID:           groupMemberIDs[0],
+			groupMemberIDs:     groupMemberIDs,
+			dishonestThreshold: 1,
+		},
+		thresholdKey: ThresholdKey(testData[1]),
+		epoch:        1,
+		previousKey:  &previousKey,
+	}
+
+	_, err = signer.RefreshKeyShare(
+		context.Background(),
+		nil,
+		nil,
+		nil,
+		time.Minute,
+	)
+
+	expectedError := fmt.Errorf(
+		"key share has to be reconciled before it is refreshed",
+	)
+	if !reflect.DeepEqual(expectedError, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+}
@@ -0,0 +1,68 @@
+package tss
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/tss"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss/gen/pb"
+)
+
+// newSchnorrSigningRound1Message creates a message broadcast in the first
+// round of the Schnorr signing protocol with commitments to the member's
+// nonces.
+func newSchnorrSigningRound1Message(
+	from *tss.PartyID,
+	bigD *crypto.ECPoint,
+	bigE *crypto.ECPoint,
+) tss.ParsedMessage {
+	content := &pb.SchnorrSigningRound1Message{
+		NonceCommitmentD: [][]byte{bigD.X().Bytes(), bigD.Y().Bytes()},
+		NonceCommitmentE: [][]byte{bigE.X().Bytes(), bigE.Y().Bytes()},
+	}
+
+	return newBroadcastMessage(from, content)
+}
+
+// newSchnorrSigningRound2Message creates a message broadcast in the second
+// round of the Schnorr signing protocol with the member's signature share.
+func newSchnorrSigningRound2Message(
+	from *tss.PartyID,
+	signatureShare *big.Int,
+) tss.ParsedMessage {
+	content := &pb.SchnorrSigningRound2Message{
+		SignatureShare: signatureShare.Bytes(),
+	}
+
+	return newBroadcastMessage(from, content)
+}
+
+func newBroadcastMessage(
+	from *tss.PartyID,
+	content tss.MessageContent,
+) tss.ParsedMessage {
+	routing := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+
+	return tss.NewMessage(
+		routing,
+		content,
+		tss.NewMessageWrapper(routing, content),
+	)
+}
+
+// unmarshalNonceCommitment unmarshals coordinates of a nonce commitment
+// point received in the first round of the Schnorr signing protocol.
+func unmarshalNonceCommitment(
+	ec elliptic.Curve,
+	coordinates [][]byte,
+) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(coordinates[0]),
+		new(big.Int).SetBytes(coordinates[1]),
+	)
+}
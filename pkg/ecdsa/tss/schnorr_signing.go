@@ -0,0 +1,685 @@
+package tss
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/tss"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss/gen/pb"
+)
+
+const (
+	schnorrSigningTaskName = "schnorr signing"
+	// Suffix of the protocol session ID of the Schnorr signing protocol.
+	// The session ID includes also a hash of the digest and the tweak so that
+	// messages of signing protocols executed concurrently for different
+	// digests or keys are not mixed.
+	schnorrSigningSessionSuffix = "-schnorr-sign-"
+)
+
+// schnorrSigningSessionID returns ID of the protocol session in which
+// the Schnorr signature for the given digest and tweak is calculated.
+func (s *ThresholdSigner) schnorrSigningSessionID(
+	digest []byte,
+	tweak []byte,
+) string {
+	hash := sha256.New()
+	hash.Write(digest)
+	hash.Write(tweak)
+
+	return s.epochSessionPrefix() + schnorrSigningSessionSuffix +
+		hex.EncodeToString(hash.Sum(nil))
+}
+
+// schnorrKey is the group key in the x-only form used by BIP-340 signatures,
+// optionally tweaked as taproot output keys are.
+//
+// BIP-340 implicitly chooses the point with an even `y` coordinate for
+// the x-only public key. The group public key and the tweaked key are negated
+// if needed and so are the secret key shares and the tweak, when they are used
+// to calculate signatures.
+type schnorrKey struct {
+	// Public key with an even `y` coordinate.
+	publicKey *crypto.ECPoint
+	// Either 1 or -1 modulo the curve order; secret key shares have to be
+	// multiplied by it to correspond to the public key.
+	secretSign *big.Int
+	// Tweak added to the secret key, already multiplied by the sign of
+	// the tweaked key.
+	tweak *big.Int
+}
+
+// newSchnorrKey derives the key used to calculate Schnorr signatures from
+// the group public key. The key is tweaked with the given 32-byte value,
+// the same way as taproot output keys, unless the tweak is empty.
+func newSchnorrKey(
+	groupPublicKey *crypto.ECPoint,
+	tweak []byte,
+) (*schnorrKey, error) {
+	curve := tss.EC()
+	modN := common.ModInt(curve.Params().N)
+	zero := big.NewInt(0)
+
+	if len(tweak) != 0 && len(tweak) != 32 {
+		return nil, fmt.Errorf("tweak must be 32 bytes long")
+	}
+
+	t := new(big.Int).SetBytes(tweak)
+	if t.Cmp(curve.Params().N) >= 0 {
+		return nil, fmt.Errorf("tweak is out of range")
+	}
+
+	publicKey := groupPublicKey
+	secretSign := big.NewInt(1)
+
+	if publicKey.Y().Bit(0) != 0 {
+		publicKey = negatePoint(publicKey)
+		secretSign = modN.Sub(zero, secretSign)
+	}
+
+	if t.Sign() != 0 {
+		var err error
+		publicKey, err = publicKey.Add(crypto.ScalarBaseMult(curve, t))
+		if err != nil {
+			return nil, fmt.Errorf("invalid tweaked public key: [%v]", err)
+		}
+
+		if publicKey.Y().Bit(0) != 0 {
+			publicKey = negatePoint(publicKey)
+			secretSign = modN.Sub(zero, secretSign)
+			t = modN.Sub(zero, t)
+		}
+	}
+
+	return &schnorrKey{
+		publicKey:  publicKey,
+		secretSign: secretSign,
+		tweak:      t,
+	}, nil
+}
+
+// bytes returns the 32-byte x-only public key.
+func (sk *schnorrKey) bytes() []byte {
+	return sk.publicKey.X().FillBytes(make([]byte, ecdsa.SchnorrPublicKeySize))
+}
+
+// SchnorrPublicKey returns the 32-byte x-only public key of the group which
+// verifies BIP-340 signatures calculated with CalculateSchnorrSignature for
+// the same tweak. The key is the group public key tweaked with the given
+// 32-byte value, the same way as taproot output keys, unless the tweak is
+// empty.
+func (s *ThresholdSigner) SchnorrPublicKey(tweak []byte) ([]byte, error) {
+	key, err := newSchnorrKey(s.thresholdKey.ECDSAPub, tweak)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.bytes(), nil
+}
+
+// calculateSchnorrSignatureLocally calculates a Schnorr signature over
+// the given digest with the key of a single-member group.
+func (s *ThresholdSigner) calculateSchnorrSignatureLocally(
+	digest []byte,
+	key *schnorrKey,
+) ([]byte, error) {
+	curve := tss.EC()
+	modN := common.ModInt(curve.Params().N)
+
+	k := common.GetRandomPositiveInt(curve.Params().N)
+	bigR := crypto.ScalarBaseMult(curve, k)
+	if bigR.Y().Bit(0) != 0 {
+		k = modN.Sub(big.NewInt(0), k)
+		bigR = negatePoint(bigR)
+	}
+
+	r := bigR.X().FillBytes(make([]byte, 32))
+	challenge := ecdsa.SchnorrChallenge(r, key.bytes(), digest)
+
+	secretKey := modN.Add(
+		modN.Mul(key.secretSign, s.thresholdKey.Xi),
+		key.tweak,
+	)
+	z := modN.Add(k, modN.Mul(challenge, secretKey))
+
+	signature := append(r, z.FillBytes(make([]byte, 32))...)
+
+	if !ecdsa.VerifySchnorrSignature(key.bytes(), digest, signature) {
+		return nil, fmt.Errorf("signature verification failed")
+	}
+
+	return signature, nil
+}
+
+// initializeSchnorrSigning initializes a member to run the Schnorr signing
+// protocol. Signature will be calculated for provided digest by the provided
+// signing members. Network bridge has to be already connected.
+func (s *ThresholdSigner) initializeSchnorrSigning(
+	ctx context.Context,
+	sessionID string,
+	digest []byte,
+	key *schnorrKey,
+	signingMemberIDs []MemberID,
+	netBridge *networkBridge,
+) (*schnorrSigningSigner, error) {
+	tssMessageChan := make(chan tss.Message, len(signingMemberIDs))
+	endChan := make(chan []byte, 1)
+
+	currentPartyID, groupPartiesIDs, err := generatePartiesIDs(
+		s.memberID,
+		signingMemberIDs,
+		s.partyKeyFn(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate parties IDs: [%v]", err)
+	}
+
+	if currentPartyID == nil {
+		return nil, fmt.Errorf("member is not one of the signing members")
+	}
+
+	params := tss.NewParameters(
+		tss.EC(),
+		tss.NewPeerContext(tss.SortPartyIDs(groupPartiesIDs)),
+		currentPartyID,
+		len(groupPartiesIDs),
+		s.dishonestThreshold,
+	)
+
+	protocol := newSchnorrSigningProtocol(
+		params,
+		digest,
+		key,
+		s.thresholdKey,
+		tssMessageChan,
+		endChan,
+	)
+	party := newProtocolParty(
+		params,
+		schnorrSigningTaskName,
+		protocol,
+		schnorrWireMessageTypes,
+	)
+
+	netBridge.bindSession(
+		ctx,
+		sessionID,
+		tssMessageChan,
+		party,
+		params.Parties().IDs(),
+		schnorrWireMessageTypes,
+		func(tss.Message) []string { return []string{sessionID} },
+	)
+
+	return &schnorrSigningSigner{
+		networkBridge:   netBridge,
+		sessionID:       sessionID,
+		signingProtocol: protocol,
+		signingParty:    party,
+		signingEndChan:  endChan,
+	}, nil
+}
+
+// schnorrSigningSigner represents Signer who initialized Schnorr signing stage
+// and is ready to start signature calculation.
+type schnorrSigningSigner struct {
+	// Network bridge used for messages transport.
+	networkBridge *networkBridge
+	// ID of the protocol session the party is bound to.
+	sessionID string
+	// Protocol executed by the party.
+	signingProtocol *schnorrSigningProtocol
+	// Party for the signing protocol execution.
+	signingParty tss.Party
+	// Channel where a result of the signing protocol execution will be
+	// written to.
+	signingEndChan <-chan []byte
+}
+
+// sign executes the protocol to calculate a Schnorr signature. This function
+// needs to be executed only after all members finished the initialization
+// stage.
+func (s *schnorrSigningSigner) sign(
+	ctx context.Context,
+	protocolTimeout time.Duration,
+) ([]byte, error) {
+	if err := s.signingParty.Start(); err != nil {
+		return nil, fmt.Errorf(
+			"failed to start signing: [%v]",
+			s.signingParty.WrapError(err),
+		)
+	}
+
+	select {
+	case signature := <-s.signingEndChan:
+		// Send the share once again as peer members who joined the protocol
+		// late may still be waiting for it.
+		s.networkBridge.sendTSSMessage(
+			ctx,
+			s.signingProtocol.ownShareMessage(),
+			[]string{s.sessionID},
+		)
+
+		return signature, nil
+	case <-ctx.Done():
+		return nil, timeoutError{
+			protocolTimeout,
+			"schnorr signing",
+			s.networkBridge.blamedCulprits(s.signingParty),
+		}
+	}
+}
+
+// schnorrSigningProtocol calculates a BIP-340 Schnorr signature with the key
+// shares produced by the key generation protocol. The protocol follows FROST
+// (Komlo, Goldberg; https://eprint.iacr.org/2020/852.pdf):
+//
+//  1. Each member picks two random nonces d_i and e_i and broadcasts
+//     commitments D_i = d_i⋅G and E_i = e_i⋅G.
+//  2. Each member calculates binding factors ρ_j for all the members from
+//     the digest and all the commitments, the group nonce
+//     R = Σ (D_j + ρ_j⋅E_j) and the challenge c. The member broadcasts its
+//     signature share z_i = d_i + ρ_i⋅e_i + c⋅λ_i⋅x_i, where λ_i is
+//     the Lagrange coefficient of the member within the signing group.
+//  3. Each signature share is verified against the public key share of
+//     the member who sent it and the signature (R, Σ z_j) is returned.
+//
+// Nonces and secret key shares are negated where BIP-340 requires points with
+// an even `y` coordinate.
+type schnorrSigningProtocol struct {
+	params       *tss.Parameters
+	digest       []byte
+	key          *schnorrKey
+	thresholdKey ThresholdKey
+
+	out chan<- tss.Message
+	end chan<- []byte
+
+	// Nonces picked in the first round.
+	d, e *big.Int
+
+	// Values calculated in the second round and used to verify signature
+	// shares. Binding factors and nonce commitments are indexed by the party
+	// index.
+	bindingFactors   []*big.Int
+	nonceCommitments []*crypto.ECPoint
+	nonceSign        *big.Int
+	bigR             *crypto.ECPoint
+	challenge        *big.Int
+
+	// Messages received from other parties indexed by the party index.
+	round1Messages []tss.ParsedMessage
+	round2Messages []tss.ParsedMessage
+}
+
+func newSchnorrSigningProtocol(
+	params *tss.Parameters,
+	digest []byte,
+	key *schnorrKey,
+	thresholdKey ThresholdKey,
+	out chan<- tss.Message,
+	end chan<- []byte,
+) *schnorrSigningProtocol {
+	partyCount := len(params.Parties().IDs())
+
+	return &schnorrSigningProtocol{
+		params:         params,
+		digest:         digest,
+		key:            key,
+		thresholdKey:   thresholdKey,
+		out:            out,
+		end:            end,
+		round1Messages: make([]tss.ParsedMessage, partyCount),
+		round2Messages: make([]tss.ParsedMessage, partyCount),
+	}
+}
+
+func (ssp *schnorrSigningProtocol) lastRound() int {
+	return 3
+}
+
+func (ssp *schnorrSigningProtocol) startRound(round *protocolRound) *tss.Error {
+	switch round.number {
+	case 1:
+		return ssp.startRound1(round)
+	case 2:
+		return ssp.startRound2(round)
+	case 3:
+		round.markAllOK()
+		return ssp.finalize(round)
+	default:
+		return round.WrapError(fmt.Errorf("unexpected round [%d]", round.number))
+	}
+}
+
+func (ssp *schnorrSigningProtocol) acceptsMessage(
+	roundNumber int,
+	msg tss.ParsedMessage,
+) bool {
+	switch msg.Content().(type) {
+	case *pb.SchnorrSigningRound1Message:
+		return roundNumber == 1 && msg.IsBroadcast()
+	case *pb.SchnorrSigningRound2Message:
+		return roundNumber == 2 && msg.IsBroadcast()
+	}
+
+	return false
+}
+
+func (ssp *schnorrSigningProtocol) roundMessages(
+	roundNumber int,
+	partyIndex int,
+) []tss.ParsedMessage {
+	switch roundNumber {
+	case 1:
+		return []tss.ParsedMessage{ssp.round1Messages[partyIndex]}
+	case 2:
+		return []tss.ParsedMessage{ssp.round2Messages[partyIndex]}
+	}
+
+	return []tss.ParsedMessage{}
+}
+
+func (ssp *schnorrSigningProtocol) storeMessage(msg tss.ParsedMessage) bool {
+	switch msg.Content().(type) {
+	case *pb.SchnorrSigningRound1Message:
+		ssp.round1Messages[msg.GetFrom().Index] = msg
+		return true
+	case *pb.SchnorrSigningRound2Message:
+		ssp.round2Messages[msg.GetFrom().Index] = msg
+		return true
+	}
+
+	return false
+}
+
+// startRound1 picks the nonces and broadcasts commitments to them, points D_i
+// and E_i.
+func (ssp *schnorrSigningProtocol) startRound1(round *protocolRound) *tss.Error {
+	if len(ssp.digest) != 32 {
+		return round.WrapError(errors.New("digest must be 32 bytes long"))
+	}
+
+	ec := ssp.params.EC()
+
+	ssp.d = common.GetRandomPositiveInt(ec.Params().N)
+	ssp.e = common.GetRandomPositiveInt(ec.Params().N)
+
+	bigD := crypto.ScalarBaseMult(ec, ssp.d)
+	bigE := crypto.ScalarBaseMult(ec, ssp.e)
+
+	message := newSchnorrSigningRound1Message(ssp.params.PartyID(), bigD, bigE)
+	ssp.round1Messages[ssp.params.PartyID().Index] = message
+	ssp.out <- message
+
+	return nil
+}
+
+// startRound2 calculates the group nonce and the challenge from the nonce
+// commitments of all the members and broadcasts the member's signature share.
+func (ssp *schnorrSigningProtocol) startRound2(round *protocolRound) *tss.Error {
+	ec := ssp.params.EC()
+	modN := common.ModInt(ec.Params().N)
+	parties := ssp.params.Parties().IDs()
+
+	commitmentsD := make([]*crypto.ECPoint, len(parties))
+	commitmentsE := make([]*crypto.ECPoint, len(parties))
+	for j, message := range ssp.round1Messages {
+		content := message.Content().(*pb.SchnorrSigningRound1Message)
+
+		bigD, err := unmarshalNonceCommitment(ec, content.GetNonceCommitmentD())
+		if err != nil {
+			return round.WrapError(
+				fmt.Errorf("invalid nonce commitment: [%v]", err),
+				message.GetFrom(),
+			)
+		}
+		bigE, err := unmarshalNonceCommitment(ec, content.GetNonceCommitmentE())
+		if err != nil {
+			return round.WrapError(
+				fmt.Errorf("invalid nonce commitment: [%v]", err),
+				message.GetFrom(),
+			)
+		}
+
+		commitmentsD[j] = bigD
+		commitmentsE[j] = bigE
+	}
+
+	// Party keys are of variable length so they are hashed before being
+	// bound to binding factors.
+	partyKeyHashes := make([][32]byte, len(parties))
+	for j, party := range parties {
+		partyKeyHashes[j] = sha256.Sum256(party.GetKey())
+	}
+
+	// All the commitments, along with the digest and the public key, are
+	// bound to each binding factor so that a member cannot influence
+	// the group nonce after seeing commitments of other members.
+	commitments := make([]byte, 0, len(parties)*(32+2*33))
+	for j := range parties {
+		commitments = append(commitments, partyKeyHashes[j][:]...)
+		commitments = append(commitments, compressPoint(commitmentsD[j])...)
+		commitments = append(commitments, compressPoint(commitmentsE[j])...)
+	}
+
+	ssp.bindingFactors = make([]*big.Int, len(parties))
+	for j := range parties {
+		hash := ecdsa.TaggedHash(
+			"FROST/binding",
+			partyKeyHashes[j][:],
+			ssp.digest,
+			ssp.key.bytes(),
+			commitments,
+		)
+		ssp.bindingFactors[j] = new(big.Int).Mod(
+			new(big.Int).SetBytes(hash[:]),
+			ec.Params().N,
+		)
+	}
+
+	// Nonce commitment of each member is combined into a single point
+	// D_j + ρ_j⋅E_j and the group nonce is the sum of those points.
+	ssp.nonceCommitments = make([]*crypto.ECPoint, len(parties))
+	var bigR *crypto.ECPoint
+	for j := range parties {
+		nonceCommitment, err := commitmentsD[j].Add(
+			commitmentsE[j].ScalarMult(ssp.bindingFactors[j]),
+		)
+		if err != nil {
+			return round.WrapError(
+				fmt.Errorf("invalid nonce commitment: [%v]", err),
+				parties[j],
+			)
+		}
+		ssp.nonceCommitments[j] = nonceCommitment
+
+		if bigR == nil {
+			bigR = nonceCommitment
+			continue
+		}
+
+		bigR, err = bigR.Add(nonceCommitment)
+		if err != nil {
+			return round.WrapError(fmt.Errorf("invalid group nonce: [%v]", err))
+		}
+	}
+
+	ssp.nonceSign = big.NewInt(1)
+	if bigR.Y().Bit(0) != 0 {
+		bigR = negatePoint(bigR)
+		ssp.nonceSign = modN.Sub(big.NewInt(0), ssp.nonceSign)
+	}
+	ssp.bigR = bigR
+
+	ssp.challenge = ecdsa.SchnorrChallenge(
+		bigR.X().FillBytes(make([]byte, 32)),
+		ssp.key.bytes(),
+		ssp.digest,
+	)
+
+	index := ssp.params.PartyID().Index
+
+	nonce := modN.Add(ssp.d, modN.Mul(ssp.bindingFactors[index], ssp.e))
+	zi := modN.Add(
+		modN.Mul(ssp.nonceSign, nonce),
+		modN.Mul(
+			modN.Mul(ssp.challenge, ssp.lagrangeCoefficient(index)),
+			modN.Mul(ssp.key.secretSign, ssp.thresholdKey.Xi),
+		),
+	)
+
+	message := newSchnorrSigningRound2Message(ssp.params.PartyID(), zi)
+	ssp.round2Messages[index] = message
+	ssp.out <- message
+
+	return nil
+}
+
+// ownShareMessage returns the message with the member's share of
+// the signature broadcast in the second round.
+func (ssp *schnorrSigningProtocol) ownShareMessage() tss.ParsedMessage {
+	return ssp.round2Messages[ssp.params.PartyID().Index]
+}
+
+// finalize verifies signature shares of all the members and combines them
+// into the signature. Members whose shares are invalid are blamed for
+// the failure.
+func (ssp *schnorrSigningProtocol) finalize(round *protocolRound) *tss.Error {
+	ec := ssp.params.EC()
+	modN := common.ModInt(ec.Params().N)
+	parties := ssp.params.Parties().IDs()
+
+	z := modN.Mul(ssp.challenge, ssp.key.tweak)
+
+	culprits := []*tss.PartyID{}
+	for j, message := range ssp.round2Messages {
+		zj := new(big.Int).SetBytes(
+			message.Content().(*pb.SchnorrSigningRound2Message).GetSignatureShare(),
+		)
+
+		if !ssp.verifyShare(j, zj) {
+			culprits = append(culprits, parties[j])
+			continue
+		}
+
+		z = modN.Add(z, zj)
+	}
+
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("invalid signature shares"), culprits...)
+	}
+
+	signature := append(
+		ssp.bigR.X().FillBytes(make([]byte, 32)),
+		z.FillBytes(make([]byte, 32))...,
+	)
+
+	if !ecdsa.VerifySchnorrSignature(ssp.key.bytes(), ssp.digest, signature) {
+		return round.WrapError(errors.New("signature verification failed"))
+	}
+
+	ssp.end <- signature
+
+	return nil
+}
+
+// verifyShare checks the signature share of the party with the given index:
+// z_j⋅G = ±(D_j + ρ_j⋅E_j) + c⋅λ_j⋅(±X_j), where X_j is the public key
+// share of the party.
+func (ssp *schnorrSigningProtocol) verifyShare(index int, zj *big.Int) bool {
+	ec := ssp.params.EC()
+	modN := common.ModInt(ec.Params().N)
+
+	if zj.Cmp(ec.Params().N) >= 0 {
+		return false
+	}
+
+	publicKeyShare, err := ssp.publicKeyShare(index)
+	if err != nil {
+		return false
+	}
+
+	expected, err := ssp.nonceCommitments[index].ScalarMult(ssp.nonceSign).Add(
+		publicKeyShare.ScalarMult(
+			modN.Mul(
+				modN.Mul(ssp.challenge, ssp.lagrangeCoefficient(index)),
+				ssp.key.secretSign,
+			),
+		),
+	)
+	if err != nil {
+		return false
+	}
+
+	return crypto.ScalarBaseMult(ec, zj).Equals(expected)
+}
+
+// publicKeyShare returns the public key share of the party with the given
+// index.
+func (ssp *schnorrSigningProtocol) publicKeyShare(
+	index int,
+) (*crypto.ECPoint, error) {
+	partyKey := ssp.params.Parties().IDs()[index].KeyInt()
+
+	for j, shareID := range ssp.thresholdKey.Ks {
+		if shareID.Cmp(partyKey) == 0 {
+			return ssp.thresholdKey.BigXj[j], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no public key share for party [%v]", partyKey)
+}
+
+// lagrangeCoefficient calculates the Lagrange coefficient of the party with
+// the given index for interpolating the secret at zero from the shares of
+// the signing parties.
+func (ssp *schnorrSigningProtocol) lagrangeCoefficient(index int) *big.Int {
+	modN := common.ModInt(ssp.params.EC().Params().N)
+	parties := ssp.params.Parties().IDs()
+
+	xi := parties[index].KeyInt()
+
+	coefficient := big.NewInt(1)
+	for j, party := range parties {
+		if j == index {
+			continue
+		}
+
+		xj := party.KeyInt()
+		coefficient = modN.Mul(
+			coefficient,
+			modN.Mul(xj, modN.ModInverse(modN.Sub(xj, xi))),
+		)
+	}
+
+	return coefficient
+}
+
+// negatePoint returns the point with the same `x` and the opposite `y`
+// coordinate.
+func negatePoint(point *crypto.ECPoint) *crypto.ECPoint {
+	return crypto.NewECPointNoCurveCheck(
+		point.Curve(),
+		point.X(),
+		new(big.Int).Sub(point.Curve().Params().P, point.Y()),
+	)
+}
+
+// compressPoint serializes the point in the compressed SEC 1 form.
+func compressPoint(point *crypto.ECPoint) []byte {
+	prefix := byte(0x02)
+	if point.Y().Bit(0) != 0 {
+		prefix = 0x03
+	}
+
+	return append([]byte{prefix}, point.X().FillBytes(make([]byte, 32))...)
+}
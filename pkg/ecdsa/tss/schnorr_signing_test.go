@@ -0,0 +1,278 @@
+package tss
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	tssLib "github.com/binance-chain/tss-lib/tss"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/keep-network/keep-ecdsa/internal/testhelper/netsim"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
+)
+
+func TestSimulatedSchnorrSigning(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 180*time.Second)
+	defer cancel()
+
+	simulation := newSimulation(t, simulationSeed, 3, 1)
+
+	for i := range simulation.memberIDs {
+		simulation.setFaults(i, netsim.Faults{
+			DuplicateRate: 0.2,
+			ReorderRate:   0.2,
+			MaxDelay:      20 * time.Millisecond,
+		})
+	}
+
+	signers := simulation.generateKeys(ctx, t)
+
+	internalKey, err := signers[0].SchnorrPublicKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		tweak []byte
+	}{
+		"no tweak": {
+			tweak: nil,
+		},
+		"taproot tweak": {
+			tweak: ecdsa.TaprootTweak(internalKey),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			digest := sha256.Sum256([]byte("message to sign " + testName))
+
+			signatures := simulation.signSchnorr(ctx, t, signers, digest[:], test.tweak)
+
+			publicKey, err := signers[0].SchnorrPublicKey(test.tweak)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, signature := range signatures {
+				if !ecdsa.VerifySchnorrSignature(publicKey, digest[:], signature) {
+					t.Errorf(
+						"invalid signature of member [%d]: [%x]",
+						i,
+						signature,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestSchnorrPublicKey(t *testing.T) {
+	// Test vector from BIP-86, the first receiving address of account 0:
+	// https://github.com/bitcoin/bips/blob/master/bip-0086.mediawiki
+	internalKey, _ := hex.DecodeString(
+		"cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115",
+	)
+	expectedOutputKey, _ := hex.DecodeString(
+		"a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c",
+	)
+
+	internalPoint, err := btcec.ParsePubKey(
+		append([]byte{0x02}, internalKey...),
+		btcec.S256(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evenKey, err := crypto.NewECPoint(tssLib.EC(), internalPoint.X, internalPoint.Y)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		groupPublicKey    *crypto.ECPoint
+		tweak             []byte
+		expectedPublicKey []byte
+	}{
+		"even group public key": {
+			groupPublicKey:    evenKey,
+			tweak:             nil,
+			expectedPublicKey: internalKey,
+		},
+		"odd group public key": {
+			groupPublicKey:    negatePoint(evenKey),
+			tweak:             nil,
+			expectedPublicKey: internalKey,
+		},
+		"taproot output key": {
+			groupPublicKey:    evenKey,
+			tweak:             ecdsa.TaprootTweak(internalKey),
+			expectedPublicKey: expectedOutputKey,
+		},
+		"taproot output key for odd group public key": {
+			groupPublicKey:    negatePoint(evenKey),
+			tweak:             ecdsa.TaprootTweak(internalKey),
+			expectedPublicKey: expectedOutputKey,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			signer := &ThresholdSigner{
+				thresholdKey: ThresholdKey{ECDSAPub: test.groupPublicKey},
+			}
+
+			publicKey, err := signer.SchnorrPublicKey(test.tweak)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(test.expectedPublicKey, publicKey) {
+				t.Errorf(
+					"unexpected public key\nexpected: [%x]\nactual:   [%x]",
+					test.expectedPublicKey,
+					publicKey,
+				)
+			}
+		})
+	}
+}
+
+func TestSchnorrLagrangeCoefficients(t *testing.T) {
+	// Shares of the secret 7 are points of the polynomial f(x) = 7 + 3x + 5x^2
+	// for the party keys. The secret is interpolated at zero from the shares.
+	keys := []*big.Int{big.NewInt(2), big.NewInt(5), big.NewInt(9)}
+	polynomial := func(x *big.Int) *big.Int {
+		y := new(big.Int).Mul(big.NewInt(5), new(big.Int).Mul(x, x))
+		y.Add(y, new(big.Int).Mul(big.NewInt(3), x))
+		return y.Add(y, big.NewInt(7))
+	}
+
+	partyIDs := make([]*tssLib.PartyID, len(keys))
+	for i, key := range keys {
+		partyIDs[i] = tssLib.NewPartyID(
+			fmt.Sprintf("%d", i),
+			fmt.Sprintf("party-%d", i),
+			key,
+		)
+	}
+	sortedPartyIDs := tssLib.SortPartyIDs(partyIDs)
+
+	protocol := &schnorrSigningProtocol{
+		params: tssLib.NewParameters(
+			tssLib.EC(),
+			tssLib.NewPeerContext(sortedPartyIDs),
+			sortedPartyIDs[0],
+			len(sortedPartyIDs),
+			1,
+		),
+	}
+
+	modN := tssLib.EC().Params().N
+
+	secret := big.NewInt(0)
+	for j, party := range sortedPartyIDs {
+		share := polynomial(party.KeyInt())
+		secret.Add(secret, new(big.Int).Mul(protocol.lagrangeCoefficient(j), share))
+		secret.Mod(secret, modN)
+	}
+
+	if secret.Cmp(big.NewInt(7)) != 0 {
+		t.Errorf(
+			"unexpected interpolated secret\nexpected: [%v]\nactual:   [%v]",
+			7,
+			secret,
+		)
+	}
+}
+
+func TestLocalSignerCalculateSchnorrSignature(t *testing.T) {
+	signer, err := GenerateLocalSigner("test-group-id-1", MemberID([]byte("member-1")))
+	if err != nil {
+		t.Fatalf("failed to generate local signer: [%v]", err)
+	}
+
+	digest := sha256.Sum256([]byte("test message"))
+
+	internalKey, err := signer.SchnorrPublicKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tweak := range [][]byte{nil, ecdsa.TaprootTweak(internalKey)} {
+		signature, err := signer.CalculateSchnorrSignature(
+			context.Background(),
+			digest[:],
+			tweak,
+			nil,
+			nil,
+			time.Minute,
+		)
+		if err != nil {
+			t.Fatalf("failed to calculate signature: [%v]", err)
+		}
+
+		publicKey, err := signer.SchnorrPublicKey(tweak)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !ecdsa.VerifySchnorrSignature(publicKey, digest[:], signature) {
+			t.Errorf("invalid signature for tweak [%x]: [%x]", tweak, signature)
+		}
+	}
+}
+
+// signSchnorr executes Schnorr signing with the given signers. Signers are
+// expected to be the first members of the group.
+func (s *simulation) signSchnorr(
+	ctx context.Context,
+	t *testing.T,
+	signers []*ThresholdSigner,
+	digest []byte,
+	tweak []byte,
+) [][]byte {
+	signatures := make([][]byte, len(signers))
+	signingErrors := make(chan error, len(signers))
+
+	var signingWait sync.WaitGroup
+	signingWait.Add(len(signers))
+
+	for i := range signers {
+		go func(index int) {
+			defer signingWait.Done()
+
+			signature, err := signers[index].CalculateSchnorrSignature(
+				ctx,
+				digest,
+				tweak,
+				s.providers[index],
+				simulationPubKeyToAddress,
+				time.Minute,
+			)
+			if err != nil {
+				signingErrors <- fmt.Errorf("failed to sign: [%v]", err)
+				return
+			}
+
+			signatures[index] = signature
+		}(i)
+	}
+
+	signingWait.Wait()
+	close(signingErrors)
+
+	for err := range signingErrors {
+		t.Fatalf("unexpected error on signing: [%v]", err)
+	}
+
+	return signatures
+}
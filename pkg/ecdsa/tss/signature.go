@@ -0,0 +1,92 @@
+package tss
+
+import (
+	cecdsa "crypto/ecdsa"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
+)
+
+// verifySignature verifies the signature calculated by the given signing
+// members over the given digest before it is used anywhere. The signature is valid if
+// `r` and `s` are within the range of the curve order, the signature verifies
+// against the group public key and the recovery ID recovers the group public
+// key.
+//
+// A valid signature is returned in the canonical form with a low `s` value,
+// flipping the recovery ID if `s` has been negated.
+//
+// An invalid signature means one of the peer members who took part in
+// the signing contributed an invalid share. The member cannot tell which one
+// so all peer signing members are blamed for the failure.
+func (s *ThresholdSigner) verifySignature(
+	digest []byte,
+	signature *ecdsa.Signature,
+	signingMemberIDs []MemberID,
+) (*ecdsa.Signature, error) {
+	fail := func(reason string) (*ecdsa.Signature, error) {
+		return nil, invalidSignatureError{
+			reason,
+			s.peerCulpritsAmong(signingMemberIDs, InvalidSignature),
+		}
+	}
+
+	curve := btcec.S256()
+
+	if signature == nil || signature.R == nil || signature.S == nil {
+		return fail("missing signature values")
+	}
+
+	if signature.R.Sign() <= 0 || signature.R.Cmp(curve.N) >= 0 {
+		return fail("r out of range")
+	}
+	if signature.S.Sign() <= 0 || signature.S.Cmp(curve.N) >= 0 {
+		return fail("s out of range")
+	}
+	if signature.RecoveryID < 0 || signature.RecoveryID > 3 {
+		return fail("recovery ID out of range")
+	}
+
+	normalized := &ecdsa.Signature{
+		R:          new(big.Int).Set(signature.R),
+		S:          new(big.Int).Set(signature.S),
+		RecoveryID: signature.RecoveryID,
+	}
+
+	// Negating `s` yields a signature which is valid for the same digest and
+	// the public key but the point `R` recovered from it has the other `y`
+	// coordinate parity, hence the lowest bit of the recovery ID is flipped.
+	halfOrder := new(big.Int).Rsh(curve.N, 1)
+	if normalized.S.Cmp(halfOrder) > 0 {
+		normalized.S.Sub(curve.N, normalized.S)
+		normalized.RecoveryID ^= 1
+	}
+
+	publicKey := s.PublicKey()
+
+	if !cecdsa.Verify(publicKey, digest, normalized.R, normalized.S) {
+		return fail("verification against the group public key failed")
+	}
+
+	compactSignature := make([]byte, 65)
+	compactSignature[0] = byte(27 + normalized.RecoveryID)
+	normalized.R.FillBytes(compactSignature[1:33])
+	normalized.S.FillBytes(compactSignature[33:65])
+
+	recoveredPublicKey, _, err := btcec.RecoverCompact(
+		curve,
+		compactSignature,
+		digest,
+	)
+	if err != nil {
+		return fail("public key could not be recovered")
+	}
+
+	if recoveredPublicKey.X.Cmp(publicKey.X) != 0 ||
+		recoveredPublicKey.Y.Cmp(publicKey.Y) != 0 {
+		return fail("recovery ID does not recover the group public key")
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,132 @@
+package tss
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
+)
+
+func TestVerifySignature(t *testing.T) {
+	memberID := MemberID([]byte("member-1"))
+	peerMemberID := MemberID([]byte("member-2"))
+	nonSigningMemberID := MemberID([]byte("member-3"))
+
+	signer, err := GenerateLocalSigner("test-group-id-1", memberID)
+	if err != nil {
+		t.Fatalf("failed to generate local signer: [%v]", err)
+	}
+
+	// Pretend the signer has peer members so that the peer who took part in
+	// the signing can be blamed and the one who did not can be left out.
+	signer.groupMemberIDs = []MemberID{memberID, peerMemberID, nonSigningMemberID}
+	signingMemberIDs := []MemberID{memberID, peerMemberID}
+
+	digest := sha256.Sum256([]byte("test message"))
+	otherDigest := sha256.Sum256([]byte("other message"))
+
+	signature, err := signer.calculateSignatureLocally(digest[:])
+	if err != nil {
+		t.Fatalf("failed to calculate signature: [%v]", err)
+	}
+
+	highSSignature := &ecdsa.Signature{
+		R:          signature.R,
+		S:          new(big.Int).Sub(btcec.S256().N, signature.S),
+		RecoveryID: signature.RecoveryID ^ 1,
+	}
+
+	expectedCulprits := []Culprit{
+		{MemberID: peerMemberID, Reason: InvalidSignature},
+	}
+
+	var tests = map[string]struct {
+		digest            []byte
+		signature         *ecdsa.Signature
+		expectedSignature *ecdsa.Signature
+		expectedError     bool
+	}{
+		"valid signature": {
+			digest:            digest[:],
+			signature:         signature,
+			expectedSignature: signature,
+		},
+		"valid signature with high s": {
+			digest:            digest[:],
+			signature:         highSSignature,
+			expectedSignature: signature,
+		},
+		"signature over another digest": {
+			digest:        otherDigest[:],
+			signature:     signature,
+			expectedError: true,
+		},
+		"invalid recovery ID": {
+			digest: digest[:],
+			signature: &ecdsa.Signature{
+				R:          signature.R,
+				S:          signature.S,
+				RecoveryID: signature.RecoveryID ^ 1,
+			},
+			expectedError: true,
+		},
+		"r out of range": {
+			digest: digest[:],
+			signature: &ecdsa.Signature{
+				R:          big.NewInt(0),
+				S:          signature.S,
+				RecoveryID: signature.RecoveryID,
+			},
+			expectedError: true,
+		},
+		"s out of range": {
+			digest: digest[:],
+			signature: &ecdsa.Signature{
+				R:          signature.R,
+				S:          btcec.S256().N,
+				RecoveryID: signature.RecoveryID,
+			},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			verifiedSignature, err := signer.verifySignature(
+				test.digest,
+				test.signature,
+				signingMemberIDs,
+			)
+
+			if test.expectedError {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+
+				if !reflect.DeepEqual(expectedCulprits, Culprits(err)) {
+					t.Errorf(
+						"unexpected culprits\nexpected: [%v]\nactual:   [%v]",
+						expectedCulprits,
+						Culprits(err),
+					)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: [%v]", err)
+			}
+
+			if !reflect.DeepEqual(test.expectedSignature, verifiedSignature) {
+				t.Errorf(
+					"unexpected signature\nexpected: [%v]\nactual:   [%v]",
+					test.expectedSignature,
+					verifiedSignature,
+				)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package tss
 
 import (
 	cecdsa "crypto/ecdsa"
+	"fmt"
 
 	"github.com/binance-chain/tss-lib/ecdsa/keygen"
 	tssLib "github.com/binance-chain/tss-lib/tss"
@@ -14,6 +15,15 @@ type ThresholdSigner struct {
 	// thresholdKey contains a signer's key generated for a threshold signing
 	// scheme. This data should be persisted to a local storage.
 	thresholdKey ThresholdKey
+
+	// epoch is the number of key share refreshes the threshold key went
+	// through since the key generation.
+	epoch uint64
+
+	// previousKey contains a signer's key from before the last key share
+	// refresh. It is retained until all group members are known to hold
+	// the refreshed key share and is nil otherwise.
+	previousKey *ThresholdKey
 }
 
 // ThresholdKey contains data of signer's threshold key.
@@ -29,6 +39,63 @@ func (s *ThresholdSigner) GroupID() string {
 	return s.groupID
 }
 
+// RefreshEpoch returns the number of key share refreshes the signer's key
+// share went through since the key generation.
+func (s *ThresholdSigner) RefreshEpoch() uint64 {
+	return s.epoch
+}
+
+// IsReconciled returns true if the signer holds only the key share of
+// the current refresh epoch. A signer who completed a key share refresh holds
+// also the key share of the previous epoch until all group members are known
+// to hold the refreshed key share.
+func (s *ThresholdSigner) IsReconciled() bool {
+	return s.previousKey == nil
+}
+
+// heldEpochs returns refresh epochs of all the key shares the signer holds,
+// in ascending order.
+func (s *ThresholdSigner) heldEpochs() []uint64 {
+	if s.IsReconciled() {
+		return []uint64{s.epoch}
+	}
+
+	return []uint64{s.epoch - 1, s.epoch}
+}
+
+// previous returns a signer holding only the key share of the previous
+// refresh epoch.
+func (s *ThresholdSigner) previous() *ThresholdSigner {
+	return &ThresholdSigner{
+		groupInfo:    s.groupInfo,
+		thresholdKey: *s.previousKey,
+		epoch:        s.epoch - 1,
+	}
+}
+
+// withoutPrevious returns a signer holding only the key share of the current
+// refresh epoch.
+func (s *ThresholdSigner) withoutPrevious() *ThresholdSigner {
+	return &ThresholdSigner{
+		groupInfo:    s.groupInfo,
+		thresholdKey: s.thresholdKey,
+		epoch:        s.epoch,
+	}
+}
+
+// epochSessionPrefix returns a prefix of protocol session IDs for the signer's
+// key share. Members holding key shares of different refresh epochs cannot
+// execute protocols together, so sessions are bound to the epoch. Sessions of
+// key shares which have never been refreshed are prefixed only with the group
+// ID.
+func (s *ThresholdSigner) epochSessionPrefix() string {
+	if s.epoch == 0 {
+		return s.groupID
+	}
+
+	return fmt.Sprintf("%s-epoch-%d", s.groupID, s.epoch)
+}
+
 // PublicKey returns signer's ECDSA public key which is also the signing group's
 // public key.
 func (s *ThresholdSigner) PublicKey() *cecdsa.PublicKey {
@@ -43,3 +110,30 @@ func (s *ThresholdSigner) PublicKey() *cecdsa.PublicKey {
 
 	return &publicKey
 }
+
+// partyKeyFn returns a function which maps group members to keys identifying
+// them in the TSS protocol for the signer's key share.
+//
+// Key shares produced by the key generation protocol are bound to keys equal
+// to member IDs. The key share refresh protocol requires members of the old
+// and new committee to be identified by different keys, so each refresh
+// switches members between keys equal to member IDs and alternative keys
+// derived from member IDs.
+func (s *ThresholdSigner) partyKeyFn() partyKeyFn {
+	if s.thresholdKey.ShareID.Cmp(s.memberID.bigInt()) == 0 {
+		return MemberID.bigInt
+	}
+
+	return MemberID.alternativeBigInt
+}
+
+// refreshedPartyKeyFn returns a function which maps group members to keys
+// identifying them in the TSS protocol for the key share produced by the next
+// refresh of the signer's key share.
+func (s *ThresholdSigner) refreshedPartyKeyFn() partyKeyFn {
+	if s.thresholdKey.ShareID.Cmp(s.memberID.bigInt()) == 0 {
+		return MemberID.alternativeBigInt
+	}
+
+	return MemberID.bigInt
+}
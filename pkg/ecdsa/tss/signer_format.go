@@ -0,0 +1,217 @@
+package tss
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss/gen/pb"
+)
+
+// Serialized signer is wrapped in an envelope consisting of:
+//
+//	magic    [4]byte  - identifies the envelope
+//	version  uint32   - version of the serialization format, big-endian
+//	checksum [32]byte - SHA-256 checksum of the payload
+//	payload  []byte   - signer serialized in the given version of the format
+//
+// Signers persisted before the envelope was introduced are raw protobuf
+// messages without any header. They are treated as version 0 of the format.
+// Protobuf messages never start with a zero byte, so they cannot be mistaken
+// for the envelope.
+const (
+	signerEnvelopeMagic = "\x00TSS"
+
+	signerEnvelopeVersionLength  = 4
+	signerEnvelopeChecksumLength = sha256.Size
+	signerEnvelopeHeaderLength   = len(signerEnvelopeMagic) +
+		signerEnvelopeVersionLength +
+		signerEnvelopeChecksumLength
+)
+
+// Versions of the signer serialization format.
+const (
+	// Raw `pb.ThresholdSigner` message without the envelope.
+	SignerFormatV0 = 0
+	// `pb.ThresholdSigner` message wrapped in the envelope.
+	SignerFormatV1 = 1
+	// `pb.ThresholdSigner` message wrapped in the envelope with pre-parameters
+	// other than the Paillier private key stripped from the threshold key.
+	SignerFormatV2 = 2
+	// `pb.ThresholdSigner` message wrapped in the envelope with the refresh
+	// epoch and the threshold key of the previous refresh epoch.
+	SignerFormatV3 = 3
+
+	// CurrentSignerFormat is the version of the format signers are serialized
+	// with.
+	CurrentSignerFormat = SignerFormatV3
+)
+
+// Versions of the EdDSA signer serialization format. EdDSA signers are
+// wrapped in the same envelope as ECDSA signers but versions of their format
+// are independent from versions of the ECDSA signer format.
+const (
+	// `pb.ThresholdSigner` message with `pb.EdDSALocalPartySaveData` threshold
+	// key wrapped in the envelope.
+	EdDSASignerFormatV1 = 1
+
+	// CurrentEdDSASignerFormat is the version of the format EdDSA signers are
+	// serialized with.
+	CurrentEdDSASignerFormat = EdDSASignerFormatV1
+)
+
+// signerMigration converts a payload of the signer serialized in one version
+// of the format to a payload of the next version.
+type signerMigration func(payload []byte) ([]byte, error)
+
+// signerMigrations holds migrations indexed by the version they migrate from.
+// A migration has to be registered for each version older than the current
+// one. Migrations must never be removed as long as signers serialized with
+// the version they migrate from may still exist.
+var signerMigrations = map[uint32]signerMigration{
+	SignerFormatV0: migrateSignerV0ToV1,
+	SignerFormatV1: migrateSignerV1ToV2,
+	SignerFormatV2: migrateSignerV2ToV3,
+}
+
+// migrateSignerV0ToV1 migrates the payload of the signer serialized before
+// the envelope was introduced. The payload itself did not change.
+func migrateSignerV0ToV1(payload []byte) ([]byte, error) {
+	return payload, nil
+}
+
+// migrateSignerV1ToV2 strips pre-parameters not used by the signing protocol
+// from the threshold key. Only the Paillier private key is retained.
+func migrateSignerV1ToV2(payload []byte) ([]byte, error) {
+	pbSigner := &pb.ThresholdSigner{}
+	if err := pbSigner.Unmarshal(payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal signer: [%v]", err)
+	}
+
+	pbKey := &pb.LocalPartySaveData{}
+	if err := pbKey.Unmarshal(pbSigner.GetThresholdKey()); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal threshold key: [%v]", err)
+	}
+
+	if pbKey.LocalPreParams != nil {
+		pbKey.LocalPreParams = &pb.LocalPartySaveData_LocalPreParams{
+			PaillierSK: pbKey.LocalPreParams.GetPaillierSK(),
+		}
+	}
+
+	thresholdKey, err := pbKey.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal threshold key: [%v]", err)
+	}
+
+	pbSigner.ThresholdKey = thresholdKey
+
+	return pbSigner.Marshal()
+}
+
+// migrateSignerV2ToV3 migrates the payload of the signer serialized before
+// key shares were tagged with refresh epochs. The payload itself did not
+// change. The signer holds a single key share which is treated as a key share
+// of epoch zero, so it is bound to the same protocol sessions as before.
+func migrateSignerV2ToV3(payload []byte) ([]byte, error) {
+	return payload, nil
+}
+
+// SignerFormatVersion returns the version of the format the given serialized
+// signer uses.
+func SignerFormatVersion(data []byte) (uint32, error) {
+	if !bytes.HasPrefix(data, []byte(signerEnvelopeMagic)) {
+		return SignerFormatV0, nil
+	}
+
+	if len(data) < signerEnvelopeHeaderLength {
+		return 0, fmt.Errorf("signer envelope is too short")
+	}
+
+	return binary.BigEndian.Uint32(data[len(signerEnvelopeMagic):]), nil
+}
+
+// sealSigner wraps the signer payload serialized with the current version of
+// the format in the envelope.
+func sealSigner(payload []byte) []byte {
+	return sealEnvelope(CurrentSignerFormat, payload)
+}
+
+// openSigner verifies the envelope of the serialized signer and returns
+// the signer payload migrated to the current version of the format.
+func openSigner(data []byte) ([]byte, error) {
+	version, payload, err := openEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if version > CurrentSignerFormat {
+		return nil, fmt.Errorf(
+			"signer format version [%d] is newer than supported version [%d]",
+			version,
+			CurrentSignerFormat,
+		)
+	}
+
+	for ; version < CurrentSignerFormat; version++ {
+		migration, ok := signerMigrations[version]
+		if !ok {
+			return nil, fmt.Errorf(
+				"no migration from signer format version [%d]",
+				version,
+			)
+		}
+
+		payload, err = migration(payload)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to migrate signer from format version [%d]: [%v]",
+				version,
+				err,
+			)
+		}
+	}
+
+	return payload, nil
+}
+
+// sealEnvelope wraps the payload serialized with the given version of
+// the format in the envelope.
+func sealEnvelope(version uint32, payload []byte) []byte {
+	checksum := sha256.Sum256(payload)
+
+	data := make([]byte, 0, signerEnvelopeHeaderLength+len(payload))
+	data = append(data, signerEnvelopeMagic...)
+	data = append(data, make([]byte, signerEnvelopeVersionLength)...)
+	binary.BigEndian.PutUint32(data[len(signerEnvelopeMagic):], version)
+	data = append(data, checksum[:]...)
+	data = append(data, payload...)
+
+	return data
+}
+
+// openEnvelope verifies the envelope and returns the version of the format
+// and the payload wrapped in it. Data without the envelope are returned as
+// a payload of version 0 of the format.
+func openEnvelope(data []byte) (uint32, []byte, error) {
+	version, err := SignerFormatVersion(data)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if version == SignerFormatV0 {
+		return version, data, nil
+	}
+
+	checksumStart := len(signerEnvelopeMagic) + signerEnvelopeVersionLength
+	checksum := data[checksumStart:signerEnvelopeHeaderLength]
+	payload := data[signerEnvelopeHeaderLength:]
+
+	expectedChecksum := sha256.Sum256(payload)
+	if !bytes.Equal(expectedChecksum[:], checksum) {
+		return 0, nil, fmt.Errorf("signer checksum mismatch")
+	}
+
+	return version, payload, nil
+}
@@ -0,0 +1,161 @@
+package tss
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-ecdsa/internal/testdata"
+)
+
+func TestSignerGoldenFiles(t *testing.T) {
+	expectedSigner := loadGoldenSigner(t)
+
+	currentGolden, err := testdata.LoadSignerGoldenFile(CurrentSignerFormat)
+	if err != nil {
+		t.Fatalf("failed to load golden file: [%v]", err)
+	}
+
+	for version := uint32(0); version <= CurrentSignerFormat; version++ {
+		t.Run(fmt.Sprintf("version %d", version), func(t *testing.T) {
+			golden, err := testdata.LoadSignerGoldenFile(version)
+			if err != nil {
+				t.Fatalf("failed to load golden file: [%v]", err)
+			}
+
+			actualVersion, err := SignerFormatVersion(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if version != actualVersion {
+				t.Errorf(
+					"unexpected format version\nexpected: [%v]\nactual:   [%v]",
+					version,
+					actualVersion,
+				)
+			}
+
+			signer := &ThresholdSigner{}
+			if err := signer.Unmarshal(golden); err != nil {
+				t.Fatalf("failed to unmarshal signer: [%v]", err)
+			}
+
+			if !reflect.DeepEqual(expectedSigner, signer) {
+				t.Errorf(
+					"unexpected content of unmarshaled signer\n"+
+						"expected: [%+v]\nactual:   [%+v]",
+					expectedSigner,
+					signer,
+				)
+			}
+
+			// Signer is always marshaled with the current version.
+			marshaled, err := signer.Marshal()
+			if err != nil {
+				t.Fatalf("failed to marshal signer: [%v]", err)
+			}
+
+			if !bytes.Equal(currentGolden, marshaled) {
+				t.Errorf("marshaled signer does not match the current golden file")
+			}
+		})
+	}
+}
+
+func TestSignerEnvelopeValidation(t *testing.T) {
+	golden, err := testdata.LoadSignerGoldenFile(CurrentSignerFormat)
+	if err != nil {
+		t.Fatalf("failed to load golden file: [%v]", err)
+	}
+
+	modify := func(modifyFn func(data []byte)) []byte {
+		data := append([]byte{}, golden...)
+		modifyFn(data)
+		return data
+	}
+
+	var tests = map[string]struct {
+		data          []byte
+		expectedError string
+	}{
+		"corrupted payload": {
+			data: modify(func(data []byte) {
+				data[len(data)-1] ^= 0xff
+			}),
+			expectedError: "failed to open signer envelope: " +
+				"[signer checksum mismatch]",
+		},
+		"corrupted checksum": {
+			data: modify(func(data []byte) {
+				data[signerEnvelopeHeaderLength-1] ^= 0xff
+			}),
+			expectedError: "failed to open signer envelope: " +
+				"[signer checksum mismatch]",
+		},
+		"newer version": {
+			data: modify(func(data []byte) {
+				binary.BigEndian.PutUint32(
+					data[len(signerEnvelopeMagic):],
+					CurrentSignerFormat+1,
+				)
+			}),
+			expectedError: fmt.Sprintf(
+				"failed to open signer envelope: [signer format version [%d] "+
+					"is newer than supported version [%d]]",
+				CurrentSignerFormat+1,
+				CurrentSignerFormat,
+			),
+		},
+		"truncated header": {
+			data: golden[:signerEnvelopeHeaderLength-1],
+			expectedError: "failed to open signer envelope: " +
+				"[signer envelope is too short]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := (&ThresholdSigner{}).Unmarshal(test.data)
+			if err == nil {
+				t.Fatalf("expected error")
+			}
+
+			if test.expectedError != err.Error() {
+				t.Errorf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+// loadGoldenSigner returns the signer stored in golden files as it is expected
+// to be unmarshaled with the current version of the format.
+func loadGoldenSigner(t *testing.T) *ThresholdSigner {
+	groupSize := 5
+	signerIndex := 2
+
+	testData, err := testdata.LoadKeygenTestFixtures(groupSize)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	groupMembersIDs := make([]MemberID, groupSize)
+	for i := range groupMembersIDs {
+		groupMembersIDs[i] = MemberID([]byte(fmt.Sprintf("member-%d", i)))
+	}
+
+	return &ThresholdSigner{
+		groupInfo: &groupInfo{
+			groupID:            "test-group-id-1",
+			memberID:           groupMembersIDs[signerIndex],
+			groupMemberIDs:     groupMembersIDs,
+			dishonestThreshold: 2,
+		},
+		thresholdKey: persistedThresholdKey(ThresholdKey(testData[signerIndex])),
+	}
+}
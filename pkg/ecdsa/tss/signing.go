@@ -2,8 +2,11 @@ package tss
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/binance-chain/tss-lib/common"
 	"github.com/binance-chain/tss-lib/ecdsa/keygen"
@@ -13,18 +16,52 @@ import (
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
 )
 
+// Suffix of the protocol session ID of the signing protocol. The session ID
+// includes also the digest so that messages of signing protocols executed
+// concurrently for different digests are not mixed.
+const signingSessionSuffix = "-sign-"
+
+// signingSessionID returns ID of the protocol session in which the signature
+// for the given digest is calculated.
+func (s *ThresholdSigner) signingSessionID(digest []byte) string {
+	return s.epochSessionPrefix() + signingSessionSuffix +
+		hex.EncodeToString(digest)
+}
+
+// Suffix of the protocol session ID in which members signal their readiness
+// to calculate signatures for a batch of digests. The session ID includes also
+// a hash of all the digests in the batch.
+const batchSigningSessionSuffix = "-sign-batch-"
+
+// batchSigningSessionID returns ID of the protocol session in which members
+// signal their readiness to calculate signatures for the given digests.
+func (s *ThresholdSigner) batchSigningSessionID(digests [][]byte) string {
+	hash := sha256.New()
+	for _, digest := range digests {
+		digestHash := sha256.Sum256(digest)
+		hash.Write(digestHash[:])
+	}
+
+	return s.epochSessionPrefix() + batchSigningSessionSuffix +
+		hex.EncodeToString(hash.Sum(nil))
+}
+
 // initializeSigning initializes a member to run a threshold multi-party signature
-// calculation protocol. Signature will be calculated for provided digest.
+// calculation protocol. Signature will be calculated for provided digest by
+// the provided signing members. Network bridge has to be already connected.
 func (s *ThresholdSigner) initializeSigning(
 	ctx context.Context,
 	digest []byte,
+	signingMemberIDs []MemberID,
 	netBridge *networkBridge,
 ) (*signingSigner, error) {
 	digestInt := new(big.Int).SetBytes(digest)
 
 	party, endChan, err := s.initializeSigningParty(
 		ctx,
+		s.signingSessionID(digest),
 		digestInt,
+		signingMemberIDs,
 		netBridge,
 	)
 	if err != nil {
@@ -56,7 +93,10 @@ type signingSigner struct {
 // executed only after all members finished the initialization stage. As a result
 // the calculated ECDSA signature will be returned or an error, if the signature
 // generation failed.
-func (s *signingSigner) sign(ctx context.Context) (*ecdsa.Signature, error) {
+func (s *signingSigner) sign(
+	ctx context.Context,
+	timeout time.Duration,
+) (*ecdsa.Signature, error) {
 	if s.signingParty == nil {
 		return nil, fmt.Errorf("failed to get initialized signing party")
 	}
@@ -75,44 +115,33 @@ func (s *signingSigner) sign(ctx context.Context) (*ecdsa.Signature, error) {
 
 			return &ecdsaSignature, nil
 		case <-ctx.Done():
-			memberIDs := []MemberID{}
-
-			if s.signingParty.WaitingFor() != nil {
-				for _, partyID := range s.signingParty.WaitingFor() {
-					memberID, err := MemberIDFromString(partyID.GetId())
-					if err != nil {
-						logger.Errorf(
-							"cannot get member id from string [%v]: [%v]",
-							partyID.GetId(),
-							err,
-						)
-						continue
-					}
-
-					memberIDs = append(memberIDs, memberID)
-				}
+			return nil, timeoutError{
+				timeout,
+				"signing",
+				s.networkBridge.blamedCulprits(s.signingParty),
 			}
-
-			return nil, timeoutError{SigningProtocolTimeout, "signing", memberIDs}
 		}
 	}
 }
 
 func (s *ThresholdSigner) initializeSigningParty(
 	ctx context.Context,
+	sessionID string,
 	digest *big.Int,
+	signingMemberIDs []MemberID,
 	netBridge *networkBridge,
 ) (
 	tssLib.Party,
 	<-chan common.SignatureData,
 	error,
 ) {
-	tssMessageChan := make(chan tss.Message, len(s.groupMemberIDs))
+	tssMessageChan := make(chan tss.Message, len(signingMemberIDs))
 	endChan := make(chan common.SignatureData)
 
 	currentPartyID, groupPartiesIDs, err := generatePartiesIDs(
 		s.memberID,
-		s.groupMemberIDs,
+		signingMemberIDs,
+		s.partyKeyFn(),
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to generate parties IDs: [%v]", err)
@@ -134,14 +163,15 @@ func (s *ThresholdSigner) initializeSigningParty(
 		endChan,
 	)
 
-	if err := netBridge.connect(
+	netBridge.bindSession(
 		ctx,
+		sessionID,
 		tssMessageChan,
 		party,
 		params.Parties().IDs(),
-	); err != nil {
-		return nil, nil, fmt.Errorf("failed to connect bridge network: [%v]", err)
-	}
+		ecdsaWireMessageTypes,
+		func(tss.Message) []string { return []string{sessionID} },
+	)
 
 	return party, endChan, nil
 }
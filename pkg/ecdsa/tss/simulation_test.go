@@ -0,0 +1,488 @@
+package tss
+
+import (
+	"context"
+	cecdsa "crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-ecdsa/internal/testdata"
+	"github.com/keep-network/keep-ecdsa/internal/testhelper/netsim"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss/params"
+)
+
+// Seed of the simulated network. A failing test can be reproduced with
+// the same seed.
+const simulationSeed = 20201017
+
+func TestSimulatedKeyGenerationAndSigningWithFaults(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 180*time.Second)
+	defer cancel()
+
+	simulation := newSimulation(t, simulationSeed, 3, 1)
+
+	for i := range simulation.memberIDs {
+		simulation.setFaults(i, netsim.Faults{
+			DuplicateRate: 0.2,
+			ReorderRate:   0.2,
+			MaxDelay:      20 * time.Millisecond,
+		})
+	}
+
+	signers := simulation.generateKeys(ctx, t)
+
+	digest := sha256.Sum256([]byte("message to sign"))
+
+	signatures := simulation.sign(ctx, t, signers, digest[:])
+
+	assertSignatures(t, signers[0].PublicKey(), digest[:], signatures)
+}
+
+func TestSimulatedConcurrentSigning(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 180*time.Second)
+	defer cancel()
+
+	simulation := newSimulation(t, simulationSeed, 3, 1)
+
+	for i := range simulation.memberIDs {
+		simulation.setFaults(i, netsim.Faults{
+			ReorderRate: 0.2,
+			MaxDelay:    20 * time.Millisecond,
+		})
+	}
+
+	signers := simulation.generateKeys(ctx, t)
+
+	digests := [][]byte{}
+	for i := 0; i < 3; i++ {
+		digest := sha256.Sum256([]byte(fmt.Sprintf("message to sign %d", i)))
+		digests = append(digests, digest[:])
+	}
+
+	// Signatures for all the digests are calculated at the same time by
+	// the same signers.
+	signatures := make([][]*ecdsa.Signature, len(digests))
+
+	var signingWait sync.WaitGroup
+	signingWait.Add(len(digests))
+
+	for i, digest := range digests {
+		go func(index int, digest []byte) {
+			defer signingWait.Done()
+
+			signatures[index] = simulation.sign(ctx, t, signers, digest)
+		}(i, digest)
+	}
+
+	signingWait.Wait()
+
+	for i, digest := range digests {
+		if len(signatures[i]) == 0 {
+			t.Fatalf("no signatures calculated for digest [%d]", i)
+		}
+
+		assertSignatures(t, signers[0].PublicKey(), digest, signatures[i])
+	}
+}
+
+func TestSimulatedBatchSigning(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 180*time.Second)
+	defer cancel()
+
+	simulation := newSimulation(t, simulationSeed, 3, 1)
+
+	for i := range simulation.memberIDs {
+		simulation.setFaults(i, netsim.Faults{
+			DuplicateRate: 0.2,
+			ReorderRate:   0.2,
+			MaxDelay:      20 * time.Millisecond,
+		})
+	}
+
+	signers := simulation.generateKeys(ctx, t)
+
+	digests := [][]byte{}
+	for i := 0; i < 3; i++ {
+		digest := sha256.Sum256([]byte(fmt.Sprintf("message to sign %d", i)))
+		digests = append(digests, digest[:])
+	}
+
+	signatures := make([][]*ecdsa.Signature, len(signers))
+	signingErrors := make(chan error, len(signers))
+
+	var signingWait sync.WaitGroup
+	signingWait.Add(len(signers))
+
+	for i := range signers {
+		go func(index int) {
+			defer signingWait.Done()
+
+			batchSignatures, err := signers[index].CalculateSignatures(
+				ctx,
+				digests,
+				simulation.providers[index],
+				simulationPubKeyToAddress,
+				defaultSigningProtocolTimeout,
+				2,
+			)
+			if err != nil {
+				signingErrors <- fmt.Errorf("failed to sign batch: [%v]", err)
+				return
+			}
+
+			signatures[index] = batchSignatures
+		}(i)
+	}
+
+	signingWait.Wait()
+	close(signingErrors)
+
+	for err := range signingErrors {
+		t.Fatalf("unexpected error on batch signing: [%v]", err)
+	}
+
+	for i, digest := range digests {
+		digestSignatures := make([]*ecdsa.Signature, len(signers))
+		for j := range signers {
+			digestSignatures[j] = signatures[j][i]
+		}
+
+		assertSignatures(t, signers[0].PublicKey(), digest, digestSignatures)
+	}
+}
+
+func TestSimulatedSigningWithUnreachableMember(t *testing.T) {
+	defaultProtocolReadyTimeout := protocolReadyTimeout
+	protocolReadyTimeout = 5 * time.Second
+	defer func() {
+		protocolReadyTimeout = defaultProtocolReadyTimeout
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 180*time.Second)
+	defer cancel()
+
+	simulation := newSimulation(t, simulationSeed, 3, 1)
+
+	signers := simulation.generateKeys(ctx, t)
+
+	// Messages sent by the last member do not reach peer members. The member
+	// still receives messages from peer members but it will not be selected
+	// to the signing group by them.
+	unreachableMemberIndex := len(signers) - 1
+	simulation.setFaults(unreachableMemberIndex, netsim.Faults{DropRate: 1})
+
+	unreachableMemberCtx, cancelUnreachableMember := context.WithCancel(ctx)
+	unreachableMemberDone := make(chan struct{})
+
+	go func() {
+		defer close(unreachableMemberDone)
+
+		_, err := signers[unreachableMemberIndex].CalculateSignature(
+			unreachableMemberCtx,
+			[]byte("unreachable"),
+			simulation.providers[unreachableMemberIndex],
+			simulationPubKeyToAddress,
+			defaultSigningProtocolTimeout,
+		)
+		if err == nil {
+			t.Errorf("expected signing failure of the unreachable member")
+		}
+	}()
+
+	digest := sha256.Sum256([]byte("message to sign"))
+
+	signatures := simulation.sign(
+		ctx,
+		t,
+		signers[:unreachableMemberIndex],
+		digest[:],
+	)
+
+	cancelUnreachableMember()
+	<-unreachableMemberDone
+
+	assertSignatures(t, signers[0].PublicKey(), digest[:], signatures)
+}
+
+func TestSimulatedBroadcastRecoveryAddressWithFaults(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	simulation := newSimulation(t, simulationSeed, 3, 2)
+
+	// Messages are not dropped as the member sends its address only once
+	// after it gathered addresses of all peer members and stopped
+	// retransmissions. Messages are not corrupted as the address is not
+	// authenticated by the protocol but by the network layer.
+	for i := range simulation.memberIDs {
+		simulation.setFaults(i, netsim.Faults{
+			DuplicateRate: 0.5,
+			ReorderRate:   0.2,
+			MaxDelay:      20 * time.Millisecond,
+		})
+	}
+
+	btcAddresses := []string{
+		"tb1qjy5r90er70t2cexwpmmkf9hr4glxdx83jhpwfv",
+		"2NBFNJTktNa7GZusGbDbGKRZTxdK9VVez3n",
+		"tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx",
+	}
+	maxFeesPerVByte := []int32{75, 50, 100}
+
+	groupSize := len(simulation.memberIDs)
+	retrievedAddresses := make([][]string, groupSize)
+	retrievedMaxFees := make([]int32, groupSize)
+	recoveryErrors := make(chan error, groupSize)
+
+	var recoveryWait sync.WaitGroup
+	recoveryWait.Add(groupSize)
+
+	for i, memberID := range simulation.memberIDs {
+		go func(memberID MemberID, index int) {
+			defer recoveryWait.Done()
+
+			addresses, maxFeePerVByte, err := BroadcastRecoveryAddress(
+				ctx,
+				btcAddresses[index],
+				maxFeesPerVByte[index],
+				simulation.groupID,
+				memberID,
+				simulation.memberIDs,
+				simulation.dishonestThreshold,
+				simulation.providers[index],
+				simulationPubKeyToAddress,
+				&chaincfg.TestNet3Params,
+				defaultRecoveryReadyTimeout,
+			)
+			if err != nil {
+				recoveryErrors <- fmt.Errorf(
+					"failed to broadcast recovery address: [%v]",
+					err,
+				)
+				return
+			}
+
+			retrievedAddresses[index] = addresses
+			retrievedMaxFees[index] = maxFeePerVByte
+		}(memberID, i)
+	}
+
+	recoveryWait.Wait()
+	close(recoveryErrors)
+
+	for err := range recoveryErrors {
+		t.Fatalf("unexpected error on recovery address broadcast: [%v]", err)
+	}
+
+	expectedAddresses := []string{
+		"2NBFNJTktNa7GZusGbDbGKRZTxdK9VVez3n",
+		"tb1qjy5r90er70t2cexwpmmkf9hr4glxdx83jhpwfv",
+		"tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx",
+	}
+	expectedMaxFee := int32(50)
+
+	for i := range simulation.memberIDs {
+		if !reflect.DeepEqual(expectedAddresses, retrievedAddresses[i]) {
+			t.Errorf(
+				"unexpected addresses retrieved by member [%v]\n"+
+					"expected: [%v]\nactual:   [%v]",
+				i,
+				expectedAddresses,
+				retrievedAddresses[i],
+			)
+		}
+
+		if expectedMaxFee != retrievedMaxFees[i] {
+			t.Errorf(
+				"unexpected max fee retrieved by member [%v]\n"+
+					"expected: [%v]\nactual:   [%v]",
+				i,
+				expectedMaxFee,
+				retrievedMaxFees[i],
+			)
+		}
+	}
+}
+
+// simulation holds members of a group connected to the simulated network.
+type simulation struct {
+	network            *netsim.Network
+	groupID            string
+	dishonestThreshold uint
+	memberIDs          []MemberID
+	networkKeys        []*key.NetworkPublic
+	providers          []net.Provider
+}
+
+func newSimulation(
+	t *testing.T,
+	seed int64,
+	groupSize int,
+	dishonestThreshold uint,
+) *simulation {
+	memberIDs, err := generateMemberKeys(groupSize)
+	if err != nil {
+		t.Fatalf("failed to generate members keys: [%v]", err)
+	}
+
+	network := netsim.NewNetwork(seed)
+
+	networkKeys := make([]*key.NetworkPublic, groupSize)
+	providers := make([]net.Provider, groupSize)
+	for i, memberID := range memberIDs {
+		memberPublicKey, err := memberID.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		networkPublicKey := key.NetworkPublic(*memberPublicKey)
+		networkKeys[i] = &networkPublicKey
+		providers[i] = network.Connect(&networkPublicKey)
+	}
+
+	return &simulation{
+		network:            network,
+		groupID:            fmt.Sprintf("tss-simulation-%d", seed),
+		dishonestThreshold: dishonestThreshold,
+		memberIDs:          memberIDs,
+		networkKeys:        networkKeys,
+		providers:          providers,
+	}
+}
+
+// setFaults sets faults of messages sent by the member with the given index.
+func (s *simulation) setFaults(memberIndex int, faults netsim.Faults) {
+	s.network.SetFaults(s.networkKeys[memberIndex], faults)
+}
+
+// generateKeys executes key generation with all the members of the group.
+func (s *simulation) generateKeys(
+	ctx context.Context,
+	t *testing.T,
+) []*ThresholdSigner {
+	groupSize := len(s.memberIDs)
+
+	testData, err := testdata.LoadKeygenTestFixtures(groupSize)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	signers := make([]*ThresholdSigner, groupSize)
+	keyGenErrors := make(chan error, groupSize)
+
+	var keyGenWait sync.WaitGroup
+	keyGenWait.Add(groupSize)
+
+	for i, memberID := range s.memberIDs {
+		go func(memberID MemberID, index int) {
+			defer keyGenWait.Done()
+
+			preParams := testData[index].LocalPreParams
+
+			signer, err := GenerateThresholdSigner(
+				ctx,
+				s.groupID,
+				memberID,
+				s.memberIDs,
+				s.dishonestThreshold,
+				s.providers[index],
+				simulationPubKeyToAddress,
+				params.NewBox(&preParams),
+				defaultKeyGenerationProtocolTimeout,
+			)
+			if err != nil {
+				keyGenErrors <- fmt.Errorf("failed to generate signer: [%v]", err)
+				return
+			}
+
+			signers[index] = signer
+		}(memberID, i)
+	}
+
+	keyGenWait.Wait()
+	close(keyGenErrors)
+
+	for err := range keyGenErrors {
+		t.Fatalf("unexpected error on key generation: [%v]", err)
+	}
+
+	return signers
+}
+
+// sign executes signing with the given signers. Signers are expected to be
+// the first members of the group.
+func (s *simulation) sign(
+	ctx context.Context,
+	t *testing.T,
+	signers []*ThresholdSigner,
+	digest []byte,
+) []*ecdsa.Signature {
+	signatures := make([]*ecdsa.Signature, len(signers))
+	signingErrors := make(chan error, len(signers))
+
+	var signingWait sync.WaitGroup
+	signingWait.Add(len(signers))
+
+	for i := range signers {
+		go func(index int) {
+			defer signingWait.Done()
+
+			signature, err := signers[index].CalculateSignature(
+				ctx,
+				digest,
+				s.providers[index],
+				simulationPubKeyToAddress,
+				defaultSigningProtocolTimeout,
+			)
+			if err != nil {
+				signingErrors <- fmt.Errorf("failed to sign: [%v]", err)
+				return
+			}
+
+			signatures[index] = signature
+		}(i)
+	}
+
+	signingWait.Wait()
+	close(signingErrors)
+
+	for err := range signingErrors {
+		t.Fatalf("unexpected error on signing: [%v]", err)
+	}
+
+	return signatures
+}
+
+func simulationPubKeyToAddress(publicKey cecdsa.PublicKey) []byte {
+	return elliptic.Marshal(publicKey.Curve, publicKey.X, publicKey.Y)
+}
+
+func assertSignatures(
+	t *testing.T,
+	publicKey *cecdsa.PublicKey,
+	digest []byte,
+	signatures []*ecdsa.Signature,
+) {
+	for _, signature := range signatures {
+		if !reflect.DeepEqual(signatures[0], signature) {
+			t.Errorf(
+				"signature doesn't match expected\nexpected: [%v]\nactual:   [%v]",
+				signatures[0],
+				signature,
+			)
+		}
+	}
+
+	if !cecdsa.Verify(publicKey, digest, signatures[0].R, signatures[0].S) {
+		t.Errorf("invalid signature: [%+v]", signatures[0])
+	}
+}
@@ -0,0 +1,318 @@
+package tss
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// Directions of messages recorded in a transcript.
+const (
+	InboundMessage  = "inbound"
+	OutboundMessage = "outbound"
+)
+
+// Transcript records messages exchanged by the member with peer members during
+// protocol executions. It allows to analyze a failed protocol execution after
+// the fact. The transcript contains only messages visible on the network so it
+// does not contain any secrets of the member but it still should be stored in
+// a secure way.
+//
+// A transcript cannot be replayed against local parties to reproduce
+// the failure. Messages of the member depend on random values drawn by its
+// party inside the TSS library and those values are not recorded. Recorded
+// messages can still be verified by local parties with Replay, to find
+// the first message which made the execution fail.
+type Transcript struct {
+	mutex *sync.Mutex
+
+	description    string
+	memberID       MemberID
+	groupMemberIDs []MemberID
+	entries        []*TranscriptEntry
+
+	// Inbound broadcast messages already recorded. Broadcast channel can
+	// have more than one handler and each of them receives the same message.
+	recordedBroadcasts map[string]bool
+}
+
+// TranscriptEntry is a message recorded in a transcript.
+type TranscriptEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Direction string    `json:"direction"`
+	Broadcast bool      `json:"broadcast"`
+	Type      string    `json:"type"`
+	SessionID string    `json:"sessionID,omitempty"`
+	Payload   []byte    `json:"payload"`
+}
+
+// NewTranscript creates an empty transcript with the given description of
+// the recorded protocol execution.
+func NewTranscript(description string) *Transcript {
+	return &Transcript{
+		mutex:              &sync.Mutex{},
+		description:        description,
+		recordedBroadcasts: make(map[string]bool),
+	}
+}
+
+// Description returns the description of the recorded protocol execution.
+func (t *Transcript) Description() string {
+	return t.description
+}
+
+// MemberID returns the ID of the member who recorded the transcript. It is
+// unknown if the member did not start any protocol execution.
+func (t *Transcript) MemberID() MemberID {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.memberID
+}
+
+// Entries returns messages recorded in the transcript in the order they were
+// recorded.
+func (t *Transcript) Entries() []*TranscriptEntry {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return append([]*TranscriptEntry{}, t.entries...)
+}
+
+func (t *Transcript) recordMember(memberID MemberID) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.memberID = memberID
+}
+
+func (t *Transcript) recordGroup(group *groupInfo) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.memberID = group.memberID
+	t.groupMemberIDs = group.groupMemberIDs
+}
+
+func (t *Transcript) recordOutbound(m net.TaggedMarshaler, broadcast bool) {
+	t.record(OutboundMessage, m, broadcast)
+}
+
+func (t *Transcript) recordInbound(msg net.Message, broadcast bool) {
+	payload, ok := msg.Payload().(net.TaggedMarshaler)
+	if !ok {
+		return
+	}
+
+	ownMessage := false
+	recorded := false
+
+	t.mutex.Lock()
+	if broadcast {
+		// Broadcast messages sent by the member are recorded as outbound
+		// messages, even if the network delivers them back to the member.
+		ownMessage = bytes.Equal(msg.SenderPublicKey(), t.memberID)
+
+		messageID := fmt.Sprintf(
+			"%v-%v",
+			msg.TransportSenderID().String(),
+			msg.Seqno(),
+		)
+
+		recorded = t.recordedBroadcasts[messageID]
+		t.recordedBroadcasts[messageID] = true
+	}
+	t.mutex.Unlock()
+
+	if ownMessage || recorded {
+		return
+	}
+
+	t.record(InboundMessage, payload, broadcast)
+}
+
+func (t *Transcript) record(
+	direction string,
+	m net.TaggedMarshaler,
+	broadcast bool,
+) {
+	payload, err := m.Marshal()
+	if err != nil {
+		logger.Errorf("failed to record message in transcript: [%v]", err)
+		return
+	}
+
+	entry := &TranscriptEntry{
+		Timestamp: time.Now(),
+		Direction: direction,
+		Broadcast: broadcast,
+		Type:      m.Type(),
+		Payload:   payload,
+	}
+
+	switch message := m.(type) {
+	case *ProtocolMessage:
+		entry.SessionID = message.SessionID
+	case *ReadyMessage:
+		entry.SessionID = message.SessionID
+	case *ReadyConfirmationMessage:
+		entry.SessionID = message.SessionID
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.entries = append(t.entries, entry)
+}
+
+type transcriptData struct {
+	Description    string             `json:"description"`
+	MemberID       string             `json:"memberID"`
+	GroupMemberIDs []string           `json:"groupMemberIDs"`
+	Entries        []*TranscriptEntry `json:"entries"`
+}
+
+// Marshal converts the transcript to a byte array.
+func (t *Transcript) Marshal() ([]byte, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	data := &transcriptData{
+		Description: t.description,
+		MemberID:    t.memberID.String(),
+		Entries:     t.entries,
+	}
+
+	for _, memberID := range t.groupMemberIDs {
+		data.GroupMemberIDs = append(data.GroupMemberIDs, memberID.String())
+	}
+
+	return json.Marshal(data)
+}
+
+// Unmarshal converts a byte array back to the transcript.
+func (t *Transcript) Unmarshal(transcriptBytes []byte) error {
+	data := &transcriptData{}
+	if err := json.Unmarshal(transcriptBytes, data); err != nil {
+		return fmt.Errorf("failed to unmarshal transcript: [%v]", err)
+	}
+
+	memberID, err := MemberIDFromString(data.MemberID)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal member ID: [%v]", err)
+	}
+
+	groupMemberIDs := make([]MemberID, len(data.GroupMemberIDs))
+	for i, groupMemberID := range data.GroupMemberIDs {
+		groupMemberIDs[i], err = MemberIDFromString(groupMemberID)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal group member ID: [%v]", err)
+		}
+	}
+
+	t.mutex = &sync.Mutex{}
+	t.description = data.Description
+	t.memberID = memberID
+	t.groupMemberIDs = groupMemberIDs
+	t.entries = data.Entries
+	t.recordedBroadcasts = make(map[string]bool)
+
+	return nil
+}
+
+type transcriptContextKey struct{}
+
+// WithTranscript returns a copy of the context in which messages exchanged
+// by protocol executions are recorded in the given transcript.
+func WithTranscript(ctx context.Context, transcript *Transcript) context.Context {
+	return context.WithValue(ctx, transcriptContextKey{}, transcript)
+}
+
+// transcriptFromContext returns the transcript messages should be recorded
+// in or nil if messages should not be recorded.
+func transcriptFromContext(ctx context.Context) *Transcript {
+	transcript, _ := ctx.Value(transcriptContextKey{}).(*Transcript)
+	return transcript
+}
+
+// recordingBroadcastChannel records messages sent and received through
+// the broadcast channel in the transcript.
+type recordingBroadcastChannel struct {
+	net.BroadcastChannel
+
+	transcript *Transcript
+}
+
+// newRecordingBroadcastChannel wraps the broadcast channel so that messages
+// are recorded in the transcript. If the transcript is nil, the channel is
+// returned unchanged.
+func newRecordingBroadcastChannel(
+	channel net.BroadcastChannel,
+	transcript *Transcript,
+) net.BroadcastChannel {
+	if transcript == nil {
+		return channel
+	}
+
+	return &recordingBroadcastChannel{channel, transcript}
+}
+
+func (rbc *recordingBroadcastChannel) Send(
+	ctx context.Context,
+	m net.TaggedMarshaler,
+) error {
+	rbc.transcript.recordOutbound(m, true)
+	return rbc.BroadcastChannel.Send(ctx, m)
+}
+
+func (rbc *recordingBroadcastChannel) Recv(
+	ctx context.Context,
+	handler func(m net.Message),
+) {
+	rbc.BroadcastChannel.Recv(ctx, func(m net.Message) {
+		rbc.transcript.recordInbound(m, true)
+		handler(m)
+	})
+}
+
+// recordingUnicastChannel records messages sent and received through
+// the unicast channel in the transcript.
+type recordingUnicastChannel struct {
+	net.UnicastChannel
+
+	transcript *Transcript
+}
+
+// newRecordingUnicastChannel wraps the unicast channel so that messages
+// are recorded in the transcript. If the transcript is nil, the channel is
+// returned unchanged.
+func newRecordingUnicastChannel(
+	channel net.UnicastChannel,
+	transcript *Transcript,
+) net.UnicastChannel {
+	if transcript == nil {
+		return channel
+	}
+
+	return &recordingUnicastChannel{channel, transcript}
+}
+
+func (ruc *recordingUnicastChannel) Send(m net.TaggedMarshaler) error {
+	ruc.transcript.recordOutbound(m, false)
+	return ruc.UnicastChannel.Send(m)
+}
+
+func (ruc *recordingUnicastChannel) Recv(
+	ctx context.Context,
+	handler func(m net.Message),
+) {
+	ruc.UnicastChannel.Recv(ctx, func(m net.Message) {
+		ruc.transcript.recordInbound(m, false)
+		handler(m)
+	})
+}
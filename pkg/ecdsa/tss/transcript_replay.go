@@ -0,0 +1,280 @@
+package tss
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// ReplayFailure describes the first message recorded in a transcript which
+// failed verification during the replay.
+type ReplayFailure struct {
+	// Index of the message among all messages recorded in the transcript.
+	Index int
+	Entry *TranscriptEntry
+	// ID of the member the message was sent by, if it could be determined.
+	SenderID MemberID
+	Reason   string
+}
+
+func (rf *ReplayFailure) String() string {
+	sender := "unknown sender"
+	if rf.SenderID != nil {
+		sender = fmt.Sprintf("member [%s]", rf.SenderID)
+	}
+
+	return fmt.Sprintf(
+		"%s message [%d] of type [%s] in session [%s] recorded at [%v] "+
+			"sent by %s failed verification: %s",
+		rf.Entry.Direction,
+		rf.Index,
+		rf.Entry.Type,
+		rf.Entry.SessionID,
+		rf.Entry.Timestamp,
+		sender,
+		rf.Reason,
+	)
+}
+
+// Replay feeds protocol messages recorded in the transcript to local parties
+// of the member, in the order they were recorded, and verifies them the same
+// way parties verify messages received from the network before they update
+// their state. Inbound messages are also checked against outbound messages of
+// the member recorded within the same protocol session: they have to belong to
+// the same protocol and a peer member cannot send different messages of the same
+// type within the session. As a result the first message which failed
+// verification is returned, or nil if all messages passed it.
+//
+// Parties are not started, as messages of the member depend on random values
+// drawn by its party inside the TSS library and those values are not
+// recorded. For this reason the replay cannot verify the content of messages
+// against the state of the party, e.g. zero-knowledge proofs. Only messages of
+// ECDSA key generation, signing and key share refresh protocols and of
+// the Schnorr signing protocol can be replayed.
+func (t *Transcript) Replay() (*ReplayFailure, error) {
+	t.mutex.Lock()
+	memberID := t.memberID
+	groupMemberIDs := t.groupMemberIDs
+	entries := append([]*TranscriptEntry{}, t.entries...)
+	t.mutex.Unlock()
+
+	if len(groupMemberIDs) < 2 {
+		return nil, fmt.Errorf(
+			"transcript does not record a group of at least 2 members",
+		)
+	}
+
+	// Members of the old and the new committee of the key share refresh
+	// protocol are identified with different party keys, so the sender of
+	// the message is looked up among parties of both of them.
+	var parties []*replayParty
+	for _, partyKey := range []partyKeyFn{
+		MemberID.bigInt,
+		MemberID.alternativeBigInt,
+	} {
+		party, err := newReplayParty(memberID, groupMemberIDs, partyKey)
+		if err != nil {
+			return nil, err
+		}
+
+		parties = append(parties, party)
+	}
+
+	messageTypes := make(wireMessageTypes)
+	for _, types := range []wireMessageTypes{
+		ecdsaWireMessageTypes,
+		schnorrWireMessageTypes,
+	} {
+		for name, messageType := range types {
+			messageTypes[name] = messageType
+		}
+	}
+
+	// Protocols executed by the member within each session, identified by
+	// packages of the messages sent by the member.
+	protocolMessageType := (&ProtocolMessage{}).Type()
+
+	sessionProtocols := make(map[string]map[string]bool)
+	for _, entry := range entries {
+		if entry.Direction == OutboundMessage && entry.Type == protocolMessageType {
+			sessionProtocols[entry.SessionID] = make(map[string]bool)
+		}
+	}
+
+	// Inbound messages already replayed, indexed by the session, the sender
+	// and the message type.
+	inboundPayloads := make(map[string][]byte)
+
+	// Outbound messages are replayed first, so that inbound messages can be
+	// checked against them even if they were received before the member sent
+	// its own messages.
+	for _, direction := range []string{OutboundMessage, InboundMessage} {
+		for index, entry := range entries {
+			if entry.Direction != direction || entry.Type != protocolMessageType {
+				continue
+			}
+
+			fail := func(senderID MemberID, reason string, args ...interface{}) (
+				*ReplayFailure,
+				error,
+			) {
+				return &ReplayFailure{
+					Index:    index,
+					Entry:    entry,
+					SenderID: senderID,
+					Reason:   fmt.Sprintf(reason, args...),
+				}, nil
+			}
+
+			protocolMessage := &ProtocolMessage{}
+			if err := protocolMessage.Unmarshal(entry.Payload); err != nil {
+				return fail(nil, "malformed protocol message: [%v]", err)
+			}
+
+			party, senderPartyID := findReplaySender(parties, protocolMessage.SenderID)
+			if senderPartyID == nil {
+				return fail(
+					nil,
+					"sender [%s] is not a member of the group",
+					protocolMessage.SenderID,
+				)
+			}
+
+			senderID, err := MemberIDFromString(senderPartyID.GetId())
+			if err != nil {
+				return nil, fmt.Errorf("failed to get sender member ID: [%v]", err)
+			}
+
+			if direction == InboundMessage && senderID.Equal(memberID) {
+				return fail(senderID, "message sent in the name of the member")
+			}
+			if direction == OutboundMessage && !senderID.Equal(memberID) {
+				return fail(senderID, "message not sent by the member")
+			}
+
+			if entry.Broadcast != protocolMessage.IsBroadcast {
+				return fail(
+					senderID,
+					"message marked as broadcast [%v] received through "+
+						"broadcast channel [%v]",
+					protocolMessage.IsBroadcast,
+					entry.Broadcast,
+				)
+			}
+
+			parsedMessage, err := messageTypes.parseWireMessage(
+				protocolMessage.Payload,
+				senderPartyID,
+				protocolMessage.IsBroadcast,
+			)
+			if err != nil {
+				return fail(senderID, "malformed TSS message: [%v]", err)
+			}
+
+			if ok, err := party.ValidateMessage(parsedMessage); !ok || err != nil {
+				return fail(senderID, "invalid TSS message: [%v]", err)
+			}
+
+			protocol := reflect.TypeOf(parsedMessage.Content()).Elem().PkgPath()
+
+			if direction == OutboundMessage {
+				sessionProtocols[entry.SessionID][protocol] = true
+				continue
+			}
+
+			if protocols, ok := sessionProtocols[entry.SessionID]; ok &&
+				!protocols[protocol] {
+				return fail(
+					senderID,
+					"message of type [%s] does not belong to the protocol "+
+						"executed by the member in the session",
+					parsedMessage.Type(),
+				)
+			}
+
+			messageKey := fmt.Sprintf(
+				"%s-%s-%s",
+				entry.SessionID,
+				senderID,
+				parsedMessage.Type(),
+			)
+			if payload, ok := inboundPayloads[messageKey]; ok &&
+				!bytes.Equal(payload, protocolMessage.Payload) {
+				return fail(
+					senderID,
+					"message of type [%s] conflicts with another message "+
+						"of the same type sent by the member in the session",
+					parsedMessage.Type(),
+				)
+			}
+			inboundPayloads[messageKey] = protocolMessage.Payload
+		}
+	}
+
+	return nil, nil
+}
+
+// replayParty is a local party of the member messages recorded in a transcript
+// are verified by.
+type replayParty struct {
+	tss.Party
+
+	partiesIDs tss.SortedPartyIDs
+}
+
+func newReplayParty(
+	memberID MemberID,
+	groupMemberIDs []MemberID,
+	partyKey partyKeyFn,
+) (*replayParty, error) {
+	partyID, partiesIDs, err := generatePartiesIDs(
+		memberID,
+		groupMemberIDs,
+		partyKey,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate parties IDs: [%v]", err)
+	}
+
+	if partyID == nil {
+		return nil, fmt.Errorf(
+			"member [%s] is not a member of the group",
+			memberID,
+		)
+	}
+
+	sortedPartiesIDs := tss.SortPartyIDs(partiesIDs)
+
+	party := keygen.NewLocalParty(
+		tss.NewParameters(
+			tss.EC(),
+			tss.NewPeerContext(sortedPartiesIDs),
+			partyID,
+			len(sortedPartiesIDs),
+			len(sortedPartiesIDs)-1,
+		),
+		make(chan tss.Message),
+		make(chan keygen.LocalPartySaveData),
+	)
+
+	return &replayParty{party, sortedPartiesIDs}, nil
+}
+
+// findReplaySender returns the party the message of the given sender should be
+// verified by and the party ID of the sender. The returned party ID is nil if
+// the sender is not a member of the group.
+func findReplaySender(
+	parties []*replayParty,
+	senderID MemberID,
+) (*replayParty, *tss.PartyID) {
+	for _, party := range parties {
+		if senderPartyID := party.partiesIDs.FindByKey(senderID.bigInt()); senderPartyID != nil {
+			return party, senderPartyID
+		}
+	}
+
+	return nil, nil
+}
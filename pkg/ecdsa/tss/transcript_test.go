@@ -0,0 +1,440 @@
+package tss
+
+import (
+	"context"
+	cecdsa "crypto/ecdsa"
+	"crypto/elliptic"
+	"math/big"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/ecdsa/signing"
+	tssLib "github.com/binance-chain/tss-lib/tss"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+)
+
+func TestTranscriptRecordsMessages(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	groupSize := 2
+
+	groupMemberIDs, err := generateMemberKeys(groupSize)
+	if err != nil {
+		t.Fatalf("failed to generate members keys: [%v]", err)
+	}
+
+	pubKeyToAddressFn := func(publicKey cecdsa.PublicKey) []byte {
+		return elliptic.Marshal(publicKey.Curve, publicKey.X, publicKey.Y)
+	}
+
+	transcripts := make([]*Transcript, groupSize)
+	bridges := make([]*networkBridge, groupSize)
+
+	for i, memberID := range groupMemberIDs {
+		memberPublicKey, err := memberID.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		networkPublicKey := key.NetworkPublic(*memberPublicKey)
+
+		transcripts[i] = NewTranscript("test")
+		bridges[i], err = newNetworkBridge(
+			&groupInfo{
+				groupID:        "test-group-1",
+				memberID:       memberID,
+				groupMemberIDs: groupMemberIDs,
+			},
+			newTestNetProvider(&networkPublicKey),
+			transcripts[i],
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Both the network bridge and the ready protocol receive messages from
+	// the broadcast channel but each message should be recorded once.
+	var readyWait sync.WaitGroup
+	readyWait.Add(groupSize)
+
+	for i := range groupMemberIDs {
+		go func(index int) {
+			defer readyWait.Done()
+
+			bridge := bridges[index]
+
+			if err := bridge.connect(ctx, groupSize); err != nil {
+				t.Errorf("failed to connect bridge: [%v]", err)
+				return
+			}
+
+			broadcastChannel, err := bridge.getBroadcastChannel()
+			if err != nil {
+				t.Errorf("failed to get broadcast channel: [%v]", err)
+				return
+			}
+
+			if _, err := readyProtocol(
+				ctx,
+				bridge.groupInfo,
+				bridge.groupInfo.groupID,
+				broadcastChannel,
+				pubKeyToAddressFn,
+				groupSize,
+			); err != nil {
+				t.Errorf("ready protocol failed: [%v]", err)
+			}
+		}(i)
+	}
+
+	readyWait.Wait()
+
+	if err := bridges[0].sendTo(
+		mustGetTransportIdentifier(t, bridges[0], groupMemberIDs[1]),
+		&ProtocolMessage{
+			SenderID:  groupMemberIDs[0],
+			Payload:   []byte{0x01},
+			SessionID: "test-session-1",
+		},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	// Wait for the unicast message to be delivered.
+	time.Sleep(100 * time.Millisecond)
+
+	countEntries := func(transcript *Transcript, direction, messageType string) int {
+		count := 0
+		for _, entry := range transcript.Entries() {
+			if entry.Direction == direction && entry.Type == messageType {
+				count++
+			}
+		}
+		return count
+	}
+
+	readyMessageType := (&ReadyMessage{}).Type()
+
+	for i, transcript := range transcripts {
+		outboundReady := countEntries(transcript, OutboundMessage, readyMessageType)
+		inboundReady := countEntries(transcript, InboundMessage, readyMessageType)
+
+		// The ready message is sent once again when the protocol completes.
+		if outboundReady < 1 || outboundReady > 2 {
+			t.Errorf(
+				"unexpected number of outbound ready messages of member [%v]\n"+
+					"expected: [1 or 2]\nactual:   [%v]",
+				i,
+				outboundReady,
+			)
+		}
+		if inboundReady < 1 || inboundReady > 2 {
+			t.Errorf(
+				"unexpected number of inbound ready messages of member [%v]\n"+
+					"expected: [1 or 2]\nactual:   [%v]",
+				i,
+				inboundReady,
+			)
+		}
+
+		if !transcript.MemberID().Equal(groupMemberIDs[i]) {
+			t.Errorf(
+				"unexpected member ID\nexpected: [%v]\nactual:   [%v]",
+				groupMemberIDs[i],
+				transcript.MemberID(),
+			)
+		}
+	}
+
+	protocolMessageType := (&ProtocolMessage{}).Type()
+
+	for _, entry := range transcripts[1].Entries() {
+		if entry.Type != protocolMessageType {
+			continue
+		}
+
+		if entry.Direction != InboundMessage {
+			t.Errorf(
+				"unexpected direction\nexpected: [%v]\nactual:   [%v]",
+				InboundMessage,
+				entry.Direction,
+			)
+		}
+		if entry.SessionID != "test-session-1" {
+			t.Errorf(
+				"unexpected session ID\nexpected: [%v]\nactual:   [%v]",
+				"test-session-1",
+				entry.SessionID,
+			)
+		}
+
+		return
+	}
+
+	t.Errorf("protocol message has not been recorded")
+}
+
+func TestTranscriptMarshaling(t *testing.T) {
+	groupMemberIDs, err := generateMemberKeys(2)
+	if err != nil {
+		t.Fatalf("failed to generate members keys: [%v]", err)
+	}
+
+	transcript := NewTranscript("signing for keep 0x01")
+	transcript.recordGroup(&groupInfo{
+		memberID:       groupMemberIDs[0],
+		groupMemberIDs: groupMemberIDs,
+	})
+	transcript.recordOutbound(&ReadyMessage{SenderID: groupMemberIDs[0]}, true)
+	transcript.recordOutbound(
+		&ProtocolMessage{
+			SenderID:  groupMemberIDs[0],
+			Payload:   []byte{0x01, 0x02},
+			SessionID: "test-session-1",
+		},
+		false,
+	)
+
+	bytes, err := transcript.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unmarshaled := &Transcript{}
+	if err := unmarshaled.Unmarshal(bytes); err != nil {
+		t.Fatal(err)
+	}
+
+	if transcript.Description() != unmarshaled.Description() {
+		t.Errorf(
+			"unexpected description\nexpected: [%v]\nactual:   [%v]",
+			transcript.Description(),
+			unmarshaled.Description(),
+		)
+	}
+
+	if !reflect.DeepEqual(transcript.groupMemberIDs, unmarshaled.groupMemberIDs) {
+		t.Errorf(
+			"unexpected group member IDs\nexpected: [%v]\nactual:   [%v]",
+			transcript.groupMemberIDs,
+			unmarshaled.groupMemberIDs,
+		)
+	}
+
+	expectedEntries := transcript.Entries()
+	actualEntries := unmarshaled.Entries()
+
+	if len(expectedEntries) != len(actualEntries) {
+		t.Fatalf(
+			"unexpected number of entries\nexpected: [%v]\nactual:   [%v]",
+			len(expectedEntries),
+			len(actualEntries),
+		)
+	}
+
+	for i := range expectedEntries {
+		if !expectedEntries[i].Timestamp.Equal(actualEntries[i].Timestamp) {
+			t.Errorf(
+				"unexpected timestamp of entry [%v]\nexpected: [%v]\nactual:   [%v]",
+				i,
+				expectedEntries[i].Timestamp,
+				actualEntries[i].Timestamp,
+			)
+		}
+
+		actualEntries[i].Timestamp = expectedEntries[i].Timestamp
+
+		if !reflect.DeepEqual(expectedEntries[i], actualEntries[i]) {
+			t.Errorf(
+				"unexpected entry [%v]\nexpected: [%+v]\nactual:   [%+v]",
+				i,
+				expectedEntries[i],
+				actualEntries[i],
+			)
+		}
+	}
+}
+
+func TestTranscriptReplay(t *testing.T) {
+	groupMemberIDs, err := generateMemberKeys(3)
+	if err != nil {
+		t.Fatalf("failed to generate members keys: [%v]", err)
+	}
+
+	outsiderIDs, err := generateMemberKeys(1)
+	if err != nil {
+		t.Fatalf("failed to generate members keys: [%v]", err)
+	}
+
+	memberID := groupMemberIDs[0]
+
+	signRound9Message := func(senderID MemberID, s int64) *ProtocolMessage {
+		return newReplayTestMessage(
+			t,
+			senderID,
+			true,
+			&signing.SignRound9Message{S: big.NewInt(s).Bytes()},
+		)
+	}
+
+	var tests = map[string]struct {
+		inboundMessages []*ProtocolMessage
+		expectedIndex   int
+		expectedReason  string
+	}{
+		"all messages are valid": {
+			inboundMessages: []*ProtocolMessage{
+				signRound9Message(groupMemberIDs[1], 1),
+				signRound9Message(groupMemberIDs[2], 2),
+				signRound9Message(groupMemberIDs[1], 1),
+			},
+			expectedIndex: -1,
+		},
+		"message sent by a member outside the group": {
+			inboundMessages: []*ProtocolMessage{
+				signRound9Message(groupMemberIDs[1], 1),
+				signRound9Message(outsiderIDs[0], 2),
+			},
+			expectedIndex:  2,
+			expectedReason: "is not a member of the group",
+		},
+		"message sent in the name of the member": {
+			inboundMessages: []*ProtocolMessage{
+				signRound9Message(memberID, 2),
+			},
+			expectedIndex:  1,
+			expectedReason: "message sent in the name of the member",
+		},
+		"message of another protocol": {
+			inboundMessages: []*ProtocolMessage{
+				newReplayTestMessage(
+					t,
+					groupMemberIDs[1],
+					false,
+					&keygen.KGRound2Message1{Share: []byte{0x01}},
+				),
+			},
+			expectedIndex:  1,
+			expectedReason: "does not belong to the protocol",
+		},
+		"conflicting messages of the same type": {
+			inboundMessages: []*ProtocolMessage{
+				signRound9Message(groupMemberIDs[1], 1),
+				signRound9Message(groupMemberIDs[2], 2),
+				signRound9Message(groupMemberIDs[1], 3),
+			},
+			expectedIndex:  3,
+			expectedReason: "conflicts with another message",
+		},
+		"message failing basic validation": {
+			inboundMessages: []*ProtocolMessage{
+				newReplayTestMessage(
+					t,
+					groupMemberIDs[1],
+					true,
+					&signing.SignRound9Message{},
+				),
+			},
+			expectedIndex:  1,
+			expectedReason: "invalid TSS message",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			transcript := NewTranscript("signing for keep 0x01")
+			transcript.recordGroup(&groupInfo{
+				memberID:       memberID,
+				groupMemberIDs: groupMemberIDs,
+			})
+			transcript.recordOutbound(signRound9Message(memberID, 10), true)
+			for _, message := range test.inboundMessages {
+				transcript.record(InboundMessage, message, message.IsBroadcast)
+			}
+
+			failure, err := transcript.Replay()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.expectedIndex < 0 {
+				if failure != nil {
+					t.Fatalf("unexpected replay failure: [%v]", failure)
+				}
+				return
+			}
+
+			if failure == nil {
+				t.Fatalf("expected replay failure")
+			}
+
+			if failure.Index != test.expectedIndex {
+				t.Errorf(
+					"unexpected index of failed message\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedIndex,
+					failure.Index,
+				)
+			}
+
+			if !strings.Contains(failure.Reason, test.expectedReason) {
+				t.Errorf(
+					"unexpected failure reason\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedReason,
+					failure.Reason,
+				)
+			}
+		})
+	}
+}
+
+func newReplayTestMessage(
+	t *testing.T,
+	senderID MemberID,
+	isBroadcast bool,
+	content tssLib.MessageContent,
+) *ProtocolMessage {
+	routing := tssLib.MessageRouting{
+		From:        tssLib.NewPartyID(senderID.String(), "", senderID.bigInt()),
+		IsBroadcast: isBroadcast,
+	}
+
+	message := tssLib.NewMessage(
+		routing,
+		content,
+		tssLib.NewMessageWrapper(routing, content),
+	)
+
+	bytes, _, err := message.WireBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &ProtocolMessage{
+		SenderID:    senderID,
+		Payload:     bytes,
+		IsBroadcast: isBroadcast,
+		SessionID:   "test-session-1",
+	}
+}
+
+func mustGetTransportIdentifier(
+	t *testing.T,
+	bridge *networkBridge,
+	memberID MemberID,
+) net.TransportIdentifier {
+	transportID, err := bridge.getTransportIdentifier(memberID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return transportID
+}
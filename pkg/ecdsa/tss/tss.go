@@ -9,7 +9,9 @@ package tss
 import (
 	"context"
 	cecdsa "crypto/ecdsa"
+	"encoding/hex"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ipfs/go-log"
@@ -18,13 +20,6 @@ import (
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss/params"
 )
 
-const (
-	// KeyGenerationProtocolTimeout represents the amount of time before we give up trying to communicate key generation
-	KeyGenerationProtocolTimeout = 8 * time.Minute
-	// SigningProtocolTimeout represents the amount of time before we give up trying to communicate signing
-	SigningProtocolTimeout = 10 * time.Minute
-)
-
 var logger = log.Logger("keep-tss")
 
 // GenerateThresholdSigner executes a threshold multi-party key generation protocol.
@@ -41,7 +36,12 @@ var logger = log.Logger("keep-tss")
 // execution. The parameters should be generated prior to running this function.
 // If not provided they will be generated.
 //
+// Key generation, including readiness signaling, has to complete within
+// the protocol timeout.
+//
 // As a result a signer will be returned or an error, if key generation failed.
+//
+// Groups consisting of just one member should use GenerateLocalSigner instead.
 func GenerateThresholdSigner(
 	parentCtx context.Context,
 	groupID string,
@@ -51,35 +51,28 @@ func GenerateThresholdSigner(
 	networkProvider net.Provider,
 	pubKeyToAddressFn func(cecdsa.PublicKey) []byte,
 	paramsBox *params.Box,
+	protocolTimeout time.Duration,
 ) (*ThresholdSigner, error) {
-	if len(groupMemberIDs) < 2 {
-		return nil, fmt.Errorf(
-			"group should have at least 2 members but got: [%d]",
-			len(groupMemberIDs),
-		)
-	}
-
-	if len(groupMemberIDs) <= int(dishonestThreshold) {
-		return nil, fmt.Errorf(
-			"group size [%d], should be greater than dishonest threshold [%d]",
-			len(groupMemberIDs),
-			dishonestThreshold,
-		)
-	}
-
-	group := &groupInfo{
-		groupID:            groupID,
-		memberID:           memberID,
-		groupMemberIDs:     groupMemberIDs,
-		dishonestThreshold: int(dishonestThreshold),
+	group, err := newThresholdGroupInfo(
+		groupID,
+		memberID,
+		groupMemberIDs,
+		dishonestThreshold,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	netBridge, err := newNetworkBridge(group, networkProvider)
+	netBridge, err := newNetworkBridge(
+		group,
+		networkProvider,
+		transcriptFromContext(parentCtx),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize network bridge: [%v]", err)
 	}
 
-	ctx, cancel := context.WithTimeout(parentCtx, KeyGenerationProtocolTimeout)
+	ctx, cancel := context.WithTimeout(parentCtx, protocolTimeout)
 	defer cancel()
 
 	preParams, err := paramsBox.Content()
@@ -103,13 +96,16 @@ func GenerateThresholdSigner(
 		return nil, err
 	}
 
-	if err := readyProtocol(
+	// Key generation requires all group members to be ready.
+	if _, err := readyProtocol(
 		ctx,
 		group,
+		group.groupID,
 		broadcastChannel,
 		pubKeyToAddressFn,
+		len(groupMemberIDs),
 	); err != nil {
-		return nil, fmt.Errorf("readiness signaling protocol failed: [%v]", err)
+		return nil, fmt.Errorf("readiness signaling protocol failed: [%w]", err)
 	}
 
 	// We are begining the communication with other members using pre-parameters
@@ -119,9 +115,9 @@ func GenerateThresholdSigner(
 
 	logger.Infof("[party:%s]: starting key generation", keyGenSigner.keygenParty.PartyID())
 
-	signer, err := keyGenSigner.generateKey(ctx)
+	signer, err := keyGenSigner.generateKey(ctx, protocolTimeout)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate key: [%v]", err)
+		return nil, fmt.Errorf("failed to generate key: [%w]", err)
 	}
 	logger.Infof("[party:%s]: completed key generation", keyGenSigner.keygenParty.PartyID())
 
@@ -130,44 +126,549 @@ func GenerateThresholdSigner(
 
 // CalculateSignature executes a threshold multi-party signature calculation
 // protocol for the given digest. As a result the calculated ECDSA signature will
-// be returned or an error, if the signature generation failed.
+// be returned or an error, if the signature generation failed. The signature
+// is verified against the group public key and returned in the canonical form
+// with a low `s` value. If it is invalid, the peer members who calculated it
+// are blamed.
+//
+// The signature is calculated by all members who signalled their readiness
+// within the readiness timeout. The protocol can proceed without some of the
+// members as long as at least `t + 1` of them, where `t` is the dishonest
+// threshold of the group, are ready. Signing, including readiness signaling,
+// has to complete within the protocol timeout.
+//
+// Signer of a single-member group calculates the signature locally, without
+// any network communication.
+//
+// All rounds of the signing protocol are executed once the digest is known.
+// Signing nonces are not precomputed ahead of signature requests, as
+// the signing protocol of the TSS library has no offline phase which could be
+// run separately from the online one.
 func (s *ThresholdSigner) CalculateSignature(
 	parentCtx context.Context,
 	digest []byte,
 	networkProvider net.Provider,
 	pubKeyToAddressFn func(cecdsa.PublicKey) []byte,
+	protocolTimeout time.Duration,
 ) (*ecdsa.Signature, error) {
-	netBridge, err := newNetworkBridge(s.groupInfo, networkProvider)
+	if s.isLocal() {
+		signature, err := s.calculateSignatureLocally(digest)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.verifySignature(digest, signature, s.groupMemberIDs)
+	}
+
+	netBridge, err := newNetworkBridge(
+		s.groupInfo,
+		networkProvider,
+		transcriptFromContext(parentCtx),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize network bridge: [%v]", err)
 	}
 
-	ctx, cancel := context.WithTimeout(parentCtx, SigningProtocolTimeout)
+	ctx, cancel := context.WithTimeout(parentCtx, protocolTimeout)
 	defer cancel()
 
-	signingSigner, err := s.initializeSigning(ctx, digest[:], netBridge)
+	// Connect to peer members before signalling readiness so that messages
+	// of members who start the signing earlier are not lost.
+	if err := netBridge.connect(ctx, s.signingQuorum()); err != nil {
+		return nil, fmt.Errorf("failed to connect bridge network: [%v]", err)
+	}
+
+	broadcastChannel, err := netBridge.getBroadcastChannel()
+	if err != nil {
+		return nil, err
+	}
+
+	signingMemberIDs, err := readyProtocol(
+		ctx,
+		s.groupInfo,
+		s.signingSessionID(digest),
+		broadcastChannel,
+		pubKeyToAddressFn,
+		s.signingQuorum(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readiness signaling protocol failed: [%w]", err)
+	}
+
+	signingSigner, err := s.initializeSigning(
+		ctx,
+		digest[:],
+		signingMemberIDs,
+		netBridge,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize signing: [%v]", err)
 	}
 
+	signature, err := signingSigner.sign(ctx, protocolTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign: [%w]", err)
+	}
+
+	return s.verifySignature(digest, signature, signingMemberIDs)
+}
+
+// CalculateSignatures executes threshold multi-party signature calculation
+// protocols for all the given digests. Contrary to calculating signatures one
+// by one, the network is set up and the readiness is signalled once for
+// the whole batch. Signatures are then calculated by the same members, each of
+// them within a separate protocol session. Up to `concurrency` signatures are
+// calculated at the same time, in the order of digests. Each signature is
+// verified against the group public key and normalized the same way as by
+// CalculateSignature. As a result the calculated ECDSA signatures are returned in
+// the order of digests or an error, if the generation of any of the signatures
+// failed.
+//
+// All members have to provide the same digests in the same order and should
+// use the same concurrency. Digests have to be unique within the batch.
+// Readiness signaling has to complete within the protocol timeout and each
+// signature has to be calculated within the protocol timeout counted from
+// the moment its calculation started.
+func (s *ThresholdSigner) CalculateSignatures(
+	parentCtx context.Context,
+	digests [][]byte,
+	networkProvider net.Provider,
+	pubKeyToAddressFn func(cecdsa.PublicKey) []byte,
+	protocolTimeout time.Duration,
+	concurrency int,
+) ([]*ecdsa.Signature, error) {
+	if len(digests) == 0 {
+		return nil, fmt.Errorf("no digests to sign")
+	}
+
+	if concurrency < 1 {
+		return nil, fmt.Errorf("invalid concurrency [%d]", concurrency)
+	}
+
+	uniqueDigests := make(map[string]bool)
+	for _, digest := range digests {
+		digestString := hex.EncodeToString(digest)
+		if uniqueDigests[digestString] {
+			return nil, fmt.Errorf("duplicated digest [%s]", digestString)
+		}
+		uniqueDigests[digestString] = true
+	}
+
+	signatures := make([]*ecdsa.Signature, len(digests))
+
+	if s.isLocal() {
+		for i, digest := range digests {
+			signature, err := s.calculateSignatureLocally(digest)
+			if err == nil {
+				signature, err = s.verifySignature(
+					digest,
+					signature,
+					s.groupMemberIDs,
+				)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			signatures[i] = signature
+		}
+
+		return signatures, nil
+	}
+
+	netBridge, err := newNetworkBridge(
+		s.groupInfo,
+		networkProvider,
+		transcriptFromContext(parentCtx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize network bridge: [%v]", err)
+	}
+
+	// The network bridge and signing parties have to be available for
+	// the whole batch: the readiness signaling and consecutive groups of
+	// `concurrency` signatures, each taking up to the protocol timeout.
+	signingRounds := (len(digests) + concurrency - 1) / concurrency
+	ctx, cancel := context.WithTimeout(
+		parentCtx,
+		time.Duration(signingRounds+1)*protocolTimeout,
+	)
+	defer cancel()
+
+	// Connect to peer members before signalling readiness so that messages
+	// of members who start the signing earlier are not lost.
+	if err := netBridge.connect(ctx, s.signingQuorum()); err != nil {
+		return nil, fmt.Errorf("failed to connect bridge network: [%v]", err)
+	}
+
 	broadcastChannel, err := netBridge.getBroadcastChannel()
 	if err != nil {
 		return nil, err
 	}
 
-	if err := readyProtocol(
+	readyCtx, cancelReady := context.WithTimeout(ctx, protocolTimeout)
+	defer cancelReady()
+
+	signingMemberIDs, err := readyProtocol(
+		readyCtx,
+		s.groupInfo,
+		s.batchSigningSessionID(digests),
+		broadcastChannel,
+		pubKeyToAddressFn,
+		s.signingQuorum(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readiness signaling protocol failed: [%w]", err)
+	}
+
+	// Parties for all the digests are initialized before any signature is
+	// calculated so that messages of members who already started calculating
+	// a signature are not lost.
+	signingSigners := make([]*signingSigner, len(digests))
+	for i, digest := range digests {
+		signingSigners[i], err = s.initializeSigning(
+			ctx,
+			digest,
+			signingMemberIDs,
+			netBridge,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to initialize signing of digest [%x]: [%v]",
+				digest,
+				err,
+			)
+		}
+	}
+
+	signingErrors := make([]error, len(digests))
+
+	indexes := make(chan int, len(digests))
+	for i := range digests {
+		indexes <- i
+	}
+	close(indexes)
+
+	sign := func(index int) (*ecdsa.Signature, error) {
+		signingCtx, cancelSigning := context.WithTimeout(ctx, protocolTimeout)
+		defer cancelSigning()
+
+		signature, err := signingSigners[index].sign(signingCtx, protocolTimeout)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.verifySignature(digests[index], signature, signingMemberIDs)
+	}
+
+	workers := concurrency
+	if workers > len(digests) {
+		workers = len(digests)
+	}
+
+	var signingWait sync.WaitGroup
+	signingWait.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer signingWait.Done()
+
+			for index := range indexes {
+				signatures[index], signingErrors[index] = sign(index)
+			}
+		}()
+	}
+
+	signingWait.Wait()
+
+	for i, err := range signingErrors {
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to sign digest [%x]: [%w]",
+				digests[i],
+				err,
+			)
+		}
+	}
+
+	return signatures, nil
+}
+
+// CalculateSchnorrSignature executes a threshold multi-party Schnorr signature
+// calculation protocol for the given 32-byte digest. As a result a 64-byte
+// signature as defined in BIP-340 is returned or an error, if the signature
+// generation failed. The signature is calculated with the same key shares as
+// ECDSA signatures.
+//
+// The signature verifies against the x-only public key returned by
+// SchnorrPublicKey for the same tweak. Empty tweak makes the signature verify
+// against the group public key, a taproot tweak makes it valid for a key path
+// spend of a taproot output.
+//
+// The signature is calculated by all members who signalled their readiness
+// within the readiness timeout, the same way as ECDSA signatures. Signer of
+// a single-member group calculates the signature locally, without any network
+// communication. Signing, including readiness signaling, has to complete
+// within the protocol timeout.
+func (s *ThresholdSigner) CalculateSchnorrSignature(
+	parentCtx context.Context,
+	digest []byte,
+	tweak []byte,
+	networkProvider net.Provider,
+	pubKeyToAddressFn func(cecdsa.PublicKey) []byte,
+	protocolTimeout time.Duration,
+) ([]byte, error) {
+	if len(digest) != 32 {
+		return nil, fmt.Errorf(
+			"digest must be 32 bytes long but has [%d] bytes",
+			len(digest),
+		)
+	}
+
+	key, err := newSchnorrKey(s.thresholdKey.ECDSAPub, tweak)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive Schnorr key: [%v]", err)
+	}
+
+	if s.isLocal() {
+		return s.calculateSchnorrSignatureLocally(digest, key)
+	}
+
+	netBridge, err := newNetworkBridge(
+		s.groupInfo,
+		networkProvider,
+		transcriptFromContext(parentCtx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize network bridge: [%v]", err)
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, protocolTimeout)
+	defer cancel()
+
+	// Connect to peer members before signalling readiness so that messages
+	// of members who start the signing earlier are not lost.
+	if err := netBridge.connect(ctx, s.signingQuorum()); err != nil {
+		return nil, fmt.Errorf("failed to connect bridge network: [%v]", err)
+	}
+
+	broadcastChannel, err := netBridge.getBroadcastChannel()
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID := s.schnorrSigningSessionID(digest, tweak)
+
+	signingMemberIDs, err := readyProtocol(
 		ctx,
 		s.groupInfo,
+		sessionID,
 		broadcastChannel,
 		pubKeyToAddressFn,
+		s.signingQuorum(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readiness signaling protocol failed: [%w]", err)
+	}
+
+	signingSigner, err := s.initializeSchnorrSigning(
+		ctx,
+		sessionID,
+		digest,
+		key,
+		signingMemberIDs,
+		netBridge,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize signing: [%v]", err)
+	}
+
+	signature, err := signingSigner.sign(ctx, protocolTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign: [%w]", err)
+	}
+
+	return signature, nil
+}
+
+// RefreshKeyShare executes a key share refresh protocol. All members of
+// the signing group replace their key shares with new ones without changing
+// the group's public key. Key shares from before the refresh cannot be combined
+// with key shares after the refresh, so shares leaked in the past are no longer
+// useful for the adversary once the refresh completes.
+//
+// Key share refresh requires all group members to participate. Each refresh
+// starts a new refresh epoch and key shares are bound to their epochs.
+// A member cannot know whether all other members completed the protocol, so
+// the returned signer holds both the refreshed key share and the key share of
+// the previous epoch. Signatures are calculated with the refreshed key share.
+// The returned signer has to be persisted and reconciled with ReconcileKeyShare
+// in a window starting at the end of the refresh protocol timeout to find out
+// which of the key shares all members hold.
+//
+// If the protocol fails after it started, the member reconciles its current
+// key share before returning an error. This way, members who completed
+// the protocol learn that not all members hold refreshed key shares and
+// return to the key shares of the previous epoch.
+//
+// Only a reconciled signer can be refreshed. TSS protocol requires
+// pre-parameters such as safe primes to be generated for execution.
+// The parameters should be generated prior to running this function.
+// Key share refresh, including readiness signaling, has to complete within
+// the protocol timeout.
+//
+// As a result a signer holding the refreshed key share will be returned or an
+// error, if the key share refresh failed.
+func (s *ThresholdSigner) RefreshKeyShare(
+	parentCtx context.Context,
+	networkProvider net.Provider,
+	pubKeyToAddressFn func(cecdsa.PublicKey) []byte,
+	paramsBox *params.Box,
+	protocolTimeout time.Duration,
+) (*ThresholdSigner, error) {
+	if s.isLocal() {
+		return nil, fmt.Errorf(
+			"key share of a single-member group cannot be refreshed",
+		)
+	}
+
+	if !s.IsReconciled() {
+		return nil, fmt.Errorf(
+			"key share has to be reconciled before it is refreshed",
+		)
+	}
+
+	netBridge, err := newNetworkBridge(
+		s.groupInfo,
+		networkProvider,
+		transcriptFromContext(parentCtx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize network bridge: [%v]", err)
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, protocolTimeout)
+	defer cancel()
+
+	preParams, err := paramsBox.Content()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pre-parameters: [%v]", err)
+	}
+
+	if err := netBridge.connect(ctx, len(s.groupMemberIDs)); err != nil {
+		return nil, fmt.Errorf("failed to connect bridge network: [%v]", err)
+	}
+
+	refreshingSigner, err := s.initializeKeyShareRefresh(
+		ctx,
+		preParams,
+		netBridge,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize key share refresh: [%v]", err)
+	}
+
+	broadcastChannel, err := netBridge.getBroadcastChannel()
+	if err != nil {
+		return nil, err
+	}
+
+	// Key share refresh requires all group members to be ready.
+	if _, err := readyProtocol(
+		ctx,
+		s.groupInfo,
+		s.epochSessionPrefix()+refreshSessionSuffix,
+		broadcastChannel,
+		pubKeyToAddressFn,
+		len(s.groupMemberIDs),
 	); err != nil {
-		return nil, fmt.Errorf("readiness signaling protocol failed: [%v]", err)
+		return nil, fmt.Errorf("readiness signaling protocol failed: [%w]", err)
 	}
 
-	signature, err := signingSigner.sign(ctx)
+	// We are begining the communication with other members using pre-parameters
+	// provided inside of this box. It's time to destroy box content so that the
+	// pre-parameters cannot be later reused.
+	paramsBox.DestroyContent()
+
+	refreshedSigner, err := refreshingSigner.refreshKeyShare(ctx, protocolTimeout)
 	if err != nil {
-		return nil, fmt.Errorf("failed to sign: [%v]", err)
+		// Some members may have completed the protocol. They keep both key
+		// shares until they learn that not all members hold refreshed key
+		// shares, so the current key share is reported to them. Members who
+		// completed the protocol reconcile their key shares once the protocol
+		// times out, so the reconciliation window starts at the same time.
+		protocolDeadline, _ := ctx.Deadline()
+		if _, reconcileErr := s.ReconcileKeyShare(
+			parentCtx,
+			networkProvider,
+			pubKeyToAddressFn,
+			protocolDeadline,
+		); reconcileErr != nil {
+			logger.Errorf(
+				"failed to reconcile key share after failed refresh: [%v]",
+				reconcileErr,
+			)
+		}
+
+		return nil, fmt.Errorf("failed to refresh key share: [%w]", err)
+	}
+
+	return refreshedSigner, nil
+}
+
+// ReconcileKeyShare executes a key share reconciliation protocol. Members
+// exchange refresh epochs of key shares they hold to find out which key share
+// can be used by all of them once a key share refresh completes.
+//
+// A signer holding key shares of two refresh epochs returns to the key share
+// of the previous epoch as soon as any member reports it does not hold
+// the refreshed key share. The signer drops the key share of the previous
+// epoch once all members report they hold the refreshed key share. If neither
+// happens within the reconciliation window, the signer keeps both key shares
+// and the reconciliation has to be repeated later.
+//
+// Members report key shares they hold until the end of the reconciliation
+// window, unless all members reported they hold the same key shares as
+// the member, so all members should execute the protocol within the same
+// window. The window starts at the given time, or immediately if the time
+// has already passed.
+//
+// As a result a reconciled signer will be returned, the same signer if key
+// shares it holds have not changed, or error if the reconciliation could not
+// be executed.
+func (s *ThresholdSigner) ReconcileKeyShare(
+	parentCtx context.Context,
+	networkProvider net.Provider,
+	pubKeyToAddressFn func(cecdsa.PublicKey) []byte,
+	windowStart time.Time,
+) (*ThresholdSigner, error) {
+	if s.isLocal() {
+		return s, nil
+	}
+
+	netBridge, err := newNetworkBridge(
+		s.groupInfo,
+		networkProvider,
+		transcriptFromContext(parentCtx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize network bridge: [%v]", err)
+	}
+
+	broadcastChannel, err := netBridge.getBroadcastChannel()
+	if err != nil {
+		return nil, err
+	}
+
+	if now := time.Now(); windowStart.Before(now) {
+		windowStart = now
 	}
 
-	return signature, err
+	ctx, cancel := context.WithDeadline(
+		parentCtx,
+		windowStart.Add(keyShareReconciliationTimeout),
+	)
+	defer cancel()
+
+	return reconcileProtocol(ctx, s, broadcastChannel, pubKeyToAddressFn), nil
 }
@@ -100,6 +100,7 @@ func TestGenerateKeyAndSign(t *testing.T) {
 					network,
 					pubKeyToAddressFn,
 					params.NewBox(&preParams),
+					defaultKeyGenerationProtocolTimeout,
 				)
 				if err != nil {
 					errChan <- fmt.Errorf("failed to generate signer: [%v]", err)
@@ -176,6 +177,7 @@ func TestGenerateKeyAndSign(t *testing.T) {
 					digest[:],
 					networkProvider,
 					pubKeyToAddressFn,
+					defaultSigningProtocolTimeout,
 				)
 				if err != nil {
 					errChan <- fmt.Errorf("failed to sign: [%v]", err)
@@ -302,3 +304,136 @@ func serializeSignature(signature *ecdsa.Signature) ([]byte, error) {
 
 	return serializedBytes, nil
 }
+
+func TestGenerateKeyAndSignWithThreshold(t *testing.T) {
+	defaultProtocolReadyTimeout := protocolReadyTimeout
+	protocolReadyTimeout = 5 * time.Second
+	defer func() {
+		protocolReadyTimeout = defaultProtocolReadyTimeout
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 180*time.Second)
+	defer cancel()
+
+	groupSize := 3
+	dishonestThreshold := uint(1)
+	groupID := fmt.Sprintf("tss-test-%d", rand.Int())
+
+	pubKeyToAddressFn := func(publicKey cecdsa.PublicKey) []byte {
+		return elliptic.Marshal(publicKey.Curve, publicKey.X, publicKey.Y)
+	}
+
+	groupMemberIDs, err := generateMemberKeys(groupSize)
+	if err != nil {
+		t.Fatalf("failed to generate members keys: [%v]", err)
+	}
+
+	testData, err := testdata.LoadKeygenTestFixtures(groupSize)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	networkProviders := make([]net.Provider, groupSize)
+	for i, memberID := range groupMemberIDs {
+		memberPublicKey, err := memberID.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		networkPublicKey := key.NetworkPublic(*memberPublicKey)
+		networkProviders[i] = newTestNetProvider(&networkPublicKey)
+	}
+
+	// Key generation requires all members.
+	signers := make([]*ThresholdSigner, groupSize)
+	keyGenErrors := make(chan error, groupSize)
+
+	var keyGenWait sync.WaitGroup
+	keyGenWait.Add(groupSize)
+
+	for i, memberID := range groupMemberIDs {
+		go func(memberID MemberID, index int) {
+			defer keyGenWait.Done()
+
+			preParams := testData[index].LocalPreParams
+
+			signer, err := GenerateThresholdSigner(
+				ctx,
+				groupID,
+				memberID,
+				groupMemberIDs,
+				dishonestThreshold,
+				networkProviders[index],
+				pubKeyToAddressFn,
+				params.NewBox(&preParams),
+				defaultKeyGenerationProtocolTimeout,
+			)
+			if err != nil {
+				keyGenErrors <- fmt.Errorf("failed to generate signer: [%v]", err)
+				return
+			}
+
+			signers[index] = signer
+		}(memberID, i)
+	}
+
+	keyGenWait.Wait()
+	close(keyGenErrors)
+
+	for err := range keyGenErrors {
+		t.Fatalf("unexpected error on key generation: [%v]", err)
+	}
+
+	// Signing with `t + 1` members while the last member is offline.
+	digest := sha256.Sum256([]byte("message to sign"))
+	signingMembersCount := int(dishonestThreshold) + 1
+
+	signatures := make([]*ecdsa.Signature, signingMembersCount)
+	signingErrors := make(chan error, signingMembersCount)
+
+	var signingWait sync.WaitGroup
+	signingWait.Add(signingMembersCount)
+
+	for i := 0; i < signingMembersCount; i++ {
+		go func(index int) {
+			defer signingWait.Done()
+
+			signature, err := signers[index].CalculateSignature(
+				ctx,
+				digest[:],
+				networkProviders[index],
+				pubKeyToAddressFn,
+				defaultSigningProtocolTimeout,
+			)
+			if err != nil {
+				signingErrors <- fmt.Errorf("failed to sign: [%v]", err)
+				return
+			}
+
+			signatures[index] = signature
+		}(i)
+	}
+
+	signingWait.Wait()
+	close(signingErrors)
+
+	for err := range signingErrors {
+		t.Fatalf("unexpected error on signing: [%v]", err)
+	}
+
+	publicKey := signers[0].PublicKey()
+
+	for _, signature := range signatures {
+		if !reflect.DeepEqual(signatures[0], signature) {
+			t.Errorf(
+				"signature doesn't match expected\nexpected: [%v]\nactual: [%v]",
+				signatures[0],
+				signature,
+			)
+		}
+	}
+
+	if !cecdsa.Verify(publicKey, digest[:], signatures[0].R, signatures[0].S) {
+		t.Errorf("invalid signature: [%+v]", signatures[0])
+	}
+}
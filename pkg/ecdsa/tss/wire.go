@@ -0,0 +1,111 @@
+package tss
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+
+	ecdsaKeygen "github.com/binance-chain/tss-lib/ecdsa/keygen"
+	ecdsaResharing "github.com/binance-chain/tss-lib/ecdsa/resharing"
+	ecdsaSigning "github.com/binance-chain/tss-lib/ecdsa/signing"
+	"github.com/binance-chain/tss-lib/tss"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss/gen/pb"
+)
+
+// wireMessageTypes maps names of TSS protocol messages to their types.
+//
+// Messages of tss-lib ECDSA and EdDSA protocols share names, e.g.
+// `KGRound1Message`, and they are all registered in the global protobuf
+// registry under those names. To avoid the registration conflict, EdDSA
+// protocols are linked only into clients built with the `eddsa` build tag.
+// In such clients the registry resolves a shared name to the type registered
+// first, which depends on the order in which packages are initialized. For
+// this reason, messages received from the network are not parsed with
+// `tss.ParseWireMessage` but with the message types of the scheme
+// the receiving party executes.
+type wireMessageTypes map[string]reflect.Type
+
+func newWireMessageTypes(messages ...tss.MessageContent) wireMessageTypes {
+	messageTypes := make(wireMessageTypes, len(messages))
+	for _, message := range messages {
+		messageTypes[proto.MessageName(message)] = reflect.TypeOf(message).Elem()
+	}
+
+	return messageTypes
+}
+
+// ecdsaWireMessageTypes are types of messages of ECDSA key generation, signing
+// and key share refresh protocols.
+var ecdsaWireMessageTypes = newWireMessageTypes(
+	&ecdsaKeygen.KGRound1Message{},
+	&ecdsaKeygen.KGRound2Message1{},
+	&ecdsaKeygen.KGRound2Message2{},
+	&ecdsaKeygen.KGRound3Message{},
+	&ecdsaSigning.SignRound1Message1{},
+	&ecdsaSigning.SignRound1Message2{},
+	&ecdsaSigning.SignRound2Message{},
+	&ecdsaSigning.SignRound3Message{},
+	&ecdsaSigning.SignRound4Message{},
+	&ecdsaSigning.SignRound5Message{},
+	&ecdsaSigning.SignRound6Message{},
+	&ecdsaSigning.SignRound7Message{},
+	&ecdsaSigning.SignRound8Message{},
+	&ecdsaSigning.SignRound9Message{},
+	&ecdsaResharing.DGRound1Message{},
+	&ecdsaResharing.DGRound2Message1{},
+	&ecdsaResharing.DGRound2Message2{},
+	&ecdsaResharing.DGRound3Message1{},
+	&ecdsaResharing.DGRound3Message2{},
+	&ecdsaResharing.DGRound4Message{},
+)
+
+// schnorrWireMessageTypes are types of messages of the Schnorr signing
+// protocol. The protocol is not implemented by tss-lib so its messages are
+// defined alongside other network messages of this package.
+var schnorrWireMessageTypes = newWireMessageTypes(
+	&pb.SchnorrSigningRound1Message{},
+	&pb.SchnorrSigningRound2Message{},
+)
+
+// parseWireMessage parses the message received from the network in the same
+// way as `tss.ParseWireMessage` does but accepts only messages of the known
+// types.
+func (wmt wireMessageTypes) parseWireMessage(
+	wireBytes []byte,
+	from *tss.PartyID,
+	isBroadcast bool,
+) (tss.ParsedMessage, error) {
+	wire := &tss.MessageWrapper{
+		Message:     &any.Any{},
+		From:        from.MessageWrapper_PartyID,
+		IsBroadcast: isBroadcast,
+	}
+	if err := proto.Unmarshal(wireBytes, wire.Message); err != nil {
+		return nil, err
+	}
+
+	typeURL := wire.Message.GetTypeUrl()
+	messageName := typeURL[strings.LastIndex(typeURL, "/")+1:]
+
+	messageType, ok := wmt[messageName]
+	if !ok {
+		return nil, fmt.Errorf("unknown message type [%s]", typeURL)
+	}
+
+	content := reflect.New(messageType).Interface().(tss.MessageContent)
+	if err := proto.Unmarshal(wire.Message.GetValue(), content); err != nil {
+		return nil, err
+	}
+
+	return tss.NewMessage(
+		tss.MessageRouting{
+			From:        from,
+			IsBroadcast: isBroadcast,
+		},
+		content,
+		wire,
+	), nil
+}
@@ -0,0 +1,64 @@
+//+build !eddsa
+
+package tss
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// tss-lib registers messages of ECDSA and EdDSA protocols in the global
+// protobuf registry under the same names. The test makes sure EdDSA protocols
+// are not linked into clients built without the `eddsa` build tag, so that
+// the registration conflict does not occur, and that the names of all ECDSA
+// protocol messages resolve to their types.
+func TestWireMessageTypesRegistration(t *testing.T) {
+	eddsaPackagesPath := "github.com/binance-chain/tss-lib/eddsa/"
+
+	protoregistry.GlobalTypes.RangeMessages(
+		func(messageType protoreflect.MessageType) bool {
+			messageName := string(messageType.Descriptor().FullName())
+
+			registeredType := proto.MessageType(messageName)
+			if registeredType == nil {
+				return true
+			}
+
+			if strings.HasPrefix(registeredType.Elem().PkgPath(), eddsaPackagesPath) {
+				t.Errorf(
+					"EdDSA protocol message [%s] of type [%s] is registered",
+					messageName,
+					qualifiedTypeName(registeredType),
+				)
+			}
+
+			return true
+		},
+	)
+
+	for messageName, messageType := range ecdsaWireMessageTypes {
+		registeredType := proto.MessageType(messageName)
+		if registeredType != reflect.PtrTo(messageType) {
+			t.Errorf(
+				"unexpected type registered for message [%s]\n"+
+					"expected: [%v]\nactual:   [%v]",
+				messageName,
+				qualifiedTypeName(reflect.PtrTo(messageType)),
+				qualifiedTypeName(registeredType),
+			)
+		}
+	}
+}
+
+func qualifiedTypeName(messageType reflect.Type) string {
+	if messageType == nil {
+		return "<nil>"
+	}
+
+	return messageType.Elem().PkgPath() + "." + messageType.Elem().Name()
+}
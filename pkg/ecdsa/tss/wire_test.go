@@ -0,0 +1,103 @@
+package tss
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/binance-chain/tss-lib/crypto/paillier"
+	ecdsaKeygen "github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+type parseWireMessageTest struct {
+	messageTypes  wireMessageTypes
+	message       tss.ParsedMessage
+	expectedError bool
+}
+
+func TestParseWireMessage(t *testing.T) {
+	from := newTestWireMessageSender()
+
+	deCommitment := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+
+	proof := paillier.Proof{}
+	for i := range proof {
+		proof[i] = big.NewInt(int64(i + 1))
+	}
+
+	schnorrMessage := newSchnorrSigningRound2Message(from, big.NewInt(5))
+
+	runParseWireMessageTests(t, from, map[string]parseWireMessageTest{
+		"ECDSA message parsed with ECDSA types": {
+			messageTypes: ecdsaWireMessageTypes,
+			message:      ecdsaKeygen.NewKGRound2Message2(from, deCommitment),
+		},
+		"ECDSA message parsed with Schnorr types": {
+			messageTypes:  schnorrWireMessageTypes,
+			message:       ecdsaKeygen.NewKGRound3Message(from, proof),
+			expectedError: true,
+		},
+		"Schnorr message parsed with Schnorr types": {
+			messageTypes: schnorrWireMessageTypes,
+			message:      schnorrMessage,
+		},
+		"Schnorr message parsed with ECDSA types": {
+			messageTypes:  ecdsaWireMessageTypes,
+			message:       schnorrMessage,
+			expectedError: true,
+		},
+	})
+}
+
+func newTestWireMessageSender() *tss.PartyID {
+	from := tss.NewPartyID("1", "member-1", big.NewInt(1))
+	from.Index = 0
+
+	return from
+}
+
+func runParseWireMessageTests(
+	t *testing.T,
+	from *tss.PartyID,
+	tests map[string]parseWireMessageTest,
+) {
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			wireBytes, _, err := test.message.WireBytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			parsed, err := test.messageTypes.parseWireMessage(
+				wireBytes,
+				from,
+				true,
+			)
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: [%v]", err)
+			}
+
+			expectedType := reflect.TypeOf(test.message.Content())
+			actualType := reflect.TypeOf(parsed.Content())
+			if expectedType != actualType {
+				t.Errorf(
+					"unexpected message content type\n"+
+						"expected: [%v]\nactual:   [%v]",
+					expectedType,
+					actualType,
+				)
+			}
+
+			if !parsed.ValidateBasic() {
+				t.Errorf("parsed message failed basic validation")
+			}
+		})
+	}
+}
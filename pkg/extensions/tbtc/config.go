@@ -3,6 +3,7 @@ package tbtc
 import (
 	"time"
 
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
 	"github.com/keep-network/keep-ecdsa/pkg/chain/bitcoin"
 
 	configtime "github.com/keep-network/keep-ecdsa/config/time"
@@ -19,6 +20,10 @@ type Config struct {
 	TBTCSystem                 string
 	Bitcoin                    bitcoin.Config
 	LiquidationRecoveryTimeout configtime.Duration
+
+	// Number of blocks which should be mined on top of the block a deposit
+	// event has been emitted in, before the event is acted upon.
+	BlockConfirmations uint64
 }
 
 // GetLiquidationRecoveryTimeout returns the liquidation recovery timeout. If a
@@ -31,3 +36,13 @@ func (c *Config) GetLiquidationRecoveryTimeout() time.Duration {
 
 	return timeout
 }
+
+// GetBlockConfirmations returns the number of blocks confirming deposit events.
+// If a value is not set it returns the default confirmation depth.
+func (c *Config) GetBlockConfirmations() uint64 {
+	if c.BlockConfirmations == 0 {
+		return chain.DefaultConfirmationDepth
+	}
+
+	return c.BlockConfirmations
+}
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	cecdsa "crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ipfs/go-log"
 
@@ -65,6 +68,93 @@ func publicKeyToP2WPKHScriptCode(
 	return script, nil
 }
 
+// publicKeyToP2TRScript converts an x-only taproot output key to a Bitcoin
+// p2tr output script: OP_1 followed by the 32-byte key.
+//
+// [BIP341]: https://github.com/bitcoin/bips/blob/master/bip-0341.mediawiki
+func publicKeyToP2TRScript(outputKey []byte) ([]byte, error) {
+	if len(outputKey) != ecdsa.SchnorrPublicKeySize {
+		return nil, fmt.Errorf(
+			"error deriving p2tr script from public key: [unexpected key length: %v]",
+			len(outputKey),
+		)
+	}
+
+	return txscript.NewScriptBuilder().
+		AddOp(txscript.OP_1).
+		AddData(outputKey).
+		Script()
+}
+
+// calculateTaprootSigHash calculates the signature hash of the transaction
+// input spending a p2tr output with the key path, with the default hash type
+// and no annex, as defined in [BIP341]. Values and scripts of outputs spent by
+// all the transaction inputs have to be provided in the order of inputs.
+func calculateTaprootSigHash(
+	transaction *wire.MsgTx,
+	inputIndex int,
+	previousOutputValues []int64,
+	previousOutputScripts [][]byte,
+) ([]byte, error) {
+	if inputIndex < 0 || inputIndex >= len(transaction.TxIn) {
+		return nil, fmt.Errorf("input index [%d] out of range", inputIndex)
+	}
+	if len(previousOutputValues) != len(transaction.TxIn) ||
+		len(previousOutputScripts) != len(transaction.TxIn) {
+		return nil, fmt.Errorf(
+			"spent outputs do not match [%d] transaction inputs",
+			len(transaction.TxIn),
+		)
+	}
+
+	prevouts := sha256.New()
+	amounts := sha256.New()
+	scriptPubKeys := sha256.New()
+	sequences := sha256.New()
+	for i, txIn := range transaction.TxIn {
+		prevouts.Write(txIn.PreviousOutPoint.Hash[:])
+		binary.Write(prevouts, binary.LittleEndian, txIn.PreviousOutPoint.Index)
+		binary.Write(amounts, binary.LittleEndian, previousOutputValues[i])
+		if err := wire.WriteVarBytes(
+			scriptPubKeys,
+			wire.ProtocolVersion,
+			previousOutputScripts[i],
+		); err != nil {
+			return nil, err
+		}
+		binary.Write(sequences, binary.LittleEndian, txIn.Sequence)
+	}
+
+	outputs := sha256.New()
+	for _, txOut := range transaction.TxOut {
+		if err := wire.WriteTxOut(
+			outputs,
+			wire.ProtocolVersion,
+			transaction.Version,
+			txOut,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	sigMsg := &bytes.Buffer{}
+	sigMsg.WriteByte(0x00) // epoch
+	sigMsg.WriteByte(0x00) // hash type: SIGHASH_DEFAULT
+	binary.Write(sigMsg, binary.LittleEndian, transaction.Version)
+	binary.Write(sigMsg, binary.LittleEndian, transaction.LockTime)
+	sigMsg.Write(prevouts.Sum(nil))
+	sigMsg.Write(amounts.Sum(nil))
+	sigMsg.Write(scriptPubKeys.Sum(nil))
+	sigMsg.Write(sequences.Sum(nil))
+	sigMsg.Write(outputs.Sum(nil))
+	sigMsg.WriteByte(0x00) // spend type: key path, no annex
+	binary.Write(sigMsg, binary.LittleEndian, uint32(inputIndex))
+
+	sigHash := ecdsa.TaggedHash("TapSighash", sigMsg.Bytes())
+
+	return sigHash[:], nil
+}
+
 // constructUnsignedTransaction produces an unsigned transaction
 func constructUnsignedTransaction(
 	previousTransactionHashHex string,
@@ -73,6 +163,66 @@ func constructUnsignedTransaction(
 	feePerVbyte int64,
 	recipientAddresses []string,
 	chainParams *chaincfg.Params,
+) (*wire.MsgTx, error) {
+	// The witness signature field is the DER signature followed by the hash type.
+	// We write a dummy signature with 73 0 bytes. DER signatures vary in encoding
+	// between 71, 72, and 73 bytes, so we choose the longest for fee purposes.
+	// We then add one more dummy byte for the SigHashType for a total of 74 bytes.
+	dummySignatureForWitness := bytes.Repeat([]byte{0}, 74)
+
+	// The compressed public key requires 33 bytes.
+	dummyCompressedPublicKeyForWitness := bytes.Repeat([]byte{0}, 33)
+
+	return constructTransaction(
+		previousTransactionHashHex,
+		previousOutputIndex,
+		previousOutputValue,
+		feePerVbyte,
+		recipientAddresses,
+		chainParams,
+		[][]byte{
+			dummySignatureForWitness,
+			dummyCompressedPublicKeyForWitness,
+		},
+	)
+}
+
+// constructUnsignedTaprootTransaction produces an unsigned transaction
+// spending a p2tr output with the key path.
+func constructUnsignedTaprootTransaction(
+	previousTransactionHashHex string,
+	previousOutputIndex uint32,
+	previousOutputValue int64,
+	feePerVbyte int64,
+	recipientAddresses []string,
+	chainParams *chaincfg.Params,
+) (*wire.MsgTx, error) {
+	// The witness of a key path spend consists of the 64-byte Schnorr
+	// signature only. The hash type byte is omitted for the default hash type.
+	dummySignatureForWitness := bytes.Repeat([]byte{0}, 64)
+
+	return constructTransaction(
+		previousTransactionHashHex,
+		previousOutputIndex,
+		previousOutputValue,
+		feePerVbyte,
+		recipientAddresses,
+		chainParams,
+		[][]byte{dummySignatureForWitness},
+	)
+}
+
+// constructTransaction produces a transaction spending the previous output to
+// the recipient addresses. The given dummy witness is used to estimate the fee
+// and has to be replaced with the real one before the transaction is sent.
+func constructTransaction(
+	previousTransactionHashHex string,
+	previousOutputIndex uint32,
+	previousOutputValue int64,
+	feePerVbyte int64,
+	recipientAddresses []string,
+	chainParams *chaincfg.Params,
+	dummyWitness wire.TxWitness,
 ) (*wire.MsgTx, error) {
 	// If the previous output transaction hash is passed as a []byte, can use
 	// chainhash.NewHash.
@@ -86,23 +236,11 @@ func constructUnsignedTransaction(
 		)
 	}
 
-	// The witness signature field is the DER signature followed by the hash type.
-	// We write a dummy signature with 73 0 bytes. DER signatures vary in encoding
-	// between 71, 72, and 73 bytes, so we choose the longest for fee purposes.
-	// We then add one more dummy byte for the SigHashType for a total of 74 bytes.
-	dummySignatureForWitness := bytes.Repeat([]byte{0}, 74)
-
-	// The compressed public key requires 33 bytes.
-	dummyCompressedPublicKeyForWitness := bytes.Repeat([]byte{0}, 33)
-
 	tx := wire.NewMsgTx(wire.TxVersion)
 	txIn := wire.NewTxIn(
 		wire.NewOutPoint(previousOutputTransactionHash, previousOutputIndex),
 		[]byte{}, // scriptSig is empty here
-		[][]byte{
-			dummySignatureForWitness,
-			dummyCompressedPublicKeyForWitness,
-		},
+		dummyWitness,
 	)
 	txIn.Sequence = 0
 	tx.AddTxIn(txIn)
@@ -173,6 +311,36 @@ func buildSignedTransactionHexString(
 		(*btcec.PublicKey)(publicKey).SerializeCompressed(),
 	}
 
+	return encodeSignedTransaction(signedTransaction)
+}
+
+// buildSignedTaprootTransactionHexString generates the final hex string of
+// the transaction spending a p2tr output with the key path that can then be
+// submitted to the chain.
+func buildSignedTaprootTransactionHexString(
+	unsignedTransaction *wire.MsgTx,
+	signature []byte,
+) (string, error) {
+	if len(signature) != ecdsa.SchnorrSignatureSize {
+		return "", fmt.Errorf(
+			"invalid Schnorr signature length: [%d]",
+			len(signature),
+		)
+	}
+
+	// For safety's sake, work on a deep copy, as mutations follow.
+	signedTransaction := unsignedTransaction.Copy()
+
+	// The witness is for the first input, since this is known to be a
+	// single-input transaction. The signature is calculated with the default
+	// hash type, so no hash type byte follows it.
+	signedTransaction.TxIn[0].Witness = wire.TxWitness{signature}
+
+	return encodeSignedTransaction(signedTransaction)
+}
+
+// encodeSignedTransaction encodes the signed transaction to a hex string.
+func encodeSignedTransaction(signedTransaction *wire.MsgTx) (string, error) {
 	// BtcEncode writes bytes, we wrap it in an hex encoder wrapped
 	// around a strings. Builder to get a hex string.
 	transactionHexBuilder := &strings.Builder{}
@@ -194,7 +362,8 @@ func buildSignedTransactionHexString(
 }
 
 // BuildBitcoinTransaction generates a signed transaction hex string that can
-// recover an underlying bitcoin deposit that has been liquidated.
+// recover an underlying bitcoin deposit that has been liquidated. The
+// transaction has to be signed within the signing timeout.
 func BuildBitcoinTransaction(
 	ctx context.Context,
 	networkProvider net.Provider,
@@ -204,6 +373,7 @@ func BuildBitcoinTransaction(
 	chainParams *chaincfg.Params,
 	retrievalAddresses []string,
 	maxFeePerVByte int32,
+	signingTimeout time.Duration,
 ) (string, error) {
 	scriptCodeBytes, err := publicKeyToP2WPKHScriptCode(signer.PublicKey(), chainParams)
 	if err != nil {
@@ -251,6 +421,7 @@ func BuildBitcoinTransaction(
 		sighashBytes,
 		networkProvider,
 		hostChain.Signing().PublicKeyToAddress,
+		signingTimeout,
 	)
 	if err != nil {
 		return "", fmt.Errorf("failed to calculate signature: [%w]", err)
@@ -268,3 +439,92 @@ func BuildBitcoinTransaction(
 		signer.PublicKey(),
 	)
 }
+
+// BuildTaprootBitcoinTransaction generates a signed transaction hex string
+// that can recover an underlying bitcoin deposit locked in a p2tr output of
+// the signer's key. The output key is the signer's x-only public key tweaked
+// for an output with no script tree, so the output is spent with the key path
+// and a threshold Schnorr signature. The transaction has to be signed within
+// the signing timeout.
+func BuildTaprootBitcoinTransaction(
+	ctx context.Context,
+	networkProvider net.Provider,
+	hostChain chain.Handle,
+	fundingInfo *chain.FundingInfo,
+	signer *tss.ThresholdSigner,
+	chainParams *chaincfg.Params,
+	retrievalAddresses []string,
+	maxFeePerVByte int32,
+	signingTimeout time.Duration,
+) (string, error) {
+	internalKey, err := signer.SchnorrPublicKey(nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve the internal key: [%v]", err)
+	}
+
+	tweak := ecdsa.TaprootTweak(internalKey)
+
+	outputKey, err := signer.SchnorrPublicKey(tweak)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve the output key: [%v]", err)
+	}
+
+	outputScript, err := publicKeyToP2TRScript(outputKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve the output script: [%v]", err)
+	}
+
+	previousOutputValue := int64(chain.UtxoValueBytesToUint32(fundingInfo.UtxoValueBytes))
+
+	unsignedTransaction, err := constructUnsignedTaprootTransaction(
+		fundingInfo.TransactionHash,
+		fundingInfo.OutputIndex,
+		previousOutputValue,
+		int64(maxFeePerVByte),
+		retrievalAddresses,
+		chainParams,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to construct the unsigned transaction: [%w]", err)
+	}
+
+	logger.Debugf(
+		"constructed unsigned taproot liquidation recovery transaction: [%+v]",
+		unsignedTransaction,
+	)
+
+	sighashBytes, err := calculateTaprootSigHash(
+		unsignedTransaction,
+		0,
+		[]int64{previousOutputValue},
+		[][]byte{outputScript},
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to calculate the sighash bytes: [%w]", err)
+	}
+
+	logger.Debugf(
+		"calculated taproot liquidation recovery transaction sighash: [%x]",
+		sighashBytes,
+	)
+
+	signature, err := signer.CalculateSchnorrSignature(
+		ctx,
+		sighashBytes,
+		tweak,
+		networkProvider,
+		hostChain.Signing().PublicKeyToAddress,
+		signingTimeout,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to calculate signature: [%w]", err)
+	}
+
+	logger.Debugf(
+		"calculated taproot liquidation recovery transaction signature for sighash [%x]: [%x]",
+		sighashBytes,
+		signature,
+	)
+
+	return buildSignedTaprootTransactionHexString(unsignedTransaction, signature)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	cecdsa "crypto/ecdsa"
 	"crypto/elliptic"
+	"encoding/binary"
 	"encoding/hex"
 	"math/big"
 	"sync"
@@ -21,6 +22,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/net/key"
 	"github.com/keep-network/keep-core/pkg/net/local"
 	"github.com/keep-network/keep-ecdsa/internal/testdata"
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
 	lc "github.com/keep-network/keep-ecdsa/pkg/chain/local"
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss"
@@ -50,6 +52,27 @@ func TestPublicKeyToP2WPKHScriptCode(t *testing.T) {
 	}
 }
 
+func TestPublicKeyToP2TRScript(t *testing.T) {
+	// Test based on test values from BIP86, the first receiving address of
+	// account 0:
+	// https://github.com/bitcoin/bips/blob/master/bip-0086.mediawiki#test-vectors
+	outputKey, _ := hex.DecodeString("a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c")
+	expectedScript, _ := hex.DecodeString("5120a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c")
+
+	script, err := publicKeyToP2TRScript(outputKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if bytes.Compare(expectedScript, script) != 0 {
+		t.Errorf(
+			"unexpected script\nexpected: %x\nactual:   %x",
+			expectedScript,
+			script,
+		)
+	}
+}
+
 func TestConstructUnsignedTransaction(t *testing.T) {
 	recipientAddresses := []string{
 		"bcrt1q5sz7jly79m76a5e8py6kv402q07p725vm4s0zl",
@@ -224,6 +247,7 @@ func TestBuildBitcoinTransaction(t *testing.T) {
 				networkProvider,
 				pubKeyToAddressFn,
 				params.NewBox(&preParams),
+				8*time.Minute,
 			)
 			if err != nil {
 				errChan <- err
@@ -239,6 +263,7 @@ func TestBuildBitcoinTransaction(t *testing.T) {
 				&chaincfg.MainNetParams,
 				btcAddresses,
 				maxFeePerVByte,
+				10*time.Minute,
 			)
 			if err != nil {
 				errChan <- err
@@ -303,6 +328,96 @@ func TestBuildBitcoinTransaction(t *testing.T) {
 	}
 }
 
+func TestBuildTaprootBitcoinTransaction(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	localChain := lc.Connect(ctx)
+
+	// Signer of a single-member group calculates signatures locally, so
+	// the transaction can be built without any network communication.
+	signer, err := tss.GenerateLocalSigner(
+		"test-group-id-1",
+		tss.MemberID([]byte("member-1")),
+	)
+	if err != nil {
+		t.Fatalf("failed to generate local signer: [%v]", err)
+	}
+
+	previousOutputValue := int64(10000000)
+
+	fundingInfo := &chain.FundingInfo{
+		TransactionHash: "0b99dea9655f219991001e9296cfe2103dd918a21ef477a14121d1a0ba9491f1",
+		OutputIndex:     1,
+	}
+	binary.LittleEndian.PutUint64(
+		fundingInfo.UtxoValueBytes[:],
+		uint64(previousOutputValue),
+	)
+
+	btcAddresses := []string{
+		"1MjCqoLqMZ6Ru64TTtP16XnpSdiE8Kpgcx",
+		"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq",
+	}
+
+	signedTransactionHex, err := BuildTaprootBitcoinTransaction(
+		ctx,
+		nil,
+		localChain,
+		fundingInfo,
+		signer,
+		&chaincfg.MainNetParams,
+		btcAddresses,
+		int32(73),
+		time.Minute,
+	)
+	if err != nil {
+		t.Fatalf("failed to build transaction: [%v]", err)
+	}
+
+	transaction := decodeTransaction(t, signedTransactionHex)
+
+	if len(transaction.TxOut) != len(btcAddresses) {
+		t.Errorf(
+			"wrong number of outputs\nexpected: %d\nactual:   %d",
+			len(btcAddresses),
+			len(transaction.TxOut),
+		)
+	}
+
+	witness := transaction.TxIn[0].Witness
+	if len(witness) != 1 || len(witness[0]) != 64 {
+		t.Fatalf("unexpected key path spend witness: [%x]", witness)
+	}
+
+	internalKey, err := signer.SchnorrPublicKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputKey, err := signer.SchnorrPublicKey(ecdsa.TaprootTweak(internalKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputScript, err := publicKeyToP2TRScript(outputKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sighash, err := calculateTaprootSigHash(
+		transaction,
+		0,
+		[]int64{previousOutputValue},
+		[][]byte{outputScript},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ecdsa.VerifySchnorrSignature(outputKey, sighash, witness[0]) {
+		t.Errorf("invalid key path spend signature: [%x]", witness[0])
+	}
+}
+
 func decodeTransaction(t *testing.T, txHex string) *wire.MsgTx {
 	txBytes, err := hex.DecodeString(txHex)
 	if err != nil {
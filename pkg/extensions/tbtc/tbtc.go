@@ -5,15 +5,11 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
-	"math"
 	"math/big"
-	"math/rand"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/keep-network/keep-common/pkg/chain/ethlike"
-
 	"github.com/keep-network/keep-common/pkg/cache"
 
 	"github.com/ipfs/go-log"
@@ -22,6 +18,7 @@ import (
 	"github.com/keep-network/keep-common/pkg/wrappers"
 	corechain "github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/utils/backoff"
 )
 
 var logger = log.Logger("keep-tbtc-extension")
@@ -35,10 +32,6 @@ const (
 	// during the past events lookup.
 	pastEventsLookbackBlocks = 10000
 
-	// Number of blocks which should elapse before confirming
-	// the given chain state expectations.
-	defaultBlockConfirmations = 12
-
 	// Determines how long the monitoring cache will maintain its entries about
 	// which deposits should be monitored by this client instance.
 	monitoringCachePeriod = 24 * time.Hour
@@ -53,46 +46,77 @@ const (
 	confirmInitialStateTimeout = 30 * time.Second
 )
 
+// actBackoff determines delays between action attempts. For each attempt
+// the delay will be in range:
+// - attempt 1: [2000ms, 2100ms)
+// - attempt 2: [4000ms, 4100ms)
+// - attempt 3 and later: [8000ms, 8100ms)
+// The delay stops growing after the third attempt, so that raising
+// maxActAttempts does not postpone the action beyond the on-chain timeouts.
+var actBackoff = &backoff.Exponential{
+	InitialDelay: 2 * time.Second,
+	MaxDelay:     8 * time.Second,
+	Jitter:       100 * time.Millisecond,
+}
+
 // Initialize initializes extension specific to the TBTC application.
-// TODO: Resume monitoring after client restart
+// Monitoring of deposits backed by the given keeps, started before the client
+// restart, is resumed with the timeout remaining until the action should be
+// performed.
 func Initialize(
 	ctx context.Context,
 	tbtcHandle chain.TBTCHandle,
 	blockCounter corechain.BlockCounter,
+	eventPipeline *chain.EventPipeline,
 	blockTimestamp func(blockNumber *big.Int) (uint64, error),
+	keeps []chain.BondedECDSAKeepHandle,
+	config *Config,
 ) {
 	logger.Infof("initializing tbtc extension")
 
 	tbtc := newTBTC(
 		tbtcHandle,
 		blockCounter,
+		eventPipeline,
 		blockTimestamp,
+		config.GetBlockConfirmations(),
 	)
 
-	tbtc.monitorRetrievePubKey(
+	resumeRetrievePubKey := tbtc.monitorRetrievePubKey(
 		ctx,
-		exponentialBackoff,
+		actBackoff,
 		165*time.Minute, // 15 minutes before the 3 hours on-chain timeout
 	)
 
-	tbtc.monitorProvideRedemptionSignature(
+	resumeProvideRedemptionSignature := tbtc.monitorProvideRedemptionSignature(
 		ctx,
-		exponentialBackoff,
+		actBackoff,
 		105*time.Minute, // 15 minutes before the 2 hours on-chain timeout
 	)
 
-	tbtc.monitorProvideRedemptionProof(
+	resumeProvideRedemptionProof := tbtc.monitorProvideRedemptionProof(
 		ctx,
-		exponentialBackoff,
+		actBackoff,
 		345*time.Minute, // 15 minutes before the 6 hours on-chain timeout
 	)
 
+	go tbtc.resumeMonitoring(
+		ctx,
+		keeps,
+		map[chain.DepositState]depositEventHandler{
+			chain.AwaitingSignerSetup:         resumeRetrievePubKey,
+			chain.AwaitingWithdrawalSignature: resumeProvideRedemptionSignature,
+			chain.AwaitingWithdrawalProof:     resumeProvideRedemptionProof,
+		},
+	)
+
 	logger.Infof("tbtc extension has been initialized")
 }
 
 type tbtc struct {
 	handle         chain.TBTCHandle
 	blockCounter   corechain.BlockCounter
+	eventPipeline  *chain.EventPipeline
 	blockTimestamp func(blockNumber *big.Int) (uint64, error)
 
 	monitoringLocks        sync.Map
@@ -105,31 +129,43 @@ type tbtc struct {
 func newTBTC(
 	tbtcHandle chain.TBTCHandle,
 	blockCounter corechain.BlockCounter,
+	eventPipeline *chain.EventPipeline,
 	blockTimestamp func(blockNumber *big.Int) (uint64, error),
+	blockConfirmations uint64,
 ) *tbtc {
 	return &tbtc{
 		handle:         tbtcHandle,
 		blockCounter:   blockCounter,
+		eventPipeline:  eventPipeline,
 		blockTimestamp: blockTimestamp,
 
-		blockConfirmations:     defaultBlockConfirmations,
+		blockConfirmations:     blockConfirmations,
 		memberDepositsCache:    cache.NewTimeCache(monitoringCachePeriod),
 		notMemberDepositsCache: cache.NewTimeCache(monitoringCachePeriod),
 		signerActionDelayStep:  defaultSignerActionDelayStep,
 	}
 }
 
+// monitorRetrievePubKey monitors deposits awaiting signer setup and retrieves
+// the signer public key if nobody else did it in the expected time frame. It
+// returns a handler resuming the monitoring of a deposit which has been created
+// before the monitoring started.
 func (t *tbtc) monitorRetrievePubKey(
 	ctx context.Context,
-	actBackoffFn backoffFn,
+	actBackoff backoff.Policy,
 	timeout time.Duration,
-) {
+) depositEventHandler {
 	initialDepositState := chain.AwaitingSignerSetup
 
 	monitoringStartFn := func(
 		handler depositEventHandler,
 	) subscription.EventSubscription {
-		return t.handle.OnDepositCreated(handler)
+		return t.onConfirmedDepositEvent(
+			"deposit created",
+			t.handle.OnDepositCreated,
+			nil,
+			handler,
+		)
 	}
 
 	shouldMonitorFn := func(depositAddress string) bool {
@@ -143,21 +179,12 @@ func (t *tbtc) monitorRetrievePubKey(
 	monitoringStopFn := func(
 		handler depositEventHandler,
 	) subscription.EventSubscription {
-		return t.handle.OnDepositRegisteredPubkey(func(depositAddress string) {
-			if t.waitDepositStateChangeConfirmation(
-				depositAddress,
-				initialDepositState,
-			) {
-				handler(depositAddress)
-			} else {
-				logger.Warningf(
-					"retrieve pubkey monitoring stop event for "+
-						"deposit [%v] is not confirmed; "+
-						"monitoring will be continued",
-					depositAddress,
-				)
-			}
-		})
+		return t.onConfirmedDepositEvent(
+			"registered pubkey",
+			t.handle.OnDepositRegisteredPubkey,
+			t.depositStateChanged(initialDepositState),
+			handler,
+		)
 	}
 
 	actFn := func(depositAddress string) error {
@@ -167,6 +194,7 @@ func (t *tbtc) monitorRetrievePubKey(
 		}
 
 		if !t.waitDepositStateChangeConfirmation(
+			ctx,
 			depositAddress,
 			initialDepositState,
 		) {
@@ -185,7 +213,27 @@ func (t *tbtc) monitorRetrievePubKey(
 		return timeout + actionDelay, nil
 	}
 
-	monitoringSubscription := t.monitorAndAct(
+	resumeTimeoutFn := func(depositAddress string) (time.Duration, error) {
+		keep, err := t.handle.Keep(depositAddress)
+		if err != nil {
+			return 0, err
+		}
+
+		// The keep is opened along with the deposit creation.
+		keepOpenedTimestamp, err := keep.GetOpenedTimestamp()
+		if err != nil {
+			return 0, err
+		}
+
+		timeout, err := timeoutFn(depositAddress)
+		if err != nil {
+			return 0, err
+		}
+
+		return timeout - time.Since(keepOpenedTimestamp), nil
+	}
+
+	monitoringSubscription, resumeMonitoringFn := t.monitorAndAct(
 		ctx,
 		"retrieve pubkey",
 		shouldMonitorFn,
@@ -193,8 +241,9 @@ func (t *tbtc) monitorRetrievePubKey(
 		monitoringStopFn,
 		t.watchKeepClosed,
 		actFn,
-		actBackoffFn,
+		actBackoff,
 		timeoutFn,
+		resumeTimeoutFn,
 	)
 
 	go func() {
@@ -204,13 +253,20 @@ func (t *tbtc) monitorRetrievePubKey(
 	}()
 
 	logger.Infof("retrieve pubkey monitoring initialized")
+
+	return resumeMonitoringFn
 }
 
+// monitorProvideRedemptionSignature monitors deposits awaiting the redemption
+// signature and provides the signature submitted by the keep if nobody else did
+// it in the expected time frame. It returns a handler resuming the monitoring
+// of a deposit whose redemption has been requested before the monitoring
+// started.
 func (t *tbtc) monitorProvideRedemptionSignature(
 	ctx context.Context,
-	actBackoffFn backoffFn,
+	actBackoff backoff.Policy,
 	timeout time.Duration,
-) {
+) depositEventHandler {
 	initialDepositState := chain.AwaitingWithdrawalSignature
 
 	monitoringStartFn := func(
@@ -218,7 +274,12 @@ func (t *tbtc) monitorProvideRedemptionSignature(
 	) subscription.EventSubscription {
 		// Start right after a redemption has been requested or the redemption
 		// fee has been increased.
-		return t.handle.OnDepositRedemptionRequested(handler)
+		return t.onConfirmedDepositEvent(
+			"redemption requested",
+			t.handle.OnDepositRedemptionRequested,
+			nil,
+			handler,
+		)
 	}
 
 	shouldMonitorFn := func(depositAddress string) bool {
@@ -233,41 +294,19 @@ func (t *tbtc) monitorProvideRedemptionSignature(
 		handler depositEventHandler,
 	) subscription.EventSubscription {
 		// Stop in case the redemption signature has been provided by someone else.
-		signatureSubscription := t.handle.OnDepositGotRedemptionSignature(
-			func(depositAddress string) {
-				if t.waitDepositStateChangeConfirmation(
-					depositAddress,
-					initialDepositState,
-				) {
-					handler(depositAddress)
-				} else {
-					logger.Warningf(
-						"provide redemption signature monitoring stop "+
-							"event for deposit [%v] is not confirmed; "+
-							"monitoring will be continued",
-						depositAddress,
-					)
-				}
-			},
+		signatureSubscription := t.onConfirmedDepositEvent(
+			"got redemption signature",
+			t.handle.OnDepositGotRedemptionSignature,
+			t.depositStateChanged(initialDepositState),
+			handler,
 		)
 
 		// Stop in case the redemption proof has been provided by someone else.
-		redeemedSubscription := t.handle.OnDepositRedeemed(
-			func(depositAddress string) {
-				if t.waitDepositStateChangeConfirmation(
-					depositAddress,
-					initialDepositState,
-				) {
-					handler(depositAddress)
-				} else {
-					logger.Warningf(
-						"provide redemption signature monitoring stop "+
-							"event for deposit [%v] is not confirmed; "+
-							"monitoring will be continued",
-						depositAddress,
-					)
-				}
-			},
+		redeemedSubscription := t.onConfirmedDepositEvent(
+			"redeemed",
+			t.handle.OnDepositRedeemed,
+			t.depositStateChanged(initialDepositState),
+			handler,
 		)
 
 		return subscription.NewEventSubscription(
@@ -342,6 +381,7 @@ func (t *tbtc) monitorProvideRedemptionSignature(
 		}
 
 		if !t.waitDepositStateChangeConfirmation(
+			ctx,
 			depositAddress,
 			initialDepositState,
 		) {
@@ -360,7 +400,25 @@ func (t *tbtc) monitorProvideRedemptionSignature(
 		return timeout + actionDelay, nil
 	}
 
-	monitoringSubscription := t.monitorAndAct(
+	resumeTimeoutFn := func(depositAddress string) (time.Duration, error) {
+		redemptionRequestedTimestamp, err := t.latestRedemptionRequestedTimestamp(
+			depositAddress,
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		timeout, err := timeoutFn(depositAddress)
+		if err != nil {
+			return 0, err
+		}
+
+		return timeout - time.Since(
+			time.Unix(int64(redemptionRequestedTimestamp), 0),
+		), nil
+	}
+
+	monitoringSubscription, resumeMonitoringFn := t.monitorAndAct(
 		ctx,
 		"provide redemption signature",
 		shouldMonitorFn,
@@ -368,8 +426,9 @@ func (t *tbtc) monitorProvideRedemptionSignature(
 		monitoringStopFn,
 		t.watchKeepClosed,
 		actFn,
-		actBackoffFn,
+		actBackoff,
 		timeoutFn,
+		resumeTimeoutFn,
 	)
 
 	go func() {
@@ -379,20 +438,31 @@ func (t *tbtc) monitorProvideRedemptionSignature(
 	}()
 
 	logger.Infof("provide redemption signature monitoring initialized")
+
+	return resumeMonitoringFn
 }
 
+// monitorProvideRedemptionProof monitors deposits awaiting the redemption
+// proof and increases the redemption fee if nobody provided the proof in
+// the expected time frame. It returns a handler resuming the monitoring of
+// a deposit which got the redemption signature before the monitoring started.
 func (t *tbtc) monitorProvideRedemptionProof(
 	ctx context.Context,
-	actBackoffFn backoffFn,
+	actBackoff backoff.Policy,
 	timeout time.Duration,
-) {
+) depositEventHandler {
 	initialDepositState := chain.AwaitingWithdrawalProof
 
 	monitoringStartFn := func(
 		handler depositEventHandler,
 	) subscription.EventSubscription {
 		// Start right after a redemption signature has been provided.
-		return t.handle.OnDepositGotRedemptionSignature(handler)
+		return t.onConfirmedDepositEvent(
+			"got redemption signature",
+			t.handle.OnDepositGotRedemptionSignature,
+			nil,
+			handler,
+		)
 	}
 
 	shouldMonitorFn := func(depositAddress string) bool {
@@ -407,41 +477,19 @@ func (t *tbtc) monitorProvideRedemptionProof(
 		handler depositEventHandler,
 	) subscription.EventSubscription {
 		// Stop in case the redemption fee has been increased by someone else.
-		redemptionRequestedSubscription := t.handle.OnDepositRedemptionRequested(
-			func(depositAddress string) {
-				if t.waitDepositStateChangeConfirmation(
-					depositAddress,
-					initialDepositState,
-				) {
-					handler(depositAddress)
-				} else {
-					logger.Warningf(
-						"provide redemption proof monitoring stop "+
-							"event for deposit [%v] is not confirmed; "+
-							"monitoring will be continued",
-						depositAddress,
-					)
-				}
-			},
+		redemptionRequestedSubscription := t.onConfirmedDepositEvent(
+			"redemption requested",
+			t.handle.OnDepositRedemptionRequested,
+			t.depositStateChanged(initialDepositState),
+			handler,
 		)
 
 		// Stop in case the redemption proof has been provided by someone else.
-		redeemedSubscription := t.handle.OnDepositRedeemed(
-			func(depositAddress string) {
-				if t.waitDepositStateChangeConfirmation(
-					depositAddress,
-					initialDepositState,
-				) {
-					handler(depositAddress)
-				} else {
-					logger.Warningf(
-						"provide redemption proof monitoring stop "+
-							"event for deposit [%v] is not confirmed; "+
-							"monitoring will be continued",
-						depositAddress,
-					)
-				}
-			},
+		redeemedSubscription := t.onConfirmedDepositEvent(
+			"redeemed",
+			t.handle.OnDepositRedeemed,
+			t.depositStateChanged(initialDepositState),
+			handler,
 		)
 
 		return subscription.NewEventSubscription(
@@ -510,6 +558,7 @@ func (t *tbtc) monitorProvideRedemptionProof(
 		}
 
 		if !t.waitDepositStateChangeConfirmation(
+			ctx,
 			depositAddress,
 			initialDepositState,
 		) {
@@ -525,27 +574,9 @@ func (t *tbtc) monitorProvideRedemptionProof(
 		// the `GotRedemptionSignature` event.
 		gotRedemptionSignatureTimestamp := uint64(time.Now().Unix())
 
-		redemptionRequestedEvents, err := t.handle.PastDepositRedemptionRequestedEvents(
-			t.pastEventsLookupStartBlock(),
-			depositAddress,
-		)
-		if err != nil {
-			return 0, err
-		}
-
-		if len(redemptionRequestedEvents) == 0 {
-			return 0, fmt.Errorf(
-				"no redemption requested events found for deposit: [%v]",
-				depositAddress,
-			)
-		}
-
-		latestRedemptionRequestedEvent :=
-			redemptionRequestedEvents[len(redemptionRequestedEvents)-1]
-
 		// Get the seconds timestamp for the latest redemption request.
-		redemptionRequestedTimestamp, err := t.blockTimestamp(
-			new(big.Int).SetUint64(latestRedemptionRequestedEvent.BlockNumber),
+		redemptionRequestedTimestamp, err := t.latestRedemptionRequestedTimestamp(
+			depositAddress,
 		)
 		if err != nil {
 			return 0, err
@@ -567,7 +598,7 @@ func (t *tbtc) monitorProvideRedemptionProof(
 		return (timeout - timeoutShift) + actionDelay, nil
 	}
 
-	monitoringSubscription := t.monitorAndAct(
+	monitoringSubscription, resumeMonitoringFn := t.monitorAndAct(
 		ctx,
 		"provide redemption proof",
 		shouldMonitorFn,
@@ -575,7 +606,10 @@ func (t *tbtc) monitorProvideRedemptionProof(
 		monitoringStopFn,
 		t.watchKeepClosed,
 		actFn,
-		actBackoffFn,
+		actBackoff,
+		timeoutFn,
+		// The timeout is already counted from the redemption request so it
+		// is the remaining one no matter when the monitoring starts.
 		timeoutFn,
 	)
 
@@ -586,10 +620,14 @@ func (t *tbtc) monitorProvideRedemptionProof(
 	}()
 
 	logger.Infof("provide redemption proof monitoring initialized")
+
+	return resumeMonitoringFn
 }
 
 type shouldMonitorDepositFn func(depositAddress string) bool
 
+type checkDepositFn func(depositAddress string) (bool, error)
+
 type depositEventHandler func(depositAddress string)
 
 type watchDepositEventFn func(
@@ -604,10 +642,13 @@ type watchKeepClosedFn func(depositAddress string) (
 
 type submitDepositTxFn func(depositAddress string) error
 
-type backoffFn func(iteration int) time.Duration
-
 type timeoutFn func(depositAddress string) (time.Duration, error)
 
+// monitorAndAct starts the monitoring of a deposit on each start event and
+// performs the action if no stop event occurred before the timeout. Along with
+// the start events subscription, it returns a handler resuming the monitoring
+// of a deposit whose start event occurred before the client started. Timeout
+// of the resumed monitoring is determined with resumeTimeoutFn.
 func (t *tbtc) monitorAndAct(
 	ctx context.Context,
 	monitoringName string,
@@ -616,10 +657,14 @@ func (t *tbtc) monitorAndAct(
 	monitoringStopFn watchDepositEventFn,
 	keepClosedFn watchKeepClosedFn,
 	actFn submitDepositTxFn,
-	actBackoffFn backoffFn,
+	actBackoff backoff.Policy,
 	timeoutFn timeoutFn,
-) subscription.EventSubscription {
-	handleStartEvent := func(depositAddress string) {
+	resumeTimeoutFn timeoutFn,
+) (subscription.EventSubscription, depositEventHandler) {
+	handleStartEvent := func(
+		depositAddress string,
+		monitoringTimeoutFn func(depositAddress string) (time.Duration, error),
+	) {
 		if !shouldMonitorFn(depositAddress) {
 			return
 		}
@@ -666,7 +711,7 @@ func (t *tbtc) monitorAndAct(
 		}
 		defer keepClosedUnsubscribe()
 
-		timeout, err := timeoutFn(depositAddress)
+		timeout, err := monitoringTimeoutFn(depositAddress)
 		if err != nil {
 			logger.Errorf(
 				"could determine timeout value for [%v] "+
@@ -731,7 +776,7 @@ func (t *tbtc) monitorAndAct(
 						break monitoring
 					}
 
-					backoff := actBackoffFn(actionAttempt)
+					delay := actBackoff.Delay(actionAttempt)
 
 					logger.Errorf(
 						"could not perform action "+
@@ -740,10 +785,10 @@ func (t *tbtc) monitorAndAct(
 						monitoringName,
 						depositAddress,
 						err,
-						backoff,
+						delay,
 					)
 
-					timeoutChan = time.After(backoff)
+					timeoutChan = time.After(delay)
 					actionAttempt++
 				} else {
 					break monitoring
@@ -758,11 +803,78 @@ func (t *tbtc) monitorAndAct(
 		)
 	}
 
-	return monitoringStartFn(
+	monitoringSubscription := monitoringStartFn(
 		func(depositAddress string) {
-			go handleStartEvent(depositAddress)
+			go handleStartEvent(depositAddress, timeoutFn)
 		},
 	)
+
+	resumeMonitoringFn := func(depositAddress string) {
+		go handleStartEvent(depositAddress, resumeTimeoutFn)
+	}
+
+	return monitoringSubscription, resumeMonitoringFn
+}
+
+// resumeMonitoring resumes monitoring of deposits backed by the given keeps
+// which was in progress before the client restart. Deposits are resumed by
+// handlers of monitoring expecting the current state of the deposit.
+func (t *tbtc) resumeMonitoring(
+	ctx context.Context,
+	keeps []chain.BondedECDSAKeepHandle,
+	resumeMonitoringFns map[chain.DepositState]depositEventHandler,
+) {
+	for _, keep := range keeps {
+		if ctx.Err() != nil {
+			return
+		}
+
+		isActive, err := keep.IsActive()
+		if err != nil {
+			logger.Errorf(
+				"could not check if keep [%v] is active: [%v]",
+				keep.ID(),
+				err,
+			)
+			continue
+		}
+
+		if !isActive {
+			continue
+		}
+
+		owner, err := keep.GetOwner()
+		if err != nil {
+			logger.Errorf(
+				"could not get owner of keep [%v]: [%v]",
+				keep.ID(),
+				err,
+			)
+			continue
+		}
+
+		depositAddress := owner.String()
+
+		depositState, err := t.handle.CurrentState(depositAddress)
+		if err != nil {
+			logger.Warningf(
+				"could not get state of deposit [%v] backed by keep [%v]: [%v]",
+				depositAddress,
+				keep.ID(),
+				err,
+			)
+			continue
+		}
+
+		if resumeMonitoringFn, ok := resumeMonitoringFns[depositState]; ok {
+			logger.Infof(
+				"resuming monitoring for deposit [%v] in state [%v]",
+				depositAddress,
+				depositState,
+			)
+			resumeMonitoringFn(depositAddress)
+		}
+	}
 }
 
 func (t *tbtc) watchKeepClosed(
@@ -775,32 +887,61 @@ func (t *tbtc) watchKeepClosed(
 		return nil, nil, err
 	}
 
+	isInactive := func() (bool, error) {
+		isActive, err := keep.IsActive()
+		if err != nil {
+			return false, err
+		}
+
+		return !isActive, nil
+	}
+
+	signal := func() {
+		signalChan <- struct{}{}
+	}
+
+	confirmedKeepClosed := t.eventPipeline.Subscribe(
+		"keep closed",
+		t.blockConfirmations,
+	)
+
 	keepClosedSubscription, err := keep.OnKeepClosed(
-		func(_ *chain.KeepClosedEvent) {
+		func(event *chain.KeepClosedEvent) {
 			logger.Infof(
 				"keep closed event received for deposit [%s]",
 				depositAddress,
 			)
 
-			if t.waitKeepNotActiveConfirmation(keep) {
-				signalChan <- struct{}{}
-			}
+			confirmedKeepClosed.Deliver(
+				event.BlockNumber,
+				event.BlockHash,
+				isInactive,
+				signal,
+			)
 		},
 	)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	confirmedKeepTerminated := t.eventPipeline.Subscribe(
+		"keep terminated",
+		t.blockConfirmations,
+	)
+
 	keepTerminatedSubscription, err := keep.OnKeepTerminated(
-		func(_ *chain.KeepTerminatedEvent) {
+		func(event *chain.KeepTerminatedEvent) {
 			logger.Infof(
 				"keep terminated event received for deposit [%s]",
 				depositAddress,
 			)
 
-			if t.waitKeepNotActiveConfirmation(keep) {
-				signalChan <- struct{}{}
-			}
+			confirmedKeepTerminated.Deliver(
+				event.BlockNumber,
+				event.BlockHash,
+				isInactive,
+				signal,
+			)
 		},
 	)
 	if err != nil {
@@ -808,8 +949,8 @@ func (t *tbtc) watchKeepClosed(
 	}
 
 	unsubscribe := func() {
-		keepClosedSubscription.Unsubscribe()
-		keepTerminatedSubscription.Unsubscribe()
+		confirmedKeepClosed.With(keepClosedSubscription).Unsubscribe()
+		confirmedKeepTerminated.With(keepTerminatedSubscription).Unsubscribe()
 	}
 
 	return signalChan, unsubscribe, nil
@@ -904,35 +1045,18 @@ func (t *tbtc) getSignerActionDelay(
 }
 
 func (t *tbtc) waitDepositStateChangeConfirmation(
+	ctx context.Context,
 	depositAddress string,
 	initialDepositState chain.DepositState,
 ) bool {
-	stateCheck := func() (bool, error) {
-		currentState, err := t.handle.CurrentState(depositAddress)
-		if err != nil {
-			return false, err
-		}
-
-		return currentState != initialDepositState, nil
-	}
-
-	currentBlock, err := t.blockCounter.CurrentBlock()
-	if err != nil {
-		logger.Errorf(
-			"could not get current block while confirming "+
-				"state [%v] change for deposit [%v]: [%v]",
-			initialDepositState,
-			depositAddress,
-			err,
-		)
-		return false
-	}
+	stateChanged := t.depositStateChanged(initialDepositState)
 
-	confirmed, err := ethlike.WaitForBlockConfirmations(
-		t.blockCounter,
-		currentBlock,
+	confirmed, err := t.eventPipeline.ConfirmState(
+		ctx,
 		t.blockConfirmations,
-		stateCheck,
+		func() (bool, error) {
+			return stateChanged(depositAddress)
+		},
 	)
 	if err != nil {
 		logger.Errorf(
@@ -947,38 +1071,88 @@ func (t *tbtc) waitDepositStateChangeConfirmation(
 	return confirmed
 }
 
-func (t *tbtc) waitKeepNotActiveConfirmation(
-	keep chain.BondedECDSAKeepHandle,
-) bool {
-	currentBlock, err := t.blockCounter.CurrentBlock()
-	if err != nil {
-		logger.Errorf(
-			"could not get current block while confirming "+
-				"keep [%v] is not active: [%v]",
-			keep.ID(),
-			err,
-		)
-		return false
+// depositStateChanged returns a function checking whether the current state
+// of a deposit is different than the given initial state.
+func (t *tbtc) depositStateChanged(
+	initialDepositState chain.DepositState,
+) checkDepositFn {
+	return func(depositAddress string) (bool, error) {
+		currentState, err := t.handle.CurrentState(depositAddress)
+		if err != nil {
+			return false, err
+		}
+
+		return currentState != initialDepositState, nil
 	}
+}
 
-	isKeepActive, err := ethlike.WaitForBlockConfirmations(
-		t.blockCounter,
-		currentBlock,
+// onConfirmedDepositEvent subscribes for deposit events with the given function
+// and passes them through the event pipeline. Once an event is confirmed by
+// the extension's number of block confirmations and validated with the provided
+// function, if not nil, the handler is called with the deposit address. Events
+// retracted by a chain reorganization or not reflected in the deposit state are
+// dropped.
+func (t *tbtc) onConfirmedDepositEvent(
+	eventName string,
+	subscribeFn func(
+		handler func(event *chain.DepositEvent),
+	) subscription.EventSubscription,
+	validateFn checkDepositFn,
+	handler depositEventHandler,
+) subscription.EventSubscription {
+	confirmedEvents := t.eventPipeline.Subscribe(
+		eventName,
 		t.blockConfirmations,
-		func() (bool, error) {
-			return keep.IsActive()
-		},
+	)
+
+	eventSubscription := subscribeFn(func(event *chain.DepositEvent) {
+		var validate func() (bool, error)
+		if validateFn != nil {
+			validate = func() (bool, error) {
+				return validateFn(event.DepositAddress)
+			}
+		}
+
+		confirmedEvents.Deliver(
+			event.BlockNumber,
+			event.BlockHash,
+			validate,
+			func() {
+				handler(event.DepositAddress)
+			},
+		)
+	})
+
+	return confirmedEvents.With(eventSubscription)
+}
+
+// latestRedemptionRequestedTimestamp returns the timestamp in seconds of
+// the block in which the latest redemption of the deposit has been requested
+// or the redemption fee has been increased.
+func (t *tbtc) latestRedemptionRequestedTimestamp(
+	depositAddress string,
+) (uint64, error) {
+	redemptionRequestedEvents, err := t.handle.PastDepositRedemptionRequestedEvents(
+		t.pastEventsLookupStartBlock(),
+		depositAddress,
 	)
 	if err != nil {
-		logger.Errorf(
-			"could not confirm if keep [%v] is not active: [%v]",
-			keep.ID(),
-			err,
+		return 0, err
+	}
+
+	if len(redemptionRequestedEvents) == 0 {
+		return 0, fmt.Errorf(
+			"no redemption requested events found for deposit: [%v]",
+			depositAddress,
 		)
-		return false
 	}
 
-	return !isKeepActive
+	latestRedemptionRequestedEvent :=
+		redemptionRequestedEvents[len(redemptionRequestedEvents)-1]
+
+	return t.blockTimestamp(
+		new(big.Int).SetUint64(latestRedemptionRequestedEvent.BlockNumber),
+	)
 }
 
 func (t *tbtc) pastEventsLookupStartBlock() uint64 {
@@ -1018,20 +1192,6 @@ func monitoringLockKey(
 	)
 }
 
-// Computes the exponential backoff value for given iteration.
-// For each iteration the result value will be in range:
-// - iteration 1: [2000ms, 2100ms)
-// - iteration 2: [4000ms, 4100ms)
-// - iteration 3: [8000ms, 8100ms)
-// - iteration n: [2^n * 1000ms, (2^n * 1000ms) + 100ms)
-func exponentialBackoff(iteration int) time.Duration {
-	backoffMillis := math.Pow(2, float64(iteration)) * 1000
-	// #nosec G404 (insecure random number source (rand))
-	// No need to use secure randomness for jitter value.
-	jitterMillis := rand.Intn(100)
-	return time.Duration(int(backoffMillis)+jitterMillis) * time.Millisecond
-}
-
 func toLittleEndianBytes(value *big.Int) [8]byte {
 	var valueBytes [8]byte
 	binary.LittleEndian.PutUint64(valueBytes[:], value.Uint64())
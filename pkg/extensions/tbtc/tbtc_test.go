@@ -18,6 +18,7 @@ import (
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
 	"github.com/keep-network/keep-ecdsa/pkg/chain/local"
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
+	"github.com/keep-network/keep-ecdsa/pkg/utils/backoff"
 	"github.com/keep-network/keep-ecdsa/pkg/utils/byteutils"
 )
 
@@ -33,11 +34,14 @@ func newTestTBTC(
 	tbtc := newTBTC(
 		localChain,
 		localChain.BlockCounter(),
+		chain.NewEventPipeline(
+			localChain.BlockCounter(),
+			localChain.BlockHash,
+		),
 		localChain.BlockTimestamp,
+		defaultLocalBlockConfirmations,
 	)
 
-	tbtc.blockConfirmations = defaultLocalBlockConfirmations
-
 	return tbtc
 }
 
@@ -1928,7 +1932,7 @@ func TestMonitorAndActDeduplication(t *testing.T) {
 		return timeout, nil
 	}
 
-	monitoringSubscription := tbtc.monitorAndAct(
+	monitoringSubscription, _ := tbtc.monitorAndAct(
 		ctx,
 		monitoringName,
 		shouldMonitorFn,
@@ -1938,6 +1942,7 @@ func TestMonitorAndActDeduplication(t *testing.T) {
 		actFn,
 		constantBackoff,
 		timeoutFn,
+		timeoutFn,
 	)
 	defer monitoringSubscription.Unsubscribe()
 
@@ -1957,6 +1962,374 @@ func TestMonitorAndActDeduplication(t *testing.T) {
 	}
 }
 
+func TestRetrievePubkey_MonitoringResumed(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := local.NewTBTCLocalChain(ctx)
+	tbtc := newTestTBTC(tbtcChain)
+
+	signers := append(
+		[]common.Address{tbtcChain.OperatorAddress()},
+		local.RandomSigningGroup(2)...,
+	)
+
+	// The deposit is created before the monitoring starts.
+	tbtcChain.CreateDeposit(depositAddress, signers)
+
+	_, err := submitKeepPublicKey(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keep, err := tbtcChain.Keep(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// wait for the monitoring timeout to elapse since the deposit creation
+	time.Sleep(timeout)
+
+	resumeRetrievePubKey := tbtc.monitorRetrievePubKey(
+		ctx,
+		constantBackoff,
+		timeout,
+	)
+
+	tbtc.resumeMonitoring(
+		ctx,
+		[]chain.BondedECDSAKeepHandle{keep},
+		map[chain.DepositState]depositEventHandler{
+			chain.AwaitingSignerSetup: resumeRetrievePubKey,
+		},
+	)
+
+	// wait shorter than the monitoring timeout as only the remaining
+	// part of the timeout should elapse before the action is performed
+	time.Sleep(timeout / 2)
+
+	expectedRetrieveSignerPubkeyCalls := 1
+	actualRetrieveSignerPubkeyCalls := tbtcChain.Logger().
+		RetrieveSignerPubkeyCalls()
+	if expectedRetrieveSignerPubkeyCalls != actualRetrieveSignerPubkeyCalls {
+		t.Errorf(
+			"unexpected number of RetrieveSignerPubkey calls\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedRetrieveSignerPubkeyCalls,
+			actualRetrieveSignerPubkeyCalls,
+		)
+	}
+}
+
+func TestProvideRedemptionSignature_MonitoringResumed(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := local.NewTBTCLocalChain(ctx)
+	tbtc := newTestTBTC(tbtcChain)
+
+	signers := append(
+		[]common.Address{tbtcChain.OperatorAddress()},
+		local.RandomSigningGroup(2)...,
+	)
+
+	// The redemption is requested before the monitoring starts.
+	tbtcChain.CreateDeposit(depositAddress, signers)
+	tbtcChain.FundDeposit(depositAddress)
+
+	_, err := submitKeepPublicKey(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.RedeemDeposit(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keepSignature, err := submitKeepSignature(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keep, err := tbtcChain.Keep(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// wait for the monitoring timeout to elapse since the redemption request
+	time.Sleep(timeout)
+
+	resumeProvideRedemptionSignature := tbtc.monitorProvideRedemptionSignature(
+		ctx,
+		constantBackoff,
+		timeout,
+	)
+
+	tbtc.resumeMonitoring(
+		ctx,
+		[]chain.BondedECDSAKeepHandle{keep},
+		map[chain.DepositState]depositEventHandler{
+			chain.AwaitingWithdrawalSignature: resumeProvideRedemptionSignature,
+		},
+	)
+
+	// wait shorter than the monitoring timeout as only the remaining
+	// part of the timeout should elapse before the action is performed
+	time.Sleep(timeout / 2)
+
+	expectedProvideRedemptionSignatureCalls := 1
+	actualProvideRedemptionSignatureCalls := tbtcChain.Logger().
+		ProvideRedemptionSignatureCalls()
+	if expectedProvideRedemptionSignatureCalls !=
+		actualProvideRedemptionSignatureCalls {
+		t.Errorf(
+			"unexpected number of ProvideRedemptionSignature calls\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedProvideRedemptionSignatureCalls,
+			actualProvideRedemptionSignatureCalls,
+		)
+	}
+
+	depositSignature, err := tbtcChain.DepositRedemptionSignature(
+		depositAddress,
+	)
+	if err != nil {
+		t.Errorf(
+			"unexpected error while fetching deposit signature: [%v]",
+			err,
+		)
+	}
+
+	if !areChainSignaturesEqual(keepSignature, depositSignature) {
+		t.Errorf(
+			"unexpected signature\n"+
+				"expected: [%+v]\n"+
+				"actual:   [%+v]",
+			keepSignature,
+			depositSignature,
+		)
+	}
+}
+
+func TestResumeMonitoring(t *testing.T) {
+	var tests = map[string]struct {
+		prepareDeposit        func(tbtcChain *local.TBTCLocalChain) error
+		expectedResumedStates []chain.DepositState
+	}{
+		"deposit awaiting signer setup": {
+			prepareDeposit: func(tbtcChain *local.TBTCLocalChain) error {
+				return nil
+			},
+			expectedResumedStates: []chain.DepositState{
+				chain.AwaitingSignerSetup,
+			},
+		},
+		"deposit awaiting withdrawal signature": {
+			prepareDeposit: func(tbtcChain *local.TBTCLocalChain) error {
+				tbtcChain.FundDeposit(depositAddress)
+				return tbtcChain.RedeemDeposit(depositAddress)
+			},
+			expectedResumedStates: []chain.DepositState{
+				chain.AwaitingWithdrawalSignature,
+			},
+		},
+		"deposit not monitored in its state": {
+			prepareDeposit: func(tbtcChain *local.TBTCLocalChain) error {
+				return tbtcChain.RetrieveSignerPubkey(depositAddress)
+			},
+			expectedResumedStates: []chain.DepositState{},
+		},
+		"keep closed": {
+			prepareDeposit: func(tbtcChain *local.TBTCLocalChain) error {
+				return closeKeep(depositAddress, tbtcChain)
+			},
+			expectedResumedStates: []chain.DepositState{},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx, cancelCtx := context.WithCancel(context.Background())
+			defer cancelCtx()
+
+			tbtcChain := local.NewTBTCLocalChain(ctx)
+			tbtc := newTestTBTC(tbtcChain)
+
+			signers := append(
+				[]common.Address{tbtcChain.OperatorAddress()},
+				local.RandomSigningGroup(2)...,
+			)
+
+			tbtcChain.CreateDeposit(depositAddress, signers)
+
+			_, err := submitKeepPublicKey(depositAddress, tbtcChain)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := test.prepareDeposit(tbtcChain); err != nil {
+				t.Fatal(err)
+			}
+
+			keep, err := tbtcChain.Keep(depositAddress)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resumedStates := []chain.DepositState{}
+			resumeMonitoringFn := func(state chain.DepositState) depositEventHandler {
+				return func(resumedDepositAddress string) {
+					if resumedDepositAddress != depositAddress {
+						t.Errorf(
+							"unexpected deposit\nexpected: [%v]\nactual:   [%v]",
+							depositAddress,
+							resumedDepositAddress,
+						)
+					}
+					resumedStates = append(resumedStates, state)
+				}
+			}
+
+			tbtc.resumeMonitoring(
+				ctx,
+				[]chain.BondedECDSAKeepHandle{keep},
+				map[chain.DepositState]depositEventHandler{
+					chain.AwaitingSignerSetup: resumeMonitoringFn(
+						chain.AwaitingSignerSetup,
+					),
+					chain.AwaitingWithdrawalSignature: resumeMonitoringFn(
+						chain.AwaitingWithdrawalSignature,
+					),
+					chain.AwaitingWithdrawalProof: resumeMonitoringFn(
+						chain.AwaitingWithdrawalProof,
+					),
+				},
+			)
+
+			if !reflect.DeepEqual(test.expectedResumedStates, resumedStates) {
+				t.Errorf(
+					"unexpected resumed monitoring\nexpected: [%v]\nactual:   [%v]",
+					test.expectedResumedStates,
+					resumedStates,
+				)
+			}
+		})
+	}
+}
+
+func TestOnConfirmedDepositEvent(t *testing.T) {
+	var tests = map[string]struct {
+		blockHash         func(tbtcChain *local.TBTCLocalChain) [32]byte
+		validateFn        checkDepositFn
+		expectedDelivered bool
+	}{
+		"event confirmed": {
+			blockHash:         currentBlockHash,
+			expectedDelivered: true,
+		},
+		"event confirmed and validated": {
+			blockHash: currentBlockHash,
+			validateFn: func(depositAddress string) (bool, error) {
+				return true, nil
+			},
+			expectedDelivered: true,
+		},
+		"event not reflected in the deposit state": {
+			blockHash: currentBlockHash,
+			validateFn: func(depositAddress string) (bool, error) {
+				return false, nil
+			},
+			expectedDelivered: false,
+		},
+		"event retracted by a chain reorganization": {
+			blockHash: func(tbtcChain *local.TBTCLocalChain) [32]byte {
+				return [32]byte{1}
+			},
+			expectedDelivered: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx, cancelCtx := context.WithCancel(context.Background())
+			defer cancelCtx()
+
+			tbtcChain := local.NewTBTCLocalChain(ctx)
+			tbtc := newTestTBTC(tbtcChain)
+
+			currentBlock, err := tbtcChain.BlockCounter().CurrentBlock()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var emitEvent func(event *chain.DepositEvent)
+			subscribeFn := func(
+				handler func(event *chain.DepositEvent),
+			) subscription.EventSubscription {
+				emitEvent = handler
+				return subscription.NewEventSubscription(func() {})
+			}
+
+			deliveredChan := make(chan string, 1)
+			eventSubscription := tbtc.onConfirmedDepositEvent(
+				"test",
+				subscribeFn,
+				test.validateFn,
+				func(depositAddress string) {
+					deliveredChan <- depositAddress
+				},
+			)
+			defer eventSubscription.Unsubscribe()
+
+			emitEvent(&chain.DepositEvent{
+				DepositAddress: depositAddress,
+				BlockNumber:    currentBlock,
+				BlockHash:      test.blockHash(tbtcChain),
+			})
+
+			select {
+			case deliveredDepositAddress := <-deliveredChan:
+				if !test.expectedDelivered {
+					t.Fatalf(
+						"unexpected event delivered for deposit [%v]",
+						deliveredDepositAddress,
+					)
+				}
+				if deliveredDepositAddress != depositAddress {
+					t.Errorf(
+						"unexpected deposit address\n"+
+							"expected: [%v]\n"+
+							"actual:   [%v]",
+						depositAddress,
+						deliveredDepositAddress,
+					)
+				}
+			case <-time.After(timeout):
+				if test.expectedDelivered {
+					t.Fatal("expected event has not been delivered")
+				}
+			}
+		})
+	}
+}
+
+func currentBlockHash(tbtcChain *local.TBTCLocalChain) [32]byte {
+	currentBlock, err := tbtcChain.BlockCounter().CurrentBlock()
+	if err != nil {
+		panic(err)
+	}
+
+	blockHash, err := tbtcChain.BlockHash(new(big.Int).SetUint64(currentBlock))
+	if err != nil {
+		panic(err)
+	}
+
+	return blockHash
+}
+
 func TestAcquireMonitoringLock(t *testing.T) {
 	ctx, cancelCtx := context.WithCancel(context.Background())
 	defer cancelCtx()
@@ -2417,6 +2790,4 @@ func terminateKeep(
 	return nil
 }
 
-func constantBackoff(_ int) time.Duration {
-	return time.Millisecond
-}
+var constantBackoff = backoff.Constant(time.Millisecond)
@@ -39,6 +39,54 @@ func ObserveTSSPreParamsPoolSize(
 	)
 }
 
+// ObserveTSSPreParamsPoolFill triggers an observation process of the
+// tss_pre_params_pool_fill metric. The metric is the ratio of the current
+// size of the pool to the target size of the pool.
+func ObserveTSSPreParamsPoolFill(
+	ctx context.Context,
+	registry *metrics.Registry,
+	clientHandle *client.Handle,
+	tick time.Duration,
+) {
+	input := func() float64 {
+		targetSize := clientHandle.TSSPreParamsPoolTargetSize()
+		if targetSize == 0 {
+			return 0
+		}
+
+		return float64(clientHandle.TSSPreParamsPoolSize()) / float64(targetSize)
+	}
+
+	observe(
+		ctx,
+		"tss_pre_params_pool_fill",
+		input,
+		registry,
+		validateTick(tick, DefaultClientMetricsTick),
+	)
+}
+
+// ObserveTSSPreParamsGenerationsInProgress triggers an observation process of
+// the tss_pre_params_generations_in_progress metric.
+func ObserveTSSPreParamsGenerationsInProgress(
+	ctx context.Context,
+	registry *metrics.Registry,
+	clientHandle *client.Handle,
+	tick time.Duration,
+) {
+	input := func() float64 {
+		return float64(clientHandle.TSSPreParamsGenerationsInProgress())
+	}
+
+	observe(
+		ctx,
+		"tss_pre_params_generations_in_progress",
+		input,
+		registry,
+		validateTick(tick, DefaultClientMetricsTick),
+	)
+}
+
 func observe(
 	ctx context.Context,
 	name string,
@@ -0,0 +1,167 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss"
+)
+
+// absenceTracker tracks keep members absent in consecutive key generation
+// attempts. A member is absent in an attempt if they did not announce their
+// presence or did not send messages expected from them during the protocol
+// execution. A member is chronically absent once they have been absent in all
+// attempts recorded within the chronic absence period.
+type absenceTracker struct {
+	members []chain.ID
+	// Period of absence after which the member is considered chronically
+	// absent. Members are never considered chronically absent if the period
+	// is zero.
+	chronicAbsencePeriod time.Duration
+	// Time of the first attempt in the series of consecutive attempts the
+	// member has been absent in, indexed by lowercase member ID string.
+	absentSince map[string]time.Time
+}
+
+func newAbsenceTracker(
+	members []chain.ID,
+	chronicAbsencePeriod time.Duration,
+) *absenceTracker {
+	return &absenceTracker{
+		members:              members,
+		chronicAbsencePeriod: chronicAbsencePeriod,
+		absentSince:          make(map[string]time.Time),
+	}
+}
+
+// chronicAbsencePeriod returns the period of absence after which a member is
+// considered chronically absent in key generation executed within the given
+// context. It is half of the time left until the key generation deadline, so
+// a member offline only for a while, e.g. restarting their client, does not
+// make the key generation abandoned. There is no such period if the context
+// has no deadline.
+func chronicAbsencePeriod(ctx context.Context) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+
+	return time.Until(deadline) / 2
+}
+
+// recordAttempt records the outcome of a key generation attempt made at
+// the given time. Members present in the attempt have their absence reset.
+func (at *absenceTracker) recordAttempt(
+	absentMembers []chain.ID,
+	attemptTime time.Time,
+) {
+	absent := make(map[string]bool)
+	for _, member := range absentMembers {
+		absent[absenceKey(member)] = true
+	}
+
+	for _, member := range at.members {
+		key := absenceKey(member)
+		if !absent[key] {
+			delete(at.absentSince, key)
+			continue
+		}
+
+		if _, ok := at.absentSince[key]; !ok {
+			at.absentSince[key] = attemptTime
+		}
+	}
+}
+
+// chronicallyAbsent returns members who have been absent in all attempts
+// recorded within at least the chronic absence period before the given time,
+// in the order they are listed in the keep.
+func (at *absenceTracker) chronicallyAbsent(now time.Time) []chain.ID {
+	chronicallyAbsent := []chain.ID{}
+	if at.chronicAbsencePeriod == 0 {
+		return chronicallyAbsent
+	}
+
+	for _, member := range at.members {
+		absentSince, ok := at.absentSince[absenceKey(member)]
+		if ok && now.Sub(absentSince) >= at.chronicAbsencePeriod {
+			chronicallyAbsent = append(chronicallyAbsent, member)
+		}
+	}
+
+	return chronicallyAbsent
+}
+
+func absenceKey(member chain.ID) string {
+	return strings.ToLower(member.String())
+}
+
+// operatorIDs returns operator IDs of keep members with the given member IDs.
+func (n *Node) operatorIDs(memberIDs []tss.MemberID) []chain.ID {
+	operatorIDs := []chain.ID{}
+	for _, memberID := range memberIDs {
+		publicKey, err := memberID.PublicKey()
+		if err != nil {
+			logger.Errorf(
+				"could not get public key for member [%s]: [%v]",
+				memberID,
+				err,
+			)
+			continue
+		}
+
+		operatorIDs = append(operatorIDs, n.chain.PublicKeyToOperatorID(publicKey))
+	}
+
+	return operatorIDs
+}
+
+// excludeMembers returns keep members except the excluded ones.
+func excludeMembers(members []chain.ID, excluded []chain.ID) []chain.ID {
+	isExcluded := make(map[string]bool)
+	for _, member := range excluded {
+		isExcluded[absenceKey(member)] = true
+	}
+
+	result := []chain.ID{}
+	for _, member := range members {
+		if !isExcluded[absenceKey(member)] {
+			result = append(result, member)
+		}
+	}
+
+	return result
+}
+
+// absentCulprits returns keep members blamed for not sending messages expected
+// from them during the protocol execution resulting with the given error.
+func absentCulprits(err error) []tss.MemberID {
+	absentMemberIDs := []tss.MemberID{}
+	for _, culprit := range tss.Culprits(err) {
+		if culprit.Reason == tss.NoMessage {
+			absentMemberIDs = append(absentMemberIDs, culprit.MemberID)
+		}
+	}
+
+	return absentMemberIDs
+}
+
+// checkNoChronicAbsence returns an error if any keep member is chronically
+// absent. The keep accepts the generated public key only once all its members
+// submitted it so the key can never be accepted without them.
+func checkNoChronicAbsence(absences *absenceTracker, now time.Time) error {
+	chronicallyAbsent := absences.chronicallyAbsent(now)
+
+	if len(chronicallyAbsent) > 0 {
+		return fmt.Errorf(
+			"key generation abandoned; members [%v] are chronically absent "+
+				"and the keep cannot accept a key generated without them",
+			chronicallyAbsent,
+		)
+	}
+
+	return nil
+}
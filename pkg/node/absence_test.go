@@ -0,0 +1,154 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/chain/local"
+)
+
+func TestAbsenceTracker(t *testing.T) {
+	members := generateMembers(t, 3)
+
+	absences := newAbsenceTracker(members, 30*time.Minute)
+
+	start := time.Now()
+
+	absences.recordAttempt([]chain.ID{members[1], members[2]}, start)
+	absences.recordAttempt(
+		[]chain.ID{members[1], members[2]},
+		start.Add(10*time.Minute),
+	)
+
+	if chronicallyAbsent := absences.chronicallyAbsent(
+		start.Add(20 * time.Minute),
+	); len(chronicallyAbsent) != 0 {
+		t.Errorf("unexpected chronically absent members: [%v]", chronicallyAbsent)
+	}
+
+	// The third member was present in the last attempt so their absence is
+	// reset.
+	absences.recordAttempt([]chain.ID{members[1]}, start.Add(20*time.Minute))
+	absences.recordAttempt(
+		[]chain.ID{members[1], members[2]},
+		start.Add(30*time.Minute),
+	)
+
+	expectedChronicallyAbsent := []chain.ID{members[1]}
+	chronicallyAbsent := absences.chronicallyAbsent(start.Add(30 * time.Minute))
+	if !reflect.DeepEqual(expectedChronicallyAbsent, chronicallyAbsent) {
+		t.Errorf(
+			"unexpected chronically absent members\nexpected: [%v]\nactual:   [%v]",
+			expectedChronicallyAbsent,
+			chronicallyAbsent,
+		)
+	}
+}
+
+func TestCheckNoChronicAbsence(t *testing.T) {
+	members := generateMembers(t, 3)
+
+	var tests = map[string]struct {
+		chronicAbsencePeriod time.Duration
+		absentMembers        []chain.ID
+		absenceDuration      time.Duration
+		expectError          bool
+	}{
+		"no absent members": {
+			chronicAbsencePeriod: time.Hour,
+			absentMembers:        []chain.ID{},
+			absenceDuration:      time.Hour,
+			expectError:          false,
+		},
+		"member absent for less than chronic absence period": {
+			chronicAbsencePeriod: time.Hour,
+			absentMembers:        []chain.ID{members[0]},
+			absenceDuration:      59 * time.Minute,
+			expectError:          false,
+		},
+		"member chronically absent": {
+			chronicAbsencePeriod: time.Hour,
+			absentMembers:        []chain.ID{members[0]},
+			absenceDuration:      time.Hour,
+			expectError:          true,
+		},
+		"no chronic absence period": {
+			chronicAbsencePeriod: 0,
+			absentMembers:        []chain.ID{members[0]},
+			absenceDuration:      24 * time.Hour,
+			expectError:          false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			absences := newAbsenceTracker(members, test.chronicAbsencePeriod)
+
+			start := time.Now()
+			end := start.Add(test.absenceDuration)
+
+			absences.recordAttempt(test.absentMembers, start)
+			absences.recordAttempt(test.absentMembers, end)
+
+			err := checkNoChronicAbsence(absences, end)
+			if test.expectError != (err != nil) {
+				t.Errorf(
+					"unexpected error\nexpected error: [%v]\nactual:         [%v]",
+					test.expectError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestChronicAbsencePeriod(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Hour)
+	defer cancel()
+
+	period := chronicAbsencePeriod(ctx)
+	if period <= 89*time.Minute || period > 90*time.Minute {
+		t.Errorf("unexpected chronic absence period: [%v]", period)
+	}
+
+	if period := chronicAbsencePeriod(context.Background()); period != 0 {
+		t.Errorf("unexpected chronic absence period: [%v]", period)
+	}
+}
+
+func TestExcludeMembers(t *testing.T) {
+	members := generateMembers(t, 3)
+
+	expectedMembers := []chain.ID{members[0], members[2]}
+	actualMembers := excludeMembers(members, []chain.ID{members[1]})
+
+	if !reflect.DeepEqual(expectedMembers, actualMembers) {
+		t.Errorf(
+			"unexpected members\nexpected: [%v]\nactual:   [%v]",
+			expectedMembers,
+			actualMembers,
+		)
+	}
+}
+
+func generateMembers(t *testing.T, count int) []chain.ID {
+	localChain := local.Connect(context.Background())
+
+	members := make([]chain.ID, count)
+	for i := range members {
+		member, err := localChain.UnmarshalID(
+			fmt.Sprintf("0x%040x", i+1),
+		)
+		if err != nil {
+			t.Fatalf("failed to unmarshal member ID: [%v]", err)
+		}
+
+		members[i] = member
+	}
+
+	return members
+}
@@ -0,0 +1,195 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss"
+)
+
+const (
+	// Name of the file under which blame records are persisted in the keep's
+	// directory.
+	blameRecordsFileName = "blame"
+
+	// Maximum number of the most recent blame records retained for a keep.
+	maxBlameRecordsPerKeep = 50
+)
+
+// Protocol stages for which blame records are kept.
+const (
+	KeyGenerationStage   = "key generation"
+	SigningStage         = "signing"
+	KeyShareRefreshStage = "key share refresh"
+)
+
+// BlameRecord describes a failed protocol execution for a keep together with
+// keep members blamed for the failure.
+type BlameRecord struct {
+	Stage     string          `json:"stage"`
+	Timestamp time.Time       `json:"timestamp"`
+	Error     string          `json:"error"`
+	Culprits  []BlamedCulprit `json:"culprits"`
+}
+
+// BlamedCulprit is a keep member blamed for a failed protocol execution.
+type BlamedCulprit struct {
+	MemberID string `json:"memberID"`
+	Reason   string `json:"reason"`
+}
+
+type blameRegistry struct {
+	mutex   *sync.RWMutex
+	records map[string][]*BlameRecord // keep ID -> blame records
+
+	handle persistence.Handle
+}
+
+// InitializeBlameRegistry loads blame records persisted with the given handle
+// and starts recording members blamed for failures of key generation, signing
+// and key share refresh. The handle should not be used to persist any other
+// data.
+func (n *Node) InitializeBlameRegistry(handle persistence.Handle) {
+	n.blameRegistry = &blameRegistry{
+		mutex:   &sync.RWMutex{},
+		records: make(map[string][]*BlameRecord),
+		handle:  handle,
+	}
+
+	n.blameRegistry.load()
+}
+
+// BlameRecords returns blame records indexed by keep ID. Records of each keep
+// are ordered from the oldest to the most recent.
+func (n *Node) BlameRecords() map[string][]*BlameRecord {
+	result := make(map[string][]*BlameRecord)
+
+	if n.blameRegistry == nil {
+		return result
+	}
+
+	n.blameRegistry.mutex.RLock()
+	defer n.blameRegistry.mutex.RUnlock()
+
+	for keepID, records := range n.blameRegistry.records {
+		result[keepID] = append([]*BlameRecord{}, records...)
+	}
+
+	return result
+}
+
+// recordBlame records members blamed for the failure of the protocol
+// execution resulting with the given error. Nothing is recorded if the failure
+// cannot be attributed to any of the members.
+func (n *Node) recordBlame(keepID chain.ID, stage string, err error) {
+	culprits := tss.Culprits(err)
+	if len(culprits) == 0 {
+		return
+	}
+
+	record := &BlameRecord{
+		Stage:     stage,
+		Timestamp: time.Now(),
+		Error:     err.Error(),
+	}
+
+	for _, culprit := range culprits {
+		logger.Warningf(
+			"member [%s] blamed for [%s] failure in keep [%s]: [%s]",
+			culprit.MemberID,
+			stage,
+			keepID,
+			culprit.Reason,
+		)
+
+		record.Culprits = append(record.Culprits, BlamedCulprit{
+			MemberID: culprit.MemberID.String(),
+			Reason:   string(culprit.Reason),
+		})
+	}
+
+	if n.blameRegistry == nil {
+		return
+	}
+
+	if err := n.blameRegistry.add(keepID, record); err != nil {
+		logger.Errorf(
+			"could not persist blame record for keep [%s]: [%v]",
+			keepID,
+			err,
+		)
+	}
+}
+
+func (br *blameRegistry) add(keepID chain.ID, record *BlameRecord) error {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+
+	records := append(br.records[keepID.String()], record)
+	if len(records) > maxBlameRecordsPerKeep {
+		records = records[len(records)-maxBlameRecordsPerKeep:]
+	}
+
+	br.records[keepID.String()] = records
+
+	recordsBytes, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("failed to marshal blame records: [%v]", err)
+	}
+
+	return br.handle.Save(recordsBytes, keepID.String(), blameRecordsFileName)
+}
+
+func (br *blameRegistry) load() {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+
+	dataChannel, errorsChannel := br.handle.ReadAll()
+
+	// Channels are not buffered and we do not know in what order
+	// information is written to them so they are read at the same time.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for descriptor := range dataChannel {
+			content, err := descriptor.Content()
+			if err != nil {
+				logger.Errorf(
+					"failed to decode blame records from directory [%v]: [%v]",
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			var records []*BlameRecord
+			if err := json.Unmarshal(content, &records); err != nil {
+				logger.Errorf(
+					"failed to unmarshal blame records from directory [%v]: [%v]",
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			br.records[descriptor.Directory()] = records
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for err := range errorsChannel {
+			logger.Errorf("could not load blame records from storage: [%v]", err)
+		}
+	}()
+
+	wg.Wait()
+}
@@ -0,0 +1,79 @@
+package node
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-ecdsa/pkg/chain/local"
+)
+
+func TestBlameRecordsPersistence(t *testing.T) {
+	handle, err := persistence.NewDiskHandle(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create disk handle: [%v]", err)
+	}
+
+	keepID, err := local.Connect(context.Background()).UnmarshalID(
+		"0x770a9E2F2Aa1eC2d3Ca916Fc3e6A55058A898632",
+	)
+	if err != nil {
+		t.Fatalf("failed to unmarshal keep ID: [%v]", err)
+	}
+
+	node := &Node{}
+	node.InitializeBlameRegistry(handle)
+
+	records := []*BlameRecord{}
+	for i := 0; i < maxBlameRecordsPerKeep+2; i++ {
+		record := &BlameRecord{
+			Stage:     SigningStage,
+			Timestamp: time.Unix(int64(1600000000+i), 0).UTC(),
+			Error:     "timeout exceeded",
+			Culprits: []BlamedCulprit{
+				{MemberID: "01", Reason: "no message"},
+			},
+		}
+
+		if err := node.blameRegistry.add(keepID, record); err != nil {
+			t.Fatalf("failed to add blame record: [%v]", err)
+		}
+
+		records = append(records, record)
+	}
+
+	// Only the most recent records are retained.
+	expectedRecords := map[string][]*BlameRecord{
+		keepID.String(): records[2:],
+	}
+
+	if !reflect.DeepEqual(expectedRecords, node.BlameRecords()) {
+		t.Errorf(
+			"unexpected blame records\nexpected: [%v]\nactual:   [%v]",
+			expectedRecords,
+			node.BlameRecords(),
+		)
+	}
+
+	restartedNode := &Node{}
+	restartedNode.InitializeBlameRegistry(handle)
+
+	if !reflect.DeepEqual(expectedRecords, restartedNode.BlameRecords()) {
+		t.Errorf(
+			"unexpected loaded blame records\nexpected: [%v]\nactual:   [%v]",
+			expectedRecords,
+			restartedNode.BlameRecords(),
+		)
+	}
+}
+
+func TestBlameRecordsNotInitialized(t *testing.T) {
+	node := &Node{}
+
+	blameRecords := node.BlameRecords()
+	if len(blameRecords) != 0 {
+		t.Errorf("unexpected blame records: [%v]", blameRecords)
+	}
+}
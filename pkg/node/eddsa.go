@@ -0,0 +1,179 @@
+package node
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/keep-network/keep-core/pkg/operator"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss"
+	"github.com/keep-network/keep-ecdsa/pkg/registry"
+)
+
+// GenerateEdDSASignerForKeep generates a new threshold signer with Ed25519 key
+// pair and submits the public key to the on-chain keep. Any subset of
+// `honestThreshold` keep members can calculate a signature with the generated
+// key.
+//
+// The attempt for generating signer is retried on failure until the provided
+// context is done, the same way as for ECDSA signers. Contrary to ECDSA
+// signers, EdDSA signers require at least two keep members.
+//
+// EdDSA keeps are supported only by the local chain and by clients built with
+// the `eddsa` build tag, see chain.EdDSAKeepHandle.
+func (n *Node) GenerateEdDSASignerForKeep(
+	ctx context.Context,
+	operatorPublicKey *operator.PublicKey,
+	keep chain.EdDSAKeepHandle,
+	members []chain.ID,
+	honestThreshold uint64,
+	keepsRegistry *registry.Keeps,
+) (*tss.EdDSAThresholdSigner, error) {
+	if honestThreshold < 2 || honestThreshold > uint64(len(members)) {
+		return nil, fmt.Errorf(
+			"invalid honest threshold [%d] for EdDSA keep with [%d] members",
+			honestThreshold,
+			len(members),
+		)
+	}
+
+	memberID := tss.MemberIDFromPublicKey(operatorPublicKey)
+
+	var signer *tss.EdDSAThresholdSigner
+	err := n.generateSigner(
+		ctx,
+		operatorPublicKey,
+		keep,
+		members,
+		func(attemptCtx context.Context, memberIDs []tss.MemberID) error {
+			var err error
+			signer, err = tss.GenerateEdDSAThresholdSigner(
+				attemptCtx,
+				keep.ID().String(),
+				memberID,
+				memberIDs,
+				uint(honestThreshold-1),
+				n.networkProvider,
+				n.chain.Signing().PublicKeyToAddress,
+				n.tssConfig.GetKeyGenerationProtocolTimeout(),
+			)
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := n.registerEdDSASigner(keep, signer, keepsRegistry); err != nil {
+		return nil, err
+	}
+
+	return signer, nil // key generation succeeded.
+}
+
+// registerEdDSASigner persists the generated EdDSA signer and submits its
+// public key to the keep.
+func (n *Node) registerEdDSASigner(
+	keep chain.EdDSAKeepHandle,
+	signer *tss.EdDSAThresholdSigner,
+	keepsRegistry *registry.Keeps,
+) error {
+	// Make a snapshot of the generated signer before publishing the public
+	// key to the keep, the same way as for ECDSA signers.
+	err := keepsRegistry.SnapshotEdDSASigner(keep.ID(), signer)
+	if err != nil {
+		return fmt.Errorf(
+			"could not make snapshot of signer for keep [%s]: [%v]",
+			keep.ID(),
+			err,
+		)
+	}
+
+	var publicKey [32]byte
+	copy(publicKey[:], signer.PublicKey())
+
+	err = keep.SubmitEdDSAPublicKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("failed to submit public key: [%v]", err)
+	}
+
+	return nil
+}
+
+// CalculateEdDSASignature calculates an Ed25519 signature over a digest with
+// threshold signer and publishes the result to the keep associated with
+// the signer.
+//
+// The calculated signature is verified against the group public key and
+// the digest before it is published. An invalid signature is never published;
+// the attempt fails and peer members are blamed for it instead.
+//
+// The attempt for generating and publishing signature is retried on failure
+// until the provided context is done.
+func (n *Node) CalculateEdDSASignature(
+	ctx context.Context,
+	keep chain.EdDSAKeepHandle,
+	signer *tss.EdDSAThresholdSigner,
+	digest [32]byte,
+) error {
+	execution := n.executions.start(
+		keep.ID(),
+		SigningStage,
+		hex.EncodeToString(digest[:]),
+	)
+	defer execution.finish()
+
+	attemptCounter := 0
+	for {
+		attemptCounter++
+		execution.recordAttempt(attemptCounter)
+
+		logger.Infof(
+			"calculate EdDSA signature for keep [%s]; attempt [%v]",
+			keep.ID(),
+			attemptCounter,
+		)
+
+		// Global timeout for generating a signature exceeded.
+		// We are giving up and leaving this function.
+		if ctx.Err() != nil {
+			return fmt.Errorf("signing timeout exceeded")
+		}
+
+		attemptCtx, transcript := n.recordTranscript(ctx, keep.ID(), SigningStage)
+
+		signature, err := signer.CalculateSignature(
+			attemptCtx,
+			digest[:],
+			n.networkProvider,
+			n.chain.Signing().PublicKeyToAddress,
+			n.tssConfig.GetSigningProtocolTimeout(),
+		)
+		if err != nil {
+			logger.Errorf(
+				"failed to calculate EdDSA signature for keep [%s]: [%v]",
+				keep.ID(),
+				err,
+			)
+			n.recordBlame(keep.ID(), SigningStage, err)
+			n.saveTranscript(keep.ID(), transcript)
+			n.waitBeforeRetry(ctx, attemptCounter)
+			continue
+		}
+
+		logger.Debugf(
+			"EdDSA signature calculated for keep [%s]: [%x]",
+			keep.ID(),
+			signature,
+		)
+
+		var signatureBytes [64]byte
+		copy(signatureBytes[:], signature)
+
+		return n.publishSignature(ctx, keep, digest, func() error {
+			return keep.SubmitEdDSASignature(signatureBytes)
+		})
+	}
+}
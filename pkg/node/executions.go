@@ -0,0 +1,102 @@
+package node
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+)
+
+// ProtocolExecution describes a key generation or signing protocol execution
+// in progress for a keep.
+type ProtocolExecution struct {
+	KeepID    string    `json:"keepID"`
+	Stage     string    `json:"stage"`
+	Digest    string    `json:"digest,omitempty"`
+	StartedAt time.Time `json:"startedAt"`
+	Attempt   int       `json:"attempt"`
+}
+
+// executionTracker tracks protocol executions in progress together with
+// the number of the current attempt of each execution. The zero value is
+// ready to use.
+type executionTracker struct {
+	mutex      sync.Mutex
+	executions map[*trackedExecution]bool
+}
+
+// trackedExecution is a handle to a protocol execution registered in
+// the tracker.
+type trackedExecution struct {
+	tracker   *executionTracker
+	execution ProtocolExecution
+}
+
+// start registers a new protocol execution for the keep. The digest is
+// expected to be empty for executions not related to a signature.
+func (et *executionTracker) start(
+	keepID chain.ID,
+	stage string,
+	digest string,
+) *trackedExecution {
+	et.mutex.Lock()
+	defer et.mutex.Unlock()
+
+	if et.executions == nil {
+		et.executions = make(map[*trackedExecution]bool)
+	}
+
+	te := &trackedExecution{
+		tracker: et,
+		execution: ProtocolExecution{
+			KeepID:    keepID.String(),
+			Stage:     stage,
+			Digest:    digest,
+			StartedAt: time.Now(),
+		},
+	}
+	et.executions[te] = true
+
+	return te
+}
+
+// list returns copies of executions in progress ordered by their start time.
+func (et *executionTracker) list() []*ProtocolExecution {
+	et.mutex.Lock()
+	defer et.mutex.Unlock()
+
+	executions := make([]*ProtocolExecution, 0, len(et.executions))
+	for te := range et.executions {
+		execution := te.execution
+		executions = append(executions, &execution)
+	}
+
+	sort.Slice(executions, func(i, j int) bool {
+		return executions[i].StartedAt.Before(executions[j].StartedAt)
+	})
+
+	return executions
+}
+
+// recordAttempt sets the number of the current attempt of the execution.
+func (te *trackedExecution) recordAttempt(attempt int) {
+	te.tracker.mutex.Lock()
+	defer te.tracker.mutex.Unlock()
+
+	te.execution.Attempt = attempt
+}
+
+// finish removes the execution from the tracker.
+func (te *trackedExecution) finish() {
+	te.tracker.mutex.Lock()
+	defer te.tracker.mutex.Unlock()
+
+	delete(te.tracker.executions, te)
+}
+
+// ProtocolExecutions returns key generation and signing protocol executions
+// currently in progress, ordered by their start time.
+func (n *Node) ProtocolExecutions() []*ProtocolExecution {
+	return n.executions.list()
+}
@@ -0,0 +1,74 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain/local"
+)
+
+func TestExecutionTracker(t *testing.T) {
+	localChain := local.Connect(context.Background())
+
+	keepID1, err := localChain.UnmarshalID(
+		"0x770a9E2F2Aa1eC2d3Ca916Fc3e6A55058A898632",
+	)
+	if err != nil {
+		t.Fatalf("failed to unmarshal keep ID: [%v]", err)
+	}
+	keepID2, err := localChain.UnmarshalID(
+		"0x4e09cadc7037afa36603138d1c0b76fe2aa5039c",
+	)
+	if err != nil {
+		t.Fatalf("failed to unmarshal keep ID: [%v]", err)
+	}
+
+	node := &Node{}
+
+	keyGeneration := node.executions.start(keepID1, KeyGenerationStage, "")
+	keyGeneration.recordAttempt(1)
+
+	signing := node.executions.start(keepID2, SigningStage, "0102")
+	signing.recordAttempt(1)
+	signing.recordAttempt(2)
+
+	executions := node.ProtocolExecutions()
+
+	if len(executions) != 2 {
+		t.Fatalf(
+			"unexpected number of executions\nexpected: [%v]\nactual:   [%v]",
+			2,
+			len(executions),
+		)
+	}
+
+	if executions[0].KeepID != keepID1.String() ||
+		executions[0].Stage != KeyGenerationStage ||
+		executions[0].Attempt != 1 {
+		t.Errorf("unexpected first execution: [%+v]", executions[0])
+	}
+
+	if executions[1].KeepID != keepID2.String() ||
+		executions[1].Stage != SigningStage ||
+		executions[1].Digest != "0102" ||
+		executions[1].Attempt != 2 {
+		t.Errorf("unexpected second execution: [%+v]", executions[1])
+	}
+
+	// Returned executions should not be affected by further attempts.
+	signing.recordAttempt(3)
+	if executions[1].Attempt != 2 {
+		t.Errorf(
+			"unexpected attempt of returned execution\nexpected: [%v]\nactual:   [%v]",
+			2,
+			executions[1].Attempt,
+		)
+	}
+
+	keyGeneration.finish()
+	signing.finish()
+
+	if executions := node.ProtocolExecutions(); len(executions) != 0 {
+		t.Errorf("unexpected executions after finish: [%v]", executions)
+	}
+}
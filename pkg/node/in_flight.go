@@ -0,0 +1,100 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// inFlightProtocols tracks protocol executions in progress, so that the node
+// can wait for them to complete before the client shuts down. The zero value
+// is ready to use.
+type inFlightProtocols struct {
+	mutex sync.Mutex
+	count int
+	// Closed when the last execution in progress completes. Created when
+	// the first execution begins.
+	idle chan struct{}
+}
+
+// begin marks the start of a protocol execution. It returns a function which
+// has to be called when the execution completes. Calling the returned
+// function more than once has no effect.
+func (ifp *inFlightProtocols) begin() func() {
+	ifp.mutex.Lock()
+	defer ifp.mutex.Unlock()
+
+	if ifp.count == 0 {
+		ifp.idle = make(chan struct{})
+	}
+	ifp.count++
+
+	var once sync.Once
+	return func() {
+		once.Do(ifp.complete)
+	}
+}
+
+func (ifp *inFlightProtocols) complete() {
+	ifp.mutex.Lock()
+	defer ifp.mutex.Unlock()
+
+	ifp.count--
+	if ifp.count == 0 {
+		close(ifp.idle)
+	}
+}
+
+// wait blocks until there are no protocol executions in progress or until
+// the context is done, whichever happens first. It returns an error if the
+// context is done before all executions complete.
+func (ifp *inFlightProtocols) wait(ctx context.Context) error {
+	ifp.mutex.Lock()
+	if ifp.count == 0 {
+		ifp.mutex.Unlock()
+		return nil
+	}
+	idle := ifp.idle
+	ifp.mutex.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		ifp.mutex.Lock()
+		defer ifp.mutex.Unlock()
+
+		return fmt.Errorf(
+			"[%d] protocol executions still in progress: [%v]",
+			ifp.count,
+			ctx.Err(),
+		)
+	}
+}
+
+func (ifp *inFlightProtocols) inProgress() int {
+	ifp.mutex.Lock()
+	defer ifp.mutex.Unlock()
+
+	return ifp.count
+}
+
+// BeginProtocol marks the start of a protocol execution, which the client
+// should let complete before it shuts down. It returns a function which has
+// to be called when the execution completes, including all actions required
+// to persist its result.
+func (n *Node) BeginProtocol() func() {
+	return n.inFlight.begin()
+}
+
+// Drain waits until all protocol executions marked with BeginProtocol
+// complete. It returns an error if the context is done before that happens.
+func (n *Node) Drain(ctx context.Context) error {
+	return n.inFlight.wait(ctx)
+}
+
+// ProtocolsInProgress returns the number of protocol executions marked with
+// BeginProtocol which have not completed yet.
+func (n *Node) ProtocolsInProgress() int {
+	return n.inFlight.inProgress()
+}
@@ -0,0 +1,77 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDrainWithNoProtocolsInProgress(t *testing.T) {
+	node := &Node{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := node.Drain(ctx); err != nil {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+}
+
+func TestDrainWaitsForProtocolsInProgress(t *testing.T) {
+	node := &Node{}
+
+	done1 := node.BeginProtocol()
+	done2 := node.BeginProtocol()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		done1()
+		// Calling the function again should not affect the other execution.
+		done1()
+		time.Sleep(50 * time.Millisecond)
+		done2()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+
+	if err := node.Drain(ctx); err != nil {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("drain completed before protocols; took [%v]", elapsed)
+	}
+
+	// The node should be reusable after it has been drained.
+	done3 := node.BeginProtocol()
+	done3()
+
+	if err := node.Drain(ctx); err != nil {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+}
+
+func TestDrainTimeout(t *testing.T) {
+	node := &Node{}
+
+	done := node.BeginProtocol()
+	defer done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := node.Drain(ctx)
+
+	expectedError := "[1] protocol executions still in progress: " +
+		"[context deadline exceeded]"
+	if err == nil || err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+}
@@ -4,10 +4,11 @@ package node
 import (
 	"context"
 	cecdsa "crypto/ecdsa"
+	"encoding/hex"
 	"fmt"
 	"time"
 
-	"github.com/keep-network/keep-common/pkg/chain/ethlike"
+	"github.com/keep-network/keep-common/pkg/persistence"
 
 	"github.com/keep-network/keep-ecdsa/pkg/registry"
 
@@ -18,21 +19,18 @@ import (
 
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
-	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss"
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss/params"
+	"github.com/keep-network/keep-ecdsa/pkg/utils/backoff"
 )
 
 var logger = log.Logger("keep-ecdsa")
 
 const (
-	// Determines the delay which should be preserved before retrying
-	// actions within the key generation and signing process.
-	retryDelay = 1 * time.Second
-
-	// Number of blocks which should elapse before confirming
-	// the given chain state expectations.
-	blockConfirmations = uint64(12)
+	// Minimum delay before retrying a failed signature submission. Submissions
+	// are retried less eagerly than other actions so that gas is not wasted
+	// on transactions failing for the same reason.
+	signatureSubmissionRetryDelay = 1 * time.Minute
 
 	// Used to calculate the publication delay factor for the given signer index
 	// to avoid all signers publishing the same signature for given keep at the
@@ -44,33 +42,54 @@ const (
 // transport layer.
 type Node struct {
 	chain           chain.Handle
+	eventPipeline   *chain.EventPipeline
 	networkProvider net.Provider
 	tssParamsPool   *tssPreParamsPool
 	tssConfig       *tss.Config
+	retryBackoff    backoff.Policy
+	blameRegistry   *blameRegistry
+	inFlight        inFlightProtocols
+	executions      executionTracker
+
+	transcriptsHandle persistence.Handle
 }
 
-// NewNode initializes node struct with provided chain interface and
-// network provider. It also initializes TSS Pre-Parameters pool. But does not
+// NewNode initializes node struct with provided chain interface, pipeline
+// confirming chain state expectations and network provider. It also initializes TSS Pre-Parameters pool. But does not
 // start parameters generation. This should be called separately.
+//
+// Failed actions within the key generation and signing process are retried
+// with delays determined by the provided backoff policy.
 func NewNode(
 	chain chain.Handle,
+	eventPipeline *chain.EventPipeline,
 	networkProvider net.Provider,
 	tssConfig *tss.Config,
+	retryBackoff backoff.Policy,
 ) *Node {
 	return &Node{
 		chain:           chain,
+		eventPipeline:   eventPipeline,
 		networkProvider: networkProvider,
 		tssConfig:       tssConfig,
+		retryBackoff:    retryBackoff,
 	}
 }
 
+// TSSConfig returns the configuration of TSS protocols executed by the node.
+func (n *Node) TSSConfig() *tss.Config {
+	return n.tssConfig
+}
+
 // AnnounceSignerPresence triggers the announce protocol in order to signal
-// signer presence and gather information about other signers.
+// signer presence and gather information about other signers. The protocol
+// succeeds if at least `quorum` signers announced their presence.
 func (n *Node) AnnounceSignerPresence(
 	ctx context.Context,
 	operatorPublicKey *operator.PublicKey,
 	keepID chain.ID,
 	keepMemberIDs []chain.ID,
+	quorum int,
 ) ([]tss.MemberID, error) {
 	broadcastChannel, err := n.networkProvider.BroadcastChannelFor(keepID.String())
 	if err != nil {
@@ -93,8 +112,10 @@ func (n *Node) AnnounceSignerPresence(
 		operatorPublicKey,
 		keepID,
 		keepMemberIDs,
+		quorum,
 		broadcastChannel,
 		n.chain.PublicKeyToOperatorID,
+		n.tssConfig.GetProtocolAnnounceTimeout(),
 	)
 }
 
@@ -124,23 +145,131 @@ func createAddressFilter(
 }
 
 // GenerateSignerForKeep generates a new threshold signer with ECDSA key pair
-// and submits the public key to the on-chain keep.
+// and submits the public key to the on-chain keep. Any subset of
+// `honestThreshold` keep members can calculate a signature with the generated
+// key.
 //
 // The attempt for generating signer is retried on failure until the provided
-// context is done.
+// context is done. Members absent in consecutive attempts are tracked. The keep
+// accepts the public key only once all its members submitted it so the key
+// is never generated without some of them. If any member has been absent for
+// half of the time given for the key generation, they are unlikely to return
+// before the deadline and the function gives up without further attempts.
 func (n *Node) GenerateSignerForKeep(
 	ctx context.Context,
 	operatorPublicKey *operator.PublicKey,
 	keep chain.BondedECDSAKeepHandle,
 	members []chain.ID,
+	honestThreshold uint64,
 	keepsRegistry *registry.Keeps,
 ) (*tss.ThresholdSigner, error) {
+	// Threshold signing protocol requires at least two members to calculate
+	// a signature. Only a single-member keep can have an honest threshold of
+	// one.
+	minHonestThreshold := uint64(2)
+	if len(members) == 1 {
+		minHonestThreshold = 1
+	}
+
+	if honestThreshold < minHonestThreshold ||
+		honestThreshold > uint64(len(members)) {
+		return nil, fmt.Errorf(
+			"invalid honest threshold [%d] for keep with [%d] members",
+			honestThreshold,
+			len(members),
+		)
+	}
+
 	memberID := tss.MemberIDFromPublicKey(operatorPublicKey)
-	preParamsBox := params.NewBox(n.tssParamsPool.get())
+
+	// There are no other members to run the key generation protocol with.
+	// The key is generated locally and no pre-parameters are required.
+	if len(members) == 1 {
+		signer, err := tss.GenerateLocalSigner(keep.ID().String(), memberID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate local signer: [%v]", err)
+		}
+
+		if err := n.registerSigner(keep, signer, keepsRegistry); err != nil {
+			return nil, err
+		}
+
+		return signer, nil
+	}
+
+	// Pre-parameters are taken from the pool only once the members who are
+	// going to generate the key are known so that they are not consumed by
+	// attempts that cannot succeed.
+	var preParamsBox *params.Box
+
+	var signer *tss.ThresholdSigner
+	err := n.generateSigner(
+		ctx,
+		operatorPublicKey,
+		keep,
+		members,
+		func(attemptCtx context.Context, memberIDs []tss.MemberID) error {
+			// If we are re-attempting the key generation, pre-parameters in
+			// the box could be destroyed because they were shared with other
+			// members. In this case, we need to re-generate them.
+			if preParamsBox == nil || preParamsBox.IsEmpty() {
+				preParamsBox = params.NewBox(n.tssParamsPool.get())
+			}
+
+			var err error
+			signer, err = tss.GenerateThresholdSigner(
+				attemptCtx,
+				keep.ID().String(),
+				memberID,
+				memberIDs,
+				uint(honestThreshold-1),
+				n.networkProvider,
+				n.chain.Signing().PublicKeyToAddress,
+				preParamsBox,
+				n.tssConfig.GetKeyGenerationProtocolTimeout(),
+			)
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := n.registerSigner(keep, signer, keepsRegistry); err != nil {
+		return nil, err
+	}
+
+	return signer, nil // key generation succeeded.
+}
+
+// generateSigner executes the given key generation function until it succeeds
+// or the provided context is done. Before each attempt, keep members announce
+// their presence and the function is called with identifiers of all keep
+// members once all of them did. The key generation function is expected to
+// confirm all members are ready before the key is generated.
+//
+// Members absent in consecutive attempts are tracked. Once any member has been
+// absent in all attempts for half of the time given for the key generation by
+// the context deadline, the function gives up without further attempts.
+// Absent members are never excluded from the attempts, even if the remaining
+// members satisfy the honest threshold, as the keep accepts the public key
+// only once all its members submitted it.
+func (n *Node) generateSigner(
+	ctx context.Context,
+	operatorPublicKey *operator.PublicKey,
+	keep chain.BondedECDSAKeepHandle,
+	members []chain.ID,
+	generate func(attemptCtx context.Context, memberIDs []tss.MemberID) error,
+) error {
+	absences := newAbsenceTracker(members, chronicAbsencePeriod(ctx))
+
+	execution := n.executions.start(keep.ID(), KeyGenerationStage, "")
+	defer execution.finish()
 
 	attemptCounter := 0
 	for {
 		attemptCounter++
+		execution.recordAttempt(attemptCounter)
 
 		logger.Infof(
 			"signer generation for keep [%s]; attempt [%v]",
@@ -155,27 +284,20 @@ func (n *Node) GenerateSignerForKeep(
 				keep.ID(),
 				err,
 			)
-			time.Sleep(retryDelay) // TODO: #413 Replace with backoff.
+			n.waitBeforeRetry(ctx, attemptCounter)
 			continue
 		}
 
 		// If the keep is not active there is no point in generating a signer as
 		// the keep is either closed or terminated.
 		if !isActive {
-			return nil, fmt.Errorf("keep is no longer active")
-		}
-
-		// If we are re-attempting the key generation, pre-parameters in the box
-		// could be destroyed because they were shared with other members.
-		// In this case, we need to re-generate them.
-		if preParamsBox.IsEmpty() {
-			preParamsBox = params.NewBox(n.tssParamsPool.get())
+			return fmt.Errorf("keep is no longer active")
 		}
 
 		// Global timeout for generating a signer exceeded.
 		// We are giving up and leaving this function.
 		if ctx.Err() != nil {
-			return nil, fmt.Errorf("key generation timeout exceeded")
+			return fmt.Errorf("key generation timeout exceeded")
 		}
 
 		// Announce signer presence. Other members of the keep need to receive
@@ -183,16 +305,46 @@ func (n *Node) GenerateSignerForKeep(
 		// keys of all other members. Up to this point, only addresses from
 		// signer selection protocol are known.
 		//
+		// Key generation requires all keep members to be present. Members
+		// who did not announce their presence are determined below so that
+		// their absence can be tracked.
+		//
 		// If signer announcement fails, we retry from the beginning.
-		memberIDs, err := n.AnnounceSignerPresence(
+		attemptCtx, transcript := n.recordTranscript(
 			ctx,
+			keep.ID(),
+			KeyGenerationStage,
+		)
+
+		memberIDs, err := n.AnnounceSignerPresence(
+			attemptCtx,
 			operatorPublicKey,
 			keep.ID(),
 			members,
+			1,
 		)
 		if err != nil {
 			logger.Warningf("failed to announce signer presence: [%v]", err)
-			time.Sleep(retryDelay) // TODO: #413 Replace with backoff.
+			n.saveTranscript(keep.ID(), transcript)
+			n.waitBeforeRetry(ctx, attemptCounter)
+			continue
+		}
+
+		notAnnounced := excludeMembers(members, n.operatorIDs(memberIDs))
+		if len(notAnnounced) > 0 {
+			logger.Warningf(
+				"members [%v] of keep [%s] have not announced their presence",
+				notAnnounced,
+				keep.ID(),
+			)
+			n.saveTranscript(keep.ID(), transcript)
+
+			absences.recordAttempt(notAnnounced, time.Now())
+			if err := checkNoChronicAbsence(absences, time.Now()); err != nil {
+				return err
+			}
+
+			n.waitBeforeRetry(ctx, attemptCounter)
 			continue
 		}
 
@@ -200,60 +352,281 @@ func (n *Node) GenerateSignerForKeep(
 		// keep members.
 		//
 		// If threshold key generation fails, we retry from the beginning.
-		signer, err := tss.GenerateThresholdSigner(
-			ctx,
-			keep.ID().String(),
-			memberID,
-			memberIDs,
-			uint(len(memberIDs)-1),
-			n.networkProvider,
-			n.chain.Signing().PublicKeyToAddress,
-			preParamsBox,
-		)
-		if err != nil {
+		if err := generate(attemptCtx, memberIDs); err != nil {
 			logger.Errorf("failed to generate threshold signer: [%v]", err)
-			time.Sleep(retryDelay) // TODO: #413 Replace with backoff.
+			n.recordBlame(keep.ID(), KeyGenerationStage, err)
+			n.saveTranscript(keep.ID(), transcript)
+
+			absences.recordAttempt(
+				n.operatorIDs(absentCulprits(err)),
+				time.Now(),
+			)
+			if err := checkNoChronicAbsence(absences, time.Now()); err != nil {
+				return err
+			}
+
+			n.waitBeforeRetry(ctx, attemptCounter)
 			continue
 		}
 
-		// Make a snapshot of the generated signer before publishing the public
-		// key to the keep. This guarantees the signer and their key share are
-		// safely persisted before the public key is registered on-chain.
-		// Then, the snapshot can be used for signer recovery in case something
-		// bad occurs before the final signer registration will be done.
-		err = keepsRegistry.SnapshotSigner(keep.ID(), signer)
-		if err != nil {
-			return nil, fmt.Errorf(
-				"could not make snapshot of signer for keep [%s]: [%v]",
+		return nil // key generation succeeded.
+	}
+}
+
+// registerSigner persists the generated signer and submits its public key
+// to the keep.
+func (n *Node) registerSigner(
+	keep chain.BondedECDSAKeepHandle,
+	signer *tss.ThresholdSigner,
+	keepsRegistry *registry.Keeps,
+) error {
+	// Make a snapshot of the generated signer before publishing the public
+	// key to the keep. This guarantees the signer and their key share are
+	// safely persisted before the public key is registered on-chain.
+	// Then, the snapshot can be used for signer recovery in case something
+	// bad occurs before the final signer registration will be done.
+	err := keepsRegistry.SnapshotSigner(keep.ID(), signer)
+	if err != nil {
+		return fmt.Errorf(
+			"could not make snapshot of signer for keep [%s]: [%v]",
+			keep.ID(),
+			err,
+		)
+	}
+
+	// Serialize and submit public key to the keep.
+	//
+	// We don't retry in case of an error although the specific chain
+	// implementation may implement its own retry policy. This action
+	// should never fail and if it failed, something terrible happened.
+	publicKey, err := chain.SerializePublicKey(signer.PublicKey())
+	if err != nil {
+		return fmt.Errorf("failed to serialize public key: [%v]", err)
+	}
+
+	err = keep.SubmitKeepPublicKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("failed to submit public key: [%v]", err)
+	}
+
+	go n.monitorKeepPublicKeySubmission(keep, publicKey)
+
+	return nil
+}
+
+// RefreshSignerForKeep refreshes the key share of the signer registered for
+// the given keep together with all other keep members.
+//
+// Key shares left by the previous refresh are reconciled first, as a member
+// cannot know whether all other members completed it. A key share which is
+// still not reconciled is not refreshed. The refreshed signer holds both
+// the refreshed key share and the key share of the previous refresh epoch. It
+// is persisted before members reconcile them, so that the member never reports
+// a key share it could lose on restart.
+//
+// The refresh uses the given TSS pre-parameters. A signature request for
+// the keep preempts the refresh, as signing takes precedence over it. Key
+// shares are reconciled after the refresh even if it has been preempted, as
+// some members could have completed the refresh just before the request.
+//
+// Key share refresh is not retried on failure. The current signer remains
+// valid in such a case.
+func (n *Node) RefreshSignerForKeep(
+	ctx context.Context,
+	keep chain.BondedECDSAKeepHandle,
+	keepsRegistry *registry.Keeps,
+	preParamsBox *params.Box,
+) error {
+	signer, err := keepsRegistry.GetSigner(keep.ID())
+	if err != nil {
+		return err
+	}
+
+	refreshCtx, cancelRefresh := context.WithCancel(ctx)
+	defer cancelRefresh()
+
+	subscription, err := keep.OnSignatureRequested(
+		func(event *chain.SignatureRequestedEvent) {
+			logger.Warningf(
+				"keep [%s] received a signature request for digest [%+x]; "+
+					"preempting key share refresh",
 				keep.ID(),
-				err,
+				event.Digest,
 			)
-		}
+			cancelRefresh()
+		},
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to subscribe for signature requests of keep [%s]: [%v]",
+			keep.ID(),
+			err,
+		)
+	}
+	defer subscription.Unsubscribe()
 
-		// Serialize and submit public key to the keep.
-		//
-		// We don't retry in case of an error although the specific chain
-		// implementation may implement its own retry policy. This action
-		// should never fail and if it failed, something terrible happened.
-		publicKey, err := chain.SerializePublicKey(signer.PublicKey())
-		if err != nil {
-			return nil, fmt.Errorf("failed to serialize public key: [%v]", err)
-		}
+	isActive, err := keep.IsActive()
+	if err != nil {
+		return fmt.Errorf(
+			"could not check if keep [%s] is still active: [%v]",
+			keep.ID(),
+			err,
+		)
+	}
 
-		err = keep.SubmitKeepPublicKey(publicKey)
-		if err != nil {
-			return nil, fmt.Errorf("failed to submit public key: [%v]", err)
-		}
+	// If the keep is not active there is no point in refreshing the signer as
+	// the keep is either closed or terminated.
+	if !isActive {
+		return fmt.Errorf("keep is no longer active")
+	}
+
+	signer, err = n.reconcileSignerForKeep(
+		refreshCtx,
+		keep,
+		signer,
+		keepsRegistry,
+		time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if !signer.IsReconciled() {
+		return fmt.Errorf(
+			"key shares of keep [%s] are not reconciled; "+
+				"holding key shares of epochs [%d] and [%d]",
+			keep.ID(),
+			signer.RefreshEpoch()-1,
+			signer.RefreshEpoch(),
+		)
+	}
+
+	logger.Infof(
+		"refreshing key share of epoch [%d] for keep [%s]",
+		signer.RefreshEpoch(),
+		keep.ID(),
+	)
+
+	refreshCtx, transcript := n.recordTranscript(
+		refreshCtx,
+		keep.ID(),
+		KeyShareRefreshStage,
+	)
+
+	protocolTimeout := n.tssConfig.GetKeyShareRefreshProtocolTimeout()
+	protocolDeadline := time.Now().Add(protocolTimeout)
+
+	refreshedSigner, err := signer.RefreshKeyShare(
+		refreshCtx,
+		n.networkProvider,
+		n.chain.Signing().PublicKeyToAddress,
+		preParamsBox,
+		protocolTimeout,
+	)
+	if err != nil {
+		n.recordBlame(keep.ID(), KeyShareRefreshStage, err)
+		n.saveTranscript(keep.ID(), transcript)
+
+		return fmt.Errorf(
+			"failed to refresh key share for keep [%s]: [%v]",
+			keep.ID(),
+			err,
+		)
+	}
+
+	if err := keepsRegistry.UpdateSigner(keep.ID(), refreshedSigner); err != nil {
+		return fmt.Errorf(
+			"failed to update signer for keep [%s]: [%v]",
+			keep.ID(),
+			err,
+		)
+	}
+
+	// Members who failed the refresh report their key shares once
+	// the protocol times out.
+	refreshedSigner, err = n.reconcileSignerForKeep(
+		ctx,
+		keep,
+		refreshedSigner,
+		keepsRegistry,
+		protocolDeadline,
+	)
+	if err != nil {
+		return err
+	}
+
+	if !refreshedSigner.IsReconciled() {
+		logger.Warningf(
+			"refreshed key share for keep [%s] but not all members "+
+				"confirmed they hold refreshed key shares; keeping key "+
+				"shares of epochs [%d] and [%d] until the next refresh",
+			keep.ID(),
+			refreshedSigner.RefreshEpoch()-1,
+			refreshedSigner.RefreshEpoch(),
+		)
+
+		return nil
+	}
+
+	logger.Infof(
+		"key share for keep [%s] is at refresh epoch [%d]",
+		keep.ID(),
+		refreshedSigner.RefreshEpoch(),
+	)
+
+	return nil
+}
 
-		go n.monitorKeepPublicKeySubmission(keep, publicKey)
+// reconcileSignerForKeep reconciles key shares of the signer with all other
+// keep members in a window starting at the given time and persists
+// the reconciled signer if key shares it holds have changed.
+func (n *Node) reconcileSignerForKeep(
+	ctx context.Context,
+	keep chain.BondedECDSAKeepHandle,
+	signer *tss.ThresholdSigner,
+	keepsRegistry *registry.Keeps,
+	windowStart time.Time,
+) (*tss.ThresholdSigner, error) {
+	reconciledSigner, err := signer.ReconcileKeyShare(
+		ctx,
+		n.networkProvider,
+		n.chain.Signing().PublicKeyToAddress,
+		windowStart,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to reconcile key shares for keep [%s]: [%v]",
+			keep.ID(),
+			err,
+		)
+	}
+
+	if reconciledSigner == signer {
+		return signer, nil
+	}
 
-		return signer, nil // key generation succeeded.
+	if err := keepsRegistry.UpdateSigner(
+		keep.ID(),
+		reconciledSigner,
+	); err != nil {
+		return nil, fmt.Errorf(
+			"failed to update signer for keep [%s]: [%v]",
+			keep.ID(),
+			err,
+		)
 	}
+
+	return reconciledSigner, nil
 }
 
 // CalculateSignature calculates a signature over a digest with threshold
 // signer and publishes the result to the keep associated with the signer.
 //
+// The calculated signature is verified against the group public key and
+// the digest and normalized to the canonical form with a low `s` value before
+// it is published. An invalid signature is never published; the attempt fails
+// and peer members are blamed for it instead.
+//
 // The attempt for generating and publishing signature is retried on failure
 // until the provided context is done.
 func (n *Node) CalculateSignature(
@@ -264,9 +637,17 @@ func (n *Node) CalculateSignature(
 ) error {
 	keepAddress := common.HexToAddress(signer.GroupID())
 
+	execution := n.executions.start(
+		keep.ID(),
+		SigningStage,
+		hex.EncodeToString(digest[:]),
+	)
+	defer execution.finish()
+
 	attemptCounter := 0
 	for {
 		attemptCounter++
+		execution.recordAttempt(attemptCounter)
 
 		logger.Infof(
 			"calculate signature for keep [%s]; attempt [%v]",
@@ -284,11 +665,17 @@ func (n *Node) CalculateSignature(
 		// other keep members.
 		//
 		// If threshold signing fails, we retry from the beginning.
+		attemptCtx, transcript := n.recordTranscript(ctx, keep.ID(), SigningStage)
+
+		// Submitting an invalid signature would only waste gas on a reverted
+		// transaction, so the signature is verified before it is returned and
+		// an invalid one is treated as a protocol failure.
 		signature, err := signer.CalculateSignature(
-			ctx,
+			attemptCtx,
 			digest[:],
 			n.networkProvider,
 			n.chain.Signing().PublicKeyToAddress,
+			n.tssConfig.GetSigningProtocolTimeout(),
 		)
 		if err != nil {
 			logger.Errorf(
@@ -296,7 +683,9 @@ func (n *Node) CalculateSignature(
 				keepAddress.String(),
 				err,
 			)
-			time.Sleep(retryDelay) // TODO: #413 Replace with backoff.
+			n.recordBlame(keep.ID(), SigningStage, err)
+			n.saveTranscript(keep.ID(), transcript)
+			n.waitBeforeRetry(ctx, attemptCounter)
 			continue
 		}
 
@@ -309,13 +698,15 @@ func (n *Node) CalculateSignature(
 		// We have the signature so now we need to publish it.
 		// This function implements internal retries so we do not need to
 		// retry here.
-		return n.publishSignature(ctx, keep, digest, signature)
+		return n.publishSignature(ctx, keep, digest, func() error {
+			return keep.SubmitSignature(signature)
+		})
 	}
 }
 
-// publishSignature takes the provided signature and attempts to publish it to
-// the chain. It implements retry mechanism allowing to attempt to publish again
-// in case of a failure.
+// publishSignature attempts to publish a signature to the chain with the
+// provided submission function. It implements retry mechanism allowing to
+// attempt to publish again in case of a failure.
 //
 // We do implement a retry in this function because the retry mechanism is much
 // more complex than in case of e.g. publishSignerPublicKey. Although all keep
@@ -327,9 +718,9 @@ func (n *Node) publishSignature(
 	ctx context.Context,
 	keep chain.BondedECDSAKeepHandle,
 	digest [32]byte,
-	signature *ecdsa.Signature,
+	submitSignature func() error,
 ) error {
-	n.waitSignaturePublicationDelay(keep)
+	n.waitSignaturePublicationDelay(ctx, keep)
 
 	attemptCounter := 0
 	for {
@@ -352,7 +743,7 @@ func (n *Node) publishSignature(
 				keep.ID(),
 				err,
 			)
-			time.Sleep(retryDelay) // TODO: #413 Replace with backoff.
+			n.waitBeforeRetry(ctx, attemptCounter)
 			continue
 		}
 		if !isActive {
@@ -373,14 +764,14 @@ func (n *Node) publishSignature(
 				keep.ID(),
 				err,
 			)
-			time.Sleep(retryDelay) // TODO: #413 Replace with backoff.
+			n.waitBeforeRetry(ctx, attemptCounter)
 			continue
 		}
 
 		// Someone submitted the signature, it was accepted by the keep,
 		// and there are enough confirmations from the chain.
 		// We are fine, leaving.
-		if !isAwaitingSignature && n.confirmSignature(keep, digest) {
+		if !isAwaitingSignature && n.confirmSignature(ctx, keep, digest) {
 			return nil
 		}
 
@@ -390,7 +781,7 @@ func (n *Node) publishSignature(
 			attemptCounter,
 		)
 
-		if submissionErr := keep.SubmitSignature(signature); submissionErr != nil {
+		if submissionErr := submitSignature(); submissionErr != nil {
 			isAwaitingSignature, err := keep.IsAwaitingSignature(digest)
 			if err != nil {
 				logger.Errorf(
@@ -398,7 +789,7 @@ func (n *Node) publishSignature(
 					keep.ID(),
 					err,
 				)
-				time.Sleep(retryDelay) // TODO: #413 Replace with backoff.
+				n.waitBeforeRetry(ctx, attemptCounter)
 				continue
 			}
 
@@ -407,24 +798,30 @@ func (n *Node) publishSignature(
 			// If someone else submitted in the meantime, wait for enough
 			// confirmations from the chain before making a decision about
 			// leaving the submission process.
-			if !isAwaitingSignature && n.confirmSignature(keep, digest) {
+			if !isAwaitingSignature && n.confirmSignature(ctx, keep, digest) {
 				return nil
 			}
 
 			// Our public key submission transaction failed. We are going to
 			// wait for some time and then retry from the beginning.
+			delay := n.retryBackoff.Delay(attemptCounter)
+			if delay < signatureSubmissionRetryDelay {
+				delay = signatureSubmissionRetryDelay
+			}
+
 			logger.Errorf(
 				"failed to submit signature for keep [%s]: [%v]; "+
-					"will retry after 1 minute",
+					"will retry after [%v]",
 				keep.ID(),
 				submissionErr,
+				delay,
 			)
-			time.Sleep(1 * time.Minute)
+			_ = backoff.Wait(ctx, delay)
 			continue
 		}
 
-		if !(n.waitForSignature(keep, digest) && n.confirmSignature(keep, digest)) {
-			time.Sleep(retryDelay) // TODO: #413 Replace with backoff.
+		if !(n.waitForSignature(ctx, keep, digest) && n.confirmSignature(ctx, keep, digest)) {
+			n.waitBeforeRetry(ctx, attemptCounter)
 			continue
 		}
 
@@ -434,8 +831,12 @@ func (n *Node) publishSignature(
 
 // waitSignaturePublicationDelay waits a certain amount of time appropriately
 // for the given signer index to avoid all signers publishing the same signature
-// for given keep at the same time.
-func (n *Node) waitSignaturePublicationDelay(keep chain.BondedECDSAKeepHandle) {
+// for given keep at the same time. The wait is interrupted when the context
+// is done.
+func (n *Node) waitSignaturePublicationDelay(
+	ctx context.Context,
+	keep chain.BondedECDSAKeepHandle,
+) {
 	signerIndex, err := keep.OperatorIndex()
 	if err != nil {
 		logger.Errorf(
@@ -466,18 +867,30 @@ func (n *Node) waitSignaturePublicationDelay(keep chain.BondedECDSAKeepHandle) {
 		keep.ID(),
 	)
 
-	time.Sleep(delay)
+	_ = backoff.Wait(ctx, delay)
 }
 
+// waitBeforeRetry waits before the next attempt of an action which failed in
+// the given attempt, as determined by the retry backoff policy. The wait is
+// interrupted when the context is done; callers are expected to check the
+// context before the next attempt.
+func (n *Node) waitBeforeRetry(ctx context.Context, attempt int) {
+	_ = backoff.Wait(ctx, n.retryBackoff.Delay(attempt))
+}
+
+// waitForSignature waits until the signature for the given digest appears
+// on-chain. It returns false if the signature has not appeared within the wait
+// timeout or the provided context is done before that.
 func (n *Node) waitForSignature(
+	ctx context.Context,
 	keep chain.BondedECDSAKeepHandle,
 	digest [32]byte,
 ) bool {
 	const waitTimeout = 30 * time.Minute
 	const checkTick = 1 * time.Minute
 
-	ctx, cancelCtx := context.WithTimeout(context.Background(), waitTimeout)
-	defer cancelCtx()
+	waitCtx, cancelWaitCtx := context.WithTimeout(ctx, waitTimeout)
+	defer cancelWaitCtx()
 
 	checkTicker := time.NewTicker(checkTick)
 	defer checkTicker.Stop()
@@ -512,7 +925,17 @@ func (n *Node) waitForSignature(
 				)
 				return true
 			}
-		case <-ctx.Done():
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				logger.Warningf(
+					"stopped waiting for signature for keep [%s] to appear "+
+						"on-chain: [%v]",
+					keep.ID(),
+					ctx.Err(),
+				)
+				return false
+			}
+
 			logger.Errorf(
 				"signature for keep [%s] has not appeared on the chain "+
 					"after [%v] from submitting it",
@@ -525,6 +948,7 @@ func (n *Node) waitForSignature(
 }
 
 func (n *Node) confirmSignature(
+	ctx context.Context,
 	keep chain.BondedECDSAKeepHandle,
 	digest [32]byte,
 ) bool {
@@ -533,21 +957,9 @@ func (n *Node) confirmSignature(
 		keep.ID(),
 	)
 
-	currentBlock, err := n.chain.BlockCounter().CurrentBlock()
-	if err != nil {
-		logger.Errorf(
-			"could not get current block while confirming "+
-				"signature submission for keep [%s]: [%v]",
-			keep.ID(),
-			err,
-		)
-		return false
-	}
-
-	isSignatureConfirmed, err := ethlike.WaitForBlockConfirmations(
-		n.chain.BlockCounter(),
-		currentBlock,
-		blockConfirmations,
+	isSignatureConfirmed, err := n.eventPipeline.ConfirmState(
+		ctx,
+		chain.DefaultConfirmationDepth,
 		func() (bool, error) {
 			isAwaitingSignature, err := keep.IsAwaitingSignature(digest)
 			if err != nil {
@@ -691,22 +1103,11 @@ func (n *Node) monitorKeepPublicKeySubmission(
 				continue
 			} else {
 				if len(keepPublicKey) > 0 {
-					currentBlock, err := n.chain.BlockCounter().CurrentBlock()
-					if err != nil {
-						logger.Errorf(
-							"failed to get the current block while "+
-								"performing public key submission confirmation "+
-								"for keep [%s]: [%v]",
-							keep.ID(),
-							err,
-						)
-						continue
-					}
-
-					isConfirmed, err := ethlike.WaitForBlockConfirmations(
-						n.chain.BlockCounter(),
-						currentBlock,
-						blockConfirmations,
+					// The monitoring outlives the key generation and
+					// is not bound to any context.
+					isConfirmed, err := n.eventPipeline.ConfirmState(
+						context.Background(),
+						chain.DefaultConfirmationDepth,
 						func() (bool, error) {
 							key, err := keep.GetPublicKey()
 							if err != nil {
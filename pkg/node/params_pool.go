@@ -1,36 +1,128 @@
 package node
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/binance-chain/tss-lib/ecdsa/keygen"
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss/params"
+	"github.com/keep-network/keep-ecdsa/pkg/utils/persistenceutils"
 )
 
+// Name of the file under which TSS pre-parameters are persisted. Each entry
+// of the pool is persisted in a separate directory.
+const preParamsFileName = "pre-params"
+
 // tssPreParamsPool is a pool holding TSS pre parameters. It autogenerates entries
 // up to the pool size. When an entry is pulled from the pool it will generate
 // new entry.
+//
+// If the persistence handle is set, entries are persisted when they are added
+// to the pool and deleted before they are pulled from the pool, so that
+// entries generated before a restart can be used after the restart and an
+// entry is never pulled twice. Entries are deleted rather than archived as
+// they are secret and never needed once pulled.
+//
+// Entries are generated by `workers` workers in parallel. Each worker holds
+// a generated entry until there is a room for it in the pool and persists it
+// only once the room is reserved, so that no more entries are persisted than
+// the pool can hold.
 type tssPreParamsPool struct {
-	pool chan *keygen.LocalPreParams
-	new  func() (*keygen.LocalPreParams, error)
+	pool chan *preParamsEntry
+	// Slots of the pool reserved for entries either held in the pool or being
+	// persisted before they are added to the pool.
+	slots   chan struct{}
+	new     func() (*keygen.LocalPreParams, error)
+	workers int
+
+	// Number of workers currently generating an entry.
+	activeWorkers int32
+
+	handle persistenceutils.Handle
 }
 
-// InitializeTSSPreParamsPool generates TSS pre-parameters and stores them in a pool.
-func (n *Node) InitializeTSSPreParamsPool() {
+// preParamsEntry is a pool entry. The entry ID is the name of the directory
+// under which the entry is persisted.
+type preParamsEntry struct {
+	id     string
+	params *keygen.LocalPreParams
+}
+
+// InitializeTSSPreParamsPool loads TSS pre-parameters persisted with the given
+// handle, generates more of them up to the target pool size and stores them
+// in a pool. The handle should not be used to persist any other data and it
+// should encrypt the data as pre-parameters are secret.
+func (n *Node) InitializeTSSPreParamsPool(handle persistenceutils.Handle) {
 	poolSize := n.tssConfig.GetPreParamsTargetPoolSize()
+	workers := n.tssConfig.GetPreParamsGenerationWorkers()
+	concurrency := n.tssConfig.GetPreParamsGenerationConcurrency()
 
-	logger.Infof("TSS pre-parameters target pool size is [%v]", poolSize)
+	logger.Infof(
+		"TSS pre-parameters target pool size is [%v]; generating with [%v] "+
+			"workers using [%v] routines each",
+		poolSize,
+		workers,
+		concurrency,
+	)
 
-	n.tssParamsPool = &tssPreParamsPool{
-		pool: make(chan *keygen.LocalPreParams, poolSize),
-		new: func() (*keygen.LocalPreParams, error) {
+	n.tssParamsPool = newTSSPreParamsPool(
+		poolSize,
+		workers,
+		func() (*keygen.LocalPreParams, error) {
 			return tss.GenerateTSSPreParams(
 				n.tssConfig.GetPreParamsGenerationTimeout(),
+				concurrency,
 			)
 		},
+		handle,
+	)
+
+	n.tssParamsPool.load()
+
+	n.tssParamsPool.pumpPool()
+}
+
+// newTSSPreParamsPool creates a pool of the given size with entries generated
+// by the given function. The handle may be nil if entries should not be
+// persisted.
+func newTSSPreParamsPool(
+	poolSize int,
+	workers int,
+	new func() (*keygen.LocalPreParams, error),
+	handle persistenceutils.Handle,
+) *tssPreParamsPool {
+	return &tssPreParamsPool{
+		pool:    make(chan *preParamsEntry, poolSize),
+		slots:   make(chan struct{}, poolSize),
+		new:     new,
+		workers: workers,
+		handle:  handle,
 	}
+}
+
+// TSSPreParams takes TSS pre-parameters from the pool. If the pool is empty,
+// it waits until new pre-parameters are generated, but not longer than
+// the pre-parameters generation timeout.
+func (n *Node) TSSPreParams() (*params.Box, error) {
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		n.tssConfig.GetPreParamsGenerationTimeout(),
+	)
+	defer cancel()
 
-	go n.tssParamsPool.pumpPool()
+	preParams, err := n.tssParamsPool.getWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return params.NewBox(preParams), nil
 }
 
 // TSSPreParamsPoolSize returns the current size of the TSS params pool.
@@ -42,13 +134,46 @@ func (n *Node) TSSPreParamsPoolSize() int {
 	return len(n.tssParamsPool.pool)
 }
 
+// TSSPreParamsPoolTargetSize returns the target size of the TSS params pool.
+func (n *Node) TSSPreParamsPoolTargetSize() int {
+	if n.tssParamsPool == nil {
+		return 0
+	}
+
+	return cap(n.tssParamsPool.pool)
+}
+
+// TSSPreParamsGenerationsInProgress returns the number of TSS params currently
+// being generated.
+func (n *Node) TSSPreParamsGenerationsInProgress() int {
+	if n.tssParamsPool == nil {
+		return 0
+	}
+
+	return int(atomic.LoadInt32(&n.tssParamsPool.activeWorkers))
+}
+
+// pumpPool starts workers generating entries of the pool.
 func (t *tssPreParamsPool) pumpPool() {
+	workers := t.workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	for i := 0; i < workers; i++ {
+		go t.pumpWorker()
+	}
+}
+
+func (t *tssPreParamsPool) pumpWorker() {
 	for {
 		logger.Info("generating new tss pre parameters")
 
 		start := time.Now()
 
+		atomic.AddInt32(&t.activeWorkers, 1)
 		params, err := t.new()
+		atomic.AddInt32(&t.activeWorkers, -1)
 		if err != nil {
 			logger.Warningf(
 				"failed to generate tss pre parameters after [%s]: [%v]",
@@ -58,18 +183,192 @@ func (t *tssPreParamsPool) pumpPool() {
 			continue
 		}
 
+		generationTime := time.Since(start)
+
+		// The entry is persisted only once there is a room for it in the pool.
+		t.slots <- struct{}{}
+
+		entry, err := t.persist(params)
+		if err != nil {
+			logger.Warningf(
+				"failed to persist tss pre parameters: [%v]",
+				err,
+			)
+			<-t.slots
+			continue
+		}
+
 		logger.Infof(
 			"generated new tss pre parameters, took: [%s], current pool size: [%d]",
-			time.Since(start),
+			generationTime,
 			len(t.pool)+1,
 		)
 
-		t.pool <- params
+		t.pool <- entry
 	}
 }
 
 // get returns TSS pre parameters from the pool. It pumps the pool after getting
 // and entry. If the pool is empty it will wait for a new entry to be generated.
+//
+// The entry is deleted from the storage before it is returned. Entries which
+// could not be deleted are discarded as they could be loaded again after
+// a restart.
 func (t *tssPreParamsPool) get() *keygen.LocalPreParams {
-	return <-t.pool
+	preParams, _ := t.getWithContext(context.Background())
+	return preParams
+}
+
+// getWithContext returns TSS pre parameters from the pool the same way as get
+// but it stops waiting for a new entry when the context is done. In such
+// a case, an error is returned.
+func (t *tssPreParamsPool) getWithContext(
+	ctx context.Context,
+) (*keygen.LocalPreParams, error) {
+	for {
+		var entry *preParamsEntry
+		select {
+		case entry = <-t.pool:
+		case <-ctx.Done():
+			return nil, fmt.Errorf(
+				"no tss pre parameters available: [%w]",
+				ctx.Err(),
+			)
+		}
+		<-t.slots
+
+		if t.handle != nil {
+			if err := t.handle.Delete(entry.id); err != nil {
+				logger.Errorf(
+					"failed to delete tss pre parameters [%s]; "+
+						"discarding them: [%v]",
+					entry.id,
+					err,
+				)
+				continue
+			}
+		}
+
+		return entry.params, nil
+	}
+}
+
+func (t *tssPreParamsPool) persist(
+	params *keygen.LocalPreParams,
+) (*preParamsEntry, error) {
+	paramsBytes, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pre parameters: [%v]", err)
+	}
+
+	paramsHash := sha256.Sum256(paramsBytes)
+	entry := &preParamsEntry{
+		id:     hex.EncodeToString(paramsHash[:]),
+		params: params,
+	}
+
+	if t.handle == nil {
+		return entry, nil
+	}
+
+	if err := t.handle.Save(paramsBytes, entry.id, preParamsFileName); err != nil {
+		return nil, fmt.Errorf("failed to save pre parameters: [%v]", err)
+	}
+
+	return entry, nil
+}
+
+// load fills the pool with entries persisted before. Entries exceeding
+// the pool size, e.g. persisted with a greater target pool size configured,
+// are deleted, as the pool would never use them.
+func (t *tssPreParamsPool) load() {
+	if t.handle == nil {
+		return
+	}
+
+	dataChannel, errorsChannel := t.handle.ReadAll()
+
+	// Excess entries are deleted once all entries are read, so that
+	// directories are not deleted while they are being read.
+	excessEntryIDs := []string{}
+
+	// Channels are not buffered and we do not know in what order
+	// information is written to them so they are read at the same time.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for descriptor := range dataChannel {
+			content, err := descriptor.Content()
+			if err != nil {
+				logger.Errorf(
+					"failed to decode tss pre parameters from directory [%v]: [%v]",
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			params := &keygen.LocalPreParams{}
+			if err := json.Unmarshal(content, params); err != nil {
+				logger.Errorf(
+					"failed to unmarshal tss pre parameters from directory [%v]: [%v]",
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			if !params.ValidateWithProof() {
+				logger.Errorf(
+					"invalid tss pre parameters in directory [%v]",
+					descriptor.Directory(),
+				)
+				continue
+			}
+
+			select {
+			case t.slots <- struct{}{}:
+				t.pool <- &preParamsEntry{
+					id:     descriptor.Directory(),
+					params: params,
+				}
+			default:
+				excessEntryIDs = append(
+					excessEntryIDs,
+					descriptor.Directory(),
+				)
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for err := range errorsChannel {
+			logger.Errorf("could not load tss pre parameters from storage: [%v]", err)
+		}
+	}()
+
+	wg.Wait()
+
+	for _, entryID := range excessEntryIDs {
+		logger.Debugf(
+			"tss pre parameters pool is full; deleting pre parameters "+
+				"from directory [%v]",
+			entryID,
+		)
+
+		if err := t.handle.Delete(entryID); err != nil {
+			logger.Errorf(
+				"failed to delete tss pre parameters [%s]: [%v]",
+				entryID,
+				err,
+			)
+		}
+	}
+
+	logger.Infof("loaded [%d] tss pre parameters from storage", len(t.pool))
 }
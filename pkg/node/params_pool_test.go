@@ -1,12 +1,23 @@
 package node
 
 import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"reflect"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/binance-chain/tss-lib/crypto/paillier"
 	"github.com/binance-chain/tss-lib/ecdsa/keygen"
 	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-ecdsa/pkg/utils/persistenceutils"
 )
 
 func TestTSSPreParamsPool(t *testing.T) {
@@ -51,10 +62,13 @@ func TestTSSPreParamsPool(t *testing.T) {
 		t.Errorf("result is nil")
 	}
 
-	if len(tssPool.pool) != poolSize-1 {
+	// A worker holding a generated entry may have already added it to
+	// the pool in place of one of the pulled entries.
+	if len(tssPool.pool) != poolSize-2 && len(tssPool.pool) != poolSize-1 {
 		t.Errorf(
-			"invalid after get length\nexpected: [%d]\nactual:   [%d]",
+			"invalid after get length\nexpected: [%d] or [%d]\nactual:   [%d]",
 			poolSize-2,
+			poolSize-1,
 			len(tssPool.pool),
 		)
 	}
@@ -167,12 +181,277 @@ func TestTSSPreParamsPoolConcurrent(t *testing.T) {
 	}
 }
 
+func TestTSSPreParamsPoolParallelWorkers(t *testing.T) {
+	poolSize := 6
+	workers := 3
+
+	release := make(chan struct{})
+
+	tssPool := newTSSPreParamsPool(
+		poolSize,
+		workers,
+		func() (*keygen.LocalPreParams, error) {
+			<-release
+			return &keygen.LocalPreParams{}, nil
+		},
+		nil,
+	)
+
+	tssPool.pumpPool()
+	time.Sleep(100 * time.Millisecond)
+
+	if activeWorkers := atomic.LoadInt32(&tssPool.activeWorkers); int(activeWorkers) != workers {
+		t.Errorf(
+			"invalid number of active workers\nexpected: [%d]\nactual:   [%d]",
+			workers,
+			activeWorkers,
+		)
+	}
+
+	close(release)
+	time.Sleep(100 * time.Millisecond)
+
+	if len(tssPool.pool) != poolSize {
+		t.Errorf(
+			"invalid end length\nexpected: [%d]\nactual:   [%d]",
+			poolSize,
+			len(tssPool.pool),
+		)
+	}
+}
+
+func TestTSSPreParamsPoolPersistence(t *testing.T) {
+	path := t.TempDir()
+	diskHandle, err := persistence.NewDiskHandle(path)
+	if err != nil {
+		t.Fatalf("failed to create disk handle: [%v]", err)
+	}
+	handle := persistenceutils.NewEncryptedDiskHandle(
+		diskHandle,
+		path,
+		"password",
+	)
+
+	poolSize := 2
+
+	generatedParams := make(chan *keygen.LocalPreParams, poolSize+1)
+	for i := 1; i <= poolSize+1; i++ {
+		generatedParams <- newTestPreParams(int64(i))
+	}
+
+	tssPool := newTSSPreParamsPool(
+		poolSize,
+		1,
+		func() (*keygen.LocalPreParams, error) {
+			// Blocks once all the parameters have been generated.
+			return <-generatedParams, nil
+		},
+		handle,
+	)
+
+	go tssPool.pumpPool()
+
+	consumedParams := tssPool.get()
+
+	// Wait until the pool is refilled.
+	time.Sleep(100 * time.Millisecond)
+
+	if len(tssPool.pool) != poolSize {
+		t.Fatalf(
+			"invalid pool length\nexpected: [%d]\nactual:   [%d]",
+			poolSize,
+			len(tssPool.pool),
+		)
+	}
+
+	restartedPool := newTSSPreParamsPool(poolSize, 1, nil, handle)
+	restartedPool.load()
+
+	if len(restartedPool.pool) != poolSize {
+		t.Fatalf(
+			"invalid loaded pool length\nexpected: [%d]\nactual:   [%d]",
+			poolSize,
+			len(restartedPool.pool),
+		)
+	}
+
+	for i := 0; i < poolSize; i++ {
+		loadedParams := restartedPool.get()
+
+		if reflect.DeepEqual(consumedParams, loadedParams) {
+			t.Errorf("consumed parameters loaded from storage")
+		}
+
+		if !loadedParams.ValidateWithProof() {
+			t.Errorf("invalid parameters loaded from storage")
+		}
+	}
+
+	// All the parameters have been consumed.
+	emptyPool := newTSSPreParamsPool(poolSize, 1, nil, handle)
+	emptyPool.load()
+
+	if len(emptyPool.pool) != 0 {
+		t.Errorf(
+			"invalid loaded pool length\nexpected: [%d]\nactual:   [%d]",
+			0,
+			len(emptyPool.pool),
+		)
+	}
+
+	// Consumed parameters are deleted, not archived.
+	archived, _ := ioutil.ReadDir(filepath.Join(path, "archive"))
+	if len(archived) != 0 {
+		t.Errorf(
+			"invalid number of archived parameters\nexpected: [%d]\nactual:   [%d]",
+			0,
+			len(archived),
+		)
+	}
+}
+
+func TestTSSPreParamsPoolPersistsOnlyPoolSize(t *testing.T) {
+	path := t.TempDir()
+	handle := newTestPreParamsHandle(t, path)
+
+	poolSize := 2
+	workers := 3
+
+	var seed int64
+	tssPool := newTSSPreParamsPool(
+		poolSize,
+		workers,
+		func() (*keygen.LocalPreParams, error) {
+			return newTestPreParams(atomic.AddInt64(&seed, 1)), nil
+		},
+		handle,
+	)
+
+	tssPool.pumpPool()
+
+	// Wait until the pool is filled and all workers wait for a room in it.
+	time.Sleep(100 * time.Millisecond)
+
+	persisted, _ := ioutil.ReadDir(filepath.Join(path, "current"))
+	if len(persisted) != poolSize {
+		t.Errorf(
+			"invalid number of persisted parameters\nexpected: [%d]\nactual:   [%d]",
+			poolSize,
+			len(persisted),
+		)
+	}
+}
+
+func TestTSSPreParamsPoolLoadDeletesExcess(t *testing.T) {
+	path := t.TempDir()
+	handle := newTestPreParamsHandle(t, path)
+
+	poolSize := 3
+
+	tssPool := newTSSPreParamsPool(poolSize, 1, nil, handle)
+	for i := 1; i <= poolSize; i++ {
+		if _, err := tssPool.persist(newTestPreParams(int64(i))); err != nil {
+			t.Fatalf("failed to persist parameters: [%v]", err)
+		}
+	}
+
+	smallerPoolSize := 2
+
+	smallerPool := newTSSPreParamsPool(smallerPoolSize, 1, nil, handle)
+	smallerPool.load()
+
+	if len(smallerPool.pool) != smallerPoolSize {
+		t.Errorf(
+			"invalid loaded pool length\nexpected: [%d]\nactual:   [%d]",
+			smallerPoolSize,
+			len(smallerPool.pool),
+		)
+	}
+
+	persisted, _ := ioutil.ReadDir(filepath.Join(path, "current"))
+	if len(persisted) != smallerPoolSize {
+		t.Errorf(
+			"invalid number of persisted parameters\nexpected: [%d]\nactual:   [%d]",
+			smallerPoolSize,
+			len(persisted),
+		)
+	}
+}
+
+func newTestPreParamsHandle(t *testing.T, path string) persistenceutils.Handle {
+	diskHandle, err := persistence.NewDiskHandle(path)
+	if err != nil {
+		t.Fatalf("failed to create disk handle: [%v]", err)
+	}
+
+	return persistenceutils.NewEncryptedDiskHandle(
+		diskHandle,
+		path,
+		"password",
+	)
+}
+
+func newTestPreParams(seed int64) *keygen.LocalPreParams {
+	value := func(offset int64) *big.Int {
+		return big.NewInt(seed*100 + offset)
+	}
+
+	return &keygen.LocalPreParams{
+		PaillierSK: &paillier.PrivateKey{
+			PublicKey: paillier.PublicKey{N: value(1)},
+			LambdaN:   value(2),
+			PhiN:      value(3),
+		},
+		NTildei: value(4),
+		H1i:     value(5),
+		H2i:     value(6),
+		Alpha:   value(7),
+		Beta:    value(8),
+		P:       value(9),
+		Q:       value(10),
+	}
+}
+
 func newTestPool(poolSize int) *tssPreParamsPool {
-	return &tssPreParamsPool{
-		pool: make(chan *keygen.LocalPreParams, poolSize),
-		new: func() (*keygen.LocalPreParams, error) {
+	return newTSSPreParamsPool(
+		poolSize,
+		1,
+		func() (*keygen.LocalPreParams, error) {
 			time.Sleep(10 * time.Millisecond)
 			return &keygen.LocalPreParams{}, nil
 		},
+		nil,
+	)
+}
+
+func TestTSSPreParamsPoolGetWithContext(t *testing.T) {
+	poolSize := 1
+
+	// Create new pool without pumping it.
+	tssPool := newTestPool(poolSize)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	result, err := tssPool.getWithContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			context.DeadlineExceeded,
+			err,
+		)
+	}
+	if result != nil {
+		t.Errorf("unexpected result: [%v]", result)
+	}
+
+	tssPool.pumpPool()
+
+	result, err = tssPool.getWithContext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+	if result == nil {
+		t.Errorf("result is nil")
 	}
 }
@@ -0,0 +1,81 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss"
+)
+
+// Prefix of names of files under which transcripts of failed protocol
+// executions are persisted in the keep's directory.
+const transcriptFileNamePrefix = "transcript-"
+
+// InitializeTranscriptRecording starts recording messages exchanged with peer
+// members during protocol executions. Transcripts of failed executions are
+// persisted with the given handle in the keep's directory, so that they can be
+// analyzed offline. The handle should encrypt the data and it should not be
+// used to persist any other data.
+func (n *Node) InitializeTranscriptRecording(handle persistence.Handle) {
+	n.transcriptsHandle = handle
+}
+
+// recordTranscript returns a copy of the context in which messages exchanged
+// during the protocol execution are recorded in the returned transcript. If
+// transcripts are not recorded, the context is returned unchanged together
+// with a nil transcript.
+func (n *Node) recordTranscript(
+	ctx context.Context,
+	keepID chain.ID,
+	stage string,
+) (context.Context, *tss.Transcript) {
+	if n.transcriptsHandle == nil {
+		return ctx, nil
+	}
+
+	transcript := tss.NewTranscript(fmt.Sprintf("%s for keep [%s]", stage, keepID))
+
+	return tss.WithTranscript(ctx, transcript), transcript
+}
+
+// saveTranscript persists the transcript of the failed protocol execution.
+// Nothing is persisted if the transcript is nil.
+func (n *Node) saveTranscript(keepID chain.ID, transcript *tss.Transcript) {
+	if transcript == nil {
+		return
+	}
+
+	transcriptBytes, err := transcript.Marshal()
+	if err != nil {
+		logger.Errorf(
+			"could not marshal transcript for keep [%s]: [%v]",
+			keepID,
+			err,
+		)
+		return
+	}
+
+	fileName := fmt.Sprintf("%s%d", transcriptFileNamePrefix, time.Now().UnixNano())
+
+	if err := n.transcriptsHandle.Save(
+		transcriptBytes,
+		keepID.String(),
+		fileName,
+	); err != nil {
+		logger.Errorf(
+			"could not persist transcript for keep [%s]: [%v]",
+			keepID,
+			err,
+		)
+		return
+	}
+
+	logger.Infof(
+		"persisted transcript of [%s] as [%s]",
+		transcript.Description(),
+		fileName,
+	)
+}
@@ -0,0 +1,106 @@
+package node
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-ecdsa/pkg/chain/local"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss"
+)
+
+func TestSaveTranscript(t *testing.T) {
+	handle, err := persistence.NewDiskHandle(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create disk handle: [%v]", err)
+	}
+
+	keepID, err := local.Connect(context.Background()).UnmarshalID(
+		"0x770a9E2F2Aa1eC2d3Ca916Fc3e6A55058A898632",
+	)
+	if err != nil {
+		t.Fatalf("failed to unmarshal keep ID: [%v]", err)
+	}
+
+	node := &Node{}
+	node.InitializeTranscriptRecording(handle)
+
+	_, transcript := node.recordTranscript(
+		context.Background(),
+		keepID,
+		SigningStage,
+	)
+	if transcript == nil {
+		t.Fatal("transcript is not recorded")
+	}
+
+	node.saveTranscript(keepID, transcript)
+
+	dataChannel, errorsChannel := handle.ReadAll()
+
+	descriptors := []persistence.DataDescriptor{}
+	for descriptor := range dataChannel {
+		descriptors = append(descriptors, descriptor)
+	}
+	for err := range errorsChannel {
+		t.Fatal(err)
+	}
+
+	if len(descriptors) != 1 {
+		t.Fatalf(
+			"unexpected number of persisted transcripts\n"+
+				"expected: [%v]\nactual:   [%v]",
+			1,
+			len(descriptors),
+		)
+	}
+
+	if descriptors[0].Directory() != keepID.String() {
+		t.Errorf(
+			"unexpected directory\nexpected: [%v]\nactual:   [%v]",
+			keepID.String(),
+			descriptors[0].Directory(),
+		)
+	}
+
+	if !strings.HasPrefix(descriptors[0].Name(), transcriptFileNamePrefix) {
+		t.Errorf("unexpected file name: [%v]", descriptors[0].Name())
+	}
+
+	content, err := descriptors[0].Content()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	persistedTranscript := &tss.Transcript{}
+	if err := persistedTranscript.Unmarshal(content); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedDescription := "signing for keep [" + keepID.String() + "]"
+	if expectedDescription != persistedTranscript.Description() {
+		t.Errorf(
+			"unexpected description\nexpected: [%v]\nactual:   [%v]",
+			expectedDescription,
+			persistedTranscript.Description(),
+		)
+	}
+}
+
+func TestTranscriptRecordingNotInitialized(t *testing.T) {
+	node := &Node{}
+
+	ctx := context.Background()
+
+	transcriptCtx, transcript := node.recordTranscript(ctx, nil, SigningStage)
+	if transcript != nil {
+		t.Errorf("unexpected transcript: [%v]", transcript)
+	}
+	if transcriptCtx != ctx {
+		t.Errorf("unexpected context: [%v]", transcriptCtx)
+	}
+
+	// Should not panic.
+	node.saveTranscript(nil, transcript)
+}
@@ -5,9 +5,9 @@ import (
 	"sync"
 
 	"github.com/ipfs/go-log"
-	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss"
+	"github.com/keep-network/keep-ecdsa/pkg/utils/persistenceutils"
 )
 
 var logger = log.Logger("keep-registry")
@@ -16,6 +16,9 @@ var logger = log.Logger("keep-registry")
 type Keeps struct {
 	myKeepsMutex *sync.RWMutex
 	myKeeps      map[chain.ID]*tss.ThresholdSigner
+	myEdDSAKeeps map[chain.ID]*tss.EdDSAThresholdSigner
+	// Locks guarding usage of signers of keeps.
+	signerLocks map[chain.ID]*sync.RWMutex
 
 	storage     storage
 	unmarshalID func(string) (chain.ID, error)
@@ -23,12 +26,14 @@ type Keeps struct {
 
 // NewKeepsRegistry returns an empty keeps registry.
 func NewKeepsRegistry(
-	persistence persistence.Handle,
+	persistence persistenceutils.Handle,
 	unmarshalIDFunc func(string) (chain.ID, error),
 ) *Keeps {
 	return &Keeps{
 		myKeepsMutex: &sync.RWMutex{},
 		myKeeps:      make(map[chain.ID]*tss.ThresholdSigner),
+		myEdDSAKeeps: make(map[chain.ID]*tss.EdDSAThresholdSigner),
+		signerLocks:  make(map[chain.ID]*sync.RWMutex),
 		storage:      newStorage(persistence),
 		unmarshalID:  unmarshalIDFunc,
 	}
@@ -43,7 +48,7 @@ func (k *Keeps) RegisterSigner(
 	k.myKeepsMutex.Lock()
 	defer k.myKeepsMutex.Unlock()
 
-	if _, exists := k.myKeeps[keepID]; exists {
+	if k.isRegistered(keepID) {
 		return fmt.Errorf(
 			"signer for keep [%s] already registered",
 			keepID.String(),
@@ -72,6 +77,127 @@ func (k *Keeps) SnapshotSigner(
 	return k.storage.snapshot(keepID, signer)
 }
 
+// RegisterEdDSASigner registers that an EdDSA signer was successfully created
+// for the given keep.
+func (k *Keeps) RegisterEdDSASigner(
+	keepID chain.ID,
+	signer *tss.EdDSAThresholdSigner,
+) error {
+	k.myKeepsMutex.Lock()
+	defer k.myKeepsMutex.Unlock()
+
+	if k.isRegistered(keepID) {
+		return fmt.Errorf(
+			"signer for keep [%s] already registered",
+			keepID.String(),
+		)
+	}
+
+	err := k.storage.saveEdDSA(keepID, signer)
+	if err != nil {
+		return fmt.Errorf(
+			"could not persist signer for keep [%s] in the storage: [%v]",
+			keepID.String(),
+			err,
+		)
+	}
+
+	k.myEdDSAKeeps[keepID] = signer
+
+	return nil
+}
+
+// SnapshotEdDSASigner stores a snapshot of a keep address with its EdDSA
+// signer.
+func (k *Keeps) SnapshotEdDSASigner(
+	keepID chain.ID,
+	signer *tss.EdDSAThresholdSigner,
+) error {
+	return k.storage.snapshotEdDSA(keepID, signer)
+}
+
+// isRegistered returns true if a signer of any signature scheme is registered
+// for the given keep. It expects the keeps mutex to be already locked.
+func (k *Keeps) isRegistered(keepID chain.ID) bool {
+	_, hasSigner := k.myKeeps[keepID]
+	_, hasEdDSASigner := k.myEdDSAKeeps[keepID]
+	return hasSigner || hasEdDSASigner
+}
+
+// UpdateSigner replaces the signer registered for the given keep with a new
+// one, e.g. holding a refreshed key share. The new signer is persisted as
+// a snapshot and saved over the current signer before the replaced signer is
+// archived, so that the current directory always holds a signer of the keep,
+// even if the process is interrupted in the middle of the update. Replaced
+// signers are archived under versioned names, so signers replaced by
+// consecutive updates are all kept in the archive.
+func (k *Keeps) UpdateSigner(
+	keepID chain.ID,
+	signer *tss.ThresholdSigner,
+) error {
+	k.myKeepsMutex.Lock()
+	defer k.myKeepsMutex.Unlock()
+
+	replacedSigner, exists := k.myKeeps[keepID]
+	if !exists {
+		return fmt.Errorf(
+			"signer for keep [%s] is not registered",
+			keepID.String(),
+		)
+	}
+
+	err := k.storage.snapshot(keepID, signer)
+	if err != nil {
+		return fmt.Errorf(
+			"could not make snapshot of signer for keep [%s]: [%v]",
+			keepID.String(),
+			err,
+		)
+	}
+
+	err = k.storage.save(keepID, signer)
+	if err != nil {
+		return fmt.Errorf(
+			"could not persist signer for keep [%s] in the storage: [%v]",
+			keepID.String(),
+			err,
+		)
+	}
+
+	k.myKeeps[keepID] = signer
+
+	// The new signer is already persisted, so failing to archive the replaced
+	// one does not fail the update.
+	err = k.storage.archiveSigner(keepID, replacedSigner)
+	if err != nil {
+		logger.Errorf(
+			"could not archive replaced signer for keep [%s]: [%v]",
+			keepID.String(),
+			err,
+		)
+	}
+
+	return nil
+}
+
+// SignerLock returns a lock guarding usage of the signer of the given keep.
+// Protocols using the signer, like signing, should hold the read lock so that
+// they can be executed concurrently. Protocols replacing the signer, like key
+// share refresh, should hold the write lock so that they are never executed
+// concurrently with protocols using the signer.
+func (k *Keeps) SignerLock(keepID chain.ID) *sync.RWMutex {
+	k.myKeepsMutex.Lock()
+	defer k.myKeepsMutex.Unlock()
+
+	lock, ok := k.signerLocks[keepID]
+	if !ok {
+		lock = &sync.RWMutex{}
+		k.signerLocks[keepID] = lock
+	}
+
+	return lock
+}
+
 // UnregisterKeep archives threeshold signer info for the given keep address.
 func (k *Keeps) UnregisterKeep(keepID chain.ID) {
 	k.myKeepsMutex.Lock()
@@ -83,6 +209,7 @@ func (k *Keeps) UnregisterKeep(keepID chain.ID) {
 	}
 
 	delete(k.myKeeps, keepID)
+	delete(k.myEdDSAKeeps, keepID)
 }
 
 // GetSigner gets signer for a keep address.
@@ -111,17 +238,49 @@ func (k *Keeps) HasSigner(keepID chain.ID) bool {
 	return has
 }
 
-// GetKeepsIDs returns ids of all registered keeps.
+// GetEdDSASigner gets EdDSA signer for a keep address.
+func (k *Keeps) GetEdDSASigner(
+	keepID chain.ID,
+) (*tss.EdDSAThresholdSigner, error) {
+	k.myKeepsMutex.RLock()
+	defer k.myKeepsMutex.RUnlock()
+
+	signer, ok := k.myEdDSAKeeps[keepID]
+	if !ok {
+		return nil, fmt.Errorf(
+			"could not find EdDSA signer for keep: [%s]",
+			keepID.String(),
+		)
+	}
+
+	return signer, nil
+}
+
+// HasEdDSASigner returns true if an EdDSA signer exists in the registry for
+// the keep with the given address.
+func (k *Keeps) HasEdDSASigner(keepID chain.ID) bool {
+	k.myKeepsMutex.RLock()
+	defer k.myKeepsMutex.RUnlock()
+
+	_, has := k.myEdDSAKeeps[keepID]
+	return has
+}
+
+// GetKeepsIDs returns ids of all registered keeps of all signature schemes.
 func (k *Keeps) GetKeepsIDs() []chain.ID {
 	k.myKeepsMutex.RLock()
 	defer k.myKeepsMutex.RUnlock()
 
-	keepIDs := make([]chain.ID, 0, len(k.myKeeps))
+	keepIDs := make([]chain.ID, 0, len(k.myKeeps)+len(k.myEdDSAKeeps))
 
 	for keepID := range k.myKeeps {
 		keepIDs = append(keepIDs, keepID)
 	}
 
+	for keepID := range k.myEdDSAKeeps {
+		keepIDs = append(keepIDs, keepID)
+	}
+
 	return keepIDs
 }
 
@@ -144,7 +303,7 @@ func (k *Keeps) LoadExistingKeeps() {
 
 	go func() {
 		for keepSigner := range keepSignersChannel {
-			if _, exists := k.myKeeps[keepSigner.keepID]; exists {
+			if k.isRegistered(keepSigner.keepID) {
 				logger.Errorf(
 					"signer for keep [%s] already loaded; "+
 						"possible duplicate in the storage layer",
@@ -153,6 +312,11 @@ func (k *Keeps) LoadExistingKeeps() {
 				continue
 			}
 
+			if keepSigner.eddsaSigner != nil {
+				k.myEdDSAKeeps[keepSigner.keepID] = keepSigner.eddsaSigner
+				continue
+			}
+
 			k.myKeeps[keepSigner.keepID] = keepSigner.signer
 		}
 
@@ -171,7 +335,7 @@ func (k *Keeps) LoadExistingKeeps() {
 
 	logger.Infof(
 		"loaded [%d] keeps from the local storage",
-		len(k.myKeeps),
+		len(k.myKeeps)+len(k.myEdDSAKeeps),
 	)
 
 	for keepAddress := range k.myKeeps {
@@ -180,4 +344,11 @@ func (k *Keeps) LoadExistingKeeps() {
 			keepAddress.String(),
 		)
 	}
+
+	for keepAddress := range k.myEdDSAKeeps {
+		logger.Debugf(
+			"loaded EdDSA signer for keep [%s]",
+			keepAddress.String(),
+		)
+	}
 }
@@ -0,0 +1,147 @@
+//+build eddsa
+
+package registry
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-ecdsa/internal/testdata"
+	"github.com/keep-network/keep-ecdsa/internal/testhelper"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss"
+)
+
+func TestRegisterEdDSASigner(t *testing.T) {
+	persistenceMock, kr := buildRegistry()
+
+	signer, err := newTestEdDSASigner()
+	if err != nil {
+		t.Fatalf("failed to get signer: [%v]", err)
+	}
+
+	expectedSignerBytes, err := signer.Marshal()
+	if err != nil {
+		t.Fatalf("failed to marshal signer: [%v]", err)
+	}
+
+	expectedFile := &testhelper.TestFileInfo{
+		Data:      expectedSignerBytes,
+		Directory: keepID3.String(),
+		Name: fmt.Sprintf(
+			"/eddsa_membership_%.40s",
+			signer.MemberID().String(),
+		),
+	}
+
+	err = kr.RegisterEdDSASigner(keepID3, signer)
+	if err != nil {
+		t.Fatalf("failed to register signer: [%v]", err)
+	}
+
+	if len(persistenceMock.PersistedGroups) != 1 {
+		t.Fatalf(
+			"unexpected number of persisted groups\nexpected: [%d]\nactual:   [%d]",
+			1,
+			len(persistenceMock.PersistedGroups),
+		)
+	}
+
+	if !reflect.DeepEqual(
+		expectedFile,
+		persistenceMock.PersistedGroups[0],
+	) {
+		t.Errorf(
+			"unexpected persisted group\nexpected: [%+v]\nactual:   [%+v]",
+			expectedFile,
+			persistenceMock.PersistedGroups[0],
+		)
+	}
+
+	if !kr.HasEdDSASigner(keepID3) {
+		t.Errorf("EdDSA signer should be registered")
+	}
+
+	if kr.HasSigner(keepID3) {
+		t.Errorf("ECDSA signer should not be registered")
+	}
+
+	ecdsaSigner, err := newTestSigner(0)
+	if err != nil {
+		t.Fatalf("failed to get signer: [%v]", err)
+	}
+
+	err = kr.RegisterSigner(keepID3, ecdsaSigner)
+
+	expectedError := fmt.Errorf(
+		"signer for keep [%s] already registered",
+		keepID3.String(),
+	)
+	if !reflect.DeepEqual(expectedError, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestLoadExistingEdDSAKeeps(t *testing.T) {
+	persistenceMock, kr := buildRegistry()
+
+	signer, err := newTestEdDSASigner()
+	if err != nil {
+		t.Fatalf("failed to get signer: [%v]", err)
+	}
+
+	err = persistenceMock.MockEdDSASigner(0, keepID3.String(), signer)
+	if err != nil {
+		t.Fatalf("failed to mock signer: [%v]", err)
+	}
+
+	kr.LoadExistingKeeps()
+
+	if len(kr.GetKeepsIDs()) != 3 {
+		t.Fatalf(
+			"unexpected number of keeps\nexpected: [%d]\nactual:   [%d]",
+			3,
+			len(kr.GetKeepsIDs()),
+		)
+	}
+
+	actualSigner, err := kr.GetEdDSASigner(keepID3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(signer, actualSigner) {
+		t.Errorf(
+			"\nexpected: [%v]\nactual:   [%v]",
+			signer,
+			actualSigner,
+		)
+	}
+
+	if _, err := kr.GetSigner(keepID3); err == nil {
+		t.Errorf("expected no ECDSA signer for EdDSA keep")
+	}
+
+	kr.UnregisterKeep(keepID3)
+
+	if kr.HasEdDSASigner(keepID3) {
+		t.Errorf("EdDSA signer should be unregistered")
+	}
+}
+
+func newTestEdDSASigner() (*tss.EdDSAThresholdSigner, error) {
+	golden, err := testdata.LoadEdDSASignerGoldenFile(tss.CurrentEdDSASignerFormat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load golden file: [%v]", err)
+	}
+
+	signer := &tss.EdDSAThresholdSigner{}
+	if err := signer.Unmarshal(golden); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal signer: [%v]", err)
+	}
+
+	return signer, nil
+}
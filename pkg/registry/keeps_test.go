@@ -206,6 +206,147 @@ func TestUnregisterSigner(t *testing.T) {
 	}
 }
 
+func TestUpdateSigner(t *testing.T) {
+	persistenceMock, kr := buildRegistry()
+
+	signer1, err := newTestSigner(0)
+	if err != nil {
+		t.Fatalf("failed to get signer: [%v]", err)
+	}
+
+	err = kr.RegisterSigner(keepID1, signer1)
+	if err != nil {
+		t.Fatalf("failed to register signer: [%v]", err)
+	}
+
+	signer2, err := newTestSigner(1)
+	if err != nil {
+		t.Fatalf("failed to get signer: [%v]", err)
+	}
+
+	expectedSignerBytes, err := signer2.Marshal()
+	if err != nil {
+		t.Fatalf("failed to marshal signer: [%v]", err)
+	}
+
+	expectedFile := &testhelper.TestFileInfo{
+		Data:      expectedSignerBytes,
+		Directory: keepID1.String(),
+		Name:      fmt.Sprintf("/membership_%s", signer2.MemberID().String()),
+	}
+
+	err = kr.UpdateSigner(keepID1, signer2)
+	if err != nil {
+		t.Fatalf("failed to update signer: [%v]", err)
+	}
+
+	if !reflect.DeepEqual(
+		[]*testhelper.TestFileInfo{expectedFile},
+		persistenceMock.Snapshots,
+	) {
+		t.Errorf(
+			"unexpected snapshots\nexpected: [%+v]\nactual:   [%+v]",
+			[]*testhelper.TestFileInfo{expectedFile},
+			persistenceMock.Snapshots,
+		)
+	}
+
+	replacedSignerBytes, err := signer1.Marshal()
+	if err != nil {
+		t.Fatalf("failed to marshal signer: [%v]", err)
+	}
+
+	expectedArchivedFile := &testhelper.TestFileInfo{
+		Data:      replacedSignerBytes,
+		Directory: keepID1.String(),
+		Name:      fmt.Sprintf("/membership_%s", signer1.MemberID().String()),
+	}
+
+	if !reflect.DeepEqual(
+		[]*testhelper.TestFileInfo{expectedArchivedFile},
+		persistenceMock.ArchivedFiles,
+	) {
+		t.Errorf(
+			"unexpected archived files\nexpected: [%+v]\nactual:   [%+v]",
+			[]*testhelper.TestFileInfo{expectedArchivedFile},
+			persistenceMock.ArchivedFiles,
+		)
+	}
+
+	if len(persistenceMock.ArchivedGroups) != 0 {
+		t.Errorf(
+			"unexpected archived groups\nexpected: [%v]\nactual:   [%v]",
+			[]string{},
+			persistenceMock.ArchivedGroups,
+		)
+	}
+
+	// The first persisted file is the signer registered before the update.
+	expectedPersistedFiles := []*testhelper.TestFileInfo{
+		persistenceMock.PersistedGroups[0],
+		expectedFile,
+	}
+
+	if !reflect.DeepEqual(
+		expectedPersistedFiles,
+		persistenceMock.PersistedGroups,
+	) {
+		t.Errorf(
+			"unexpected persisted groups\nexpected: [%+v]\nactual:   [%+v]",
+			expectedPersistedFiles,
+			persistenceMock.PersistedGroups,
+		)
+	}
+
+	signer, err := kr.GetSigner(keepID1)
+	if err != nil {
+		t.Fatalf("failed to get signer: [%v]", err)
+	}
+
+	if signer != signer2 {
+		t.Errorf("signer has not been updated in the registry")
+	}
+}
+
+func TestSignerLock(t *testing.T) {
+	_, kr := buildRegistry()
+
+	if kr.SignerLock(keepID1) != kr.SignerLock(keepID1) {
+		t.Errorf("expected the same lock for the same keep")
+	}
+
+	if kr.SignerLock(keepID1) == kr.SignerLock(keepID2) {
+		t.Errorf("expected different locks for different keeps")
+	}
+}
+
+func TestUpdateSignerNotRegistered(t *testing.T) {
+	persistenceMock, kr := buildRegistry()
+
+	signer1, err := newTestSigner(0)
+	if err != nil {
+		t.Fatalf("failed to get signer: [%v]", err)
+	}
+
+	err = kr.UpdateSigner(keepID3, signer1)
+
+	expectedError := fmt.Errorf(
+		"signer for keep [%s] is not registered",
+		keepID3.String(),
+	)
+	if !reflect.DeepEqual(expectedError, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+
+	if len(persistenceMock.Snapshots) != 0 {
+		t.Errorf("unexpected snapshots: [%v]", persistenceMock.Snapshots)
+	}
+}
+
 func TestGetSigner(t *testing.T) {
 	_, kr := buildRegistry()
 
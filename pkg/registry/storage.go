@@ -2,25 +2,34 @@ package registry
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 	"sync"
 
-	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss"
+	"github.com/keep-network/keep-ecdsa/pkg/utils/persistenceutils"
 )
 
+// Prefix of names of files under which EdDSA signers are persisted in the keep's
+// directory. ECDSA signers are persisted under the `membership_` prefix.
+const eddsaMembershipFilePrefix = "eddsa_membership_"
+
 type storage interface {
 	save(keepID chain.ID, signer *tss.ThresholdSigner) error
 	snapshot(keepID chain.ID, signer *tss.ThresholdSigner) error
+	saveEdDSA(keepID chain.ID, signer *tss.EdDSAThresholdSigner) error
+	snapshotEdDSA(keepID chain.ID, signer *tss.EdDSAThresholdSigner) error
 	readAll(unmarshalIDFunc func(string) (chain.ID, error)) (<-chan *keepSigner, <-chan error)
 	archive(keepID chain.ID) error
+	archiveSigner(keepID chain.ID, signer *tss.ThresholdSigner) error
 }
 
 type persistentStorage struct {
-	handle persistence.Handle
+	handle persistenceutils.Handle
 }
 
-func newStorage(persistence persistence.Handle) storage {
+func newStorage(persistence persistenceutils.Handle) storage {
 	return &persistentStorage{
 		handle: persistence,
 	}
@@ -38,9 +47,7 @@ func (ps *persistentStorage) save(
 	return ps.handle.Save(
 		signerBytes,
 		keepID.String(),
-		// Take just the first 20 bytes of member ID so that we don't produce
-		// too long file names.
-		fmt.Sprintf("/membership_%.40s", signer.MemberID().String()),
+		membershipFileName(signer.MemberID()),
 	)
 }
 
@@ -56,15 +63,60 @@ func (ps *persistentStorage) snapshot(
 	return ps.handle.Snapshot(
 		signerBytes,
 		keepID.String(),
-		// Take just the first 20 bytes of member ID so that we don't produce
-		// too long file names.
-		fmt.Sprintf("/membership_%.40s", signer.MemberID().String()),
+		membershipFileName(signer.MemberID()),
+	)
+}
+
+func membershipFileName(memberID tss.MemberID) string {
+	// Take just the first 20 bytes of member ID so that we don't produce
+	// too long file names.
+	return fmt.Sprintf("/membership_%.40s", memberID.String())
+}
+
+func (ps *persistentStorage) saveEdDSA(
+	keepID chain.ID,
+	signer *tss.EdDSAThresholdSigner,
+) error {
+	signerBytes, err := signer.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal signer: [%v]", err)
+	}
+
+	return ps.handle.Save(
+		signerBytes,
+		keepID.String(),
+		eddsaMembershipFileName(signer.MemberID()),
 	)
 }
 
+func (ps *persistentStorage) snapshotEdDSA(
+	keepID chain.ID,
+	signer *tss.EdDSAThresholdSigner,
+) error {
+	signerBytes, err := signer.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal signer: [%v]", err)
+	}
+
+	return ps.handle.Snapshot(
+		signerBytes,
+		keepID.String(),
+		eddsaMembershipFileName(signer.MemberID()),
+	)
+}
+
+func eddsaMembershipFileName(memberID tss.MemberID) string {
+	// Take just the first 20 bytes of member ID so that we don't produce
+	// too long file names.
+	return fmt.Sprintf("/%s%.40s", eddsaMembershipFilePrefix, memberID.String())
+}
+
+// keepSigner holds a signer read from the storage. Exactly one of ECDSA and
+// EdDSA signers is set.
 type keepSigner struct {
-	keepID chain.ID
-	signer *tss.ThresholdSigner
+	keepID      chain.ID
+	signer      *tss.ThresholdSigner
+	eddsaSigner *tss.EdDSAThresholdSigner
 }
 
 func (ps *persistentStorage) readAll(
@@ -129,6 +181,29 @@ func (ps *persistentStorage) readAll(
 				continue
 			}
 
+			if strings.HasPrefix(
+				filepath.Base(descriptor.Name()),
+				eddsaMembershipFilePrefix,
+			) {
+				eddsaSigner := &tss.EdDSAThresholdSigner{}
+				err = eddsaSigner.Unmarshal(content)
+				if err != nil {
+					outputErrors <- fmt.Errorf(
+						"failed to unmarshal EdDSA signer from file [%v] in directory [%v]: [%v]",
+						descriptor.Name(),
+						descriptor.Directory(),
+						err,
+					)
+					continue
+				}
+
+				outputKeepSigner <- &keepSigner{
+					keepID:      keepID,
+					eddsaSigner: eddsaSigner,
+				}
+				continue
+			}
+
 			signer := &tss.ThresholdSigner{}
 			err = signer.Unmarshal(content)
 			if err != nil {
@@ -156,3 +231,21 @@ func (ps *persistentStorage) readAll(
 func (ps *persistentStorage) archive(keepID chain.ID) error {
 	return ps.handle.Archive(keepID.String())
 }
+
+// archiveSigner writes the given signer to the archive, leaving files persisted
+// for the keep in the current directory untouched.
+func (ps *persistentStorage) archiveSigner(
+	keepID chain.ID,
+	signer *tss.ThresholdSigner,
+) error {
+	signerBytes, err := signer.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal signer: [%v]", err)
+	}
+
+	return ps.handle.ArchiveData(
+		signerBytes,
+		keepID.String(),
+		membershipFileName(signer.MemberID()),
+	)
+}
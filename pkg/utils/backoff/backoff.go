@@ -0,0 +1,84 @@
+// Package backoff provides policies determining delays between consecutive
+// attempts of actions retried on failure.
+package backoff
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Policy determines how long to wait before retrying an action which failed
+// in the given attempt. Attempts are numbered starting from 1.
+type Policy interface {
+	Delay(attempt int) time.Duration
+}
+
+// Constant is a policy waiting the same amount of time after each attempt.
+type Constant time.Duration
+
+// Delay returns the constant delay regardless of the attempt.
+func (c Constant) Delay(_ int) time.Duration {
+	return time.Duration(c)
+}
+
+// Exponential is a policy doubling the delay after each consecutive attempt.
+// The delay after the first attempt is equal to the initial delay and never
+// exceeds the maximum delay, if set. A random jitter from range [0, Jitter)
+// is added to each delay so that clients retrying the same action do not
+// retry it at the same time.
+type Exponential struct {
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Jitter       time.Duration
+}
+
+// Delay returns the delay before retrying an action which failed in the given
+// attempt. For each attempt the result value will be in range:
+// - attempt 1: [InitialDelay, InitialDelay + Jitter)
+// - attempt 2: [2 * InitialDelay, 2 * InitialDelay + Jitter)
+// - attempt n: [2^(n-1) * InitialDelay, 2^(n-1) * InitialDelay + Jitter)
+// with the delay, not including the jitter, capped at MaxDelay.
+func (e *Exponential) Delay(attempt int) time.Duration {
+	delay := e.InitialDelay
+	for i := 1; i < attempt; i++ {
+		if e.MaxDelay > 0 && delay >= e.MaxDelay {
+			break
+		}
+
+		// Stop doubling before the delay overflows.
+		if delay > math.MaxInt64/2 {
+			break
+		}
+
+		delay *= 2
+	}
+
+	if e.MaxDelay > 0 && delay > e.MaxDelay {
+		delay = e.MaxDelay
+	}
+
+	if e.Jitter > 0 {
+		// #nosec G404 (insecure random number source (rand))
+		// No need to use secure randomness for jitter value.
+		delay += time.Duration(rand.Int63n(int64(e.Jitter)))
+	}
+
+	return delay
+}
+
+// Wait blocks for the given delay or until the context is done, whichever
+// happens first. It returns the context error if the context is done before
+// the delay elapses.
+func Wait(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
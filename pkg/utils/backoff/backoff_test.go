@@ -0,0 +1,105 @@
+package backoff
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestExponentialDelay(t *testing.T) {
+	var tests = map[string]struct {
+		policy        *Exponential
+		attempt       int
+		expectedDelay time.Duration
+	}{
+		"first attempt": {
+			policy:        &Exponential{InitialDelay: time.Second},
+			attempt:       1,
+			expectedDelay: time.Second,
+		},
+		"third attempt": {
+			policy:        &Exponential{InitialDelay: time.Second},
+			attempt:       3,
+			expectedDelay: 4 * time.Second,
+		},
+		"attempt above the maximum delay": {
+			policy: &Exponential{
+				InitialDelay: time.Second,
+				MaxDelay:     10 * time.Second,
+			},
+			attempt:       5,
+			expectedDelay: 10 * time.Second,
+		},
+		"attempt overflowing the delay": {
+			policy:        &Exponential{InitialDelay: time.Second},
+			attempt:       100,
+			expectedDelay: (1 << 33) * time.Second,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			delay := test.policy.Delay(test.attempt)
+
+			if delay != test.expectedDelay {
+				t.Errorf(
+					"unexpected delay\nexpected: [%v]\nactual:   [%v]",
+					test.expectedDelay,
+					delay,
+				)
+			}
+		})
+	}
+}
+
+func TestExponentialDelayJitter(t *testing.T) {
+	policy := &Exponential{
+		InitialDelay: time.Second,
+		Jitter:       100 * time.Millisecond,
+	}
+
+	for attempt := 1; attempt <= 5; attempt++ {
+		minDelay := time.Duration(1<<(attempt-1)) * time.Second
+		maxDelay := minDelay + policy.Jitter
+
+		delay := policy.Delay(attempt)
+
+		if delay < minDelay || delay >= maxDelay {
+			t.Errorf(
+				"delay [%v] of attempt [%v] out of range [%v, %v)",
+				delay,
+				attempt,
+				minDelay,
+				maxDelay,
+			)
+		}
+	}
+}
+
+func TestWait(t *testing.T) {
+	err := Wait(context.Background(), time.Millisecond)
+	if err != nil {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+}
+
+func TestWaitCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+
+	err := Wait(ctx, time.Hour)
+
+	if err != context.Canceled {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			context.Canceled,
+			err,
+		)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("wait not interrupted; took [%v]", elapsed)
+	}
+}
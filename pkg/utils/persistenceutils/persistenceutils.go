@@ -0,0 +1,411 @@
+// Package persistenceutils provides a persistence handle supporting operations
+// on single persisted files, in addition to operations on whole directories
+// supported by the persistence handle.
+package persistenceutils
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/keep-network/keep-common/pkg/encryption"
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+// Names of directories under which the disk persistence stores current and
+// archived data.
+// TODO: These are copied from keep-common. Consider exposing them there.
+const (
+	currentDir  = "current"
+	archiveDir  = "archive"
+	snapshotDir = "snapshot"
+)
+
+// Handle is a persistence handle supporting operations on single files.
+type Handle interface {
+	persistence.Handle
+
+	// ArchiveData writes the given data to the archive, under the file with
+	// the given name in the provided directory. Data are archived under
+	// a versioned name so that archiving data under the same name more than
+	// once never overwrites the previously archived ones. Files persisted in
+	// the current directory are left untouched.
+	ArchiveData(data []byte, directory string, name string) error
+	// Delete removes the given directory with all files persisted in it.
+	// Unlike archived data, deleted data cannot be recovered.
+	Delete(directory string) error
+	// DeleteLatestSnapshot removes the most recent snapshot of the file with
+	// the given name from the provided directory. Snapshots of a file are
+	// distinguished by the time they were made.
+	DeleteLatestSnapshot(directory string, name string) error
+	// ReadAllRetained reads all snapshots and archived files. Directories of
+	// the returned descriptors are prefixed with the name of the directory
+	// the files are retained in, e.g. `snapshot/<directory>`, so that they
+	// can be told apart from the current files read with ReadAll.
+	ReadAllRetained() (<-chan persistence.DataDescriptor, <-chan error)
+	// ReplaceRetained replaces content of the snapshot or archived file with
+	// the given name in the provided directory, as returned by
+	// ReadAllRetained. The file is replaced atomically so that its original
+	// content is never lost if the replacement fails.
+	ReplaceRetained(data []byte, directory string, name string) error
+	// Close waits for write operations in progress to complete and flushes
+	// all persisted data to the disk. Write operations requested after
+	// the handle is closed fail.
+	Close() error
+}
+
+type diskHandle struct {
+	persistence.Handle
+
+	path string
+	// Encrypts data archived under single files and decrypts retained files
+	// if data persisted with the handle are encrypted, nil otherwise.
+	box encryption.Box
+
+	// Write operations hold the read lock so that they can be executed
+	// concurrently, closing the handle holds the write lock.
+	closeMutex sync.RWMutex
+	closed     bool
+}
+
+// NewDiskHandle wraps the given handle persisting data on disk under the given
+// path so that single files persisted with the handle can be operated on.
+func NewDiskHandle(handle persistence.Handle, path string) Handle {
+	return &diskHandle{
+		Handle: handle,
+		path:   path,
+	}
+}
+
+// NewEncryptedDiskHandle wraps the given handle persisting data on disk under
+// the given path so that data are encrypted with the given password and single
+// files persisted with the handle can be operated on.
+func NewEncryptedDiskHandle(
+	handle persistence.Handle,
+	path string,
+	password string,
+) Handle {
+	return &diskHandle{
+		Handle: persistence.NewEncryptedPersistence(handle, password),
+		path:   path,
+		// The key is derived from the password the same way the encrypted
+		// persistence derives it, so that data archived under single files
+		// can be read the same way as data archived with the handle.
+		box: encryption.NewBox(sha256.Sum256([]byte(password))),
+	}
+}
+
+func (dh *diskHandle) Save(data []byte, directory string, name string) error {
+	if err := dh.beginWrite(); err != nil {
+		return err
+	}
+	defer dh.endWrite()
+
+	return dh.Handle.Save(data, directory, name)
+}
+
+func (dh *diskHandle) Snapshot(data []byte, directory string, name string) error {
+	if err := dh.beginWrite(); err != nil {
+		return err
+	}
+	defer dh.endWrite()
+
+	return dh.Handle.Snapshot(data, directory, name)
+}
+
+func (dh *diskHandle) Archive(directory string) error {
+	if err := dh.beginWrite(); err != nil {
+		return err
+	}
+	defer dh.endWrite()
+
+	return dh.Handle.Archive(directory)
+}
+
+func (dh *diskHandle) ArchiveData(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	if err := dh.beginWrite(); err != nil {
+		return err
+	}
+	defer dh.endWrite()
+
+	err := persistence.EnsureDirectoryExists(
+		filepath.Join(dh.path, archiveDir),
+		directory,
+	)
+	if err != nil {
+		return err
+	}
+
+	if dh.box != nil {
+		encrypted, err := dh.box.Encrypt(data)
+		if err != nil {
+			return err
+		}
+		data = encrypted
+	}
+
+	archivedPath := filepath.Join(dh.path, archiveDir, directory, name)
+
+	// Versions are numbered from 1. The first version not archived yet is
+	// used.
+	for version := 1; ; version++ {
+		to := fmt.Sprintf("%s.v%d", archivedPath, version)
+
+		_, err := os.Stat(to)
+		if os.IsNotExist(err) {
+			return persistence.Write(to, data)
+		}
+		if err != nil {
+			return fmt.Errorf("could not stat archived file: [%v]", err)
+		}
+	}
+}
+
+func (dh *diskHandle) Delete(directory string) error {
+	if err := dh.beginWrite(); err != nil {
+		return err
+	}
+	defer dh.endWrite()
+
+	return os.RemoveAll(filepath.Join(dh.path, currentDir, directory))
+}
+
+func (dh *diskHandle) DeleteLatestSnapshot(directory string, name string) error {
+	if err := dh.beginWrite(); err != nil {
+		return err
+	}
+	defer dh.endWrite()
+
+	snapshotsPath := filepath.Join(dh.path, snapshotDir, directory)
+
+	files, err := ioutil.ReadDir(snapshotsPath)
+	if err != nil {
+		return fmt.Errorf("could not read snapshots: [%v]", err)
+	}
+
+	// Snapshot names are suffixed with the time in milliseconds at which
+	// the snapshot was made.
+	prefix := strings.TrimPrefix(name, "/") + "."
+	latest := ""
+	latestTimestamp := int64(-1)
+	for _, file := range files {
+		if !strings.HasPrefix(file.Name(), prefix) {
+			continue
+		}
+
+		timestamp, err := strconv.ParseInt(
+			strings.TrimPrefix(file.Name(), prefix),
+			10,
+			64,
+		)
+		if err != nil {
+			continue
+		}
+
+		if timestamp > latestTimestamp {
+			latest = file.Name()
+			latestTimestamp = timestamp
+		}
+	}
+
+	if latest == "" {
+		return fmt.Errorf("no snapshot of file [%s]", name)
+	}
+
+	return os.Remove(filepath.Join(snapshotsPath, latest))
+}
+
+func (dh *diskHandle) ReadAllRetained() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	dataChannel := make(chan persistence.DataDescriptor)
+	errorChannel := make(chan error)
+
+	go func() {
+		defer close(dataChannel)
+		defer close(errorChannel)
+
+		for _, retainedDir := range []string{snapshotDir, archiveDir} {
+			directories, err := ioutil.ReadDir(filepath.Join(dh.path, retainedDir))
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				errorChannel <- fmt.Errorf(
+					"could not read the directory [%s]: [%v]",
+					retainedDir,
+					err,
+				)
+				continue
+			}
+
+			for _, directory := range directories {
+				if !directory.IsDir() {
+					continue
+				}
+
+				descriptorDirectory := filepath.Join(retainedDir, directory.Name())
+
+				files, err := ioutil.ReadDir(filepath.Join(dh.path, descriptorDirectory))
+				if err != nil {
+					errorChannel <- fmt.Errorf(
+						"could not read the directory [%s]: [%v]",
+						descriptorDirectory,
+						err,
+					)
+					continue
+				}
+
+				for _, file := range files {
+					if file.IsDir() {
+						continue
+					}
+
+					dataChannel <- &retainedDataDescriptor{
+						name:      file.Name(),
+						directory: descriptorDirectory,
+						path:      filepath.Join(dh.path, descriptorDirectory, file.Name()),
+						box:       dh.box,
+					}
+				}
+			}
+		}
+	}()
+
+	return dataChannel, errorChannel
+}
+
+func (dh *diskHandle) ReplaceRetained(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	if err := dh.beginWrite(); err != nil {
+		return err
+	}
+	defer dh.endWrite()
+
+	retainedDir := strings.SplitN(filepath.ToSlash(filepath.Clean(directory)), "/", 2)[0]
+	if retainedDir != snapshotDir && retainedDir != archiveDir {
+		return fmt.Errorf("directory [%s] is not retained", directory)
+	}
+
+	path := filepath.Join(dh.path, directory, name)
+
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("could not stat retained file: [%v]", err)
+	}
+
+	if dh.box != nil {
+		encrypted, err := dh.box.Encrypt(data)
+		if err != nil {
+			return err
+		}
+		data = encrypted
+	}
+
+	// The replacing content is written to a temporary file first which is
+	// then renamed, so that the file holds either the original or the new
+	// content in full.
+	temporaryPath := path + ".tmp"
+	if err := persistence.Write(temporaryPath, data); err != nil {
+		return err
+	}
+
+	return os.Rename(temporaryPath, path)
+}
+
+func (dh *diskHandle) Close() error {
+	dh.closeMutex.Lock()
+	defer dh.closeMutex.Unlock()
+
+	if dh.closed {
+		return nil
+	}
+	dh.closed = true
+
+	// Files are synced when written but entries of directories they are
+	// created in, moved to or removed from are not.
+	return filepath.Walk(
+		dh.path,
+		func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() {
+				return nil
+			}
+
+			return syncDirectory(path)
+		},
+	)
+}
+
+func (dh *diskHandle) beginWrite() error {
+	dh.closeMutex.RLock()
+
+	if dh.closed {
+		dh.closeMutex.RUnlock()
+		return fmt.Errorf("persistence handle is closed")
+	}
+
+	return nil
+}
+
+func (dh *diskHandle) endWrite() {
+	dh.closeMutex.RUnlock()
+}
+
+func syncDirectory(path string) error {
+	directory, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	if err := directory.Sync(); err != nil {
+		directory.Close()
+		return fmt.Errorf("could not sync directory [%s]: [%v]", path, err)
+	}
+
+	return directory.Close()
+}
+
+// retainedDataDescriptor describes a snapshot or an archived file and reads
+// its content lazily, decrypting it if needed.
+type retainedDataDescriptor struct {
+	name      string
+	directory string
+	path      string
+	box       encryption.Box
+}
+
+func (rdd *retainedDataDescriptor) Name() string {
+	return rdd.name
+}
+
+func (rdd *retainedDataDescriptor) Directory() string {
+	return rdd.directory
+}
+
+func (rdd *retainedDataDescriptor) Content() ([]byte, error) {
+	content, err := persistence.Read(rdd.path)
+	if err != nil {
+		return nil, err
+	}
+
+	if rdd.box == nil {
+		return content, nil
+	}
+
+	return rdd.box.Decrypt(content)
+}
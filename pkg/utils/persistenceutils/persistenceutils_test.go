@@ -0,0 +1,341 @@
+package persistenceutils
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/encryption"
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+func TestArchiveData(t *testing.T) {
+	handle := newTestDiskHandle(t)
+
+	if err := handle.Save([]byte("signer-3"), "keep", "/membership"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handle.ArchiveData([]byte("signer-1"), "keep", "/membership"); err != nil {
+		t.Fatal(err)
+	}
+	if err := handle.ArchiveData([]byte("signer-2"), "keep", "/membership"); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedArchived := map[string]string{
+		"membership.v1": "signer-1",
+		"membership.v2": "signer-2",
+	}
+	archived := readFiles(t, filepath.Join(handle.path, archiveDir, "keep"))
+	if !reflect.DeepEqual(expectedArchived, archived) {
+		t.Errorf(
+			"unexpected archived files\nexpected: [%v]\nactual:   [%v]",
+			expectedArchived,
+			archived,
+		)
+	}
+
+	expectedCurrent := map[string]string{
+		"membership": "signer-3",
+	}
+	current := readFiles(t, filepath.Join(handle.path, currentDir, "keep"))
+	if !reflect.DeepEqual(expectedCurrent, current) {
+		t.Errorf(
+			"unexpected current files\nexpected: [%v]\nactual:   [%v]",
+			expectedCurrent,
+			current,
+		)
+	}
+}
+
+func TestArchiveDataEncrypted(t *testing.T) {
+	handle := newTestEncryptedDiskHandle(t)
+
+	if err := handle.ArchiveData([]byte("signer-1"), "keep", "/membership"); err != nil {
+		t.Fatal(err)
+	}
+
+	archived := readFiles(t, filepath.Join(handle.path, archiveDir, "keep"))
+	if archived["membership.v1"] == "signer-1" {
+		t.Fatalf("archived data are not encrypted")
+	}
+
+	box := encryption.NewBox(sha256.Sum256([]byte("password")))
+	decrypted, err := box.Decrypt([]byte(archived["membership.v1"]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decrypted) != "signer-1" {
+		t.Errorf(
+			"unexpected archived data\nexpected: [%v]\nactual:   [%s]",
+			"signer-1",
+			decrypted,
+		)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	handle := newTestDiskHandle(t)
+
+	if err := handle.Save([]byte("deleted"), "deleted", "/file"); err != nil {
+		t.Fatal(err)
+	}
+	if err := handle.Save([]byte("kept"), "kept", "/file"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handle.Delete("deleted"); err != nil {
+		t.Fatal(err)
+	}
+
+	directories, err := ioutil.ReadDir(filepath.Join(handle.path, currentDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(directories) != 1 || directories[0].Name() != "kept" {
+		t.Errorf("unexpected directories: [%v]", directories)
+	}
+
+	if _, err := os.Stat(filepath.Join(handle.path, archiveDir, "deleted")); !os.IsNotExist(err) {
+		t.Errorf("deleted directory should not be archived")
+	}
+}
+
+func TestDeleteLatestSnapshot(t *testing.T) {
+	handle := newTestDiskHandle(t)
+
+	if err := handle.Snapshot([]byte("older"), "keep", "/membership"); err != nil {
+		t.Fatal(err)
+	}
+	// Snapshots are distinguished by the time they were made with
+	// a millisecond precision.
+	time.Sleep(2 * time.Millisecond)
+	if err := handle.Snapshot([]byte("latest"), "keep", "/membership"); err != nil {
+		t.Fatal(err)
+	}
+	if err := handle.Snapshot([]byte("other"), "keep", "/other"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handle.DeleteLatestSnapshot("keep", "/membership"); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots := readFiles(t, filepath.Join(handle.path, snapshotDir, "keep"))
+
+	contents := []string{}
+	for _, content := range snapshots {
+		contents = append(contents, content)
+	}
+	sort.Strings(contents)
+
+	expectedContents := []string{"older", "other"}
+	if !reflect.DeepEqual(expectedContents, contents) {
+		t.Errorf(
+			"unexpected snapshots\nexpected: [%v]\nactual:   [%v]",
+			expectedContents,
+			contents,
+		)
+	}
+
+	if err := handle.DeleteLatestSnapshot("keep", "/missing"); err == nil {
+		t.Errorf("expected error when there is no snapshot")
+	}
+}
+
+func TestReadAllRetained(t *testing.T) {
+	handle := newTestEncryptedDiskHandle(t)
+
+	if err := handle.Save([]byte("current"), "keep", "/membership"); err != nil {
+		t.Fatal(err)
+	}
+	if err := handle.Snapshot([]byte("snapshot"), "keep", "/membership"); err != nil {
+		t.Fatal(err)
+	}
+	if err := handle.ArchiveData([]byte("archived"), "keep", "/membership"); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedContents := map[string]string{
+		"archive/keep/membership.v1": "archived",
+		"snapshot/keep/membership":   "snapshot",
+	}
+	contents := readRetained(t, handle)
+	if !reflect.DeepEqual(expectedContents, contents) {
+		t.Errorf(
+			"unexpected retained files\nexpected: [%v]\nactual:   [%v]",
+			expectedContents,
+			contents,
+		)
+	}
+}
+
+func TestReplaceRetained(t *testing.T) {
+	handle := newTestEncryptedDiskHandle(t)
+
+	if err := handle.Save([]byte("current"), "keep", "/membership"); err != nil {
+		t.Fatal(err)
+	}
+	if err := handle.ArchiveData([]byte("archived"), "keep", "/membership"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handle.ReplaceRetained(
+		[]byte("replaced"),
+		"archive/keep",
+		"membership.v1",
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedContents := map[string]string{
+		"archive/keep/membership.v1": "replaced",
+	}
+	contents := readRetained(t, handle)
+	if !reflect.DeepEqual(expectedContents, contents) {
+		t.Errorf(
+			"unexpected retained files\nexpected: [%v]\nactual:   [%v]",
+			expectedContents,
+			contents,
+		)
+	}
+
+	if err := handle.ReplaceRetained(
+		[]byte("replaced"),
+		"current/keep",
+		"membership",
+	); err == nil {
+		t.Errorf("expected error when replacing a current file")
+	}
+
+	if err := handle.ReplaceRetained(
+		[]byte("replaced"),
+		"archive/keep",
+		"missing",
+	); err == nil {
+		t.Errorf("expected error when replacing a missing file")
+	}
+}
+
+func TestClose(t *testing.T) {
+	handle := newTestDiskHandle(t)
+
+	if err := handle.Save([]byte("saved"), "keep", "/membership"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handle.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handle.Save([]byte("rejected"), "keep", "/other"); err == nil {
+		t.Errorf("expected error when saving with a closed handle")
+	}
+
+	if err := handle.Delete("keep"); err == nil {
+		t.Errorf("expected error when deleting with a closed handle")
+	}
+
+	expectedCurrent := map[string]string{
+		"membership": "saved",
+	}
+	current := readFiles(t, filepath.Join(handle.path, currentDir, "keep"))
+	if !reflect.DeepEqual(expectedCurrent, current) {
+		t.Errorf(
+			"unexpected current files\nexpected: [%v]\nactual:   [%v]",
+			expectedCurrent,
+			current,
+		)
+	}
+
+	// Closing the handle more than once has no effect.
+	if err := handle.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestDiskHandle(t *testing.T) *diskHandle {
+	path, err := ioutil.TempDir("", "persistenceutils")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(path)
+	})
+
+	handle, err := persistence.NewDiskHandle(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewDiskHandle(handle, path).(*diskHandle)
+}
+
+func newTestEncryptedDiskHandle(t *testing.T) *diskHandle {
+	path, err := ioutil.TempDir("", "persistenceutils")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(path)
+	})
+
+	handle, err := persistence.NewDiskHandle(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewEncryptedDiskHandle(handle, path, "password").(*diskHandle)
+}
+
+// readRetained reads contents of retained files by their paths. Suffixes of
+// snapshots, distinguishing them by time, are trimmed.
+func readRetained(t *testing.T, handle *diskHandle) map[string]string {
+	dataChannel, errorChannel := handle.ReadAllRetained()
+
+	contents := make(map[string]string)
+	for descriptor := range dataChannel {
+		content, err := descriptor.Content()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		name := descriptor.Name()
+		if strings.HasPrefix(descriptor.Directory(), snapshotDir) {
+			name = name[:strings.LastIndex(name, ".")]
+		}
+
+		contents[descriptor.Directory()+"/"+name] = string(content)
+	}
+	for err := range errorChannel {
+		t.Fatal(err)
+	}
+
+	return contents
+}
+
+func readFiles(t *testing.T, directory string) map[string]string {
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contents := make(map[string]string)
+	for _, file := range files {
+		content, err := ioutil.ReadFile(filepath.Join(directory, file.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		contents[file.Name()] = string(content)
+	}
+
+	return contents
+}